@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"zatrano/configs/configslog"
+	"zatrano/middlewares"
+	"zatrano/pkg/templatehelpers"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/template/html/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newErrorApp(t *testing.T) *fiber.App {
+	t.Helper()
+	testutil.Setup()
+	engine := html.New("../../views", ".html")
+	engine.AddFuncMap(templatehelpers.TemplateHelpers())
+	app := fiber.New(fiber.Config{Views: engine, ErrorHandler: errorHandler})
+	app.Use(middlewares.RequestIDMiddleware)
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		return errors.New("pq: password authentication failed for user \"zatrano\"")
+	})
+	app.Get("/forbidden", func(c *fiber.Ctx) error { return fiber.ErrForbidden })
+	app.Use(func(c *fiber.Ctx) error { return fiber.ErrNotFound })
+	return app
+}
+
+func TestErrorHandlerRendersHTMLPages(t *testing.T) {
+	app := newErrorApp(t)
+	tests := []struct {
+		path   string
+		status int
+		want   string
+	}{
+		{"/yok-boyle-bir-sayfa", fiber.StatusNotFound, "404"},
+		{"/forbidden", fiber.StatusForbidden, "403"},
+		{"/boom", fiber.StatusInternalServerError, "Beklenmeyen bir hata oluştu"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.path, nil)
+		req.Header.Set("Accept", "text/html,application/xhtml+xml")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: status %d, beklenen %d", tt.path, resp.StatusCode, tt.status)
+		}
+		if ct := resp.Header.Get(fiber.HeaderContentType); !strings.HasPrefix(ct, fiber.MIMETextHTML) {
+			t.Errorf("%s: content-type %q, beklenen text/html", tt.path, ct)
+		}
+		if !strings.Contains(string(body), tt.want) {
+			t.Errorf("%s: gövdede %q yok", tt.path, tt.want)
+		}
+		if strings.Contains(string(body), "password authentication") {
+			t.Errorf("%s: iç hata mesajı sayfaya sızdı", tt.path)
+		}
+		if id := resp.Header.Get(fiber.HeaderXRequestID); tt.status == fiber.StatusInternalServerError && !strings.Contains(string(body), id) {
+			t.Errorf("500 sayfasında istek kimliği %q yok", id)
+		}
+	}
+}
+
+func TestErrorHandlerKeepsJSONForAPIClients(t *testing.T) {
+	app := newErrorApp(t)
+	req := httptest.NewRequest("GET", "/boom", nil)
+	req.Header.Set("Accept", fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("status %d, beklenen 500", resp.StatusCode)
+	}
+	if ct := resp.Header.Get(fiber.HeaderContentType); !strings.HasPrefix(ct, fiber.MIMEApplicationJSON) {
+		t.Errorf("content-type %q, beklenen JSON", ct)
+	}
+	var body struct {
+		Code      string `json:"code"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Code != "internal_error" || body.RequestID == "" || strings.Contains(body.Message, "password") {
+		t.Errorf("JSON gövdesi = %+v", body)
+	}
+}
+
+func TestErrorHandlerLogsTheRequestIDItReturns(t *testing.T) {
+	app := newErrorApp(t)
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := configslog.Log
+	configslog.Log = zap.New(core)
+	t.Cleanup(func() { configslog.Log = previous })
+
+	req := httptest.NewRequest("GET", "/boom", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "destek-42")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if got := resp.Header.Get(fiber.HeaderXRequestID); got != "destek-42" {
+		t.Errorf("X-Request-ID = %q, beklenen destek-42", got)
+	}
+	if !strings.Contains(string(body), "destek-42") {
+		t.Error("hata sayfasında referans kimliği yok")
+	}
+
+	entries := logs.FilterMessage("Fiber request error").All()
+	if len(entries) != 1 {
+		t.Fatalf("%d hata logu, beklenen 1", len(entries))
+	}
+	if got := entries[0].ContextMap()["request_id"]; got != "destek-42" {
+		t.Errorf("logdaki request_id = %v, beklenen destek-42", got)
+	}
+}
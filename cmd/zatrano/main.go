@@ -3,86 +3,148 @@ package main
 import (
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
+	"zatrano/configs/configsapp"
 	"zatrano/configs/configscsrf"
 	"zatrano/configs/configsdatabase"
 	"zatrano/configs/configslog"
 	"zatrano/configs/configssession"
+	"zatrano/middlewares"
+	"zatrano/pkg/apierrors"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/jobs"
+	"zatrano/pkg/passwordhash"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/renderer"
 	"zatrano/pkg/templatehelpers"
+	"zatrano/repositories"
 	"zatrano/routes"
+	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/template/html/v2"
-	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		panic("Error loading .env file: " + err.Error())
-	}
+	dotEnvErr := configsapp.LoadDotEnv()
+	cfg := configsapp.MustLoad()
 
 	configslog.InitLogger()
-	defer configslog.SyncLogger()
 
+	if dotEnvErr != nil {
+		configslog.SLog.Warnw(".env dosyası yüklenemedi, sistem ortam değişkenleri kullanılacak (eğer varsa)", "error", dotEnvErr)
+	}
 	configslog.SLog.Debugw("Ortam değişkenleri yüklendi ve logger başlatıldı")
 
 	configsdatabase.InitDB()
-	defer configsdatabase.CloseDB()
+
+	passwordhash.Configure(passwordhash.FromSettings(cfg.Password))
+	queryparams.SetMaxPerPage(cfg.MaxPerPage)
 
 	configssession.InitSession()
 
+	jobs.Register(services.UserImportJobType, services.NewUserService().ImportUsersJob)
+	if err := jobs.Start(cfg.JobConcurrency); err != nil {
+		configslog.Log.Fatal("İş çalıştırıcı başlatılamadı", zap.Error(err))
+	}
+
 	engine := html.New("./views", ".html")
 	engine.AddFunc("getFlashMessages", flashmessages.GetFlashMessages)
+	templatehelpers.SetLocation(cfg.Location)
+	renderer.SetUnreadNotificationCounter(services.NewNotificationService().UnreadCount)
 	engine.AddFuncMap(templatehelpers.TemplateHelpers())
 
 	app := fiber.New(fiber.Config{
-		Views: engine,
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			message := "Internal Server Error"
-
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-				message = e.Message
-			}
-
-			configslog.Log.Error("Fiber request error",
-				zap.Error(err),
-				zap.Int("status_code", code),
-				zap.String("method", c.Method()),
-				zap.String("path", c.Path()),
-				zap.String("ip", c.IP()),
-			)
+		Views:        engine,
+		ErrorHandler: errorHandler,
+	})
 
-			return c.Status(code).JSON(fiber.Map{"error": message})
-		},
+	// Sonraki tüm middleware'lerdeki panic'leri de yakalaması için ilk sırada eklenir
+	app.Use(middlewares.Recover)
+	// Statik dosyalar dahil tüm yanıtlar sıkıştırılır; bu yüzden Static'ten önce eklenir
+	app.Use(middlewares.Compression())
+	app.Static("/", "./public", fiber.Static{
+		ByteRange: true,
+		MaxAge:    int(cfg.StaticMaxAge.Seconds()),
 	})
 
-	app.Static("/", "./public")
+	prometheus.MustRegister(repositories.MetricsCollectors()...)
+	prometheus.MustRegister(middlewares.MetricsCollectors()...)
+	prometheus.MustRegister(configsdatabase.MetricsCollectors()...)
+	app.Get("/metrics", middlewares.MetricsGuard(cfg.Metrics.Token, cfg.Metrics.AllowedIPs), adaptor.HTTPHandler(promhttp.Handler()))
+
+	app.Use(middlewares.RequestIDMiddleware)
 	app.Use(configscsrf.SetupCSRF())
 	routes.SetupRoutes(app, configsdatabase.GetDB())
 
-	startServer(app)
+	startServer(app, cfg.Port, cfg.ShutdownTimeout)
 }
 
-func startServer(app *fiber.App) {
+func errorHandler(c *fiber.Ctx, err error) error {
+	// Handler'dan dönen servis ve repository hataları da durum koduna eşlenir
+	apiErr := apierrors.From(err)
+	code := apiErr.Status
+	message := apiErr.Message
+	requestID := middlewares.RequestID(c)
+
+	configslog.Log.Error("Fiber request error",
+		zap.Error(err),
+		zap.Int("status_code", code),
+		zap.String("method", c.Method()),
+		zap.String("path", c.Path()),
+		zap.String("ip", c.IP()),
+		zap.String("request_id", requestID),
+	)
+
+	// İç hata mesajları istemciye sızdırılmaz
+	if code >= fiber.StatusInternalServerError {
+		message = "Beklenmeyen bir hata oluştu"
+	}
+
+	if renderer.WantsJSON(c) {
+		return apierrors.Write(c, apiErr)
+	}
+
+	view := "errors/500"
+	switch code {
+	case fiber.StatusNotFound:
+		view = "errors/404"
+	case fiber.StatusUnauthorized, fiber.StatusForbidden:
+		view = "errors/403"
+	}
+
+	renderErr := c.Status(code).Render(view, fiber.Map{
+		"Title":     message,
+		"Code":      code,
+		"Message":   message,
+		"RequestID": requestID,
+	}, "layouts/auth")
+	if renderErr != nil {
+		configslog.Log.Error("Hata sayfası render edilemedi", zap.String("view", view), zap.Error(renderErr))
+		c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+		return c.Status(code).SendString(message)
+	}
+	return nil
+}
+
+func startServer(app *fiber.App, port int, shutdownTimeout time.Duration) {
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
-		port := os.Getenv("APP_PORT")
-		if port == "" {
-			port = "3000"
-		}
-		address := ":" + port
+		address := ":" + strconv.Itoa(port)
 		fullAddress := "http://localhost" + address
 
 		configslog.Log.Info("Uygulama başlatılıyor",
 			zap.String("address", fullAddress),
-			zap.String("port", port),
+			zap.Int("port", port),
 		)
 
 		if err := app.Listen(address); err != nil {
@@ -93,14 +155,8 @@ func startServer(app *fiber.App) {
 		}
 	}()
 
-	<-shutdown
-	configslog.Log.Info("Kapatma sinyali alındı, uygulama kapatılıyor...")
-
-	if err := app.Shutdown(); err != nil {
-		configslog.Log.Error("Sunucu kapatılırken hata oluştu", zap.Error(err))
-	} else {
-		configslog.Log.Info("Sunucu başarıyla kapatıldı")
-	}
+	sig := <-shutdown
+	configslog.Log.Info("Kapatma sinyali alındı, uygulama kapatılıyor...", zap.String("signal", sig.String()))
 
-	configslog.Log.Info("Uygulama başarıyla sonlandırıldı.")
+	gracefulShutdown(app, shutdown, shutdownTimeout)
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
+	"zatrano/pkg/jobs"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Kapatma sırası: yeni bağlantıları ve yeni işleri reddet, süren istekleri ve arka plan işlerini aynı süre içinde
+// birlikte bekle, ardından session storage, veritabanı ve en son logger. Toplam bekleme SHUTDOWN_TIMEOUT'u aşmaz.
+// Bekleme sırasında gelen ikinci sinyal beklemeden çıkışa zorlar.
+func gracefulShutdown(app *fiber.App, signals <-chan os.Signal, timeout time.Duration) {
+	go func() {
+		sig := <-signals
+		configslog.Log.Warn("İkinci kapatma sinyali alındı, beklemeden çıkılıyor", zap.String("signal", sig.String()))
+		configslog.SyncLogger()
+		os.Exit(1)
+	}()
+
+	configslog.Log.Info("Yeni bağlantılar reddediliyor, süren istekler bekleniyor", zap.Duration("timeout", timeout))
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// İşler HTTP ile paralel durdurulur; süre dolunca iptal edilen işlerin çıkması beklenir
+	configslog.SLog.Info("Süren arka plan işleri bekleniyor...")
+	jobsDone := make(chan error, 1)
+	go func() {
+		jobsDone <- jobs.Shutdown(ctx)
+	}()
+
+	start := time.Now()
+	err := app.ShutdownWithContext(ctx)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		configslog.Log.Warn("Süren istekler zaman aşımında tamamlanmadı, kapatmaya devam ediliyor", zap.Duration("timeout", timeout))
+	case err != nil:
+		configslog.Log.Error("Sunucu kapatılırken hata oluştu", zap.Error(err))
+	default:
+		configslog.Log.Info("Süren istekler tamamlandı, HTTP sunucusu kapatıldı", zap.Duration("duration", time.Since(start)))
+	}
+
+	if err := <-jobsDone; err != nil {
+		configslog.Log.Warn("Süren işler zaman aşımında tamamlanmadı, iptal edildi", zap.Duration("timeout", timeout))
+	}
+
+	// Kapatma fonksiyonları hatalarını kendileri loglar
+	configslog.SLog.Info("Session storage kapatılıyor...")
+	_ = configssession.CloseSession()
+
+	configslog.SLog.Info("Veritabanı bağlantıları kapatılıyor...")
+	_ = configsdatabase.CloseDB()
+
+	configslog.Log.Info("Uygulama başarıyla sonlandırıldı.")
+	configslog.SyncLogger()
+}
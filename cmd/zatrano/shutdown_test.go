@@ -0,0 +1,183 @@
+package main
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+)
+
+type shutdownHarness struct {
+	app     *fiber.App
+	url     string
+	entered chan struct{}
+	release chan struct{}
+	logs    *observer.ObservedLogs
+	db      *gorm.DB
+}
+
+// /slow isteği release kapanana kadar bekler
+func newShutdownHarness(t *testing.T) *shutdownHarness {
+	t.Helper()
+	db := testutil.NewDB(t)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	previousLog, previousSLog := configslog.Log, configslog.SLog
+	configslog.Log = zap.New(core)
+	configslog.SLog = configslog.Log.Sugar()
+	t.Cleanup(func() { configslog.Log, configslog.SLog = previousLog, previousSLog })
+
+	h := &shutdownHarness{
+		app:     fiber.New(fiber.Config{DisableStartupMessage: true}),
+		entered: make(chan struct{}),
+		release: make(chan struct{}),
+		logs:    logs,
+		db:      db,
+	}
+	h.app.Get("/slow", func(c *fiber.Ctx) error {
+		close(h.entered)
+		<-h.release
+		return c.SendString("tamam")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.url = "http://" + ln.Addr().String()
+	go h.app.Listener(ln)
+	return h
+}
+
+type slowResult struct {
+	body string
+	err  error
+}
+
+func (h *shutdownHarness) startSlowRequest(t *testing.T) <-chan slowResult {
+	t.Helper()
+	result := make(chan slowResult, 1)
+	go func() {
+		resp, err := http.Get(h.url + "/slow")
+		if err != nil {
+			result <- slowResult{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		result <- slowResult{body: string(body), err: err}
+	}()
+	select {
+	case <-h.entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("yavaş istek handler'a ulaşmadı")
+	}
+	return result
+}
+
+func (h *shutdownHarness) messages() []string {
+	var messages []string
+	for _, entry := range h.logs.All() {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+// Mesajlar verilen sırayla (aralarında başka mesajlar olabilir) loglanmış olmalı
+func assertLogOrder(t *testing.T, messages []string, want ...string) {
+	t.Helper()
+	next := 0
+	for _, message := range messages {
+		if next < len(want) && message == want[next] {
+			next++
+		}
+	}
+	if next != len(want) {
+		t.Errorf("log sırası %q, beklenen alt dizi %q", messages, want)
+	}
+}
+
+func TestGracefulShutdownDrainsInFlightRequests(t *testing.T) {
+	h := newShutdownHarness(t)
+	result := h.startSlowRequest(t)
+
+	done := make(chan struct{})
+	go func() {
+		gracefulShutdown(h.app, make(chan os.Signal), 5*time.Second)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("kapatma süren isteği beklemedi")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(h.release)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("istek bittikten sonra kapatma tamamlanmadı")
+	}
+	if r := <-result; r.err != nil || r.body != "tamam" {
+		t.Errorf("süren istek yanıtı = %q, %v", r.body, r.err)
+	}
+
+	assertLogOrder(t, h.messages(),
+		"Süren istekler tamamlandı, HTTP sunucusu kapatıldı",
+		"Session storage kapatılıyor...",
+		"Veritabanı bağlantıları kapatılıyor...",
+		"Uygulama başarıyla sonlandırıldı.",
+	)
+	if _, err := http.Get(h.url + "/slow"); err == nil {
+		t.Error("kapatma sonrası yeni bağlantı kabul edildi")
+	}
+}
+
+func TestGracefulShutdownGivesUpAfterTimeout(t *testing.T) {
+	h := newShutdownHarness(t)
+	defer close(h.release)
+	h.startSlowRequest(t)
+
+	timeout := 200 * time.Millisecond
+	start := time.Now()
+	done := make(chan struct{})
+	go func() {
+		gracefulShutdown(h.app, make(chan os.Signal), timeout)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("asılı istek kapatmayı süresiz bekletti")
+	}
+	if elapsed := time.Since(start); elapsed < timeout || elapsed > timeout+time.Second {
+		t.Errorf("kapatma %v sürdü, yaklaşık %v bekleniyordu", elapsed, timeout)
+	}
+
+	assertLogOrder(t, h.messages(),
+		"Süren istekler zaman aşımında tamamlanmadı, kapatmaya devam ediliyor",
+		"Session storage kapatılıyor...",
+		"Veritabanı bağlantıları kapatılıyor...",
+		"Uygulama başarıyla sonlandırıldı.",
+	)
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sqlDB.Ping(); err == nil {
+		t.Error("zaman aşımından sonra veritabanı kapatılmadı")
+	}
+}
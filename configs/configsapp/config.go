@@ -0,0 +1,429 @@
+package configsapp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	// Saat dilimi veritabanı olmayan minimal imajlarda da APP_TIMEZONE çözümlenebilsin
+	_ "time/tzdata"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	Env  string
+	Port int
+	// Kapatmada süren isteklerin tamamlanması için beklenecek en uzun süre
+	ShutdownTimeout time.Duration
+	// APP_TIMEZONE; tarihlerin gösterildiği ve tarih filtrelerinin yorumlandığı bölge
+	Location *time.Location
+	// Arka plan işlerini aynı anda çalıştıran worker sayısı
+	JobConcurrency int
+	// /public yanıtlarındaki Cache-Control max-age değeri
+	StaticMaxAge time.Duration
+	// Kiracıya ait repository'ler oturumdaki kuruluşa göre kapsamlanır
+	MultiTenant bool
+	// E-postalardaki bağlantıların kökü; sondaki / atılır
+	AppURL string
+	// Liste isteklerinde izin verilen en büyük sayfa boyutu
+	MaxPerPage int
+	// /readyz bağımlılık kontrolleri için süre sınırı
+	HealthCheckTimeout time.Duration
+	Log                LogSettings
+	Database           DatabaseSettings
+	Session            SessionSettings
+	Redis              RedisSettings
+	Metrics            MetricsSettings
+	Auth               AuthSettings
+	Cache              CacheSettings
+	Mail               MailSettings
+	Password           PasswordSettings
+}
+
+type LogSettings struct {
+	// Boşsa ortama göre seçilir
+	Level  string
+	Format string
+	// stdout, file veya both
+	Output   string
+	FilePath string
+	// Dosya bu boyuta ulaşınca döndürülür
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+	// İstek loguna yazılmayan yol önekleri
+	SkipPaths []string
+}
+
+func (s LogSettings) WritesStdout() bool {
+	return s.Output != "file"
+}
+
+func (s LogSettings) WritesFile() bool {
+	return s.Output == "file" || s.Output == "both"
+}
+
+type DatabaseSettings struct {
+	URL    string
+	Driver string
+	Host   string
+	// 0 ise sürücünün varsayılan portu kullanılır
+	Port     int
+	User     string
+	Password string
+	Name     string
+	SSLMode  string
+	TimeZone string
+
+	ConnectMaxRetries    int
+	ConnectRetryInterval time.Duration
+	MaxIdleConns         int
+	MaxOpenConns         int
+	ConnMaxLifetime      time.Duration
+
+	// Replika alanlarında 0 birincil veritabanının değerini kullan demektir
+	ReadHost            string
+	ReadPort            int
+	ReadMaxIdleConns    int
+	ReadMaxOpenConns    int
+	ReadConnMaxLifetime time.Duration
+
+	LogLevel     string
+	QueryTimeout time.Duration
+	// Bu süreyi aşan sorgular warn seviyesinde loglanır; 0 ise kapalıdır
+	SlowQueryThreshold time.Duration
+	// Deadlock ve serialization hatalarında Update/Delete/BulkUpdate kaç kez denenir; 1 tekrar denemeyi kapatır
+	RetryMaxAttempts int
+	RetryBackoff     time.Duration
+}
+
+type SessionSettings struct {
+	Driver          string
+	KeyPrefix       string
+	Table           string
+	GCInterval      time.Duration
+	IdleTimeout     time.Duration
+	AbsoluteTimeout time.Duration
+}
+
+type RedisSettings struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	DB       int
+	TLS      bool
+}
+
+type MetricsSettings struct {
+	Token string
+	// IP adresleri ya da CIDR blokları
+	AllowedIPs []string
+}
+
+type AuthSettings struct {
+	// Pencere içinde IP + hesap adı başına izin verilen başarısız giriş denemesi
+	LoginRateLimit  int
+	LoginRateWindow time.Duration
+	RememberMe      time.Duration
+	// 0 hesap kilitlemeyi kapatır
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	ResetTokenTTL    time.Duration
+	// Hesap adı (e-posta) değişikliğinde mevcut şifre istenir
+	AccountChangeNeedsPassword bool
+}
+
+type CacheSettings struct {
+	UserTTL       time.Duration
+	PermissionTTL time.Duration
+}
+
+// Host boşsa e-postalar gönderilmez, loga yazılır
+type MailSettings struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type PasswordSettings struct {
+	MinLength           int
+	RequireDigit        bool
+	RequireLetter       bool
+	RequireUpper        bool
+	RequireSymbol       bool
+	DisallowAccountName bool
+
+	HashScheme        string
+	Argon2MemoryKB    int
+	Argon2Iterations  int
+	Argon2Parallelism int
+	BcryptCost        int
+}
+
+func (c *Config) IsProduction() bool {
+	return c.Env == "production"
+}
+
+// Bulunan tüm sorunları tek seferde raporlamak için toplar
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "yapılandırma geçersiz (%d sorun):", len(e.Problems))
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(problem)
+	}
+	return b.String()
+}
+
+var current *Config
+
+// .env yoksa hata döner; çağıran uyarıp sistem ortam değişkenleriyle devam edebilir
+func LoadDotEnv() error {
+	return godotenv.Load()
+}
+
+func Load() (*Config, error) {
+	return load(os.Getenv)
+}
+
+// Yapılandırma geçersizse raporu stderr'e yazar ve süreci sonlandırır
+func MustLoad() *Config {
+	cfg, err := Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Uygulama başlatılamadı, "+err.Error())
+		os.Exit(1)
+	}
+	current = cfg
+	return cfg
+}
+
+// MustLoad henüz çağrılmadıysa ortam değişkenlerinden yükler
+func Get() *Config {
+	if current == nil {
+		return MustLoad()
+	}
+	return current
+}
+
+// Handler'lar kullanıcıya gösterilen ya da kullanıcıdan alınan tarihleri bu bölgeye göre yorumlar
+func Location() *time.Location {
+	return Get().Location
+}
+
+func load(getenv func(string) string) (*Config, error) {
+	values := make(map[string]string, len(Settings))
+	for _, setting := range Settings {
+		value := strings.TrimSpace(getenv(setting.Env))
+		if value == "" {
+			value = setting.Default
+		}
+		if len(setting.Allowed) > 0 {
+			value = strings.ToLower(value)
+		}
+		values[setting.Env] = value
+	}
+
+	var problems []string
+	for _, setting := range Settings {
+		if err := validate(setting, values); err != nil {
+			problems = append(problems, setting.Env+": "+err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	v := parsedValues(values)
+	// checkTimeZone aynı değeri doğruladığından buraya hata ulaşmamalı; ulaşırsa UTC'ye sessizce dönülmez
+	location, err := time.LoadLocation(v.str("APP_TIMEZONE"))
+	if err != nil {
+		return nil, &ValidationError{Problems: []string{"APP_TIMEZONE: " + err.Error()}}
+	}
+	return &Config{
+		Env:             v.str("APP_ENV"),
+		Port:            v.int("APP_PORT"),
+		ShutdownTimeout: v.duration("SHUTDOWN_TIMEOUT_SECONDS", time.Second),
+		Location:        location,
+		JobConcurrency:  v.int("JOBS_CONCURRENCY"),
+		StaticMaxAge:    v.duration("STATIC_MAX_AGE_SECONDS", time.Second),
+		MultiTenant:     v.bool("MULTI_TENANT"),
+		AppURL:          strings.TrimRight(v.str("APP_URL"), "/"),
+		MaxPerPage:      v.int("PAGINATION_MAX_PER_PAGE"),
+
+		HealthCheckTimeout: v.duration("HEALTH_CHECK_TIMEOUT_SECONDS", time.Second),
+		Log: LogSettings{
+			Level:      v.str("LOG_LEVEL"),
+			Format:     v.str("LOG_FORMAT"),
+			Output:     v.str("LOG_OUTPUT"),
+			FilePath:   v.str("LOG_FILE_PATH"),
+			MaxSizeMB:  v.int("LOG_MAX_SIZE_MB"),
+			MaxBackups: v.int("LOG_MAX_BACKUPS"),
+			MaxAgeDays: v.int("LOG_MAX_AGE_DAYS"),
+			Compress:   v.bool("LOG_COMPRESS"),
+			SkipPaths:  SplitList(v.str("LOG_SKIP_PATHS")),
+		},
+		Database: DatabaseSettings{
+			URL:                  v.str("DATABASE_URL"),
+			Driver:               v.str("DB_DRIVER"),
+			Host:                 v.str("DB_HOST"),
+			Port:                 v.int("DB_PORT"),
+			User:                 v.str("DB_USERNAME"),
+			Password:             v.str("DB_PASSWORD"),
+			Name:                 v.str("DB_DATABASE"),
+			SSLMode:              v.str("DB_SSL_MODE"),
+			TimeZone:             v.str("DB_TIMEZONE"),
+			ConnectMaxRetries:    v.int("DB_CONNECT_MAX_RETRIES"),
+			ConnectRetryInterval: v.duration("DB_CONNECT_RETRY_INTERVAL_SECONDS", time.Second),
+			MaxIdleConns:         v.int("DB_MAX_IDLE_CONNS"),
+			MaxOpenConns:         v.int("DB_MAX_OPEN_CONNS"),
+			ConnMaxLifetime:      v.duration("DB_CONN_MAX_LIFETIME_MINUTES", time.Minute),
+			ReadHost:             v.str("DB_READ_HOST"),
+			ReadPort:             v.int("DB_READ_PORT"),
+			ReadMaxIdleConns:     v.int("DB_READ_MAX_IDLE_CONNS"),
+			ReadMaxOpenConns:     v.int("DB_READ_MAX_OPEN_CONNS"),
+			ReadConnMaxLifetime:  v.duration("DB_READ_CONN_MAX_LIFETIME_MINUTES", time.Minute),
+			LogLevel:             v.str("DB_LOG_LEVEL"),
+			QueryTimeout:         v.duration("DB_QUERY_TIMEOUT_SECONDS", time.Second),
+			SlowQueryThreshold:   v.duration("DB_SLOW_QUERY_MS", time.Millisecond),
+			RetryMaxAttempts:     v.int("DB_RETRY_MAX_ATTEMPTS"),
+			RetryBackoff:         v.duration("DB_RETRY_BACKOFF_MS", time.Millisecond),
+		},
+		Session: SessionSettings{
+			Driver:          v.str("SESSION_DRIVER"),
+			KeyPrefix:       v.str("SESSION_KEY_PREFIX"),
+			Table:           v.str("SESSION_TABLE"),
+			GCInterval:      v.duration("SESSION_GC_INTERVAL_MINUTES", time.Minute),
+			IdleTimeout:     v.duration("SESSION_IDLE_TIMEOUT_MINUTES", time.Minute),
+			AbsoluteTimeout: v.duration("SESSION_ABSOLUTE_TIMEOUT_HOURS", time.Hour),
+		},
+		Redis: RedisSettings{
+			Host:     v.str("REDIS_HOST"),
+			Port:     v.int("REDIS_PORT"),
+			Username: v.str("REDIS_USERNAME"),
+			Password: v.str("REDIS_PASSWORD"),
+			DB:       v.int("REDIS_DB"),
+			TLS:      v.bool("REDIS_TLS"),
+		},
+		Metrics: MetricsSettings{
+			Token:      v.str("METRICS_TOKEN"),
+			AllowedIPs: SplitList(v.str("METRICS_ALLOWED_IPS")),
+		},
+		Auth: AuthSettings{
+			LoginRateLimit:             v.int("LOGIN_RATE_LIMIT"),
+			LoginRateWindow:            v.duration("LOGIN_RATE_WINDOW_SECONDS", time.Second),
+			RememberMe:                 v.duration("REMEMBER_ME_DAYS", 24*time.Hour),
+			LockoutThreshold:           v.int("ACCOUNT_LOCKOUT_THRESHOLD"),
+			LockoutDuration:            v.duration("ACCOUNT_LOCKOUT_MINUTES", time.Minute),
+			ResetTokenTTL:              v.duration("PASSWORD_RESET_TOKEN_MINUTES", time.Minute),
+			AccountChangeNeedsPassword: v.bool("PROFILE_ACCOUNT_CHANGE_REQUIRES_PASSWORD"),
+		},
+		Cache: CacheSettings{
+			UserTTL:       v.duration("USER_CACHE_TTL_SECONDS", time.Second),
+			PermissionTTL: v.duration("PERMISSION_CACHE_TTL_SECONDS", time.Second),
+		},
+		Mail: MailSettings{
+			Host:     v.str("SMTP_HOST"),
+			Port:     v.int("SMTP_PORT"),
+			Username: v.str("SMTP_USERNAME"),
+			Password: v.str("SMTP_PASSWORD"),
+			From:     v.str("SMTP_FROM"),
+		},
+		Password: PasswordSettings{
+			MinLength:           v.int("PASSWORD_MIN_LENGTH"),
+			RequireDigit:        v.bool("PASSWORD_REQUIRE_DIGIT"),
+			RequireLetter:       v.bool("PASSWORD_REQUIRE_LETTER"),
+			RequireUpper:        v.bool("PASSWORD_REQUIRE_UPPER"),
+			RequireSymbol:       v.bool("PASSWORD_REQUIRE_SYMBOL"),
+			DisallowAccountName: v.bool("PASSWORD_DISALLOW_ACCOUNT_NAME"),
+			HashScheme:          v.str("PASSWORD_HASH_SCHEME"),
+			Argon2MemoryKB:      v.int("ARGON2_MEMORY_KB"),
+			Argon2Iterations:    v.int("ARGON2_ITERATIONS"),
+			Argon2Parallelism:   v.int("ARGON2_PARALLELISM"),
+			BcryptCost:          v.int("BCRYPT_COST"),
+		},
+	}, nil
+}
+
+func validate(setting Setting, values map[string]string) error {
+	value := values[setting.Env]
+	if value == "" {
+		if setting.Required || (setting.RequiredWhen != nil && setting.RequiredWhen(values)) {
+			return errors.New("zorunlu ancak tanımlı değil")
+		}
+		return nil
+	}
+
+	shown := strconv.Quote(value)
+	if setting.Secret {
+		shown = "değer"
+	}
+
+	switch setting.Type {
+	case TypeInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s bir tam sayı değil", shown)
+		}
+		if n < setting.Min {
+			return fmt.Errorf("%d, en az %d olmalı", n, setting.Min)
+		}
+		if setting.Max > 0 && n > setting.Max {
+			return fmt.Errorf("%d, en fazla %d olmalı", n, setting.Max)
+		}
+	case TypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s true/false değil", shown)
+		}
+	}
+
+	if len(setting.Allowed) > 0 && !contains(setting.Allowed, value) {
+		return fmt.Errorf("%s geçersiz, izin verilenler: %s", shown, strings.Join(setting.Allowed, ", "))
+	}
+	if setting.Check != nil {
+		if err := setting.Check(value); err != nil {
+			return fmt.Errorf("%s geçersiz: %w", shown, err)
+		}
+	}
+	return nil
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Doğrulamadan geçmiş değerler; dönüşümler hata veremez
+type parsedValues map[string]string
+
+func (v parsedValues) str(key string) string {
+	return v[key]
+}
+
+func (v parsedValues) int(key string) int {
+	n, _ := strconv.Atoi(v[key])
+	return n
+}
+
+func (v parsedValues) bool(key string) bool {
+	b, _ := strconv.ParseBool(v[key])
+	return b
+}
+
+func (v parsedValues) duration(key string, unit time.Duration) time.Duration {
+	return time.Duration(v.int(key)) * unit
+}
@@ -0,0 +1,202 @@
+package configsapp
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeString Type = "string"
+	TypeInt    Type = "int"
+	TypeBool   Type = "bool"
+)
+
+// Uygulamanın okuduğu bir ortam değişkeninin tanımı
+type Setting struct {
+	Env      string
+	Type     Type
+	Default  string
+	Required bool
+	// Ayar yalnızca diğer ayarların (varsayılanlar uygulanmış) değerine göre zorunluysa
+	RequiredWhen func(values map[string]string) bool
+	// Boş değilse değer bu listeden biri olmalıdır; karşılaştırma büyük/küçük harf duyarsızdır
+	Allowed []string
+	// Yalnızca TypeInt için; Max 0 ise üst sınır yoktur
+	Min, Max int
+	Check    func(value string) error
+	// Raporda değeri yazdırılmaz
+	Secret bool
+}
+
+var Settings = []Setting{
+	{Env: "APP_ENV", Type: TypeString, Default: "development", Allowed: []string{"development", "production"}},
+	{Env: "APP_PORT", Type: TypeInt, Default: "3000", Min: 1, Max: 65535},
+	{Env: "SHUTDOWN_TIMEOUT_SECONDS", Type: TypeInt, Default: "30", Min: 1},
+	// Veritabanı UTC tutar; ekrandaki tarihler ve tarih filtreleri bu bölgeye göre yorumlanır
+	{Env: "APP_TIMEZONE", Type: TypeString, Default: "Europe/Istanbul", Check: checkTimeZone},
+	{Env: "JOBS_CONCURRENCY", Type: TypeInt, Default: "2", Min: 1, Max: 64},
+	// /public dosyaları parmak izi taşımadığı için kısa tutulur; süre dolunca Last-Modified ile doğrulanır
+	{Env: "STATIC_MAX_AGE_SECONDS", Type: TypeInt, Default: "3600"},
+	// Açıkken kiracıya ait repository'ler organization_id ile kapsamlanır; kuruluşu olmayan kullanıcı veri göremez
+	{Env: "MULTI_TENANT", Type: TypeBool, Default: "false"},
+	{Env: "APP_URL", Type: TypeString, Default: "http://localhost:3000", Check: checkAppURL},
+	{Env: "PAGINATION_MAX_PER_PAGE", Type: TypeInt, Default: "100", Min: 1},
+	{Env: "HEALTH_CHECK_TIMEOUT_SECONDS", Type: TypeInt, Default: "2", Min: 1},
+	{Env: "LOG_LEVEL", Type: TypeString, Allowed: []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}},
+	// Boşsa production'da json, diğer ortamlarda console kullanılır
+	{Env: "LOG_FORMAT", Type: TypeString, Allowed: []string{"json", "console"}},
+	{Env: "LOG_OUTPUT", Type: TypeString, Default: "stdout", Allowed: []string{"stdout", "file", "both"}},
+	{Env: "LOG_FILE_PATH", Type: TypeString, RequiredWhen: writesLogFile},
+	{Env: "LOG_MAX_SIZE_MB", Type: TypeInt, Default: "100", Min: 1},
+	{Env: "LOG_MAX_BACKUPS", Type: TypeInt, Default: "7"},
+	{Env: "LOG_MAX_AGE_DAYS", Type: TypeInt, Default: "30"},
+	{Env: "LOG_COMPRESS", Type: TypeBool, Default: "false"},
+	{Env: "LOG_SKIP_PATHS", Type: TypeString, Default: "/css,/js,/favicon.ico,/metrics,/healthz,/readyz"},
+
+	{Env: "DATABASE_URL", Type: TypeString, Check: checkDatabaseURL, Secret: true},
+	{Env: "DB_DRIVER", Type: TypeString, Default: "postgres", Allowed: []string{"postgres", "mysql", "sqlite"}},
+	{Env: "DB_HOST", Type: TypeString, Default: "localhost"},
+	// Boşsa sürücünün varsayılan portu kullanılır
+	{Env: "DB_PORT", Type: TypeInt, Min: 1, Max: 65535},
+	{Env: "DB_USERNAME", Type: TypeString, Default: "postgres"},
+	{Env: "DB_PASSWORD", Type: TypeString, RequiredWhen: needsDatabasePassword, Secret: true},
+	{Env: "DB_DATABASE", Type: TypeString, Default: "myapp"},
+	{Env: "DB_SSL_MODE", Type: TypeString, Default: "disable", Allowed: []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}},
+	{Env: "DB_TIMEZONE", Type: TypeString, Default: "UTC", Check: checkTimeZone},
+	{Env: "DB_CONNECT_MAX_RETRIES", Type: TypeInt, Default: "5"},
+	{Env: "DB_CONNECT_RETRY_INTERVAL_SECONDS", Type: TypeInt, Default: "2"},
+	{Env: "DB_MAX_IDLE_CONNS", Type: TypeInt, Default: "10"},
+	{Env: "DB_MAX_OPEN_CONNS", Type: TypeInt, Default: "100", Min: 1},
+	{Env: "DB_CONN_MAX_LIFETIME_MINUTES", Type: TypeInt, Default: "60"},
+	{Env: "DB_READ_HOST", Type: TypeString},
+	// Replika ayarları boşsa birincil veritabanının değerleri kullanılır
+	{Env: "DB_READ_PORT", Type: TypeInt, Min: 1, Max: 65535},
+	{Env: "DB_READ_MAX_IDLE_CONNS", Type: TypeInt},
+	{Env: "DB_READ_MAX_OPEN_CONNS", Type: TypeInt, Min: 1},
+	{Env: "DB_READ_CONN_MAX_LIFETIME_MINUTES", Type: TypeInt},
+	{Env: "DB_LOG_LEVEL", Type: TypeString, Default: "info", Allowed: []string{"silent", "error", "warn", "info"}},
+	{Env: "DB_QUERY_TIMEOUT_SECONDS", Type: TypeInt, Default: "30"},
+	// 0 yavaş sorgu kaydını kapatır
+	{Env: "DB_SLOW_QUERY_MS", Type: TypeInt, Default: "200"},
+	{Env: "DB_RETRY_MAX_ATTEMPTS", Type: TypeInt, Default: "3", Min: 1},
+	{Env: "DB_RETRY_BACKOFF_MS", Type: TypeInt, Default: "50", Min: 1},
+
+	{Env: "SESSION_DRIVER", Type: TypeString, Default: "memory", Allowed: []string{"memory", "redis", "postgres", "database"}},
+	{Env: "SESSION_KEY_PREFIX", Type: TypeString, Default: "session:"},
+	{Env: "SESSION_TABLE", Type: TypeString, Default: "sessions"},
+	{Env: "SESSION_GC_INTERVAL_MINUTES", Type: TypeInt, Default: "10", Min: 1},
+	{Env: "SESSION_IDLE_TIMEOUT_MINUTES", Type: TypeInt, Default: "30", Min: 1},
+	{Env: "SESSION_ABSOLUTE_TIMEOUT_HOURS", Type: TypeInt, Default: "12", Min: 1},
+
+	{Env: "REDIS_HOST", Type: TypeString, Default: "localhost"},
+	{Env: "REDIS_PORT", Type: TypeInt, Default: "6379", Min: 1, Max: 65535},
+	{Env: "REDIS_USERNAME", Type: TypeString},
+	{Env: "REDIS_PASSWORD", Type: TypeString, Secret: true},
+	{Env: "REDIS_DB", Type: TypeInt, Default: "0"},
+	{Env: "REDIS_TLS", Type: TypeBool, Default: "false"},
+
+	// /metrics yalnızca bu token'ı Bearer olarak gönderen ya da izinli adreslerden gelen isteklere açıktır
+	{Env: "METRICS_TOKEN", Type: TypeString, Secret: true},
+	{Env: "METRICS_ALLOWED_IPS", Type: TypeString, Default: "127.0.0.1,::1", Check: checkIPList},
+
+	{Env: "LOGIN_RATE_LIMIT", Type: TypeInt, Default: "5", Min: 1},
+	{Env: "LOGIN_RATE_WINDOW_SECONDS", Type: TypeInt, Default: "300", Min: 1},
+	{Env: "REMEMBER_ME_DAYS", Type: TypeInt, Default: "30", Min: 1},
+	// 0 hesap kilitlemeyi kapatır
+	{Env: "ACCOUNT_LOCKOUT_THRESHOLD", Type: TypeInt, Default: "5"},
+	{Env: "ACCOUNT_LOCKOUT_MINUTES", Type: TypeInt, Default: "15", Min: 1},
+	{Env: "PASSWORD_RESET_TOKEN_MINUTES", Type: TypeInt, Default: "60", Min: 1},
+	{Env: "PROFILE_ACCOUNT_CHANGE_REQUIRES_PASSWORD", Type: TypeBool, Default: "true"},
+
+	{Env: "USER_CACHE_TTL_SECONDS", Type: TypeInt, Default: "30"},
+	{Env: "PERMISSION_CACHE_TTL_SECONDS", Type: TypeInt, Default: "60"},
+
+	// SMTP_HOST boşsa e-postalar gönderilmez, loga yazılır
+	{Env: "SMTP_HOST", Type: TypeString},
+	{Env: "SMTP_PORT", Type: TypeInt, Default: "587", Min: 1, Max: 65535},
+	{Env: "SMTP_USERNAME", Type: TypeString},
+	{Env: "SMTP_PASSWORD", Type: TypeString, Secret: true},
+	{Env: "SMTP_FROM", Type: TypeString, Default: "no-reply@localhost"},
+
+	{Env: "PASSWORD_MIN_LENGTH", Type: TypeInt, Default: "8", Min: 1},
+	{Env: "PASSWORD_REQUIRE_DIGIT", Type: TypeBool, Default: "true"},
+	{Env: "PASSWORD_REQUIRE_LETTER", Type: TypeBool, Default: "true"},
+	{Env: "PASSWORD_REQUIRE_UPPER", Type: TypeBool, Default: "false"},
+	{Env: "PASSWORD_REQUIRE_SYMBOL", Type: TypeBool, Default: "false"},
+	{Env: "PASSWORD_DISALLOW_ACCOUNT_NAME", Type: TypeBool, Default: "true"},
+	{Env: "PASSWORD_HASH_SCHEME", Type: TypeString, Default: "argon2id", Allowed: []string{"argon2id", "bcrypt"}},
+	{Env: "ARGON2_MEMORY_KB", Type: TypeInt, Default: "65536", Min: 1024},
+	{Env: "ARGON2_ITERATIONS", Type: TypeInt, Default: "3", Min: 1},
+	{Env: "ARGON2_PARALLELISM", Type: TypeInt, Default: "2", Min: 1, Max: 255},
+	// bcrypt'in kabul ettiği aralık
+	{Env: "BCRYPT_COST", Type: TypeInt, Default: "10", Min: 4, Max: 31},
+}
+
+func writesLogFile(values map[string]string) bool {
+	return values["LOG_OUTPUT"] == "file" || values["LOG_OUTPUT"] == "both"
+}
+
+// Şifre DATABASE_URL içinde verilebilir; sqlite şifre kullanmaz
+func needsDatabasePassword(values map[string]string) bool {
+	return values["DATABASE_URL"] == "" && values["DB_DRIVER"] != "sqlite"
+}
+
+// Ayrıntılı çözümleme configsdatabase'dedir; burada yalnızca biçim ve şema denetlenir.
+// Hata mesajları URL'i (ve dolayısıyla şifreyi) içermez.
+func checkDatabaseURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return errors.New("URL çözümlenemedi")
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "postgres", "postgresql", "mysql", "sqlite", "sqlite3":
+		return nil
+	default:
+		return errors.New("desteklenmeyen şema (postgres, mysql, sqlite)")
+	}
+}
+
+// Virgülle ayrılmış IP adresleri ya da CIDR blokları
+func checkIPList(value string) error {
+	for _, item := range SplitList(value) {
+		if _, _, err := net.ParseCIDR(item); err == nil {
+			continue
+		}
+		if net.ParseIP(item) == nil {
+			return errors.New(strconv.Quote(item) + " bir IP adresi ya da CIDR bloğu değil")
+		}
+	}
+	return nil
+}
+
+// Boş öğeler atlanır
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func checkAppURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("http(s) ile başlayan tam bir adres olmalı")
+	}
+	return nil
+}
+
+func checkTimeZone(value string) error {
+	if _, err := time.LoadLocation(value); err != nil {
+		return errors.New("bilinmeyen zaman dilimi")
+	}
+	return nil
+}
@@ -4,7 +4,10 @@ import (
 	"strings"
 	"time"
 	"zatrano/configs/configslog"
+	"zatrano/pkg/csrftoken"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/renderer"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/csrf"
@@ -12,8 +15,13 @@ import (
 	"go.uber.org/zap"
 )
 
+// /api altındaki rotalar cookie ile değil Authorization: Bearer ile doğrulanır;
+// tarayıcı başlığı kendiliğinden eklemediği için CSRF'e açık değildir.
+// Sağlık kontrolleri de orkestratörün cookie almaması için muaf tutulur.
 var csrfExemptPaths = []string{
-	// "rotalar",
+	"/api/",
+	"/healthz",
+	"/readyz",
 }
 
 func SetupCSRF() fiber.Handler {
@@ -26,31 +34,23 @@ func SetupCSRF() fiber.Handler {
 		Expiration:     1 * time.Hour,
 		KeyGenerator:   utils.UUID,
 		ContextKey:     "csrf",
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			configslog.Log.Warn("CSRF validation failed",
-				zap.Error(err),
-				zap.String("ip", c.IP()),
-				zap.String("path", c.Path()),
-				zap.String("method", c.Method()),
-			)
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güvenlik doğrulaması başarısız oldu. Lütfen sayfayı yenileyip tekrar deneyin.")
-			return c.Redirect("/auth/login", fiber.StatusSeeOther)
-		},
+		ErrorHandler:   csrfErrorHandler,
 		Next: func(c *fiber.Ctx) bool {
+			if isExempt(c.Path()) {
+				configslog.Log.Debug("CSRF koruması atlanıyor (Next)", zap.String("path", c.Path()))
+				return true
+			}
+
+			// Şablonlar jetonu her yanıtta maskeleyerek yazar (csrftoken.Mask); doğrulamadan önce çözülür
 			token := c.Get("X-CSRF-Token")
 			if token == "" {
 				token = c.FormValue("csrf_token")
-				if token != "" {
-					c.Request().Header.Set("X-CSRF-Token", token)
-				}
 			}
-
-			path := c.Path()
-			for _, exemptPath := range csrfExemptPaths {
-				if strings.HasPrefix(path, exemptPath) {
-					configslog.Log.Debug("CSRF koruması atlanıyor (Next)", zap.String("path", path))
-					return true
-				}
+			if raw, ok := csrftoken.Unmask(token); ok {
+				token = raw
+			}
+			if token != "" {
+				c.Request().Header.Set("X-CSRF-Token", token)
 			}
 			return false
 		},
@@ -59,3 +59,34 @@ func SetupCSRF() fiber.Handler {
 	configslog.SLog.Info("CSRF middleware yapılandırıldı", zap.Strings("exempt_paths", csrfExemptPaths))
 	return csrf.New(config)
 }
+
+func isExempt(path string) bool {
+	for _, exemptPath := range csrfExemptPaths {
+		if strings.HasPrefix(path, exemptPath) || path+"/" == exemptPath {
+			return true
+		}
+	}
+	return false
+}
+
+// AJAX istekleri yönlendirme yerine {"error", "request_id"} gövdeli 403 alır
+func csrfErrorHandler(c *fiber.Ctx, err error) error {
+	configslog.Log.Warn("CSRF validation failed",
+		zap.Error(err),
+		zap.String("ip", c.IP()),
+		zap.String("path", c.Path()),
+		zap.String("method", c.Method()),
+	)
+
+	message := i18n.T(c, "common.csrf_failed")
+	if wantsJSON(c) {
+		return renderer.JSONError(c, fiber.StatusForbidden, message, nil)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+// fetch çoğu zaman Accept göndermez; X-Requested-With başlığı da JSON tercihi sayılır
+func wantsJSON(c *fiber.Ctx) bool {
+	return renderer.WantsJSON(c) || strings.EqualFold(c.Get(fiber.HeaderXRequestedWith), "XMLHttpRequest")
+}
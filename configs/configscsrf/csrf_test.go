@@ -0,0 +1,164 @@
+package configscsrf
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"zatrano/pkg/csrftoken"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMaskedTokenIsAccepted(t *testing.T) {
+	testutil.Setup()
+	app := fiber.New()
+	app.Use(SetupCSRF())
+	app.Get("/form", func(c *fiber.Ctx) error {
+		token, _ := c.Locals("csrf").(string)
+		return c.SendString(csrftoken.Mask(token))
+	})
+	app.Post("/form", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/form", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	masked, _ := io.ReadAll(resp.Body)
+	var cookie string
+	for _, c := range resp.Cookies() {
+		if c.Name == "csrf_" {
+			cookie = c.Name + "=" + c.Value
+		}
+	}
+	if cookie == "" || len(masked) == 0 {
+		t.Fatalf("jeton alınamadı: cookie %q, gövde %q", cookie, masked)
+	}
+
+	post := func(token string) int {
+		form := url.Values{"csrf_token": {token}}.Encode()
+		req := httptest.NewRequest(fiber.MethodPost, "/form", strings.NewReader(form))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+		req.Header.Set("Cookie", cookie)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+
+	if status := post(string(masked)); status != fiber.StatusOK {
+		t.Errorf("maskelenmiş jetonla POST: status %d, beklenen 200", status)
+	}
+	if status := post(csrftoken.Mask("baska-bir-jeton")); status == fiber.StatusOK {
+		t.Error("yanlış jeton kabul edildi")
+	}
+}
+
+// /form jetonu gövdede döner; /form ve /api/v1/items POST'ları "ok" yazar
+func newCSRFApp(t *testing.T) *fiber.App {
+	t.Helper()
+	testutil.Setup()
+	app := fiber.New()
+	app.Use(SetupCSRF())
+	app.Get("/form", func(c *fiber.Ctx) error {
+		token, _ := c.Locals("csrf").(string)
+		return c.SendString(token)
+	})
+	ok := func(c *fiber.Ctx) error { return c.SendString("ok") }
+	app.Post("/form", ok)
+	app.Post("/api/v1/items", ok)
+	app.Post("/apiary", ok)
+	return app
+}
+
+func fetchToken(t *testing.T, app *fiber.App) (token, cookie string) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/form", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	for _, c := range resp.Cookies() {
+		if c.Name == "csrf_" {
+			cookie = c.Name + "=" + c.Value
+		}
+	}
+	if cookie == "" || len(body) == 0 {
+		t.Fatalf("jeton alınamadı: cookie %q, gövde %q", cookie, body)
+	}
+	return string(body), cookie
+}
+
+func ajaxPost(t *testing.T, app *fiber.App, path, cookie, token string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(`{}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderXRequestedWith, "XMLHttpRequest")
+	if cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+	if token != "" {
+		req.Header.Set("X-CSRF-Token", token)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestAJAXPostWithHeaderSucceeds(t *testing.T) {
+	app := newCSRFApp(t)
+	token, cookie := fetchToken(t, app)
+
+	for name, header := range map[string]string{"ham": token, "maskelenmiş": csrftoken.Mask(token)} {
+		if resp := ajaxPost(t, app, "/form", cookie, header); resp.StatusCode != fiber.StatusOK {
+			t.Errorf("%s jetonlu AJAX POST: status %d, beklenen 200", name, resp.StatusCode)
+		}
+	}
+}
+
+func TestAJAXPostWithoutHeaderReturnsJSONError(t *testing.T) {
+	app := newCSRFApp(t)
+	_, cookie := fetchToken(t, app)
+
+	resp := ajaxPost(t, app, "/form", cookie, "")
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Fatalf("status %d, beklenen 403", resp.StatusCode)
+	}
+	if ct := resp.Header.Get(fiber.HeaderContentType); !strings.HasPrefix(ct, fiber.MIMEApplicationJSON) {
+		t.Fatalf("Content-Type = %q, JSON bekleniyordu", ct)
+	}
+	var body struct {
+		Code      string `json:"code"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("gövde çözülemedi: %v", err)
+	}
+	if body.Code != "forbidden" || body.Message != i18n.Translate(i18n.DefaultLocale, "common.csrf_failed") || body.RequestID == "" {
+		t.Errorf("beklenmeyen hata gövdesi: %+v", body)
+	}
+}
+
+func TestAPIPostsNeedNoToken(t *testing.T) {
+	app := newCSRFApp(t)
+
+	if resp := ajaxPost(t, app, "/api/v1/items", "", ""); resp.StatusCode != fiber.StatusOK {
+		t.Errorf("/api POST: status %d, beklenen 200", resp.StatusCode)
+	}
+	// Yalnızca /api altı muaftır; aynı önekle başlayan başka yollar korunur
+	if resp := ajaxPost(t, app, "/apiary", "", ""); resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("/apiary POST: status %d, beklenen 403", resp.StatusCode)
+	}
+}
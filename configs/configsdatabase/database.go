@@ -1,23 +1,34 @@
 package configsdatabase
 
 import (
-	"os"
+	"context"
+	"database/sql"
 	"strconv"
+	"strings"
 	"time"
 
-	"zatrano/configs/configsenv"
+	"zatrano/configs/configsapp"
 	"zatrano/configs/configslog"
+	"zatrano/pkg/health"
+	"zatrano/pkg/turkishsearch"
 
-	"github.com/joho/godotenv"
 	"go.uber.org/zap"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 var DB *gorm.DB
 
+var (
+	queryTimeout     time.Duration
+	retryMaxAttempts int
+	retryBackoff     time.Duration
+)
+
+var location = time.UTC
+
 type DatabaseConfig struct {
+	Driver   string
 	Host     string
 	Port     int
 	User     string
@@ -25,36 +36,25 @@ type DatabaseConfig struct {
 	Name     string
 	SSLMode  string
 	TimeZone string
+	// DATABASE_URL sorgu dizesindeki sürücüye özgü ek parametreler
+	Params map[string]string
 }
 
 func InitDB() {
-	err := godotenv.Load()
-	if err != nil {
-		configslog.SLog.Warnw(".env dosyası yüklenemedi, sistem ortam değişkenleri kullanılacak (eğer varsa)", "error", err)
-	} else {
-		configslog.SLog.Info(".env dosyası başarıyla yüklendi")
-	}
-
-	portStr := configsenv.GetEnvWithDefault("DB_PORT", "5432")
-	port, err := strconv.Atoi(portStr)
+	settings := configsapp.Get().Database
+	dbConfig, source, err := loadDatabaseConfig(settings)
 	if err != nil {
-		configslog.SLog.Fatalw("Invalid DB_PORT environment variable",
-			"value", portStr,
-			"error", err,
+		configslog.Log.Fatal("Veritabanı yapılandırması geçersiz",
+			zap.String("source", source),
+			zap.String("driver", dbConfig.Driver),
+			zap.Strings("supported", supportedDrivers),
+			zap.Error(err),
 		)
 	}
 
-	dbConfig := DatabaseConfig{
-		Host:     configsenv.GetEnvWithDefault("DB_HOST", "localhost"),
-		Port:     port,
-		User:     configsenv.GetEnvWithDefault("DB_USERNAME", "postgres"),
-		Password: configsenv.GetEnvWithDefault("DB_PASSWORD", ""),
-		Name:     configsenv.GetEnvWithDefault("DB_DATABASE", "myapp"),
-		SSLMode:  configsenv.GetEnvWithDefault("DB_SSL_MODE", "disable"),
-		TimeZone: configsenv.GetEnvWithDefault("DB_TIMEZONE", "UTC"),
-	}
-
 	configslog.Log.Info("Database configuration loaded",
+		zap.String("source", source),
+		zap.String("driver", dbConfig.Driver),
 		zap.String("host", dbConfig.Host),
 		zap.Int("port", dbConfig.Port),
 		zap.String("user", dbConfig.User),
@@ -63,21 +63,14 @@ func InitDB() {
 		zap.String("timezone", dbConfig.TimeZone),
 	)
 
-	dsn := "host=" + dbConfig.Host +
-		" user=" + dbConfig.User +
-		" password=" + dbConfig.Password +
-		" dbname=" + dbConfig.Name +
-		" port=" + strconv.Itoa(dbConfig.Port) +
-		" sslmode=" + dbConfig.SSLMode +
-		" TimeZone=" + dbConfig.TimeZone
+	loc, err := time.LoadLocation(dbConfig.TimeZone)
+	if err != nil {
+		configslog.Log.Fatal("Veritabanı saat dilimi yüklenemedi", zap.String("timezone", dbConfig.TimeZone), zap.Error(err))
+	}
+	location = loc
 
 	var gormerr error
-	DB, gormerr = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(getGormLogLevel()),
-		NowFunc: func() time.Time {
-			return time.Now().UTC()
-		},
-	})
+	DB, gormerr = openWithRetry(dbConfig)
 
 	if gormerr != nil {
 		configslog.Log.Fatal("Failed to connect to database",
@@ -94,23 +87,89 @@ func InitDB() {
 		configslog.Log.Fatal("Failed to get underlying sql.DB instance", zap.Error(err))
 	}
 
-	maxIdleConns := configsenv.GetEnvAsInt("DB_MAX_IDLE_CONNS", 10)
-	maxOpenConns := configsenv.GetEnvAsInt("DB_MAX_OPEN_CONNS", 100)
-	connMaxLifetimeMinutes := configsenv.GetEnvAsInt("DB_CONN_MAX_LIFETIME_MINUTES", 60)
+	queryTimeout = settings.QueryTimeout
+	retryMaxAttempts = settings.RetryMaxAttempts
+	retryBackoff = settings.RetryBackoff
+
+	// SQLite tek dosya/bellek üzerinde çalışır; havuz ayarları anlamsızdır
+	if dbConfig.Driver == DriverSQLite {
+		configslog.Log.Info("Database connection established successfully (sqlite, havuz ayarları atlandı)",
+			zap.String("database", dbConfig.Name),
+			zap.Duration("query_timeout", queryTimeout),
+		)
+	} else {
+		configurePool(sqlDB, settings)
+	}
+
+	if dbConfig.Driver == DriverPostgres {
+		turkishsearch.SetUnaccent(hasExtension(DB, "unaccent"))
+	}
+
+	initReadDB(dbConfig, settings)
 
-	sqlDB.SetMaxIdleConns(maxIdleConns)
-	sqlDB.SetMaxOpenConns(maxOpenConns)
-	sqlDB.SetConnMaxLifetime(time.Duration(connMaxLifetimeMinutes) * time.Minute)
+	health.Register("database", func(ctx context.Context) error {
+		return sqlDB.PingContext(ctx)
+	})
+}
+
+// Eklenti yoksa arama Türkçe katlamayla sınırlı kalır; uygulama yine de çalışır
+func hasExtension(db *gorm.DB, name string) bool {
+	var exists bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = ?)", name).Scan(&exists).Error; err != nil {
+		configslog.Log.Warn("Postgres eklentisi sorgulanamadı", zap.String("extension", name), zap.Error(err))
+		return false
+	}
+	if !exists {
+		configslog.Log.Warn("Postgres eklentisi kurulu değil; arama yalnızca Türkçe karakterleri katlayacak", zap.String("extension", name))
+	}
+	return exists
+}
+
+func configurePool(sqlDB *sql.DB, settings configsapp.DatabaseSettings) {
+	sqlDB.SetMaxIdleConns(settings.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(settings.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(settings.ConnMaxLifetime)
 
 	configslog.Log.Info("Database connection established successfully",
-		zap.Int("max_idle_conns", maxIdleConns),
-		zap.Int("max_open_conns", maxOpenConns),
-		zap.Int("conn_max_lifetime_minutes", connMaxLifetimeMinutes),
+		zap.Int("max_idle_conns", settings.MaxIdleConns),
+		zap.Int("max_open_conns", settings.MaxOpenConns),
+		zap.Duration("conn_max_lifetime", settings.ConnMaxLifetime),
+		zap.Duration("query_timeout", queryTimeout),
 	)
 }
 
+func buildDSN(dbConfig DatabaseConfig) string {
+	pairs := [][2]string{
+		{"host", dbConfig.Host},
+		{"user", dbConfig.User},
+		{"password", dbConfig.Password},
+		{"dbname", dbConfig.Name},
+		{"port", strconv.Itoa(dbConfig.Port)},
+		{"sslmode", dbConfig.SSLMode},
+		{"TimeZone", dbConfig.TimeZone},
+	}
+	for _, key := range sortedParamKeys(dbConfig.Params) {
+		pairs = append(pairs, [2]string{key, dbConfig.Params[key]})
+	}
+
+	parts := make([]string, len(pairs))
+	for i, pair := range pairs {
+		parts[i] = pair[0] + "=" + quoteDSNValue(pair[1])
+	}
+	return strings.Join(parts, " ")
+}
+
+func openGorm(dbConfig DatabaseConfig) (*gorm.DB, error) {
+	return gorm.Open(dialector(dbConfig), &gorm.Config{
+		Logger: newQueryLogger(getGormLogLevel(), configsapp.Get().Database.SlowQueryThreshold),
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+	})
+}
+
 func getGormLogLevel() logger.LogLevel {
-	switch os.Getenv("DB_LOG_LEVEL") {
+	switch configsapp.Get().Database.LogLevel {
 	case "silent":
 		return logger.Silent
 	case "error":
@@ -129,7 +188,22 @@ func GetDB() *gorm.DB {
 	return DB
 }
 
+func GetQueryTimeout() time.Duration {
+	return queryTimeout
+}
+
+// Repository'lerin varsayılan tekrar deneme politikası; InitDB çağrılmadıysa tekrar deneme kapalıdır
+func GetRetryPolicy() (int, time.Duration) {
+	return retryMaxAttempts, retryBackoff
+}
+
+func GetLocation() *time.Location {
+	return location
+}
+
 func CloseDB() error {
+	closeReadDB()
+
 	if DB == nil {
 		configslog.SLog.Info("Database connection already closed or not initialized.")
 		return nil
@@ -0,0 +1,85 @@
+package configsdatabase
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+var supportedDrivers = []string{DriverPostgres, DriverMySQL, DriverSQLite}
+
+func isSupportedDriver(driver string) bool {
+	for _, supported := range supportedDrivers {
+		if driver == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultPort(driver string) string {
+	if driver == DriverMySQL {
+		return "3306"
+	}
+	return "5432"
+}
+
+func dialector(dbConfig DatabaseConfig) gorm.Dialector {
+	switch dbConfig.Driver {
+	case DriverMySQL:
+		return mysql.Open(buildMySQLDSN(dbConfig))
+	case DriverSQLite:
+		return sqlite.Open(buildSQLiteDSN(dbConfig))
+	default:
+		return postgres.Open(buildDSN(dbConfig))
+	}
+}
+
+func buildMySQLDSN(dbConfig DatabaseConfig) string {
+	cfg := mysqldriver.NewConfig()
+	cfg.User = dbConfig.User
+	cfg.Passwd = dbConfig.Password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(dbConfig.Host, strconv.Itoa(dbConfig.Port))
+	cfg.DBName = dbConfig.Name
+	cfg.ParseTime = true
+	cfg.Loc = location
+	cfg.Params = map[string]string{"charset": "utf8mb4"}
+	for key, value := range dbConfig.Params {
+		cfg.Params[key] = value
+	}
+	return cfg.FormatDSN()
+}
+
+// DB_DATABASE dosya yoludur; ":memory:" bağlantılar arasında paylaşılan bellek içi veritabanı açar (testler için)
+func buildSQLiteDSN(dbConfig DatabaseConfig) string {
+	query := url.Values{"_foreign_keys": {"on"}}
+	for key, value := range dbConfig.Params {
+		query.Set(key, value)
+	}
+	if dbConfig.Name == ":memory:" {
+		query.Set("cache", "shared")
+		return "file::memory:?" + query.Encode()
+	}
+	return dbConfig.Name + "?" + query.Encode()
+}
+
+// Aktif sürücü adı; Postgres'e özgü SQL üreten yerler buna göre dallanır
+func Driver() string {
+	if DB == nil {
+		return DriverPostgres
+	}
+	return DB.Dialector.Name()
+}
@@ -0,0 +1,108 @@
+package configsdatabase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"zatrano/configs/configsapp"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestConfigFromSettingsSelectsDriver(t *testing.T) {
+	tests := []struct {
+		driver   string
+		want     string
+		wantPort int
+	}{
+		{"postgres", DriverPostgres, 5432},
+		{"MySQL", DriverMySQL, 3306},
+		{"sqlite", DriverSQLite, 5432},
+	}
+	for _, tt := range tests {
+		cfg, err := configFromSettings(configsapp.DatabaseSettings{Driver: tt.driver, Name: "app"})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.driver, err)
+		}
+		if cfg.Driver != tt.want || cfg.Port != tt.wantPort {
+			t.Errorf("%s: sürücü %q port %d, beklenen %q %d", tt.driver, cfg.Driver, cfg.Port, tt.want, tt.wantPort)
+		}
+		if name := dialector(cfg).Name(); name != tt.want {
+			t.Errorf("%s: dialector %q", tt.driver, name)
+		}
+	}
+
+	cfg, err := configFromSettings(configsapp.DatabaseSettings{Driver: "mysql", Port: 3307})
+	if err != nil || cfg.Port != 3307 {
+		t.Errorf("açık port korunmadı: %d, %v", cfg.Port, err)
+	}
+
+	for _, driver := range []string{"", "oracle", "mssql"} {
+		if _, err := configFromSettings(configsapp.DatabaseSettings{Driver: driver}); !errors.Is(err, ErrUnsupportedDriver) {
+			t.Errorf("%q: err = %v, beklenen ErrUnsupportedDriver", driver, err)
+		}
+	}
+}
+
+func TestBuildMySQLDSN(t *testing.T) {
+	dsn := buildMySQLDSN(DatabaseConfig{
+		Driver:   DriverMySQL,
+		Host:     "db.internal",
+		Port:     3306,
+		User:     "app",
+		Password: "p@ss:w/rd ?",
+		Name:     "zatrano",
+		Params:   map[string]string{"tls": "skip-verify"},
+	})
+
+	parsed, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("DSN ayrıştırılamadı: %v", err)
+	}
+	if parsed.User != "app" || parsed.Passwd != "p@ss:w/rd ?" || parsed.Addr != "db.internal:3306" || parsed.DBName != "zatrano" {
+		t.Errorf("ayrıştırılan DSN = %+v", parsed)
+	}
+	if !parsed.ParseTime {
+		t.Error("parseTime açık değil")
+	}
+	if parsed.TLSConfig != "skip-verify" {
+		t.Errorf("URL parametresi aktarılmadı: tls=%q", parsed.TLSConfig)
+	}
+	if !strings.Contains(dsn, "charset=utf8mb4") {
+		t.Errorf("DSN'de utf8mb4 yok: %s", dsn)
+	}
+}
+
+func TestBuildSQLiteDSN(t *testing.T) {
+	memory := buildSQLiteDSN(DatabaseConfig{Driver: DriverSQLite, Name: ":memory:"})
+	if !strings.HasPrefix(memory, "file::memory:?") || !strings.Contains(memory, "cache=shared") || !strings.Contains(memory, "_foreign_keys=on") {
+		t.Errorf("bellek DSN'i = %q", memory)
+	}
+
+	file := buildSQLiteDSN(DatabaseConfig{Driver: DriverSQLite, Name: "/var/lib/app.db", Params: map[string]string{"_busy_timeout": "5000"}})
+	if file != "/var/lib/app.db?_busy_timeout=5000&_foreign_keys=on" {
+		t.Errorf("dosya DSN'i = %q", file)
+	}
+}
+
+func TestSQLiteDialectorOpens(t *testing.T) {
+	db, err := gorm.Open(dialector(DatabaseConfig{Driver: DriverSQLite, Name: ":memory:"}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("sqlite açılamadı: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	var foreignKeys int
+	if err := db.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error; err != nil {
+		t.Fatal(err)
+	}
+	if foreignKeys != 1 {
+		t.Error("sqlite'ta yabancı anahtarlar açık değil")
+	}
+}
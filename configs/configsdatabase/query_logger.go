@@ -0,0 +1,114 @@
+package configsdatabase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/requestctx"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+var slowQueriesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "db_slow_queries_total",
+	Help: "DB_SLOW_QUERY_MS eşiğini aşan veritabanı sorgularının sayısı.",
+})
+
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{slowQueriesTotal}
+}
+
+// GORM loglarını zap'e yönlendirir. Hatalar error, eşiği aşan sorgular warn, diğerleri debug
+// seviyesinde yazılır; kayıt bulunamadı durumu hata sayılmaz.
+type queryLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+func newQueryLogger(level logger.LogLevel, slowThreshold time.Duration) logger.Interface {
+	return &queryLogger{level: level, slowThreshold: slowThreshold}
+}
+
+func (l *queryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		queryLog(ctx).Sugar().Infof(msg, data...)
+	}
+}
+
+func (l *queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		queryLog(ctx).Sugar().Warnf(msg, data...)
+	}
+}
+
+func (l *queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		queryLog(ctx).Sugar().Errorf(msg, data...)
+	}
+}
+
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+	if slow {
+		slowQueriesTotal.Inc()
+	}
+	if l.level <= logger.Silent {
+		return
+	}
+
+	var level zapcore.Level
+	var msg string
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		level, msg = zapcore.ErrorLevel, "Veritabanı sorgusu hata verdi"
+	case slow && l.level >= logger.Warn:
+		level, msg = zapcore.WarnLevel, "Yavaş veritabanı sorgusu"
+	case l.level >= logger.Info:
+		level, msg = zapcore.DebugLevel, "Veritabanı sorgusu"
+	default:
+		return
+	}
+
+	// SQL yalnızca kayıt gerçekten yazılacaksa oluşturulur
+	ce := queryLog(ctx).Check(level, msg)
+	if ce == nil {
+		return
+	}
+	sql, rows := fc()
+	fields := []zap.Field{
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("duration", elapsed),
+		zap.String("source", utils.FileWithLineNum()),
+	}
+	if slow {
+		fields = append(fields, zap.Duration("threshold", l.slowThreshold))
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	ce.Write(fields...)
+}
+
+// İstek kimliği ve kullanıcı kimliği sorgunun bağlamından alınır
+func queryLog(ctx context.Context) *zap.Logger {
+	log := configslog.FromContext(ctx)
+	if userID, ok := requestctx.UserID(ctx); ok {
+		log = log.With(zap.Uint("user_id", userID))
+	}
+	return log
+}
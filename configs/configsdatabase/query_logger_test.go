@@ -0,0 +1,105 @@
+package configsdatabase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/requestctx"
+
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func observeQueryLogs(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := configslog.Log
+	configslog.Log = zap.New(core)
+	t.Cleanup(func() { configslog.Log = previous })
+	return logs
+}
+
+func traceQuery(l logger.Interface, ctx context.Context, elapsed time.Duration, err error) {
+	// Saat enjekte etmek yerine başlangıç zamanı geriye alınır
+	l.Trace(ctx, time.Now().Add(-elapsed), func() (string, int64) {
+		return "SELECT * FROM users WHERE id = 1", 1
+	}, err)
+}
+
+func TestSlowQueryIsLoggedAtWarnWithContextFields(t *testing.T) {
+	logs := observeQueryLogs(t)
+	l := newQueryLogger(logger.Info, 200*time.Millisecond)
+	before := promtest.ToFloat64(slowQueriesTotal)
+
+	ctx := requestctx.WithUserID(configslog.WithRequestID(context.Background(), "istek-1"), 42)
+	traceQuery(l, ctx, 300*time.Millisecond, nil)
+
+	if got := promtest.ToFloat64(slowQueriesTotal) - before; got != 1 {
+		t.Errorf("yavaş sorgu sayacı %v arttı, beklenen 1", got)
+	}
+	entries := logs.FilterLevelExact(zapcore.WarnLevel).All()
+	if len(entries) != 1 {
+		t.Fatalf("warn kayıt sayısı = %d, beklenen 1 (tümü: %v)", len(entries), logs.All())
+	}
+	fields := entries[0].ContextMap()
+	if fields["sql"] != "SELECT * FROM users WHERE id = 1" || fields["rows"] != int64(1) {
+		t.Errorf("sql/rows alanları = %v / %v", fields["sql"], fields["rows"])
+	}
+	if fields[configslog.RequestIDKey] != "istek-1" || fields["user_id"] != uint64(42) {
+		t.Errorf("bağlam alanları = %v / %v", fields[configslog.RequestIDKey], fields["user_id"])
+	}
+	if d, ok := fields["duration"].(time.Duration); !ok || d < 300*time.Millisecond {
+		t.Errorf("duration = %v", fields["duration"])
+	}
+	if fields["threshold"] != 200*time.Millisecond {
+		t.Errorf("threshold = %v", fields["threshold"])
+	}
+}
+
+func TestFastQueryIsDebugAndNotCounted(t *testing.T) {
+	logs := observeQueryLogs(t)
+	l := newQueryLogger(logger.Info, 200*time.Millisecond)
+	before := promtest.ToFloat64(slowQueriesTotal)
+
+	traceQuery(l, context.Background(), 10*time.Millisecond, nil)
+
+	if got := promtest.ToFloat64(slowQueriesTotal) - before; got != 0 {
+		t.Errorf("hızlı sorgu sayaca eklendi: %v", got)
+	}
+	if entries := logs.All(); len(entries) != 1 || entries[0].Level != zapcore.DebugLevel {
+		t.Errorf("hızlı sorgu kayıtları = %v, beklenen tek debug", entries)
+	}
+}
+
+func TestRecordNotFoundIsNotLoggedAsError(t *testing.T) {
+	logs := observeQueryLogs(t)
+	l := newQueryLogger(logger.Warn, 200*time.Millisecond)
+
+	traceQuery(l, context.Background(), 10*time.Millisecond, gorm.ErrRecordNotFound)
+	if n := logs.Len(); n != 0 {
+		t.Errorf("kayıt bulunamadı %d kez loglandı: %v", n, logs.All())
+	}
+
+	traceQuery(l, context.Background(), 10*time.Millisecond, errors.New("bağlantı koptu"))
+	if entries := logs.FilterLevelExact(zapcore.ErrorLevel).All(); len(entries) != 1 {
+		t.Errorf("gerçek hata error seviyesinde loglanmadı: %v", logs.All())
+	}
+}
+
+func TestZeroThresholdDisablesSlowQueryLogging(t *testing.T) {
+	logs := observeQueryLogs(t)
+	l := newQueryLogger(logger.Warn, 0)
+	before := promtest.ToFloat64(slowQueriesTotal)
+
+	traceQuery(l, context.Background(), time.Minute, nil)
+	if got := promtest.ToFloat64(slowQueriesTotal) - before; got != 0 || logs.Len() != 0 {
+		t.Errorf("eşik 0 iken sayaç %v arttı, %d kayıt yazıldı", got, logs.Len())
+	}
+}
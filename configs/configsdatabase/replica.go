@@ -0,0 +1,100 @@
+package configsdatabase
+
+import (
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ReadDB *gorm.DB
+
+func initReadDB(primary DatabaseConfig, settings configsapp.DatabaseSettings) {
+	readHost := settings.ReadHost
+	if readHost == "" {
+		configslog.SLog.Info("DB_READ_HOST tanımlı değil, okuma sorguları birincil veritabanını kullanacak.")
+		ReadDB = nil
+		return
+	}
+
+	if primary.Driver == DriverSQLite {
+		configslog.SLog.Warn("DB_READ_HOST sqlite sürücüsünde desteklenmez, okuma sorguları birincil veritabanını kullanacak.")
+		ReadDB = nil
+		return
+	}
+
+	readConfig := primary
+	readConfig.Host = readHost
+	readConfig.Port = primary.Port
+	if settings.ReadPort != 0 {
+		readConfig.Port = settings.ReadPort
+	}
+
+	db, err := openWithRetry(readConfig)
+	if err != nil {
+		configslog.Log.Fatal("Failed to connect to read replica",
+			zap.String("host", readConfig.Host),
+			zap.Int("port", readConfig.Port),
+			zap.Error(err),
+		)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		configslog.Log.Fatal("Failed to get underlying sql.DB instance for read replica", zap.Error(err))
+	}
+
+	maxIdleConns := firstNonZero(settings.ReadMaxIdleConns, settings.MaxIdleConns)
+	maxOpenConns := firstNonZero(settings.ReadMaxOpenConns, settings.MaxOpenConns)
+	connMaxLifetime := time.Duration(firstNonZero(int(settings.ReadConnMaxLifetime), int(settings.ConnMaxLifetime)))
+
+	sqlDB.SetMaxIdleConns(maxIdleConns)
+	sqlDB.SetMaxOpenConns(maxOpenConns)
+	sqlDB.SetConnMaxLifetime(connMaxLifetime)
+
+	ReadDB = db
+	configslog.Log.Info("Read replica connection established successfully",
+		zap.String("host", readConfig.Host),
+		zap.Int("port", readConfig.Port),
+		zap.Int("max_idle_conns", maxIdleConns),
+		zap.Int("max_open_conns", maxOpenConns),
+		zap.Duration("conn_max_lifetime", connMaxLifetime),
+	)
+}
+
+func GetReadDB() *gorm.DB {
+	if ReadDB == nil {
+		return GetDB()
+	}
+	return ReadDB
+}
+
+func closeReadDB() {
+	if ReadDB == nil {
+		return
+	}
+
+	sqlDB, err := ReadDB.DB()
+	if err != nil {
+		configslog.Log.Error("Failed to get read replica instance for closing", zap.Error(err))
+		return
+	}
+	if err := sqlDB.Close(); err != nil {
+		configslog.Log.Error("Error closing read replica connection", zap.Error(err))
+		return
+	}
+
+	configslog.SLog.Info("Read replica connection closed successfully.")
+	ReadDB = nil
+}
+
+// Replika ayarı verilmemişse (0) birincil veritabanının değeri kullanılır
+func firstNonZero(value, fallback int) int {
+	if value != 0 {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,105 @@
+package configsdatabase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const maxRetryInterval = 30 * time.Second
+
+var ErrConnectAborted = errors.New("veritabanı bağlantı denemesi kapatma sinyaliyle iptal edildi")
+
+type retryPolicy struct {
+	maxRetries int
+	interval   time.Duration
+}
+
+func retryPolicyFromConfig() retryPolicy {
+	settings := configsapp.Get().Database
+	return retryPolicy{
+		maxRetries: settings.ConnectMaxRetries,
+		interval:   settings.ConnectRetryInterval,
+	}
+}
+
+// n. bekleme interval * 2^(n-1) kadardır ve maxRetryInterval ile sınırlanır
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	wait := p.interval
+	for i := 1; i < attempt && wait < maxRetryInterval; i++ {
+		wait *= 2
+	}
+	if wait > maxRetryInterval {
+		wait = maxRetryInterval
+	}
+	return wait
+}
+
+// Veritabanı container'ı uygulamadan geç ayağa kalktığında (docker-compose, Kubernetes) bağlantı yeniden denenir.
+// Bekleme sırasında gelen SIGINT/SIGTERM denemeyi hemen sonlandırır.
+func openWithRetry(dbConfig DatabaseConfig) (*gorm.DB, error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return connect(ctx, dbConfig, retryPolicyFromConfig(), openAndPing)
+}
+
+func connect(ctx context.Context, dbConfig DatabaseConfig, policy retryPolicy, open func(context.Context, DatabaseConfig) (*gorm.DB, error)) (*gorm.DB, error) {
+	for attempt := 1; ; attempt++ {
+		db, err := open(ctx, dbConfig)
+		if err == nil {
+			if attempt > 1 {
+				configslog.Log.Info("Veritabanı bağlantısı yeniden denemeyle kuruldu", zap.Int("attempt", attempt))
+			}
+			return db, nil
+		}
+		if attempt > policy.maxRetries {
+			return nil, err
+		}
+
+		wait := policy.backoff(attempt)
+		configslog.Log.Warn("Veritabanına bağlanılamadı, yeniden denenecek",
+			zap.String("host", dbConfig.Host),
+			zap.Int("port", dbConfig.Port),
+			zap.Int("attempt", attempt),
+			zap.Int("max_retries", policy.maxRetries),
+			zap.Duration("wait", wait),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ErrConnectAborted
+		case <-timer.C:
+		}
+	}
+}
+
+func openAndPing(ctx context.Context, dbConfig DatabaseConfig) (*gorm.DB, error) {
+	db, err := openGorm(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := sqlDB.PingContext(pingCtx); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
+}
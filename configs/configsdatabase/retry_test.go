@@ -0,0 +1,125 @@
+package configsdatabase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestRetryBackoffDoublesUpToLimit(t *testing.T) {
+	policy := retryPolicy{maxRetries: 10, interval: time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, maxRetryInterval, maxRetryInterval}
+	for i, expected := range want {
+		if got := policy.backoff(i + 1); got != expected {
+			t.Errorf("backoff(%d) = %v, beklenen %v", i+1, got, expected)
+		}
+	}
+	if got := (retryPolicy{interval: time.Minute}).backoff(1); got != maxRetryInterval {
+		t.Errorf("sınırı aşan ilk bekleme = %v, beklenen %v", got, maxRetryInterval)
+	}
+}
+
+// Kapalı bir porta bağlanmaya çalışan open; deneme zamanlarını kaydeder
+func closedPortOpener(t *testing.T, succeedOn int) (func(context.Context, DatabaseConfig) (*gorm.DB, error), *[]time.Time) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	var attempts []time.Time
+	return func(ctx context.Context, _ DatabaseConfig) (*gorm.DB, error) {
+		attempts = append(attempts, time.Now())
+		if len(attempts) == succeedOn {
+			return &gorm.DB{}, nil
+		}
+		conn, err := (&net.Dialer{Timeout: time.Second}).DialContext(ctx, "tcp", addr)
+		if err == nil {
+			conn.Close()
+			return nil, errors.New("kapalı port beklenmedik şekilde açık")
+		}
+		return nil, err
+	}, &attempts
+}
+
+func TestConnectGivesUpAfterMaxRetries(t *testing.T) {
+	logs := observeQueryLogs(t)
+	open, attempts := closedPortOpener(t, 0)
+	policy := retryPolicy{maxRetries: 3, interval: 10 * time.Millisecond}
+
+	_, err := connect(context.Background(), DatabaseConfig{Host: "127.0.0.1", Port: 1}, policy, open)
+	if err == nil {
+		t.Fatal("kapalı porta bağlantı başarılı sayıldı")
+	}
+	if len(*attempts) != policy.maxRetries+1 {
+		t.Fatalf("%d deneme, beklenen %d", len(*attempts), policy.maxRetries+1)
+	}
+	for i := 1; i < len(*attempts); i++ {
+		gap := (*attempts)[i].Sub((*attempts)[i-1])
+		if want := policy.backoff(i); gap < want {
+			t.Errorf("%d. denemeden önce %v beklendi, en az %v olmalıydı", i+1, gap, want)
+		}
+	}
+
+	warnings := logs.FilterMessage("Veritabanına bağlanılamadı, yeniden denenecek").All()
+	if len(warnings) != policy.maxRetries {
+		t.Fatalf("%d uyarı logu, beklenen %d", len(warnings), policy.maxRetries)
+	}
+	for i, entry := range warnings {
+		if got := entry.ContextMap()["attempt"]; got != int64(i+1) {
+			t.Errorf("%d. uyarıda attempt = %v", i+1, got)
+		}
+	}
+}
+
+func TestConnectSucceedsAfterRetry(t *testing.T) {
+	logs := observeQueryLogs(t)
+	open, attempts := closedPortOpener(t, 3)
+
+	db, err := connect(context.Background(), DatabaseConfig{}, retryPolicy{maxRetries: 5, interval: time.Millisecond}, open)
+	if err != nil || db == nil {
+		t.Fatalf("connect = %v, %v", db, err)
+	}
+	if len(*attempts) != 3 {
+		t.Errorf("%d deneme, beklenen 3", len(*attempts))
+	}
+	if len(logs.FilterMessage("Veritabanı bağlantısı yeniden denemeyle kuruldu").All()) != 1 {
+		t.Error("yeniden denemeyle kurulan bağlantı loglanmadı")
+	}
+}
+
+func TestConnectStopsOnShutdownSignal(t *testing.T) {
+	observeQueryLogs(t)
+	open, attempts := closedPortOpener(t, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := connect(ctx, DatabaseConfig{}, retryPolicy{maxRetries: 5, interval: time.Hour}, open)
+	if !errors.Is(err, ErrConnectAborted) {
+		t.Fatalf("err = %v, beklenen ErrConnectAborted", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("iptal %v sürdü, bekleme hemen kesilmeliydi", elapsed)
+	}
+	if len(*attempts) != 1 {
+		t.Errorf("%d deneme, beklenen 1", len(*attempts))
+	}
+}
+
+func TestConnectWithoutRetries(t *testing.T) {
+	observeQueryLogs(t)
+	open, attempts := closedPortOpener(t, 0)
+	if _, err := connect(context.Background(), DatabaseConfig{Port: 5432, Host: "db"}, retryPolicy{}, open); err == nil {
+		t.Fatal("hata bekleniyordu")
+	}
+	if len(*attempts) != 1 {
+		t.Errorf("yeniden deneme kapalıyken %d deneme yapıldı", len(*attempts))
+	}
+}
@@ -0,0 +1,33 @@
+package configslog
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// İstek kimliği fiber Locals'ta ve log alanında bu adla tutulur
+const RequestIDKey = "request_id"
+
+// UserContext'te başka paketlerin string anahtarlarıyla çakışmaması için ayrı tip kullanılır
+type requestIDContextKey struct{}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// Bağlamda istek kimliği varsa request_id alanıyla etiketlenmiş logger döner
+func FromContext(ctx context.Context) *zap.Logger {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return Log.With(zap.String(RequestIDKey, id))
+	}
+	return Log
+}
@@ -1,59 +1,135 @@
 package configslog
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
+	"time"
+
+	"zatrano/configs/configsapp"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var Log *zap.Logger
 var SLog *zap.SugaredLogger
 
+// LOG_OUTPUT file veya both iken döndürülen log dosyası; SyncLogger kapatırken boşaltır
+var fileSink *lumberjack.Logger
+
 func InitLogger() {
 	if Log != nil {
 		return
 	}
 
-	env := os.Getenv("APP_ENV")
-	var config zap.Config
-	var err error
-	var level zapcore.Level
-
-	if env == "production" {
-		config = zap.NewProductionConfig()
-		config.EncoderConfig.TimeKey = "timestamp"
-		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		level = zapcore.InfoLevel
-	} else {
+	cfg := configsapp.Get()
+	env := cfg.Env
+	if !cfg.IsProduction() {
 		env = "development"
-		config = zap.NewDevelopmentConfig()
-		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		level = zapcore.DebugLevel
 	}
 
-	logLevelEnv := os.Getenv("LOG_LEVEL")
-	if logLevelEnv != "" {
-		err = level.Set(logLevelEnv)
-		if err != nil {
-			panic("Geçersiz LOG_LEVEL '" + logLevelEnv + "': " + err.Error())
-		}
-	}
-	config.Level = zap.NewAtomicLevelAt(level)
-
-	Log, err = config.Build(zap.AddCaller())
+	// Logger ve dosya önce yerel olarak kurulur; hata varsa global durum hiç değişmez
+	logger, sink, err := newLogger(cfg.Log, cfg.IsProduction())
 	if err != nil {
 		panic("Zap logger başlatılamadı: " + err.Error())
 	}
-
-	SLog = Log.Sugar()
+	Log, SLog, fileSink = logger, logger.Sugar(), sink
 
 	SLog.Infow("Zap logger başarıyla başlatıldı",
 		"environment", env,
-		"log_level", config.Level.Level().String(),
+		"log_level", Log.Level().String(),
+		"format", logFormat(cfg.Log, cfg.IsProduction()),
+		"output", cfg.Log.Output,
+		"file", cfg.Log.FilePath,
 	)
 }
 
+func newLogger(settings configsapp.LogSettings, production bool) (*zap.Logger, *lumberjack.Logger, error) {
+	level := zapcore.DebugLevel
+	if production {
+		level = zapcore.InfoLevel
+	}
+	if settings.Level != "" {
+		if err := level.Set(settings.Level); err != nil {
+			return nil, nil, errors.New("geçersiz LOG_LEVEL '" + settings.Level + "': " + err.Error())
+		}
+	}
+
+	format := logFormat(settings, production)
+	var cores []zapcore.Core
+
+	if settings.WritesStdout() {
+		// Renkli seviye yalnızca terminal çıktısında kullanılır
+		cores = append(cores, zapcore.NewCore(newEncoder(format, production, true), zapcore.Lock(os.Stdout), level))
+	}
+
+	var sink *lumberjack.Logger
+	if settings.WritesFile() {
+		if settings.FilePath == "" {
+			return nil, nil, errors.New("LOG_OUTPUT=" + settings.Output + " için LOG_FILE_PATH zorunludur")
+		}
+		if err := os.MkdirAll(filepath.Dir(settings.FilePath), 0o755); err != nil {
+			return nil, nil, err
+		}
+		sink = &lumberjack.Logger{
+			Filename:   settings.FilePath,
+			MaxSize:    settings.MaxSizeMB,
+			MaxBackups: settings.MaxBackups,
+			MaxAge:     settings.MaxAgeDays,
+			Compress:   settings.Compress,
+		}
+		// Dosya yazılabilir değilse ilk log satırında değil başlangıçta hata verilir
+		if _, err := sink.Write(nil); err != nil {
+			return nil, nil, err
+		}
+		cores = append(cores, zapcore.NewCore(newEncoder(format, production, false), zapcore.AddSync(sink), level))
+	}
+
+	core := zapcore.NewTee(cores...)
+	options := []zap.Option{zap.AddCaller(), zap.ErrorOutput(zapcore.Lock(os.Stderr))}
+	if production {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100)
+		options = append(options, zap.AddStacktrace(zapcore.ErrorLevel))
+	} else {
+		options = append(options, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
+	}
+	return zap.New(core, options...), sink, nil
+}
+
+func logFormat(settings configsapp.LogSettings, production bool) string {
+	if settings.Format != "" {
+		return settings.Format
+	}
+	if production {
+		return "json"
+	}
+	return "console"
+}
+
+func newEncoder(format string, production, color bool) zapcore.Encoder {
+	var encoderConfig zapcore.EncoderConfig
+	if production {
+		encoderConfig = zap.NewProductionEncoderConfig()
+		encoderConfig.TimeKey = "timestamp"
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	} else {
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		if color {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+	}
+
+	if format == "json" {
+		return zapcore.NewJSONEncoder(encoderConfig)
+	}
+	return zapcore.NewConsoleEncoder(encoderConfig)
+}
+
+// lumberjack doğrudan dosyaya yazar; kapatmak açık dosyayı diske boşaltır ve
+// sonraki bir yazma dosyayı yeniden açar
 func SyncLogger() {
 	if Log != nil {
 		_ = Log.Sync()
@@ -61,4 +137,7 @@ func SyncLogger() {
 	if SLog != nil {
 		_ = SLog.Sync()
 	}
+	if fileSink != nil {
+		_ = fileSink.Close()
+	}
 }
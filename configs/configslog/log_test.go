@@ -0,0 +1,133 @@
+package configslog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"zatrano/configs/configsapp"
+
+	"go.uber.org/zap"
+)
+
+func fileSettings(t *testing.T) configsapp.LogSettings {
+	t.Helper()
+	return configsapp.LogSettings{
+		Level:      "info",
+		Format:     "json",
+		Output:     "file",
+		FilePath:   filepath.Join(t.TempDir(), "logs", "app.log"),
+		MaxSizeMB:  100,
+		MaxBackups: 3,
+	}
+}
+
+func TestNewLoggerWritesJSONToFile(t *testing.T) {
+	settings := fileSettings(t)
+	logger, sink, err := newLogger(settings, true)
+	if err != nil {
+		t.Fatalf("newLogger: %v", err)
+	}
+	logger.Debug("görünmemeli")
+	logger.Info("dosyaya yazıldı", zap.String("key", "değer"))
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(settings.FilePath)
+	if err != nil {
+		t.Fatalf("log dosyası okunamadı: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("%d satır, beklenen 1 (debug seviyesi atlanmalı): %q", len(lines), data)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("satır JSON değil: %v", err)
+	}
+	if entry["msg"] != "dosyaya yazıldı" || entry["key"] != "değer" || entry["level"] != "info" {
+		t.Errorf("beklenmeyen kayıt: %v", entry)
+	}
+}
+
+func TestNewLoggerRotatesAtSizeLimit(t *testing.T) {
+	settings := fileSettings(t)
+	settings.MaxSizeMB = 1
+	logger, sink, err := newLogger(settings, false)
+	if err != nil {
+		t.Fatalf("newLogger: %v", err)
+	}
+
+	payload := strings.Repeat("x", 1024)
+	for i := 0; i < 1500; i++ {
+		logger.Info("dolgu", zap.String("payload", payload))
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := os.ReadDir(filepath.Dir(settings.FilePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) < 2 {
+		t.Fatalf("dosyalar = %v, boyut sınırında döndürülmüş bir yedek bekleniyordu", files)
+	}
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > 1024*1024 {
+			t.Errorf("%s %d bayt, 1 MB sınırını aşıyor", file.Name(), info.Size())
+		}
+	}
+}
+
+func TestNewLoggerRejectsInvalidSettings(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "dosya")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*configsapp.LogSettings)
+	}{
+		{"geçersiz seviye", func(s *configsapp.LogSettings) { s.Level = "çok" }},
+		{"yolsuz dosya çıktısı", func(s *configsapp.LogSettings) { s.Output, s.FilePath = "both", "" }},
+		{"yazılamayan dizin", func(s *configsapp.LogSettings) { s.FilePath = filepath.Join(blocker, "app.log") }},
+	}
+	for _, tt := range tests {
+		settings := fileSettings(t)
+		tt.modify(&settings)
+		if logger, sink, err := newLogger(settings, false); err == nil || logger != nil || sink != nil {
+			t.Errorf("%s: err = %v, yarım kurulmuş logger döndü", tt.name, err)
+		}
+	}
+}
+
+func TestSyncLoggerFlushesFileSink(t *testing.T) {
+	settings := fileSettings(t)
+	logger, sink, err := newLogger(settings, false)
+	if err != nil {
+		t.Fatalf("newLogger: %v", err)
+	}
+	previousLog, previousSLog, previousSink := Log, SLog, fileSink
+	Log, SLog, fileSink = logger, logger.Sugar(), sink
+	t.Cleanup(func() { Log, SLog, fileSink = previousLog, previousSLog, previousSink })
+
+	SLog.Infow("kapanıştan önce", "step", "son")
+	SyncLogger()
+
+	data, err := os.ReadFile(settings.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "kapanıştan önce") {
+		t.Errorf("kapanış logu dosyada yok: %q", data)
+	}
+}
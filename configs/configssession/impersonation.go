@@ -0,0 +1,46 @@
+package configssession
+
+import (
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+// Kimliğe bürünme sırasında user_id / user_type / user_name hedef kullanıcıyı gösterir;
+// yöneticinin gerçek kimliği ayrı bir anahtarda tutulur ve çıkışta oturumla birlikte silinir
+const impersonatorIDKey = "impersonator_id"
+
+// Oturumda kimliğe bürünme yoksa false döner
+func ImpersonatorFromSession(sess *session.Session) (uint, bool) {
+	impersonatorID, ok := sess.Get(impersonatorIDKey).(uint)
+	return impersonatorID, ok && impersonatorID != 0
+}
+
+// logged_in_at korunur; zaman aşımları ve şifre değişikliği kontrolü yöneticinin girişine göre işler
+func StartImpersonation(c *fiber.Ctx, impersonatorID uint, target *models.User) error {
+	sess, err := SessionStart(c)
+	if err != nil {
+		return err
+	}
+	sess.Set(impersonatorIDKey, impersonatorID)
+	sess.Set("user_id", target.ID)
+	sess.Set("user_type", string(target.Type))
+	sess.Set("user_status", target.Status)
+	sess.Set("user_name", target.Name)
+	return sess.Save()
+}
+
+func StopImpersonation(c *fiber.Ctx, impersonator *models.User) error {
+	sess, err := SessionStart(c)
+	if err != nil {
+		return err
+	}
+
+	sess.Delete(impersonatorIDKey)
+	sess.Set("user_id", impersonator.ID)
+	sess.Set("user_type", string(impersonator.Type))
+	sess.Set("user_status", impersonator.Status)
+	sess.Set("user_name", impersonator.Name)
+	return sess.Save()
+}
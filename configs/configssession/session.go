@@ -1,25 +1,49 @@
 package configssession
 
 import (
+	"context"
 	"encoding/gob"
 	"time"
 
-	"zatrano/configs/configsenv"
+	"zatrano/configs/configsapp"
 	"zatrano/configs/configslog"
 	"zatrano/models"
+	"zatrano/pkg/health"
+	"zatrano/pkg/requestctx"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
+const (
+	sessionCookieName  = "session_id"
+	RememberCookieName = "remember_me"
+)
+
 var Session *session.Store
 
+// Birden fazla instance arasında paylaşılan storage; nil ise her süreç kendi belleğini kullanır
+var storage fiber.Storage
+
+func GetStorage() fiber.Storage {
+	return storage
+}
+
 func InitSession() {
+	storage = createStorage()
 	Session = createSessionStore()
 	registerGobTypes()
+	health.Register("session", checkStorage)
+	requestctx.RegisterSessionLookup(userIDFromSession)
 	configslog.SLog.Info("Oturum (session) sistemi başlatıldı ve utils içinde kayıt edildi.")
 }
 
+// Olmayan bir anahtarı okumak storage bağlantısını doğrulamaya yeter
+func checkStorage(_ context.Context) error {
+	_, err := Session.Storage.Get("readyz_probe")
+	return err
+}
+
 func SetupSession() *session.Store {
 	if Session == nil {
 		configslog.SLog.Warn("Session store isteniyor ancak henüz başlatılmamış, şimdi başlatılıyor.")
@@ -29,25 +53,36 @@ func SetupSession() *session.Store {
 }
 
 func createSessionStore() *session.Store {
-	sessionExpirationHours := configsenv.GetEnvAsInt("SESSION_EXPIRATION_HOURS", 24)
-	cookieSecure := configsenv.IsProduction()
+	// Storage ve cookie süresi her etkinlikte boşta kalma süresi kadar uzatılır
+	idleTimeout := IdleTimeout()
+	cookieSecure := configsapp.Get().IsProduction()
 
 	store := session.New(session.Config{
-		CookieHTTPOnly: false,
+		CookieHTTPOnly: true,
 		CookieSecure:   cookieSecure,
-		Expiration:     time.Duration(sessionExpirationHours) * time.Hour,
-		KeyLookup:      "cookie:session_id",
+		Expiration:     idleTimeout,
+		KeyLookup:      "cookie:" + sessionCookieName,
 		CookieSameSite: "Lax",
+		Storage:        storage,
 	})
 
-	configslog.SLog.Info("Cookie tabanlı session sistemi %d saatlik süreyle yapılandırıldı.", sessionExpirationHours)
+	configslog.SLog.Infof("Cookie tabanlı session sistemi yapılandırıldı (boşta kalma: %s, en uzun: %s).", idleTimeout, AbsoluteTimeout())
 	return store
 }
 
+// Hareketsiz kalan oturumun storage'da tutulduğu süre
+func Lifetime() time.Duration {
+	if Session != nil {
+		return Session.Expiration
+	}
+	return IdleTimeout()
+}
+
 func registerGobTypes() {
 	gob.Register(models.UserType(""))
 	gob.Register(&models.User{})
-	configslog.SLog.Debug("Session için gob türleri kaydedildi: models.UserType, *models.User")
+	gob.Register(map[string]string{})
+	configslog.SLog.Debug("Session için gob türleri kaydedildi: models.UserType, *models.User, map[string]string")
 }
 
 func SessionStart(c *fiber.Ctx) (*session.Session, error) {
@@ -57,12 +92,109 @@ func SessionStart(c *fiber.Ctx) (*session.Session, error) {
 	return Session.Get(c)
 }
 
+// Oturum sabitlemeye (fixation) karşı kimliği yeniler; mevcut veriler (ör. flash mesajları) korunur.
+// Dönen session hemen Save edilmelidir.
+func RegenerateSession(c *fiber.Ctx) (*session.Session, error) {
+	sess, err := SessionStart(c)
+	if err != nil {
+		return nil, err
+	}
+	// Taze oturumun kimliği bu istekte sunucu tarafından üretilmiştir ve fiber onu Locals'ta tutar;
+	// yenilenirse aynı istekteki sonraki SessionStart çağrıları boş bir oturum açar
+	if sess.Fresh() {
+		return sess, nil
+	}
+	if err := sess.Regenerate(); err != nil {
+		return nil, err
+	}
+	// Aynı istekteki sonraki SessionStart çağrıları eski (silinmiş) kimlik yerine yenisini okusun
+	c.Request().Header.SetCookie(sessionCookieName, sess.ID())
+	return sess, nil
+}
+
+// Giriş ve "beni hatırla" ile oturum açma aynı alanları yazar; dönen kimlik oturum takibinde kullanılır
+func StartUserSession(c *fiber.Ctx, user *models.User) (string, error) {
+	sess, err := RegenerateSession(c)
+	if err != nil {
+		return "", err
+	}
+
+	sess.Set("user_id", user.ID)
+	sess.Set("user_type", string(user.Type))
+	sess.Set("user_status", user.Status)
+	sess.Set("user_name", user.Name)
+	if user.OrganizationID != nil {
+		sess.Set(tenantIDKey, *user.OrganizationID)
+	}
+	now := time.Now().Unix()
+	sess.Set("logged_in_at", now)
+	sess.Set("created_at", now)
+	sess.Set("last_activity_at", now)
+
+	// Save sonrası oturum nesnesi kullanılamaz; kimlik önceden alınır
+	sessionID := sess.ID()
+	if err := sess.Save(); err != nil {
+		return "", err
+	}
+	return sessionID, nil
+}
+
+// Profil güncellemesinden sonra başlıktaki ad yeniden giriş gerektirmeden değişir
+func SetSessionUserName(c *fiber.Ctx, name string) error {
+	sess, err := SessionStart(c)
+	if err != nil {
+		return err
+	}
+	sess.Set("user_name", name)
+	return sess.Save()
+}
+
+func SetRememberCookie(c *fiber.Ctx, value string, expiresAt time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:     RememberCookieName,
+		Value:    value,
+		Path:     "/",
+		Expires:  expiresAt,
+		Secure:   configsapp.Get().IsProduction(),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func ClearRememberCookie(c *fiber.Ctx) {
+	c.ClearCookie(RememberCookieName)
+}
+
+// Oturumda tip string olarak yazılır; eski oturumlardaki models.UserType değeri de kabul edilir
 func GetUserTypeFromSession(sess *session.Session) (models.UserType, error) {
-	userType, ok := sess.Get("user_type").(models.UserType)
-	if !ok {
-		return "", fiber.NewError(fiber.StatusUnauthorized, "Geçersiz oturum veya kullanıcı tipi")
+	switch userType := sess.Get("user_type").(type) {
+	case models.UserType:
+		if userType != "" {
+			return userType, nil
+		}
+	case string:
+		if userType != "" {
+			return models.UserType(userType), nil
+		}
+	}
+	return "", fiber.NewError(fiber.StatusUnauthorized, "Geçersiz oturum veya kullanıcı tipi")
+}
+
+func userIDFromSession(c *fiber.Ctx) (uint, bool) {
+	sess, err := SessionStart(c)
+	if err != nil {
+		return 0, false
+	}
+	switch v := sess.Get("user_id").(type) {
+	case uint:
+		return v, v != 0
+	case int:
+		return uint(v), v > 0
+	case float64:
+		return uint(v), v > 0
+	default:
+		return 0, false
 	}
-	return userType, nil
 }
 
 func GetUserIDFromSession(sess *session.Session) (uint, error) {
@@ -0,0 +1,184 @@
+package configssession
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func useMemorySessions(t *testing.T) {
+	t.Helper()
+	testutil.Setup()
+	previous := Session
+	Session = createSessionStore()
+	t.Cleanup(func() { Session = previous })
+}
+
+func sessionCookie(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == sessionCookieName {
+			return cookie.Value
+		}
+	}
+	t.Fatal("oturum cookie'si yazılmadı")
+	return ""
+}
+
+func TestSessionCookieIsHTTPOnly(t *testing.T) {
+	useMemorySessions(t)
+
+	app := fiber.New()
+	app.Get("/login", func(c *fiber.Ctx) error {
+		user := &models.User{Name: "A", Type: models.Panel, Status: true}
+		user.ID = 1
+		_, err := StartUserSession(c, user)
+		return err
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/login", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name != sessionCookieName {
+			continue
+		}
+		if !cookie.HttpOnly {
+			t.Error("oturum cookie'si HttpOnly değil")
+		}
+		return
+	}
+	t.Fatal("oturum cookie'si yazılmadı")
+}
+
+func TestStartUserSessionRegeneratesID(t *testing.T) {
+	useMemorySessions(t)
+
+	app := fiber.New()
+	app.Get("/visit", func(c *fiber.Ctx) error {
+		sess, err := SessionStart(c)
+		if err != nil {
+			return err
+		}
+		sess.Set("flash", "önceki istekten")
+		return sess.Save()
+	})
+	app.Get("/login", func(c *fiber.Ctx) error {
+		// Aynı istekte girişten önce yazılan veri de korunmalı
+		sess, err := SessionStart(c)
+		if err != nil {
+			return err
+		}
+		sess.Set("same_request", "giriş öncesi")
+		if err := sess.Save(); err != nil {
+			return err
+		}
+		user := &models.User{Name: "A", Type: models.Panel, Status: true}
+		user.ID = 7
+		_, err = StartUserSession(c, user)
+		return err
+	})
+	app.Get("/whoami", func(c *fiber.Ctx) error {
+		sess, err := SessionStart(c)
+		if err != nil {
+			return err
+		}
+		userID, _ := sess.Get("user_id").(uint)
+		flash, _ := sess.Get("flash").(string)
+		sameRequest, _ := sess.Get("same_request").(string)
+		return c.JSON(fiber.Map{"user_id": userID, "flash": flash, "same_request": sameRequest})
+	})
+
+	get := func(path, cookie string) *http.Response {
+		req := httptest.NewRequest(fiber.MethodGet, path, nil)
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: cookie})
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	planted := sessionCookie(t, get("/visit", ""))
+	loggedIn := sessionCookie(t, get("/login", planted))
+	if loggedIn == planted {
+		t.Fatal("girişten sonra oturum kimliği değişmedi")
+	}
+
+	var body struct {
+		UserID      uint   `json:"user_id"`
+		Flash       string `json:"flash"`
+		SameRequest string `json:"same_request"`
+	}
+	if err := json.NewDecoder(get("/whoami", loggedIn).Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.UserID != 7 {
+		t.Errorf("yeni kimlikte user_id = %d, beklenen 7", body.UserID)
+	}
+	if body.Flash != "önceki istekten" || body.SameRequest != "giriş öncesi" {
+		t.Errorf("yenilemede veriler kayboldu: %+v", body)
+	}
+
+	body.UserID = 0
+	if err := json.NewDecoder(get("/whoami", planted).Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.UserID != 0 {
+		t.Error("sabitlenen eski kimlik girişten sonra kullanıcıya bağlı kaldı")
+	}
+}
+
+// Hatırlama token'ıyla oturum açılırken istekte geçerli bir oturum yoktur
+func TestStartUserSessionWithoutExistingSession(t *testing.T) {
+	useMemorySessions(t)
+
+	app := fiber.New()
+	app.Get("/restore", func(c *fiber.Ctx) error {
+		user := &models.User{Name: "A", Type: models.Panel, Status: true}
+		user.ID = 7
+		sessionID, err := StartUserSession(c, user)
+		if err != nil {
+			return err
+		}
+		sess, err := SessionStart(c)
+		if err != nil {
+			return err
+		}
+		userID, _ := sess.Get("user_id").(uint)
+		if userID != 7 || sess.ID() != sessionID {
+			return c.Status(fiber.StatusConflict).SendString(sess.ID())
+		}
+		return c.SendString(sessionID)
+	})
+
+	for name, cookie := range map[string]string{"cookie yok": "", "süresi dolmuş cookie": "silinmis-oturum"} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/restore", nil)
+			if cookie != "" {
+				req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: cookie})
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != fiber.StatusOK {
+				t.Fatalf("aynı istekte açılan oturum okunamadı (status %d)", resp.StatusCode)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if got := sessionCookie(t, resp); got != string(body) || got == cookie {
+				t.Errorf("cookie %q, oturum kimliği %q", got, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package configssession
+
+import (
+	"strings"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configslog"
+	"zatrano/pkg/sessionstorage"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	DriverMemory   = "memory"
+	DriverRedis    = "redis"
+	DriverPostgres = "postgres"
+	// DB_DRIVER ne olursa olsun uygulamanın kendi veritabanını kullanır; postgres geriye uyumluluk için korunur
+	DriverDatabase = "database"
+)
+
+// SESSION_DRIVER'a göre storage seçilir; memory için nil döner ve fiber kendi bellek storage'ını kullanır.
+// Yanlış yapılandırma uygulamayı başlatmadan durdurur.
+func createStorage() fiber.Storage {
+	cfg := configsapp.Get()
+	driver := strings.ToLower(cfg.Session.Driver)
+	prefix := cfg.Session.KeyPrefix
+
+	switch driver {
+	case DriverMemory:
+		configslog.SLog.Info("Session storage: bellek (yeniden başlatmada oturumlar kaybolur)")
+		return nil
+	case DriverRedis:
+		redisCfg := sessionstorage.RedisConfig{
+			Host:     cfg.Redis.Host,
+			Port:     cfg.Redis.Port,
+			Username: cfg.Redis.Username,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+			TLS:      cfg.Redis.TLS,
+			Prefix:   prefix,
+		}
+		store, err := sessionstorage.NewRedis(redisCfg)
+		if err != nil {
+			configslog.Log.Fatal("Redis session storage'a bağlanılamadı",
+				zap.String("host", redisCfg.Host),
+				zap.Int("port", redisCfg.Port),
+				zap.Int("db", redisCfg.DB),
+				zap.Bool("tls", redisCfg.TLS),
+				zap.Error(err),
+			)
+		}
+		configslog.Log.Info("Session storage: redis", zap.String("host", redisCfg.Host), zap.Int("port", redisCfg.Port), zap.Int("db", redisCfg.DB))
+		return store
+	case DriverPostgres, DriverDatabase:
+		dbCfg := sessionstorage.DatabaseConfig{
+			Table:      cfg.Session.Table,
+			Prefix:     prefix,
+			GCInterval: cfg.Session.GCInterval,
+		}
+		store, err := sessionstorage.NewDatabase(configsdatabase.GetDB(), dbCfg)
+		if err != nil {
+			configslog.Log.Fatal("Veritabanı session storage'ı hazırlanamadı", zap.String("table", dbCfg.Table), zap.Error(err))
+		}
+		configslog.Log.Info("Session storage: veritabanı", zap.String("table", dbCfg.Table))
+		return store
+	default:
+		configslog.Log.Fatal("Geçersiz SESSION_DRIVER değeri",
+			zap.String("driver", driver),
+			zap.Strings("supported", []string{DriverMemory, DriverRedis, DriverPostgres, DriverDatabase}),
+		)
+		return nil
+	}
+}
+
+func CloseSession() error {
+	if storage == nil {
+		return nil
+	}
+	if err := storage.Close(); err != nil {
+		configslog.Log.Error("Session storage kapatılamadı", zap.Error(err))
+		return err
+	}
+	configslog.SLog.Info("Session storage bağlantısı kapatıldı.")
+	return nil
+}
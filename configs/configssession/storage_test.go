@@ -0,0 +1,54 @@
+package configssession
+
+import (
+	"strconv"
+	"testing"
+
+	"zatrano/configs/configsapp"
+	"zatrano/pkg/sessionstorage"
+	"zatrano/pkg/testutil"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestCreateStorageSelectsDriver(t *testing.T) {
+	testutil.NewDB(t)
+	server := miniredis.RunT(t)
+	port, err := strconv.Atoi(server.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := configsapp.Get()
+	previousSession, previousRedis := cfg.Session, cfg.Redis
+	t.Cleanup(func() { cfg.Session, cfg.Redis = previousSession, previousRedis })
+	cfg.Redis.Host, cfg.Redis.Port = server.Host(), port
+	cfg.Session.KeyPrefix = "zatrano:"
+
+	cfg.Session.Driver = "MEMORY"
+	if s := createStorage(); s != nil {
+		t.Errorf("memory sürücüsü için storage %T, beklenen nil", s)
+	}
+
+	cfg.Session.Driver = DriverRedis
+	redisStore, ok := createStorage().(*sessionstorage.Redis)
+	if !ok {
+		t.Fatal("redis sürücüsü Redis storage döndürmedi")
+	}
+	t.Cleanup(func() { _ = redisStore.Close() })
+	if err := redisStore.Set("oturum", []byte("veri"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if !server.Exists("zatrano:oturum") {
+		t.Error("SESSION_KEY_PREFIX redis anahtarına uygulanmadı")
+	}
+
+	for _, driver := range []string{DriverDatabase, DriverPostgres} {
+		cfg.Session.Driver = driver
+		dbStore, ok := createStorage().(*sessionstorage.Database)
+		if !ok {
+			t.Fatalf("%s sürücüsü veritabanı storage'ı döndürmedi", driver)
+		}
+		_ = dbStore.Close()
+	}
+}
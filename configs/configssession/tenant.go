@@ -0,0 +1,15 @@
+package configssession
+
+import (
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+// Çok kiracılı kurulumda kullanıcının kiracısı; StartUserSession kullanıcının kuruluşundan yazar,
+// AuthMiddleware isteğin context'ine taşır
+const tenantIDKey = "tenant_id"
+
+// Tek kiracılı kurulumda oturumda kiracı olmaz ve false döner
+func TenantIDFromSession(sess *session.Session) (uint, bool) {
+	tenantID, ok := sess.Get(tenantIDKey).(uint)
+	return tenantID, ok && tenantID != 0
+}
@@ -0,0 +1,63 @@
+package configssession
+
+import (
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+func TestStartUserSessionStoresTenant(t *testing.T) {
+	testutil.Setup()
+	previous := Session
+	Session = session.New(session.Config{KeyLookup: "cookie:" + sessionCookieName})
+	t.Cleanup(func() { Session = previous })
+
+	organizationID := uint(5)
+	app := fiber.New()
+	app.Get("/login/:org", func(c *fiber.Ctx) error {
+		user := &models.User{Name: "A", Type: models.Panel, Status: true}
+		user.ID = 1
+		if c.Params("org") == "yes" {
+			user.OrganizationID = &organizationID
+		}
+		_, err := StartUserSession(c, user)
+		return err
+	})
+	app.Get("/tenant", func(c *fiber.Ctx) error {
+		sess, err := SessionStart(c)
+		if err != nil {
+			return err
+		}
+		tenantID, ok := TenantIDFromSession(sess)
+		if !ok {
+			return c.SendString("none")
+		}
+		return c.SendString(strconv.Itoa(int(tenantID)))
+	})
+
+	for path, want := range map[string]string{"/login/yes": "5", "/login/no": "none"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(fiber.MethodGet, "/tenant", nil)
+		for _, cookie := range resp.Cookies() {
+			req.AddCookie(cookie)
+		}
+		resp, err = app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != want {
+			t.Errorf("%s sonrası kiracı = %q, beklenen %q", path, body, want)
+		}
+	}
+}
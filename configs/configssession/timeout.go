@@ -0,0 +1,50 @@
+package configssession
+
+import (
+	"errors"
+	"time"
+
+	"zatrano/configs/configsapp"
+
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+// Her istekte storage'a yazmamak için son etkinlik en fazla bu sıklıkla güncellenir
+const activityRefreshInterval = time.Minute
+
+var ErrSessionTimedOut = errors.New("oturum zaman aşımına uğradı")
+
+func IdleTimeout() time.Duration {
+	return configsapp.Get().Session.IdleTimeout
+}
+
+func AbsoluteTimeout() time.Duration {
+	return configsapp.Get().Session.AbsoluteTimeout
+}
+
+// Boşta kalma ya da toplam süre sınırını aşan oturum için ErrSessionTimedOut döner
+func CheckTimeouts(sess *session.Session, now time.Time) error {
+	createdAt, _ := sess.Get("created_at").(int64)
+	lastActivityAt, _ := sess.Get("last_activity_at").(int64)
+	if createdAt == 0 || lastActivityAt == 0 {
+		return ErrSessionTimedOut
+	}
+	if now.Sub(time.Unix(createdAt, 0)) > AbsoluteTimeout() {
+		return ErrSessionTimedOut
+	}
+	if now.Sub(time.Unix(lastActivityAt, 0)) > IdleTimeout() {
+		return ErrSessionTimedOut
+	}
+	return nil
+}
+
+// last_activity_at'i günceller ve Save ile storage/cookie süresini uzatır.
+// Save sonrası oturum nesnesi kullanılamayacağı için isteğin son adımında çağrılmalıdır.
+func RefreshActivity(sess *session.Session, now time.Time) error {
+	lastActivityAt, _ := sess.Get("last_activity_at").(int64)
+	if now.Sub(time.Unix(lastActivityAt, 0)) < activityRefreshInterval {
+		return nil
+	}
+	sess.Set("last_activity_at", now.Unix())
+	return sess.Save()
+}
@@ -0,0 +1,105 @@
+package configssession
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsapp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+func setSessionTimeouts(t *testing.T, idle, absolute time.Duration) {
+	t.Helper()
+	cfg := configsapp.Get()
+	previousIdle, previousAbsolute := cfg.Session.IdleTimeout, cfg.Session.AbsoluteTimeout
+	cfg.Session.IdleTimeout, cfg.Session.AbsoluteTimeout = idle, absolute
+	t.Cleanup(func() { cfg.Session.IdleTimeout, cfg.Session.AbsoluteTimeout = previousIdle, previousAbsolute })
+}
+
+// fn, oturum deposundan alınmış bir session ile istek içinde çalıştırılır
+func withSession(t *testing.T, fn func(c *fiber.Ctx, sess *session.Session) error) {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		sess, err := SessionStart(c)
+		if err != nil {
+			return err
+		}
+		return fn(c, sess)
+	})
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+}
+
+func TestCheckTimeouts(t *testing.T) {
+	useMemorySessions(t)
+	setSessionTimeouts(t, 30*time.Minute, 12*time.Hour)
+	now := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		createdAt    time.Time
+		lastActivity time.Time
+		timedOut     bool
+	}{
+		{"yeni oturum", now.Add(-time.Minute), now.Add(-time.Minute), false},
+		{"boşta kalma sınırında", now.Add(-time.Hour), now.Add(-30 * time.Minute), false},
+		{"boşta kalma aşıldı", now.Add(-time.Hour), now.Add(-31 * time.Minute), true},
+		{"etkin fakat toplam süre aşıldı", now.Add(-12*time.Hour - time.Second), now.Add(-time.Minute), true},
+		{"zaman bilgisi yok", time.Time{}, time.Time{}, true},
+	}
+	withSession(t, func(c *fiber.Ctx, sess *session.Session) error {
+		for _, tt := range tests {
+			sess.Delete("created_at")
+			sess.Delete("last_activity_at")
+			if !tt.createdAt.IsZero() {
+				sess.Set("created_at", tt.createdAt.Unix())
+				sess.Set("last_activity_at", tt.lastActivity.Unix())
+			}
+			err := CheckTimeouts(sess, now)
+			if got := errors.Is(err, ErrSessionTimedOut); got != tt.timedOut {
+				t.Errorf("%s: zaman aşımı = %v (err %v), beklenen %v", tt.name, got, err, tt.timedOut)
+			}
+		}
+		return nil
+	})
+}
+
+func TestRefreshActivityIsThrottled(t *testing.T) {
+	useMemorySessions(t)
+	start := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	var sessionID string
+	withSession(t, func(c *fiber.Ctx, sess *session.Session) error {
+		sessionID = sess.ID()
+		sess.Set("created_at", start.Unix())
+		sess.Set("last_activity_at", start.Unix())
+
+		// Aralık dolmadan storage'a yazılmaz
+		if err := RefreshActivity(sess, start.Add(activityRefreshInterval-time.Second)); err != nil {
+			return err
+		}
+		if raw, _ := Session.Storage.Get(sessionID); raw != nil {
+			t.Error("aralık dolmadan oturum storage'a yazıldı")
+		}
+		if got, _ := sess.Get("last_activity_at").(int64); got != start.Unix() {
+			t.Errorf("last_activity_at = %d, değişmemeliydi", got)
+		}
+
+		return RefreshActivity(sess, start.Add(activityRefreshInterval))
+	})
+
+	raw, err := Session.Storage.Get(sessionID)
+	if err != nil || raw == nil {
+		t.Fatalf("aralık dolunca oturum storage'a yazılmadı: %v", err)
+	}
+}
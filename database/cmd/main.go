@@ -1,27 +1,99 @@
 package main
 
 import (
+	"bufio"
 	"flag"
+	"fmt"
+	"os"
+	"strings"
 
+	"zatrano/configs/configsapp"
 	"zatrano/configs/configsdatabase"
 	"zatrano/configs/configslog"
 	"zatrano/database"
+	"zatrano/database/seeders"
+	"zatrano/pkg/passwordhash"
 )
 
 func main() {
+	dotEnvErr := configsapp.LoadDotEnv()
+	cfg := configsapp.MustLoad()
+
 	configslog.InitLogger()
 	defer configslog.SyncLogger()
+
+	if dotEnvErr != nil {
+		configslog.SLog.Warnw(".env dosyası yüklenemedi, sistem ortam değişkenleri kullanılacak (eğer varsa)", "error", dotEnvErr)
+	}
 	migrateFlag := flag.Bool("migrate", false, "Veritabanı başlatma işlemini çalıştır (migrasyonları içerir)")
-	seedFlag := flag.Bool("seed", false, "Veritabanı başlatma işlemini çalıştır (seederları içerir)")
+	var seed seedSelection
+	flag.Var(&seed, "seed", "Seeder'ları çalıştır; -seed=users,demo-data ile seçilebilir (tanımlı: "+strings.Join(seeders.Names(), ", ")+")")
+	freshFlag := flag.Bool("fresh", false, "Tüm tabloları sil, migrasyonları uygula ve seed et (production'da çalışmaz)")
+	yesFlag := flag.Bool("yes", false, "-fresh için onay sorma")
+	rollbackFlag := flag.Bool("rollback", false, "Son uygulanan migrasyonları geri al")
+	stepsFlag := flag.Int("steps", 1, "-rollback ile geri alınacak migrasyon sayısı")
+	statusFlag := flag.Bool("status", false, "Uygulanmış ve bekleyen migrasyonları listele")
 	flag.Parse()
 
+	passwordhash.Configure(passwordhash.FromSettings(cfg.Password))
+
 	configsdatabase.InitDB()
 	defer configsdatabase.CloseDB()
 
 	db := configsdatabase.GetDB()
 
+	switch {
+	case *freshFlag:
+		if !*yesFlag && !confirm("Tüm tablolar silinecek. Devam etmek için 'evet' yazın: ") {
+			configslog.SLog.Info("İşlem iptal edildi.")
+			return
+		}
+		database.Fresh(db, seed.names)
+		return
+	case *statusFlag:
+		database.PrintStatus(db, os.Stdout)
+		return
+	case *rollbackFlag:
+		database.Rollback(db, *stepsFlag)
+		return
+	}
+
 	configslog.SLog.Info("Veritabanı başlatma işlemi çalıştırılıyor...")
-	database.Initialize(db, *migrateFlag, *seedFlag)
+	database.Initialize(db, *migrateFlag, seed.enabled, seed.names)
 
 	configslog.SLog.Info("Veritabanı başlatma işlemi tamamlandı.")
 }
+
+// -seed tek başına tüm seeder'ları, -seed=a,b yalnızca verilenleri seçer
+type seedSelection struct {
+	enabled bool
+	names   []string
+}
+
+func (s *seedSelection) String() string {
+	return strings.Join(s.names, ",")
+}
+
+func (s *seedSelection) Set(value string) error {
+	switch value {
+	case "true", "":
+		s.enabled, s.names = true, nil
+	case "false":
+		s.enabled, s.names = false, nil
+	default:
+		s.enabled = true
+		s.names = strings.Split(value, ",")
+	}
+	return nil
+}
+
+func (s *seedSelection) IsBoolFlag() bool {
+	return true
+}
+
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "evet" || answer == "yes"
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestSeedSelectionFlag(t *testing.T) {
+	tests := []struct {
+		args    []string
+		enabled bool
+		names   []string
+	}{
+		{nil, false, nil},
+		{[]string{"-seed"}, true, nil},
+		{[]string{"-seed=users,demo-data"}, true, []string{"users", "demo-data"}},
+		{[]string{"-seed=false"}, false, nil},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("db", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		var seed seedSelection
+		fs.Var(&seed, "seed", "")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if seed.enabled != tt.enabled || !reflect.DeepEqual(seed.names, tt.names) {
+			t.Errorf("%v: enabled=%v names=%v, beklenen enabled=%v names=%v", tt.args, seed.enabled, seed.names, tt.enabled, tt.names)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ErrFreshInProduction = errors.New("-fresh production ortamında çalıştırılamaz")
+
+// Tüm tabloları siler, migrasyonları baştan uygular ve seed eder. Yalnızca geliştirme veritabanları içindir.
+func Fresh(db *gorm.DB, seederNames []string) {
+	if configsapp.Get().IsProduction() {
+		configslog.Log.Fatal("Veritabanı sıfırlanamadı", zap.Error(ErrFreshInProduction))
+	}
+
+	configslog.SLog.Warn("Tüm tablolar siliniyor...")
+	if err := dropAllTables(db); err != nil {
+		configslog.Log.Fatal("Tablolar silinemedi", zap.Error(err))
+	}
+
+	Initialize(db, true, true, seederNames)
+}
+
+// Yabancı anahtar kontrolü bağlantı başına kapatıldığı için tüm işlem tek bağlantıda yapılır
+func dropAllTables(db *gorm.DB) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		tables, err := conn.Migrator().GetTables()
+		if err != nil {
+			return err
+		}
+
+		dialect := conn.Dialector.Name()
+		switch dialect {
+		case "mysql":
+			if err := conn.Exec("SET FOREIGN_KEY_CHECKS = 0").Error; err != nil {
+				return err
+			}
+			defer conn.Exec("SET FOREIGN_KEY_CHECKS = 1")
+		case "sqlite":
+			if err := conn.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+				return err
+			}
+			defer conn.Exec("PRAGMA foreign_keys = ON")
+		}
+
+		for _, table := range tables {
+			// SQLite'ın iç tabloları (sqlite_sequence vb.) silinemez
+			if dialect == "sqlite" && strings.HasPrefix(table, "sqlite_") {
+				continue
+			}
+			// Postgres'te GORM DropTable CASCADE kullanır
+			if err := conn.Migrator().DropTable(table); err != nil {
+				return errors.New(table + ": " + err.Error())
+			}
+			configslog.Log.Info("Tablo silindi", zap.String("table", table))
+		}
+
+		if dialect == "postgres" {
+			return conn.Exec("DROP TYPE IF EXISTS user_type").Error
+		}
+		return nil
+	})
+}
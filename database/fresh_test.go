@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+	"zatrano/database/migrations"
+	"zatrano/database/seeders"
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+)
+
+func newInitializedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := testutil.NewDB(t)
+	Initialize(db, true, true, []string{"users"})
+
+	extra := models.User{Name: "Eski", Account: "eski@example.com", Password: "x", Type: models.Panel}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(&extra).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestFreshRebuildsAndReseeds(t *testing.T) {
+	db := newInitializedDB(t)
+
+	Fresh(db, []string{"users"})
+
+	var users []models.User
+	if err := db.Find(&users).Error; err != nil {
+		t.Fatalf("kullanıcılar okunamadı: %v", err)
+	}
+	if len(users) != 1 || users[0].Account != seeders.GetSystemUserConfig().Account {
+		t.Fatalf("sıfırlama sonrası kullanıcılar = %+v, yalnızca sistem kullanıcısı bekleniyordu", users)
+	}
+
+	statuses, err := migrations.Status(db)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied {
+			t.Errorf("%s sıfırlama sonrası uygulanmadı", s.ID)
+		}
+	}
+}
+
+func TestFreshRefusesInProduction(t *testing.T) {
+	db := newInitializedDB(t)
+
+	cfg := configsapp.Get()
+	previousEnv := cfg.Env
+	cfg.Env = "production"
+	previousLog := configslog.Log
+	// Fatal süreci sonlandırmak yerine panic'e çevrilir
+	configslog.Log = zap.New(zapcore.NewNopCore(), zap.WithFatalHook(zapcore.WriteThenPanic))
+	t.Cleanup(func() {
+		cfg.Env = previousEnv
+		configslog.Log = previousLog
+	})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("production ortamında Fresh durdurulmadı")
+			}
+		}()
+		Fresh(db, nil)
+	}()
+
+	var count int64
+	if err := db.Model(&models.User{}).Count(&count).Error; err != nil {
+		t.Fatalf("tablolar silinmiş: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("%d kullanıcı, dokunulmamış 2 kullanıcı bekleniyordu", count)
+	}
+}
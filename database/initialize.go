@@ -1,39 +1,30 @@
 package database
 
 import (
+	"fmt"
+	"io"
+
 	"zatrano/configs/configslog"
 	"zatrano/database/migrations"
 	"zatrano/database/seeders"
-	"zatrano/models"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
-func Initialize(db *gorm.DB, migrate bool, seed bool) {
+// seederNames boşsa tüm seeder'lar çalışır
+func Initialize(db *gorm.DB, migrate bool, seed bool, seederNames []string) {
 	if !migrate && !seed {
 		configslog.SLog.Info("Migrate veya seed bayrağı belirtilmedi, işlem yapılmayacak.")
 		return
 	}
 
-	tx := db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			configslog.Log.Fatal("Veritabanı başlatma işlemi başarısız oldu, geri alındı (panic)", zap.Any("panic_info", r))
-		}
-		if tx.Error != nil && tx.Error != gorm.ErrInvalidTransaction {
-			configslog.SLog.Warn("Başlatma sırasında hata oluştuğu için işlem geri alınıyor.")
-			tx.Rollback()
-		}
-	}()
-
 	configslog.SLog.Info("Veritabanı başlatma işlemi başlıyor...")
 
+	// Her migrasyon kendi transaction'ında çalışır ve schema_migrations'a kaydedilir
 	if migrate {
 		configslog.SLog.Info("Migrasyonlar çalıştırılıyor...")
-		if err := RunMigrationsInOrder(tx); err != nil {
-			tx.Rollback()
+		if err := migrations.Migrate(db); err != nil {
 			configslog.Log.Fatal("Migrasyon başarısız oldu", zap.Error(err))
 		}
 		configslog.SLog.Info("Migrasyonlar tamamlandı.")
@@ -43,8 +34,7 @@ func Initialize(db *gorm.DB, migrate bool, seed bool) {
 
 	if seed {
 		configslog.SLog.Info("Seeder'lar çalıştırılıyor...")
-		if err := CheckAndRunSeeders(tx); err != nil {
-			tx.Rollback()
+		if err := seeders.Run(db, seederNames); err != nil {
 			configslog.Log.Fatal("Seeding başarısız oldu", zap.Error(err))
 		}
 		configslog.SLog.Info("Seeder'lar tamamlandı.")
@@ -52,52 +42,31 @@ func Initialize(db *gorm.DB, migrate bool, seed bool) {
 		configslog.SLog.Info("Seed bayrağı belirtilmedi, seeder adımı atlanıyor.")
 	}
 
-	configslog.SLog.Info("İşlem commit ediliyor...")
-	if err := tx.Commit().Error; err != nil {
-		configslog.Log.Fatal("Commit başarısız oldu", zap.Error(err))
-	}
-
 	configslog.SLog.Info("Veritabanı başlatma işlemi başarıyla tamamlandı")
 }
 
-func RunMigrationsInOrder(db *gorm.DB) error {
-	configslog.SLog.Info(" -> User migrasyonları çalıştırılıyor...")
-	if err := migrations.MigrateUsersTable(db); err != nil {
-		configslog.Log.Error("Users tablosu migrasyonu başarısız oldu", zap.Error(err))
-		return err
+func Rollback(db *gorm.DB, steps int) {
+	configslog.SLog.Infof("Son %d migrasyon geri alınıyor...", steps)
+	if err := migrations.Rollback(db, steps); err != nil {
+		configslog.Log.Fatal("Geri alma başarısız oldu", zap.Error(err))
 	}
-	configslog.SLog.Info(" -> User migrasyonları tamamlandı.")
-
-	configslog.SLog.Info("Tüm migrasyonlar başarıyla çalıştırıldı.")
-	return nil
+	configslog.SLog.Info("Geri alma tamamlandı.")
 }
 
-func CheckAndRunSeeders(db *gorm.DB) error {
-	systemUser := seeders.GetSystemUserConfig()
-	var existingUser models.User
-	result := db.Where("account = ? AND type = ?", systemUser.Account, models.Dashboard).First(&existingUser)
+func PrintStatus(db *gorm.DB, w io.Writer) {
+	statuses, err := migrations.Status(db)
+	if err != nil {
+		configslog.Log.Fatal("Migrasyon durumu alınamadı", zap.Error(err))
+	}
 
-	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			configslog.SLog.Info("Sistem kullanıcısı oluşturuluyor: %s (%s)...", systemUser.Name, systemUser.Account)
-			if err := seeders.SeedSystemUser(db); err != nil {
-				configslog.Log.Error("Sistem kullanıcısı seed edilemedi", zap.Error(err))
-				return err
-			}
-			configslog.SLog.Info(" -> Sistem kullanıcısı oluşturuldu.")
-		} else {
-			configslog.Log.Error("Sistem kullanıcısı kontrol edilirken hata", zap.Error(result.Error))
-			return result.Error
+	pending := 0
+	for _, status := range statuses {
+		if status.Applied {
+			fmt.Fprintf(w, "[uygulandı] %s  (%s)\n", status.ID, status.AppliedAt.Format("2006-01-02 15:04:05"))
+			continue
 		}
-	} else {
-		configslog.SLog.Info("Sistem kullanıcısı '%s' (%s) zaten mevcut, oluşturma adımı atlanıyor.",
-			existingUser.Name, existingUser.Account)
-		configslog.SLog.Info("Mevcut sistem kullanıcısı '%s' için güncelleme kontrolü yapılıyor...", existingUser.Account)
-		if err := seeders.SeedSystemUser(db); err != nil {
-			configslog.Log.Error("Mevcut sistem kullanıcısı güncellenirken/kontrol edilirken hata", zap.Error(err))
-			return err
-		}
-
+		pending++
+		fmt.Fprintf(w, "[bekliyor]  %s\n", status.ID)
 	}
-	return nil
+	fmt.Fprintf(w, "Toplam: %d, uygulanan: %d, bekleyen: %d\n", len(statuses), len(statuses)-pending, pending)
 }
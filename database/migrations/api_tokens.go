@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+func MigrateAPITokensTable(db *gorm.DB) error {
+	configslog.SLog.Info("APIToken tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&apiToken0001{}); err != nil {
+		return errors.New("APIToken tablosu migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("APIToken tablosu migrate işlemi tamamlandı.")
+	return nil
+}
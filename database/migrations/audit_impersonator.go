@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+// 0004 anındaki tablo tanımı; kimliğe bürünme sırasında yapılan işlemlerde yöneticinin kimliği de tutulur
+type auditLog0004 struct {
+	ID             uint      `gorm:"primarykey"`
+	EntityType     string    `gorm:"size:100;not null;index:idx_audit_logs_entity"`
+	EntityID       uint      `gorm:"not null;index:idx_audit_logs_entity"`
+	Action         string    `gorm:"size:20;not null"`
+	ActorID        uint      `gorm:"index"`
+	ImpersonatorID *uint     `gorm:"index"`
+	Changes        string    `gorm:"type:text"`
+	CreatedAt      time.Time `gorm:"index"`
+}
+
+func (auditLog0004) TableName() string { return "audit_logs" }
+
+func MigrateAuditImpersonator(db *gorm.DB) error {
+	configslog.SLog.Info("AuditLog tablosuna impersonator_id ekleniyor...")
+	if err := db.AutoMigrate(&auditLog0004{}); err != nil {
+		return errors.New("AuditLog tablosu migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("AuditLog impersonator_id migrate işlemi tamamlandı.")
+	return nil
+}
+
+func auditImpersonatorDown(db *gorm.DB) error {
+	return db.Migrator().DropColumn(&auditLog0004{}, "ImpersonatorID")
+}
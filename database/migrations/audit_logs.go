@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+func MigrateAuditLogsTable(db *gorm.DB) error {
+	configslog.SLog.Info("AuditLog tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&auditLog0001{}); err != nil {
+		return errors.New("AuditLog tablosu migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("AuditLog tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+// 0003 anındaki tablo tanımı; models.Job sonradan değişse de bu migrasyonun sonucu değişmez
+type job0003 struct {
+	ID         uint   `gorm:"primarykey"`
+	Type       string `gorm:"size:100;not null"`
+	Payload    string `gorm:"type:text"`
+	Status     string `gorm:"size:20;not null;index"`
+	Progress   int    `gorm:"not null;default:0"`
+	Result     string `gorm:"type:text"`
+	Error      string `gorm:"type:text"`
+	CreatedBy  uint   `gorm:"index"`
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (job0003) TableName() string { return "jobs" }
+
+func MigrateJobsTable(db *gorm.DB) error {
+	configslog.SLog.Info("Job tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&job0003{}); err != nil {
+		return errors.New("Job tablosu migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("Job tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func jobsDown(db *gorm.DB) error {
+	return db.Migrator().DropTable(&job0003{})
+}
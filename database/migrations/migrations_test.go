@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+func TestMigrateOnSQLite(t *testing.T) {
+	db := testutil.NewDB(t)
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	statuses, err := Status(db)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) != len(registry) {
+		t.Fatalf("durum sayısı = %d, beklenen %d", len(statuses), len(registry))
+	}
+	for _, s := range statuses {
+		if !s.Applied {
+			t.Errorf("%s uygulanmadı", s.ID)
+		}
+	}
+
+	columns, err := db.Migrator().ColumnTypes(&models.User{})
+	if err != nil {
+		t.Fatalf("ColumnTypes: %v", err)
+	}
+	found := false
+	for _, column := range columns {
+		if column.Name() == "type" {
+			found = true
+			if typ := strings.ToLower(column.DatabaseTypeName()); !strings.HasPrefix(typ, "varchar") {
+				t.Errorf("type kolonu %q, varchar bekleniyordu", typ)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("users.type kolonu bulunamadı")
+	}
+
+	user := models.User{Name: "Test", Account: "test@example.com", Password: "x", Type: models.Dashboard}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(&user).Error; err != nil {
+		t.Fatalf("kullanıcı oluşturulamadı: %v", err)
+	}
+
+	// İkinci çalıştırma bekleyen migrasyon bulmamalı
+	if err := Migrate(db); err != nil {
+		t.Fatalf("ikinci Migrate: %v", err)
+	}
+}
+
+func TestRollbackAllOnSQLite(t *testing.T) {
+	db := testutil.NewDB(t)
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if err := Rollback(db, len(registry)); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if db.Migrator().HasTable(&models.User{}) {
+		t.Error("users tablosu geri alma sonrası hâlâ var")
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("yeniden Migrate: %v", err)
+	}
+}
+
+// Model değişip yeni migrasyon eklenmezse bu test yakalar
+func TestMigrationsCoverModels(t *testing.T) {
+	db := testutil.NewDB(t)
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	all := []interface{}{
+		&models.User{}, &models.AuditLog{}, &models.PasswordResetToken{}, &models.UserSession{},
+		&models.RememberToken{}, &models.Permission{}, &models.Role{}, &models.RolePermission{},
+		&models.UserRole{}, &models.APIToken{}, &models.Notification{}, &models.Job{},
+	}
+	for _, model := range all {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !db.Migrator().HasColumn(model, field.DBName) {
+				t.Errorf("%s.%s kolonu migrasyonlarda yok", stmt.Schema.Table, field.DBName)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"zatrano/configs/configslog"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ErrUnknownMigration = errors.New("uygulanmış migrasyon kayıt listesinde bulunamadı")
+
+// Up ve Down aynı transaction içinde çalışır; MySQL'de DDL ifadeleri transaction'ı örtük olarak commit eder
+type Migration struct {
+	ID   string
+	Up   func(db *gorm.DB) error
+	Down func(db *gorm.DB) error
+}
+
+type SchemaMigration struct {
+	ID        string    `gorm:"primaryKey;size:255"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type MigrationStatus struct {
+	ID        string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+func ensureTrackingTable(db *gorm.DB) error {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return errors.New("schema_migrations tablosu oluşturulamadı: " + err.Error())
+	}
+	return nil
+}
+
+func appliedMigrations(db *gorm.DB) ([]SchemaMigration, error) {
+	var applied []SchemaMigration
+	err := db.Order("applied_at ASC, id ASC").Find(&applied).Error
+	return applied, err
+}
+
+// Bekleyen migrasyonları kayıt sırasıyla, her birini ayrı transaction'da uygular.
+// Başarısız migrasyon kaydedilmez ve sonrakiler çalıştırılmaz.
+func Migrate(db *gorm.DB) error {
+	if err := ensureTrackingTable(db); err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+	done := make(map[string]bool, len(applied))
+	for _, m := range applied {
+		done[m.ID] = true
+	}
+
+	pending := 0
+	for _, migration := range registry {
+		if done[migration.ID] {
+			continue
+		}
+		pending++
+
+		configslog.Log.Info("Migrasyon uygulanıyor", zap.String("id", migration.ID))
+		start := time.Now()
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{ID: migration.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			configslog.Log.Error("Migrasyon başarısız oldu", zap.String("id", migration.ID), zap.Duration("duration", time.Since(start)), zap.Error(err))
+			return fmt.Errorf("%s: %w", migration.ID, err)
+		}
+		configslog.Log.Info("Migrasyon uygulandı", zap.String("id", migration.ID), zap.Duration("duration", time.Since(start)))
+	}
+
+	if pending == 0 {
+		configslog.SLog.Info("Bekleyen migrasyon yok, şema güncel.")
+	}
+	return nil
+}
+
+// Son uygulanan steps adet migrasyonu ters sırayla geri alır
+func Rollback(db *gorm.DB, steps int) error {
+	if steps <= 0 {
+		steps = 1
+	}
+	if err := ensureTrackingTable(db); err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		configslog.SLog.Info("Geri alınacak migrasyon yok.")
+		return nil
+	}
+
+	for i := len(applied) - 1; i >= 0 && steps > 0; i, steps = i-1, steps-1 {
+		migration, ok := find(applied[i].ID)
+		if !ok {
+			return fmt.Errorf("%s: %w", applied[i].ID, ErrUnknownMigration)
+		}
+
+		configslog.Log.Info("Migrasyon geri alınıyor", zap.String("id", migration.ID))
+		start := time.Now()
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if migration.Down != nil {
+				if err := migration.Down(tx); err != nil {
+					return err
+				}
+			}
+			return tx.Delete(&SchemaMigration{ID: migration.ID}).Error
+		})
+		if err != nil {
+			configslog.Log.Error("Migrasyon geri alınamadı", zap.String("id", migration.ID), zap.Duration("duration", time.Since(start)), zap.Error(err))
+			return fmt.Errorf("%s: %w", migration.ID, err)
+		}
+		configslog.Log.Info("Migrasyon geri alındı", zap.String("id", migration.ID), zap.Duration("duration", time.Since(start)))
+	}
+	return nil
+}
+
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	if err := ensureTrackingTable(db); err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[string]time.Time, len(applied))
+	for _, m := range applied {
+		appliedAt[m.ID] = m.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(registry))
+	for _, migration := range registry {
+		status := MigrationStatus{ID: migration.ID}
+		if at, ok := appliedAt[migration.ID]; ok {
+			status.Applied = true
+			status.AppliedAt = &at
+			delete(appliedAt, migration.ID)
+		}
+		statuses = append(statuses, status)
+	}
+	// Kodda karşılığı kalmamış kayıtlar da gösterilir
+	for _, m := range applied {
+		if at, ok := appliedAt[m.ID]; ok {
+			statuses = append(statuses, MigrationStatus{ID: m.ID, Applied: true, AppliedAt: &at})
+		}
+	}
+	return statuses, nil
+}
+
+func find(id string) (Migration, bool) {
+	for _, migration := range registry {
+		if migration.ID == id {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
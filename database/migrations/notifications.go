@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"errors"
+	"time"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+// 0002 anındaki tablo tanımı; models.Notification sonradan değişse de bu migrasyonun sonucu değişmez
+type notification0002 struct {
+	ID        uint       `gorm:"primarykey"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_read"`
+	Title     string     `gorm:"size:200;not null"`
+	Body      string     `gorm:"type:text"`
+	Link      string     `gorm:"size:500"`
+	ReadAt    *time.Time `gorm:"index:idx_notifications_user_read"`
+	CreatedAt time.Time  `gorm:"index"`
+}
+
+func (notification0002) TableName() string { return "notifications" }
+
+func MigrateNotificationsTable(db *gorm.DB) error {
+	configslog.SLog.Info("Notification tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&notification0002{}); err != nil {
+		return errors.New("Notification tablosu migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("Notification tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+func notificationsDown(db *gorm.DB) error {
+	return db.Migrator().DropTable(&notification0002{})
+}
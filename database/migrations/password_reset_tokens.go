@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+func MigratePasswordResetTokensTable(db *gorm.DB) error {
+	configslog.SLog.Info("PasswordResetToken tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&passwordResetToken0001{}); err != nil {
+		return errors.New("PasswordResetToken tablosu migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("PasswordResetToken tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+func MigratePermissionTables(db *gorm.DB) error {
+	configslog.SLog.Info("Permission, Role, RolePermission ve UserRole tabloları migrate ediliyor...")
+	if err := db.AutoMigrate(&permission0001{}, &role0001{}, &rolePermission0001{}, &userRole0001{}); err != nil {
+		return errors.New("yetki tabloları migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("Yetki tabloları migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// Yeni migrasyonlar listenin sonuna eklenir; uygulanmış bir migrasyonun ID'si veya içeriği sonradan değiştirilmez
+var registry = []Migration{
+	{ID: "0001_initial_schema", Up: initialSchemaUp, Down: initialSchemaDown},
+	{ID: "0002_notifications", Up: MigrateNotificationsTable, Down: notificationsDown},
+	{ID: "0003_jobs", Up: MigrateJobsTable, Down: jobsDown},
+	{ID: "0004_audit_impersonator", Up: MigrateAuditImpersonator, Down: auditImpersonatorDown},
+	{ID: "0005_user_organization", Up: MigrateUserOrganization, Down: userOrganizationDown},
+	{ID: "0006_user_version", Up: MigrateUserVersion, Down: userVersionDown},
+}
+
+// AutoMigrate ile kurulmuş mevcut veritabanlarında da güvenle çalışır; tablolar zaten varsa yalnızca kayıt düşülür
+func initialSchemaUp(db *gorm.DB) error {
+	steps := []func(*gorm.DB) error{
+		MigrateUsersTable,
+		MigrateAuditLogsTable,
+		MigratePasswordResetTokensTable,
+		MigrateUserSessionsTable,
+		MigrateRememberTokensTable,
+		MigratePermissionTables,
+		MigrateAPITokensTable,
+	}
+	for _, step := range steps {
+		if err := step(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func initialSchemaDown(db *gorm.DB) error {
+	if err := db.Migrator().DropTable(
+		&apiToken0001{},
+		&userRole0001{},
+		&rolePermission0001{},
+		&role0001{},
+		&permission0001{},
+		&rememberToken0001{},
+		&userSession0001{},
+		&passwordResetToken0001{},
+		&auditLog0001{},
+		&user0001{},
+	); err != nil {
+		return err
+	}
+	if db.Dialector.Name() == "postgres" {
+		return db.Exec("DROP TYPE IF EXISTS user_type").Error
+	}
+	return nil
+}
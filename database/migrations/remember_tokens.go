@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+func MigrateRememberTokensTable(db *gorm.DB) error {
+	configslog.SLog.Info("RememberToken tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&rememberToken0001{}); err != nil {
+		return errors.New("RememberToken tablosu migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("RememberToken tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,140 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// 0001_initial_schema anındaki tablo tanımları. Migrasyonlar models paketini değil bu kopyaları kullanır;
+// model sonradan değişse de uygulanmış bir migrasyonun sonucu değişmez. Şema değişikliği yeni migrasyonla yapılır.
+
+type userType0001 string
+
+func (userType0001) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "user_type"
+	}
+	return "varchar(10)"
+}
+
+type user0001 struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	CreatedBy uint
+	UpdatedBy uint
+	DeletedBy *uint `gorm:"column:deleted_by"`
+
+	Name     string       `gorm:"size:100;not null;index"`
+	Account  string       `gorm:"size:100;unique;not null"`
+	Password string       `gorm:"size:255;not null"`
+	Status   bool         `gorm:"default:true;index"`
+	Type     userType0001 `gorm:"not null;default:'panel';index"`
+
+	FailedLoginCount  int        `gorm:"not null;default:0"`
+	LockedUntil       *time.Time `gorm:"index"`
+	PasswordChangedAt *time.Time
+	LastLoginAt       *time.Time
+	LastLoginIP       string `gorm:"size:45"`
+	PreviousLoginAt   *time.Time
+}
+
+func (user0001) TableName() string { return "users" }
+
+type auditLog0001 struct {
+	ID         uint      `gorm:"primarykey"`
+	EntityType string    `gorm:"size:100;not null;index:idx_audit_logs_entity"`
+	EntityID   uint      `gorm:"not null;index:idx_audit_logs_entity"`
+	Action     string    `gorm:"size:20;not null"`
+	ActorID    uint      `gorm:"index"`
+	Changes    string    `gorm:"type:text"`
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+func (auditLog0001) TableName() string { return "audit_logs" }
+
+type passwordResetToken0001 struct {
+	ID        uint      `gorm:"primarykey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	Used      bool      `gorm:"not null;default:false"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (passwordResetToken0001) TableName() string { return "password_reset_tokens" }
+
+type userSession0001 struct {
+	ID             uint      `gorm:"primarykey"`
+	SessionID      string    `gorm:"size:64;not null;uniqueIndex"`
+	UserID         uint      `gorm:"not null;index"`
+	IPAddress      string    `gorm:"size:45"`
+	UserAgent      string    `gorm:"size:255"`
+	LastActivityAt time.Time `gorm:"not null"`
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+func (userSession0001) TableName() string { return "user_sessions" }
+
+type rememberToken0001 struct {
+	ID            uint      `gorm:"primarykey"`
+	UserID        uint      `gorm:"not null;index"`
+	Selector      string    `gorm:"size:32;not null;uniqueIndex"`
+	ValidatorHash string    `gorm:"size:64;not null"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	LastUsedAt    *time.Time
+	CreatedAt     time.Time
+}
+
+func (rememberToken0001) TableName() string { return "remember_tokens" }
+
+type permission0001 struct {
+	ID          uint   `gorm:"primarykey"`
+	Name        string `gorm:"size:100;not null;uniqueIndex"`
+	Description string `gorm:"size:255"`
+	CreatedAt   time.Time
+}
+
+func (permission0001) TableName() string { return "permissions" }
+
+type role0001 struct {
+	ID          uint   `gorm:"primarykey"`
+	Name        string `gorm:"size:100;not null;uniqueIndex"`
+	Description string `gorm:"size:255"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (role0001) TableName() string { return "roles" }
+
+type rolePermission0001 struct {
+	RoleID       uint `gorm:"primaryKey"`
+	PermissionID uint `gorm:"primaryKey;index"`
+}
+
+func (rolePermission0001) TableName() string { return "role_permissions" }
+
+type userRole0001 struct {
+	UserID uint `gorm:"primaryKey"`
+	RoleID uint `gorm:"primaryKey;index"`
+}
+
+func (userRole0001) TableName() string { return "user_roles" }
+
+type apiToken0001 struct {
+	ID         uint   `gorm:"primarykey"`
+	UserID     uint   `gorm:"not null;index"`
+	Name       string `gorm:"size:100;not null"`
+	TokenHash  string `gorm:"size:64;not null;uniqueIndex"`
+	Prefix     string `gorm:"size:16;not null"`
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (apiToken0001) TableName() string { return "api_tokens" }
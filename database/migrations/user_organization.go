@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+// 0005 anında eklenen kolon; çok kiracılı kurulumda kullanıcı sorguları bu kolona göre süzülür
+type userOrganization0005 struct {
+	ID             uint  `gorm:"primarykey"`
+	OrganizationID *uint `gorm:"index"`
+}
+
+func (userOrganization0005) TableName() string { return "users" }
+
+func MigrateUserOrganization(db *gorm.DB) error {
+	configslog.SLog.Info("User tablosuna organization_id ekleniyor...")
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&userOrganization0005{}, "OrganizationID") {
+		if err := migrator.AddColumn(&userOrganization0005{}, "OrganizationID"); err != nil {
+			return errors.New("users.organization_id eklenemedi: " + err.Error())
+		}
+	}
+	if !migrator.HasIndex(&userOrganization0005{}, "OrganizationID") {
+		if err := migrator.CreateIndex(&userOrganization0005{}, "OrganizationID"); err != nil {
+			return errors.New("users.organization_id indeksi oluşturulamadı: " + err.Error())
+		}
+	}
+
+	configslog.SLog.Info("User organization_id migrate işlemi tamamlandı.")
+	return nil
+}
+
+func userOrganizationDown(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if migrator.HasIndex(&userOrganization0005{}, "OrganizationID") {
+		if err := migrator.DropIndex(&userOrganization0005{}, "OrganizationID"); err != nil {
+			return err
+		}
+	}
+	return migrator.DropColumn(&userOrganization0005{}, "OrganizationID")
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+func MigrateUserSessionsTable(db *gorm.DB) error {
+	configslog.SLog.Info("UserSession tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&userSession0001{}); err != nil {
+		return errors.New("UserSession tablosu migrate edilemedi: " + err.Error())
+	}
+
+	configslog.SLog.Info("UserSession tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+
+	"gorm.io/gorm"
+)
+
+// 0006 anında eklenen kolon; kullanıcı güncellemeleri beklenen sürümle koşullanır
+type userVersion0006 struct {
+	ID      uint `gorm:"primarykey"`
+	Version uint `gorm:"not null;default:1"`
+}
+
+func (userVersion0006) TableName() string { return "users" }
+
+func MigrateUserVersion(db *gorm.DB) error {
+	configslog.SLog.Info("User tablosuna version ekleniyor...")
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&userVersion0006{}, "Version") {
+		if err := migrator.AddColumn(&userVersion0006{}, "Version"); err != nil {
+			return errors.New("users.version eklenemedi: " + err.Error())
+		}
+	}
+
+	configslog.SLog.Info("User version migrate işlemi tamamlandı.")
+	return nil
+}
+
+func userVersionDown(db *gorm.DB) error {
+	return db.Migrator().DropColumn(&userVersion0006{}, "Version")
+}
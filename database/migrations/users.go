@@ -3,38 +3,43 @@ package migrations
 import (
 	"errors"
 	"zatrano/configs/configslog"
-	"zatrano/models"
 
 	"gorm.io/gorm"
 )
 
 func MigrateUsersTable(db *gorm.DB) error {
-	configslog.SLog.Info("User tablosu için enum tipi kontrol ediliyor...")
+	// Kolon tipi userType0001.GormDBDataType'tan gelir: Postgres'te user_type enum'u, diğerlerinde varchar(10)
+	if db.Dialector.Name() == "postgres" {
+		if err := createUserTypeEnum(db); err != nil {
+			return err
+		}
+	}
 
-	dropEnumQuery := `DROP TYPE IF EXISTS user_type;`
-	rawDB, err := db.DB()
-	if err != nil {
-		return errors.New("DB instance alınamadı: " + err.Error())
+	configslog.SLog.Info("User tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&user0001{}); err != nil {
+		return errors.New("User tablosu migrate edilemedi: " + err.Error())
 	}
 
-	_, err = rawDB.Exec(dropEnumQuery)
-	if err != nil {
-		return errors.New("user_type enum silinemedi: " + err.Error())
+	configslog.SLog.Info("User tablosu migrate işlemi tamamlandı.")
+	return nil
+}
+
+// Tip zaten varsa (mevcut kurulumlar) dokunulmaz; kullanımda olan bir enum silinemez
+func createUserTypeEnum(db *gorm.DB) error {
+	configslog.SLog.Info("User tablosu için enum tipi kontrol ediliyor...")
+
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM pg_type WHERE typname = ?", "user_type").Scan(&count).Error; err != nil {
+		return errors.New("user_type enum kontrol edilemedi: " + err.Error())
+	}
+	if count > 0 {
+		configslog.SLog.Info("user_type enum zaten mevcut.")
+		return nil
 	}
-	configslog.SLog.Info("user_type enum başarıyla silindi.")
 
-	createEnum := `CREATE TYPE user_type AS ENUM ('dashboard', 'panel');`
-	_, err = rawDB.Exec(createEnum)
-	if err != nil {
+	if err := db.Exec("CREATE TYPE user_type AS ENUM ('dashboard', 'panel')").Error; err != nil {
 		return errors.New("user_type enum oluşturulamadı: " + err.Error())
 	}
 	configslog.SLog.Info("user_type enum başarıyla oluşturuldu.")
-
-	configslog.SLog.Info("User tablosu migrate ediliyor...")
-	if err := db.AutoMigrate(&models.User{}); err != nil {
-		return errors.New("User tablosu migrate edilemedi: " + err.Error())
-	}
-
-	configslog.SLog.Info("User tablosu migrate işlemi tamamlandı.")
 	return nil
 }
@@ -0,0 +1,41 @@
+package seeders
+
+import (
+	"fmt"
+
+	"zatrano/models"
+	"zatrano/pkg/passwordhash"
+
+	"gorm.io/gorm"
+)
+
+const demoUserCount = 25
+
+// Arayüzü denemek için panel kullanıcıları oluşturur; hesap adına göre eşleştiği için tekrar çalıştırmak güvenlidir
+func SeedDemoData(db *gorm.DB) (int64, error) {
+	hashedPassword, err := passwordhash.Hash("demo1234")
+	if err != nil {
+		return 0, err
+	}
+
+	var created int64
+	for i := 1; i <= demoUserCount; i++ {
+		user := models.User{
+			Name:     fmt.Sprintf("Demo Kullanıcı %02d", i),
+			Account:  fmt.Sprintf("demo%02d@zatrano", i),
+			Password: hashedPassword,
+			Status:   i%5 != 0,
+			Type:     models.Panel,
+			BaseModel: models.BaseModel{
+				CreatedBy: seedActorID,
+				UpdatedBy: seedActorID,
+			},
+		}
+		result := db.Where(models.User{Account: user.Account}).Attrs(user).FirstOrCreate(&user)
+		if result.Error != nil {
+			return created, result.Error
+		}
+		created += result.RowsAffected
+	}
+	return created, nil
+}
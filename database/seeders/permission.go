@@ -0,0 +1,98 @@
+package seeders
+
+import (
+	"zatrano/configs/configslog"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// İzinler ve varsayılan roller idempotent olarak oluşturulur. Mevcut rollerin izinlerine
+// dokunulmaz; yalnızca admin rolüne sonradan eklenen izinler de bağlanır.
+func SeedPermissions(db *gorm.DB) (int64, error) {
+	var rows int64
+	permissionIDs := make(map[string]uint, len(models.DefaultPermissions))
+	for _, permission := range models.DefaultPermissions {
+		p := permission
+		result := db.Where(models.Permission{Name: p.Name}).Attrs(models.Permission{Description: p.Description}).FirstOrCreate(&p)
+		if result.Error != nil {
+			configslog.Log.Error("İzin oluşturulamadı", zap.String("permission", p.Name), zap.Error(result.Error))
+			return rows, result.Error
+		}
+		rows += result.RowsAffected
+		permissionIDs[p.Name] = p.ID
+	}
+
+	for roleName, permissionNames := range models.DefaultRolePermissions {
+		var role models.Role
+		result := db.Where(models.Role{Name: roleName}).
+			Attrs(models.Role{Description: models.DefaultRoleDescriptions[roleName]}).
+			FirstOrCreate(&role)
+		if result.Error != nil {
+			configslog.Log.Error("Rol oluşturulamadı", zap.String("role", roleName), zap.Error(result.Error))
+			return rows, result.Error
+		}
+		rows += result.RowsAffected
+
+		created := result.RowsAffected > 0
+		if roleName == models.RoleAdmin {
+			permissionNames = make([]string, 0, len(permissionIDs))
+			for name := range permissionIDs {
+				permissionNames = append(permissionNames, name)
+			}
+		} else if !created {
+			continue
+		}
+
+		links := make([]models.RolePermission, 0, len(permissionNames))
+		for _, name := range permissionNames {
+			links = append(links, models.RolePermission{RoleID: role.ID, PermissionID: permissionIDs[name]})
+		}
+		if len(links) > 0 {
+			result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links)
+			if result.Error != nil {
+				configslog.Log.Error("Rol izinleri bağlanamadı", zap.String("role", roleName), zap.Error(result.Error))
+				return rows, result.Error
+			}
+			rows += result.RowsAffected
+		}
+	}
+
+	assigned, err := assignInitialAdmins(db)
+	return rows + assigned, err
+}
+
+// Yetki sistemi ilk kurulduğunda yalnızca sistem kullanıcısına admin rolü verilir; diğer yöneticilerin
+// rollerini o atar. Tip alanı tek başına yetki sayılmaz, aksi halde her dashboard kullanıcısı admin olurdu.
+func assignInitialAdmins(db *gorm.DB) (int64, error) {
+	var assignedCount int64
+	if err := db.Model(&models.UserRole{}).Count(&assignedCount).Error; err != nil {
+		return 0, err
+	}
+	if assignedCount > 0 {
+		return 0, nil
+	}
+
+	var adminRole models.Role
+	if err := db.Where("name = ?", models.RoleAdmin).First(&adminRole).Error; err != nil {
+		return 0, err
+	}
+
+	bootstrap := GetSystemUserConfig()
+	var userIDs []uint
+	if err := db.Model(&models.User{}).Where("account = ? AND type = ?", bootstrap.Account, bootstrap.Type).Limit(1).Pluck("id", &userIDs).Error; err != nil {
+		return 0, err
+	}
+	if len(userIDs) == 0 {
+		configslog.Log.Warn("Sistem kullanıcısı bulunamadı, admin rolü atanmadı", zap.String("account", bootstrap.Account))
+		return 0, nil
+	}
+
+	if err := db.Create(&models.UserRole{UserID: userIDs[0], RoleID: adminRole.ID}).Error; err != nil {
+		return 0, err
+	}
+	configslog.SLog.Infof("Sistem kullanıcısına '%s' admin rolü atandı.", bootstrap.Account)
+	return 1, nil
+}
@@ -0,0 +1,49 @@
+package seeders
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+)
+
+func TestSeedPermissionsGrantsAdminOnlyToSystemUser(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.Permission{}, &models.Role{}, &models.RolePermission{}, &models.UserRole{})
+	if _, err := SeedSystemUser(db); err != nil {
+		t.Fatalf("SeedSystemUser: %v", err)
+	}
+	other := models.User{Name: "Diğer", Account: "diger@example.com", Password: "x", Type: models.Dashboard, Status: true}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(&other).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SeedPermissions(db); err != nil {
+		t.Fatalf("SeedPermissions: %v", err)
+	}
+	// İkinci çalıştırma yeni atama yapmamalı
+	if _, err := SeedPermissions(db); err != nil {
+		t.Fatalf("ikinci SeedPermissions: %v", err)
+	}
+
+	var links []models.UserRole
+	db.Find(&links)
+	if len(links) != 1 {
+		t.Fatalf("%d rol ataması, beklenen 1", len(links))
+	}
+	var system models.User
+	db.Where("account = ?", GetSystemUserConfig().Account).First(&system)
+	if links[0].UserID != system.ID {
+		t.Errorf("admin rolü kullanıcı %d'ye atandı, beklenen sistem kullanıcısı %d", links[0].UserID, system.ID)
+	}
+
+	var adminPermissions int64
+	db.Model(&models.RolePermission{}).
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ?", models.RoleAdmin).
+		Count(&adminPermissions)
+	if int(adminPermissions) != len(models.DefaultPermissions) {
+		t.Errorf("admin rolünde %d izin, beklenen %d", adminPermissions, len(models.DefaultPermissions))
+	}
+}
@@ -0,0 +1,108 @@
+package seeders
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ErrUnknownSeeder = errors.New("tanımsız seeder")
+
+// Run eklenen veya güncellenen satır sayısını döner; iki kez çalıştırmak kopya kayıt üretmemelidir
+type Seeder struct {
+	Name string
+	Run  func(db *gorm.DB) (int64, error)
+	// Yalnızca geliştirme ortamında çalışır; APP_ENV=production iken atlanır
+	DevOnly bool
+}
+
+// Kayıt sırası çalıştırma sırasıdır; izinler admin ataması için kullanıcılardan sonra gelir
+var registry = []Seeder{
+	{Name: "users", Run: SeedSystemUser},
+	{Name: "permissions", Run: SeedPermissions},
+	{Name: "demo-data", Run: SeedDemoData, DevOnly: true},
+}
+
+func Names() []string {
+	names := make([]string, len(registry))
+	for i, seeder := range registry {
+		names[i] = seeder.Name
+	}
+	return names
+}
+
+// names boşsa tüm seeder'lar çalışır. Her seeder kendi transaction'ında çalışır;
+// hata veren seeder geri alınır ve sonrakiler çalıştırılmaz.
+func Run(db *gorm.DB, names []string) error {
+	selected, err := selectSeeders(names)
+	if err != nil {
+		return err
+	}
+
+	for _, seeder := range selected {
+		if seeder.DevOnly && configsapp.Get().IsProduction() {
+			configslog.Log.Warn("Seeder production ortamında atlandı", zap.String("seeder", seeder.Name))
+			continue
+		}
+
+		configslog.Log.Info("Seeder çalıştırılıyor", zap.String("seeder", seeder.Name))
+		start := time.Now()
+		var rows int64
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var runErr error
+			rows, runErr = seeder.Run(tx)
+			return runErr
+		})
+		if err != nil {
+			configslog.Log.Error("Seeder başarısız oldu", zap.String("seeder", seeder.Name), zap.Error(err))
+			return errors.New(seeder.Name + ": " + err.Error())
+		}
+		configslog.Log.Info("Seeder tamamlandı",
+			zap.String("seeder", seeder.Name),
+			zap.Int64("rows", rows),
+			zap.Duration("duration", time.Since(start)),
+		)
+	}
+	return nil
+}
+
+func selectSeeders(names []string) ([]Seeder, error) {
+	if len(names) == 0 {
+		return registry, nil
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !isRegistered(name) {
+			return nil, errors.New(ErrUnknownSeeder.Error() + ": " + name + " (tanımlı: " + strings.Join(Names(), ", ") + ")")
+		}
+		wanted[name] = true
+	}
+
+	selected := make([]Seeder, 0, len(wanted))
+	for _, seeder := range registry {
+		if wanted[seeder.Name] {
+			selected = append(selected, seeder)
+		}
+	}
+	return selected, nil
+}
+
+func isRegistered(name string) bool {
+	for _, seeder := range registry {
+		if seeder.Name == name {
+			return true
+		}
+	}
+	return false
+}
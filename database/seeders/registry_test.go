@@ -0,0 +1,111 @@
+package seeders
+
+import (
+	"strings"
+	"testing"
+
+	"zatrano/configs/configsapp"
+	"zatrano/database/migrations"
+	"zatrano/models"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+func newSeedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := testutil.NewDB(t)
+	if err := migrations.Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	return db
+}
+
+type seedCounts struct {
+	users, roles, permissions, rolePermissions, userRoles int64
+}
+
+func countSeeded(t *testing.T, db *gorm.DB) seedCounts {
+	t.Helper()
+	var c seedCounts
+	for _, item := range []struct {
+		model interface{}
+		dest  *int64
+	}{
+		{&models.User{}, &c.users},
+		{&models.Role{}, &c.roles},
+		{&models.Permission{}, &c.permissions},
+		{&models.RolePermission{}, &c.rolePermissions},
+		{&models.UserRole{}, &c.userRoles},
+	} {
+		if err := db.Model(item.model).Count(item.dest).Error; err != nil {
+			t.Fatalf("sayım başarısız: %v", err)
+		}
+	}
+	return c
+}
+
+func TestRunTwiceCreatesNoDuplicates(t *testing.T) {
+	db := newSeedDB(t)
+
+	if err := Run(db, nil); err != nil {
+		t.Fatalf("ilk Run: %v", err)
+	}
+	first := countSeeded(t, db)
+	if first.users != 1+demoUserCount {
+		t.Errorf("%d kullanıcı, beklenen %d", first.users, 1+demoUserCount)
+	}
+	if first.permissions != int64(len(models.DefaultPermissions)) || first.userRoles != 1 {
+		t.Errorf("beklenmeyen izin kayıtları: %+v", first)
+	}
+
+	if err := Run(db, nil); err != nil {
+		t.Fatalf("ikinci Run: %v", err)
+	}
+	if second := countSeeded(t, db); second != first {
+		t.Errorf("ikinci çalıştırma kayıtları değiştirdi: önce %+v, sonra %+v", first, second)
+	}
+}
+
+func TestRunSeedsOnlySelectedSeeders(t *testing.T) {
+	db := newSeedDB(t)
+
+	if err := Run(db, []string{" users ", ""}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	counts := countSeeded(t, db)
+	if counts.users != 1 {
+		t.Errorf("%d kullanıcı, yalnızca sistem kullanıcısı bekleniyordu", counts.users)
+	}
+	if counts.permissions != 0 || counts.roles != 0 {
+		t.Errorf("seçilmeyen permissions seeder'ı çalıştı: %+v", counts)
+	}
+}
+
+func TestRunRejectsUnknownSeeder(t *testing.T) {
+	db := newSeedDB(t)
+
+	err := Run(db, []string{"users", "yok"})
+	if err == nil || !strings.HasPrefix(err.Error(), ErrUnknownSeeder.Error()) || !strings.Contains(err.Error(), "yok") {
+		t.Fatalf("hata = %v, tanımsız seeder hatası bekleniyordu", err)
+	}
+	// Seçim doğrulanmadan hiçbir seeder çalışmamalı
+	if counts := countSeeded(t, db); counts.users != 0 {
+		t.Errorf("geçersiz seçimde %d kullanıcı oluşturuldu", counts.users)
+	}
+}
+
+func TestRunSkipsDevOnlySeedersInProduction(t *testing.T) {
+	db := newSeedDB(t)
+	cfg := configsapp.Get()
+	previous := cfg.Env
+	cfg.Env = "production"
+	t.Cleanup(func() { cfg.Env = previous })
+
+	if err := Run(db, []string{"users", "demo-data"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if counts := countSeeded(t, db); counts.users != 1 {
+		t.Errorf("production'da %d kullanıcı, demo verisi atlanmalıydı", counts.users)
+	}
+}
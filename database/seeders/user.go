@@ -1,15 +1,17 @@
 package seeders
 
 import (
-	"context"
 	"zatrano/configs/configslog"
 	"zatrano/models"
+	"zatrano/pkg/passwordhash"
 
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// Seeder'lar repository dışından yazdığı için denetim kolonları bu kimlikle açıkça doldurulur
+const seedActorID uint = 1
+
 func GetSystemUserConfig() models.User {
 	return models.User{
 		Name:     "ZATRANO",
@@ -19,31 +21,36 @@ func GetSystemUserConfig() models.User {
 	}
 }
 
-func SeedSystemUser(db *gorm.DB) error {
+// Hesap adına göre eşleşir; mevcut kullanıcının yalnızca adı ve durumu düzeltilir, şifresine dokunulmaz
+func SeedSystemUser(db *gorm.DB) (int64, error) {
 	systemUserConfig := GetSystemUserConfig()
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(systemUserConfig.Password), bcrypt.DefaultCost)
+	hashedPassword, err := passwordhash.Hash(systemUserConfig.Password)
 	if err != nil {
 		configslog.Log.Error("Sistem kullanıcısının şifresi hash'lenirken hata oluştu",
 			zap.String("account", systemUserConfig.Account),
 			zap.Error(err),
 		)
-		return err
+		return 0, err
 	}
 
 	userToSeed := models.User{
 		Name:     systemUserConfig.Name,
 		Account:  systemUserConfig.Account,
 		Type:     systemUserConfig.Type,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
 		Status:   true,
+		BaseModel: models.BaseModel{
+			CreatedBy: seedActorID,
+			UpdatedBy: seedActorID,
+		},
 	}
 
 	var existingUser models.User
 	result := db.Where("account = ? AND type = ?", userToSeed.Account, userToSeed.Type).First(&existingUser)
 
 	if result.Error == nil {
-		configslog.SLog.Info("Sistem kullanıcısı '%s' zaten mevcut. Güncelleme gerekip gerekmediği kontrol ediliyor...", userToSeed.Account)
+		configslog.SLog.Infof("Sistem kullanıcısı '%s' zaten mevcut. Güncelleme gerekip gerekmediği kontrol ediliyor...", userToSeed.Account)
 
 		updateFields := make(map[string]interface{})
 		needsUpdate := false
@@ -58,43 +65,42 @@ func SeedSystemUser(db *gorm.DB) error {
 		}
 
 		if needsUpdate {
-			configslog.SLog.Info("Mevcut sistem kullanıcısı '%s' güncelleniyor...", userToSeed.Account)
+			configslog.SLog.Infof("Mevcut sistem kullanıcısı '%s' güncelleniyor...", userToSeed.Account)
 
-			ctx := context.WithValue(context.Background(), "user_id", uint(1))
-			err := db.WithContext(ctx).Model(&existingUser).Updates(updateFields).Error
+			updateFields["updated_by"] = seedActorID
+			err := db.Model(&existingUser).Updates(updateFields).Error
 			if err != nil {
 				configslog.Log.Error("Mevcut sistem kullanıcısı güncellenemedi",
 					zap.String("account", userToSeed.Account),
 					zap.Error(err),
 				)
-				return err
+				return 0, err
 			}
-			configslog.SLog.Info("Mevcut sistem kullanıcısı '%s' başarıyla güncellendi.", userToSeed.Account)
-		} else {
-			configslog.SLog.Info("Mevcut sistem kullanıcısı '%s' için güncelleme gerekmiyor.", userToSeed.Account)
+			configslog.SLog.Infof("Mevcut sistem kullanıcısı '%s' başarıyla güncellendi.", userToSeed.Account)
+			return 1, nil
 		}
-		return nil
+		configslog.SLog.Infof("Mevcut sistem kullanıcısı '%s' için güncelleme gerekmiyor.", userToSeed.Account)
+		return 0, nil
 
 	} else if result.Error != gorm.ErrRecordNotFound {
 		configslog.Log.Error("Sistem kullanıcısı kontrol edilirken veritabanı hatası",
 			zap.String("account", userToSeed.Account),
 			zap.Error(result.Error),
 		)
-		return result.Error
+		return 0, result.Error
 	}
 
-	configslog.SLog.Info("Sistem kullanıcısı '%s' bulunamadı. Oluşturuluyor...", userToSeed.Account)
+	configslog.SLog.Infof("Sistem kullanıcısı '%s' bulunamadı. Oluşturuluyor...", userToSeed.Account)
 
-	ctx := context.WithValue(context.Background(), "user_id", uint(1))
-	err = db.WithContext(ctx).Create(&userToSeed).Error
+	err = db.Create(&userToSeed).Error
 	if err != nil {
 		configslog.Log.Error("Sistem kullanıcısı oluşturulamadı",
 			zap.String("account", userToSeed.Account),
 			zap.Error(err),
 		)
-		return err
+		return 0, err
 	}
 
-	configslog.SLog.Info("Sistem kullanıcısı '%s' başarıyla oluşturuldu.", userToSeed.Account)
-	return nil
+	configslog.SLog.Infof("Sistem kullanıcısı '%s' başarıyla oluşturuldu.", userToSeed.Account)
+	return 1, nil
 }
@@ -1,50 +1,66 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
-
-	"davet.link/configs/configslog"
-	"davet.link/configs/configssession"
-	"davet.link/models"
-	"davet.link/pkg/flashmessages"
-	"davet.link/pkg/renderer"
-	"davet.link/services"
+	"net/url"
+	"strings"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
+	"zatrano/models"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/validation"
+	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
 type AuthHandler struct {
-	service services.IAuthService
+	service       services.IAuthService
+	sessions      services.ISessionService
+	remember      services.IRememberService
+	tokens        services.IAPITokenService
+	impersonation services.IImpersonationService
 }
 
 func NewAuthHandler() *AuthHandler {
-	return &AuthHandler{service: services.NewAuthService()}
+	return &AuthHandler{
+		service:       services.NewAuthService(),
+		sessions:      services.NewSessionService(),
+		remember:      services.NewRememberService(),
+		tokens:        services.NewAPITokenService(),
+		impersonation: services.NewImpersonationService(),
+	}
 }
 
 func (h *AuthHandler) handleError(c *fiber.Ctx, err error, userID uint, account string, action string) error {
-	var errMsg string
+	var errKey string
 	flashKey := flashmessages.FlashErrorKey
 	redirectTarget := "/auth/login"
 	logoutUser := false
 
 	switch err {
-	case services.ErrInvalidCredentials:
-		errMsg = "Kullanıcı adı veya şifre hatalı."
-	case services.ErrUserInactive:
-		errMsg = "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin."
+	case services.ErrInvalidCredentials, services.ErrUserInactive:
+		errKey = err.(services.ServiceError).MessageKey()
 	case services.ErrUserNotFound:
-		errMsg = "Kullanıcı bulunamadı, lütfen tekrar giriş yapın."
+		errKey = services.ErrUserNotFound.MessageKey()
 		logoutUser = true
 		configslog.Log.Warn(action+": Kullanıcı bulunamadı", zap.Uint("user_id", userID))
-	case services.ErrCurrentPasswordIncorrect:
-		errMsg = "Mevcut şifreniz hatalı."
-		redirectTarget = "/auth/profile"
-	case services.ErrPasswordTooShort, services.ErrPasswordSameAsOld:
-		errMsg = err.Error()
+	case services.ErrCurrentPasswordIncorrect, services.ErrPasswordSameAsOld:
+		errKey = err.(services.ServiceError).MessageKey()
 		redirectTarget = "/auth/profile"
+	case services.ErrResetTokenInvalid, services.ErrResetRequestFailed:
+		errKey = err.(services.ServiceError).MessageKey()
+		redirectTarget = "/auth/forgot-password"
 	default:
-		errMsg = "İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin."
+		errKey = "common.unexpected_error"
 		configslog.Log.Error(action+": Beklenmeyen hata",
 			zap.Uint("user_id", userID),
 			zap.String("account", account),
@@ -55,31 +71,26 @@ func (h *AuthHandler) handleError(c *fiber.Ctx, err error, userID uint, account
 		h.destroySession(c)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashKey, errMsg)
+	_ = flashmessages.SetFlashMessage(c, flashKey, i18n.T(c, errKey))
 	return c.Redirect(redirectTarget, fiber.StatusSeeOther)
 }
 
-func (h *AuthHandler) getSessionUser(c *fiber.Ctx) (uint, error) {
-	if userID, ok := c.Locals("userID").(uint); ok {
-		return userID, nil
-	}
-
-	sess, err := configssession.SessionStart(c)
-	if err != nil {
-		return 0, err
+// Politika ihlallerinin tamamı new_password alanının altında gösterilir; diğer hatalarda nil döner
+func (h *AuthHandler) passwordPolicyError(c *fiber.Ctx, err error, location string) error {
+	var policyErr *services.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
 	}
+	fieldErrors := map[string]string{"new_password": strings.Join(policyErr.Messages(i18n.Locale(c)), " ")}
+	return renderer.RedirectFieldErrors(c, i18n.T(c, "auth.password_policy"), fieldErrors, nil, location)
+}
 
-	userIDValue := sess.Get("user_id")
-	switch v := userIDValue.(type) {
-	case uint:
-		return v, nil
-	case int:
-		return uint(v), nil
-	case float64:
-		return uint(v), nil
-	default:
+func (h *AuthHandler) getSessionUser(c *fiber.Ctx) (uint, error) {
+	userID, ok := requestctx.UserIDFromFiber(c)
+	if !ok {
 		return 0, fiber.ErrUnauthorized
 	}
+	return userID, nil
 }
 
 func (h *AuthHandler) destroySession(c *fiber.Ctx) {
@@ -88,32 +99,50 @@ func (h *AuthHandler) destroySession(c *fiber.Ctx) {
 		configslog.Log.Warn("Oturum yok edilemedi (zaten yok olabilir)", zap.Error(err))
 		return
 	}
+	if !sess.Fresh() {
+		if err := h.sessions.Revoke(sess.ID()); err != nil {
+			configslog.Log.Warn("Oturum kaydı iptal edilemedi", zap.Error(err))
+		}
+	}
 	if err := sess.Destroy(); err != nil {
 		configslog.Log.Error("Oturum yok edilemedi", zap.Error(err))
 	}
 }
 
 func (h *AuthHandler) createUserSession(c *fiber.Ctx, user *models.User) error {
-	sess, err := configssession.SessionStart(c)
+	sessionID, err := configssession.StartUserSession(c, user)
 	if err != nil {
 		return err
 	}
 
-	sess.Set("user_id", user.ID)
-	sess.Set("user_type", string(user.Type))
-	sess.Set("user_status", user.Status)
-	sess.Set("user_name", user.Name)
+	// Takip kaydı yazılamazsa giriş engellenmez; AuthMiddleware bir sonraki istekte yeniden dener
+	if err := h.sessions.Track(user.ID, sessionID, c.IP(), c.Get(fiber.HeaderUserAgent)); err != nil {
+		configslog.Log.Error("Oturum kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+	return nil
+}
 
-	if err := sess.Save(); err != nil {
-		return err
+func (h *AuthHandler) issueRememberToken(c *fiber.Ctx, userID uint) {
+	value, expiresAt, err := h.remember.Issue(userID)
+	if err != nil {
+		configslog.Log.Warn("Hatırlama token'ı oluşturulamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return
 	}
+	configssession.SetRememberCookie(c, value, expiresAt)
+}
 
-	return nil
+func (h *AuthHandler) forgetRememberToken(c *fiber.Ctx) {
+	if value := c.Cookies(configssession.RememberCookieName); value != "" {
+		if err := h.remember.Forget(value); err != nil {
+			configslog.Log.Warn("Hatırlama token'ı silinemedi", zap.Error(err))
+		}
+	}
+	configssession.ClearRememberCookie(c)
 }
 
 func (h *AuthHandler) ShowLogin(c *fiber.Ctx) error {
 	mapData := fiber.Map{
-		"Title": "Giriş",
+		"Title": i18n.T(c, "auth.login_title"),
 	}
 	return renderer.Render(c, "auth/login", "layouts/auth", mapData, http.StatusOK)
 }
@@ -122,21 +151,32 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var request struct {
 		Account  string `form:"account"`
 		Password string `form:"password"`
+		Remember bool   `form:"remember"`
 	}
 
 	if err := c.BodyParser(&request); err != nil {
 		configslog.SLog.Warnf("Login isteği ayrıştırılamadı: %v", err)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen hesap adı ve şifre alanlarını doldurun.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.login_required_fields"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	if request.Account == "" || request.Password == "" {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen hesap adı ve şifre alanlarını doldurun.")
-		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	v := validation.New()
+	v.Required("account", request.Account)
+	v.Required("password", request.Password)
+	if !v.Valid() {
+		return renderer.RedirectFieldErrors(c, i18n.T(c, "auth.login_required_fields"), v.Errors(),
+			flashmessages.FormInput(c), "/auth/login")
 	}
 
 	user, err := h.service.Authenticate(request.Account, request.Password)
 	if err != nil {
+		_ = flashmessages.SetOldInput(c, flashmessages.FormInput(c))
+		var lockedErr *services.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			minutes := int(math.Ceil(time.Until(lockedErr.Until).Minutes()))
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.account_locked", max(minutes, 1)))
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		}
 		return h.handleError(c, err, 0, request.Account, "Login")
 	}
 
@@ -147,6 +187,14 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 			zap.Error(err))
 		return h.handleError(c, fiber.ErrInternalServerError, user.ID, user.Account, "Login")
 	}
+	if request.Remember {
+		h.issueRememberToken(c, user.ID)
+	}
+
+	// Yalnızca oturumu kurulmuş girişler sayılır; kayıt hatası girişi engellemez
+	if err := h.service.RecordLogin(user.ID, c.IP()); err != nil {
+		configslog.Log.Warn("Son giriş bilgisi kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
 
 	var redirectURL string
 	switch user.Type {
@@ -160,11 +208,13 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 			zap.Uint("user_id", user.ID),
 			zap.String("account", user.Account),
 			zap.String("type", string(user.Type)))
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Hesabınız için tanımlanmış bir rol bulunamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.role_missing"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Başarıyla giriş yapıldı.")
+	// Giriş oran sınırlayıcısı bunu başarılı giriş sayıp sayacı sıfırlar
+	requestctx.SetUserID(c, user.ID)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, i18n.T(c, "auth.login_success"))
 	return c.Redirect(redirectURL, fiber.StatusFound)
 }
 
@@ -173,33 +223,251 @@ func (h *AuthHandler) Profile(c *fiber.Ctx) error {
 	if err != nil {
 		configslog.Log.Warn("Profil: Geçersiz oturum", zap.Error(err))
 		h.destroySession(c)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum, lütfen tekrar giriş yapın.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.invalid_session"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
+	mapData := fiber.Map{}
+	if plain, _ := flashmessages.TakeOnce(c, newAPITokenFlashKey); plain != "" {
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		mapData["NewAPIToken"] = plain
+	}
+	return h.renderProfile(c, userID, mapData)
+}
+
+func (h *AuthHandler) renderProfile(c *fiber.Ctx, userID uint, mapData fiber.Map) error {
 	user, err := h.service.GetUserProfile(userID)
 	if err != nil {
 		return h.handleError(c, err, userID, "", "Profil")
 	}
 
-	mapData := fiber.Map{
-		"Title": "Profilim",
-		"User":  user,
+	sessions, err := h.sessions.ListSessions(userID)
+	if err != nil {
+		configslog.Log.Warn("Profil: Oturum listesi alınamadı", zap.Uint("user_id", userID), zap.Error(err))
 	}
+
+	tokens, err := h.tokens.List(userID)
+	if err != nil {
+		configslog.Log.Warn("Profil: API token listesi alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	mapData["Title"] = i18n.T(c, "auth.profile_title")
+	mapData["User"] = user
+	mapData["PasswordMinLength"] = h.service.PasswordPolicy().MinLength
+	mapData["AccountChangeRequiresPassword"] = h.service.AccountChangeRequiresPassword()
+	mapData["Sessions"] = sessions
+	mapData["CurrentSessionID"] = h.currentSessionID(c)
+	mapData["APITokens"] = tokens
 	return renderer.Render(c, "auth/profile", "layouts/auth", mapData, http.StatusOK)
 }
 
+// Düz metin token yalnızca yönlendirilen profil sayfasında bir kez gösterilir; yenileme yeni token üretmez
+const newAPITokenFlashKey = "flash_new_api_token"
+
+func (h *AuthHandler) CreateAPIToken(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.invalid_session"))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	var req struct {
+		Name          string `form:"token_name"`
+		ExpiresInDays int    `form:"expires_in_days"`
+	}
+	_ = c.BodyParser(&req)
+	if req.ExpiresInDays < 0 {
+		req.ExpiresInDays = 0
+	}
+
+	plain, token, err := h.tokens.Issue(userID, req.Name, time.Duration(req.ExpiresInDays)*24*time.Hour)
+	if err != nil {
+		if errors.Is(err, services.ErrAPITokenNameRequired) {
+			return renderer.RedirectFieldErrors(c, i18n.T(c, "auth.api_token_name_required"),
+				map[string]string{"token_name": i18n.T(c, "auth.api_token_name_required")}, nil, "/auth/profile")
+		}
+		configslog.Log.Error("API token'ı oluşturulamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, i18n.T(c, "common.unexpected_error"), "/auth/profile")
+	}
+
+	if renderer.WantsJSON(c) {
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"message": i18n.T(c, "auth.api_token_created"),
+			"token":   plain,
+		})
+	}
+	// Gösterilemeyen token kimsenin elinde olmamalı
+	if err := flashmessages.SetOnce(c, newAPITokenFlashKey, plain); err != nil {
+		_ = h.tokens.Revoke(userID, token.ID)
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, i18n.T(c, "common.unexpected_error"), "/auth/profile")
+	}
+	return renderer.RedirectSuccess(c, i18n.T(c, "auth.api_token_created"), "/auth/profile")
+}
+
+func (h *AuthHandler) RevokeAPIToken(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.invalid_session"))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, i18n.T(c, "auth.api_token_not_found"), "/auth/profile")
+	}
+	if err := h.tokens.Revoke(userID, uint(id)); err != nil {
+		if errors.Is(err, services.ErrAPITokenNotFound) {
+			return renderer.RedirectError(c, fiber.StatusNotFound, i18n.T(c, "auth.api_token_not_found"), "/auth/profile")
+		}
+		configslog.Log.Error("API token'ı iptal edilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, i18n.T(c, "common.unexpected_error"), "/auth/profile")
+	}
+
+	return renderer.RedirectSuccess(c, i18n.T(c, "auth.api_token_revoked"), "/auth/profile")
+}
+
+func (h *AuthHandler) currentSessionID(c *fiber.Ctx) string {
+	sess, err := configssession.SessionStart(c)
+	if err != nil {
+		return ""
+	}
+	return sess.ID()
+}
+
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.invalid_session"))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, i18n.T(c, "auth.session_not_found"), "/auth/profile")
+	}
+	currentID := h.currentSessionID(c)
+	if err := h.sessions.RevokeSession(userID, uint(id)); err != nil {
+		errKey := "auth.session_not_found"
+		if !errors.Is(err, services.ErrSessionNotFound) {
+			errKey = "common.unexpected_error"
+			configslog.Log.Error("Oturum sonlandırılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, errKey))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	// Kullanıcı içinde bulunduğu oturumu kapattıysa çıkış yapmış sayılır
+	if errors.Is(h.sessions.Validate(currentID), services.ErrSessionRevoked) {
+		h.forgetRememberToken(c)
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, i18n.T(c, "auth.logout_success"))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, i18n.T(c, "auth.session_revoked"))
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
+
+// Kimliğe bürünmeyi bitirip yöneticinin kendi kimliğini geri yükler
+func (h *AuthHandler) StopImpersonation(c *fiber.Ctx) error {
+	sess, err := configssession.SessionStart(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+	impersonatorID, ok := configssession.ImpersonatorFromSession(sess)
+	if !ok {
+		return c.Redirect("/", fiber.StatusSeeOther)
+	}
+	targetID, _ := h.getSessionUser(c)
+
+	impersonator, err := h.impersonation.Stop(c.UserContext(), impersonatorID, targetID)
+	if err != nil {
+		// Yönetici hesabı artık yoksa hiçbir kimlik geri yüklenmez
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.invalid_session"))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := configssession.StopImpersonation(c, impersonator); err != nil {
+		configslog.Log.Error("Kimliğe bürünme sonlandırılamadı", zap.Uint("impersonator_id", impersonatorID), zap.Error(err))
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.invalid_session"))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, i18n.T(c, "auth.impersonation_stopped"))
+	return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+}
+
+// Oturum yok edildiğinde hem etkin hem de (varsa) bürünmeden önceki kimlik silinir
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	h.forgetRememberToken(c)
 	h.destroySession(c)
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Başarıyla çıkış yapıldı.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, i18n.T(c, "auth.logout_success"))
 	return c.Redirect("/auth/login", fiber.StatusFound)
 }
 
+// Parola formuyla çakışmaması için mevcut şifre alanı profile_password adıyla gönderilir
+func (h *AuthHandler) UpdateProfile(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.invalid_session"))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	var request struct {
+		Name            string `form:"name"`
+		Account         string `form:"account"`
+		CurrentPassword string `form:"profile_password"`
+	}
+	if err := c.BodyParser(&request); err != nil {
+		configslog.SLog.Warnf("Profil güncelleme isteği ayrıştırılamadı: %v", err)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "common.check_fields"))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	v := validation.New()
+	v.Required("name", request.Name)
+	v.MaxLength("name", request.Name, 100)
+	v.Required("account", request.Account)
+	v.MaxLength("account", request.Account, 100)
+	if !v.Valid() {
+		return renderer.RedirectFieldErrors(c, i18n.T(c, "common.check_fields"), v.Errors(), flashmessages.FormInput(c), "/auth/profile")
+	}
+
+	user, err := h.service.UpdateProfile(c.UserContext(), userID, request.Name, request.Account, request.CurrentPassword)
+	if err != nil {
+		var field string
+		switch err {
+		case services.ErrNameRequired:
+			field = "name"
+		case services.ErrAccountInvalid, services.ErrAccountTaken:
+			field = "account"
+		case services.ErrCurrentPasswordIncorrect:
+			field = "profile_password"
+		default:
+			return h.handleError(c, err, userID, request.Account, "Profil Güncelleme")
+		}
+		message := i18n.T(c, err.(services.ServiceError).MessageKey())
+		return renderer.RedirectFieldErrors(c, message, map[string]string{field: message}, flashmessages.FormInput(c), "/auth/profile")
+	}
+
+	if err := configssession.SetSessionUserName(c, user.Name); err != nil {
+		configslog.Log.Warn("Oturumdaki kullanıcı adı güncellenemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return renderer.RedirectSuccess(c, i18n.T(c, "auth.profile_updated"), "/auth/profile")
+}
+
 func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 	userID, err := h.getSessionUser(c)
 	if err != nil {
 		h.destroySession(c)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.invalid_session"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
@@ -211,25 +479,111 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 
 	if err := c.BodyParser(&request); err != nil {
 		configslog.SLog.Warnf("Parola güncelleme isteği ayrıştırılamadı: %v", err)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.password_fields"))
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 
-	if request.CurrentPassword == "" || request.NewPassword == "" || request.ConfirmPassword == "" {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
-		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
-	}
-
-	if request.NewPassword != request.ConfirmPassword {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Yeni şifreler uyuşmuyor.")
-		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	v := validation.New()
+	v.Required("current_password", request.CurrentPassword)
+	v.Required("new_password", request.NewPassword)
+	v.Required("confirm_password", request.ConfirmPassword)
+	v.Matches("confirm_password", request.ConfirmPassword, request.NewPassword, i18n.T(c, "auth.password_mismatch"))
+	if !v.Valid() {
+		return renderer.RedirectFieldErrors(c, i18n.T(c, "common.check_fields"), v.Errors(), flashmessages.FormInput(c), "/auth/profile")
 	}
 
 	if err := h.service.UpdatePassword(userID, request.CurrentPassword, request.NewPassword); err != nil {
+		if policyErr := h.passwordPolicyError(c, err, "/auth/profile"); policyErr != nil {
+			return policyErr
+		}
+		_ = flashmessages.SetOldInput(c, flashmessages.FormInput(c))
 		return h.handleError(c, err, userID, "", "Parola Güncelleme")
 	}
 
+	h.forgetRememberToken(c)
+	h.destroySession(c)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, i18n.T(c, "auth.password_updated"))
+	return c.Redirect("/auth/login", fiber.StatusFound)
+}
+
+func (h *AuthHandler) ShowForgotPassword(c *fiber.Ctx) error {
+	mapData := fiber.Map{
+		"Title": i18n.T(c, "auth.forgot_title"),
+	}
+	return renderer.Render(c, "auth/forgot_password", "layouts/auth", mapData, http.StatusOK)
+}
+
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	account := c.FormValue("account")
+
+	v := validation.New()
+	v.Required("account", account)
+	if !v.Valid() {
+		return renderer.RedirectFieldErrors(c, i18n.T(c, "common.check_fields"), v.Errors(),
+			flashmessages.FormInput(c), "/auth/forgot-password")
+	}
+
+	if err := h.service.RequestPasswordReset(account); err != nil {
+		return h.handleError(c, err, 0, account, "Şifre Sıfırlama İsteği")
+	}
+
+	// Hesap bulunsa da bulunmasa da aynı mesaj gösterilir
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashInfoKey, i18n.T(c, "auth.reset_link_sent"))
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+// Token yol yerine query'de taşınır ki erişim loglarına yazılmasın; sayfa dışarıya Referer göndermez
+func (h *AuthHandler) ShowResetPassword(c *fiber.Ctx) error {
+	token := c.Query("token")
+	c.Set("Referrer-Policy", "no-referrer")
+	if err := h.service.ValidateResetToken(token); err != nil {
+		return h.handleError(c, err, 0, "", "Şifre Sıfırlama")
+	}
+
+	mapData := fiber.Map{
+		"Title":             i18n.T(c, "auth.reset_title"),
+		"Token":             token,
+		"PasswordMinLength": h.service.PasswordPolicy().MinLength,
+	}
+	return renderer.Render(c, "auth/reset_password", "layouts/auth", mapData, http.StatusOK)
+}
+
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	token := c.FormValue("token")
+	formURL := "/auth/reset-password?token=" + url.QueryEscape(token)
+
+	var request struct {
+		NewPassword     string `form:"new_password"`
+		ConfirmPassword string `form:"confirm_password"`
+	}
+	if err := c.BodyParser(&request); err != nil {
+		configslog.SLog.Warnf("Şifre sıfırlama isteği ayrıştırılamadı: %v", err)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, "auth.password_fields"))
+		return c.Redirect(formURL, fiber.StatusSeeOther)
+	}
+
+	v := validation.New()
+	v.Required("new_password", request.NewPassword)
+	v.Required("confirm_password", request.ConfirmPassword)
+	v.Matches("confirm_password", request.ConfirmPassword, request.NewPassword, i18n.T(c, "auth.password_mismatch"))
+	if !v.Valid() {
+		return renderer.RedirectFieldErrors(c, i18n.T(c, "common.check_fields"), v.Errors(), nil, formURL)
+	}
+
+	err := h.service.ResetPassword(token, request.NewPassword)
+	if policyErr := h.passwordPolicyError(c, err, formURL); policyErr != nil {
+		return policyErr
+	}
+	switch err {
+	case nil:
+	case services.ErrPasswordSameAsOld:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, i18n.T(c, err.(services.ServiceError).MessageKey()))
+		return c.Redirect(formURL, fiber.StatusSeeOther)
+	default:
+		return h.handleError(c, err, 0, "", "Şifre Sıfırlama")
+	}
+
 	h.destroySession(c)
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Şifre başarıyla güncellendi. Lütfen yeni şifrenizle tekrar giriş yapın.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, i18n.T(c, "auth.reset_success"))
 	return c.Redirect("/auth/login", fiber.StatusFound)
 }
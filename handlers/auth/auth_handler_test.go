@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configssession"
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+const sessionCookieName = "session_id"
+
+type authFixture struct {
+	app   *fiber.App
+	h     *AuthHandler
+	user  *models.User
+	other *models.User
+}
+
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.UserSession{}, &models.RememberToken{})
+	previous := configssession.Session
+	configssession.Session = session.New(session.Config{KeyLookup: "cookie:" + sessionCookieName})
+	t.Cleanup(func() { configssession.Session = previous })
+
+	ctx := requestctx.WithUserID(context.Background(), 1)
+	f := &authFixture{
+		h:     &AuthHandler{sessions: services.NewSessionService(), remember: services.NewRememberService()},
+		user:  &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Status: true, Type: models.Panel},
+		other: &models.User{Name: "Veli", Account: "veli@example.com", Password: "x", Status: true, Type: models.Panel},
+	}
+	for _, u := range []*models.User{f.user, f.other} {
+		if err := db.WithContext(ctx).Create(u).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f.app = fiber.New()
+	f.app.Get("/test-login/:id", func(c *fiber.Ctx) error {
+		user := f.user
+		if id, _ := c.ParamsInt("id"); uint(id) == f.other.ID {
+			user = f.other
+		}
+		return f.h.createUserSession(c, user)
+	})
+	f.app.Post("/auth/profile/sessions/revoke/:id", func(c *fiber.Ctx) error {
+		requestctx.SetUserID(c, f.user.ID)
+		return c.Next()
+	}, f.h.RevokeSession)
+	return f
+}
+
+func (f *authFixture) do(t *testing.T, method, path, cookie string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	if cookie != "" {
+		req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: cookie})
+	}
+	resp, err := f.app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// Giriş yapıp oturum cookie'sini ve takip kaydının kimliğini döner
+func (f *authFixture) login(t *testing.T, user *models.User) (string, uint) {
+	t.Helper()
+	resp := f.do(t, fiber.MethodGet, "/test-login/"+strconv.FormatUint(uint64(user.ID), 10), "")
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name != sessionCookieName {
+			continue
+		}
+		var record models.UserSession
+		if err := configsdatabase.DB.Where("session_id = ?", cookie.Value).First(&record).Error; err != nil {
+			t.Fatalf("oturum takip edilmedi: %v", err)
+		}
+		return cookie.Value, record.ID
+	}
+	t.Fatal("oturum cookie'si yazılmadı")
+	return "", 0
+}
+
+func revokePath(id uint) string {
+	return "/auth/profile/sessions/revoke/" + strconv.FormatUint(uint64(id), 10)
+}
+
+func TestRevokeOtherSessionKeepsCurrent(t *testing.T) {
+	f := newAuthFixture(t)
+	current, _ := f.login(t, f.user)
+	other, otherID := f.login(t, f.user)
+
+	resp := f.do(t, fiber.MethodPost, revokePath(otherID), current)
+	if resp.StatusCode != fiber.StatusSeeOther || resp.Header.Get(fiber.HeaderLocation) != "/auth/profile" {
+		t.Fatalf("yanıt %d %q, beklenen profile yönlendirme", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+	}
+	if err := f.h.sessions.Validate(other); err == nil {
+		t.Error("sonlandırılan oturum hâlâ geçerli")
+	}
+	if err := f.h.sessions.Validate(current); err != nil {
+		t.Errorf("geçerli oturum etkilendi: %v", err)
+	}
+}
+
+func TestRevokeCurrentSessionLogsOut(t *testing.T) {
+	f := newAuthFixture(t)
+	current, currentID := f.login(t, f.user)
+
+	resp := f.do(t, fiber.MethodPost, revokePath(currentID), current)
+	if resp.StatusCode != fiber.StatusSeeOther || resp.Header.Get(fiber.HeaderLocation) != "/auth/login" {
+		t.Fatalf("yanıt %d %q, beklenen girişe yönlendirme", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+	}
+	stored, err := configssession.Session.Storage.Get(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored != nil {
+		t.Error("kapatılan oturum storage'da kaldı")
+	}
+}
+
+func TestRevokeSessionOfAnotherUserIsRejected(t *testing.T) {
+	f := newAuthFixture(t)
+	current, _ := f.login(t, f.user)
+	foreign, foreignID := f.login(t, f.other)
+
+	resp := f.do(t, fiber.MethodPost, revokePath(foreignID), current)
+	if resp.Header.Get(fiber.HeaderLocation) != "/auth/profile" {
+		t.Fatalf("yönlendirme %q, beklenen /auth/profile", resp.Header.Get(fiber.HeaderLocation))
+	}
+	if err := f.h.sessions.Validate(foreign); err != nil {
+		t.Errorf("başka kullanıcının oturumu sonlandırıldı: %v", err)
+	}
+}
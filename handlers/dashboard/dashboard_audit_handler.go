@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/apierrors"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type AuditHandler struct {
+	auditService services.IAuditService
+}
+
+func NewAuditHandler() *AuditHandler {
+	return &AuditHandler{auditService: services.NewAuditService()}
+}
+
+func (h *AuditHandler) ListEntityAudit(c *fiber.Ctx) error {
+	entityType := c.Params("entity")
+	if entityType == "" {
+		return fiber.ErrNotFound
+	}
+	entityID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil || entityID == 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kayıt kimliği.", "/dashboard/home")
+	}
+
+	var params queryparams.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		configslog.Log.Warn("Denetim kayıtları: Query parametreleri parse edilemedi", zap.Error(err))
+		params = queryparams.DefaultListParams()
+	}
+
+	result, err := h.auditService.GetEntityAuditTrail(c.UserContext(), entityType, uint(entityID), params)
+	if err != nil {
+		status, message := apierrors.Message(c, err, "İşlem geçmişi alınamadı.")
+		if status >= fiber.StatusInternalServerError {
+			configslog.FromContext(c.UserContext()).Error("İşlem geçmişi alınamadı", zap.String("entity", entityType), zap.Uint64("entity_id", entityID), zap.Error(err))
+		}
+		return renderer.RedirectError(c, status, message, "/dashboard/home")
+	}
+
+	mapData := fiber.Map{
+		"Title":      "İşlem Geçmişi",
+		"EntityType": entityType,
+		"EntityID":   entityID,
+		"Result":     result,
+	}
+	// Denetim kayıtları değişmez; yeni kayıt toplamı ve kimlikleri değiştirir
+	renderer.SetListETag(c, result.TotalCount, result.Items, func(entry models.AuditLog) (uint, time.Time) {
+		return entry.ID, entry.CreatedAt
+	})
+	return renderer.Render(c, "dashboard/audit/list", "layouts/dashboard", mapData, http.StatusOK)
+}
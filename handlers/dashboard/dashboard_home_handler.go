@@ -20,7 +20,7 @@ func NewDashboardHomeHandler() *DashboardHomeHandler {
 }
 
 func (h *DashboardHomeHandler) HomePage(c *fiber.Ctx) error {
-	userCount, userErr := h.userService.GetUserCount()
+	userCount, userErr := h.userService.GetUserCount(c.UserContext())
 	if userErr != nil {
 		configslog.Log.Error("Anasayfa: Kullanıcı sayısı alınamadı", zap.Error(userErr))
 		userCount = 0
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/authz"
+	"zatrano/pkg/importer"
+	"zatrano/pkg/jobs"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type JobHandler struct{}
+
+func NewJobHandler() *JobHandler {
+	return &JobHandler{}
+}
+
+// İşi başlatan kullanıcı ve jobs.view izni olanlar görür; başkasının işi bulunamadı olarak yanıtlanır.
+// Arayüz iş bitene kadar aynı adresi ?format=json ile yoklar.
+func (h *JobHandler) ShowJob(c *fiber.Ctx) error {
+	userID, _ := requestctx.UserIDFromFiber(c)
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz iş kimliği.", "/dashboard/home")
+	}
+
+	job, err := jobs.Get(c.UserContext(), uint(id))
+	permissions, _ := authz.FromLocals(c)
+	if err == nil && job.CreatedBy != userID && !permissions.Has(models.PermJobsView) {
+		err = jobs.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, jobs.ErrNotFound) {
+			return renderer.RedirectError(c, fiber.StatusNotFound, "İş bulunamadı.", "/dashboard/home")
+		}
+		configslog.Log.Error("İş durumu alınamadı", zap.Int("job_id", id), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "İş durumu alınamadı.", "/dashboard/home")
+	}
+
+	if renderer.WantsJSON(c) {
+		return c.JSON(jobStatus(job))
+	}
+
+	data := fiber.Map{
+		"Title": "Arka Plan İşi",
+		"Job":   job,
+	}
+	if job.Type == services.UserImportJobType && job.Result != "" {
+		var result importer.Result
+		if err := json.Unmarshal([]byte(job.Result), &result); err == nil {
+			data["ImportResult"] = &result
+		}
+	}
+	return renderer.Render(c, "dashboard/jobs/show", "layouts/dashboard", data)
+}
+
+func jobStatus(job *models.Job) fiber.Map {
+	var result json.RawMessage
+	if job.Result != "" {
+		result = json.RawMessage(job.Result)
+	}
+	return fiber.Map{
+		"id":          job.ID,
+		"type":        job.Type,
+		"status":      job.Status,
+		"progress":    job.Progress,
+		"finished":    job.IsFinished(),
+		"error":       job.Error,
+		"result":      result,
+		"created_at":  job.CreatedAt,
+		"started_at":  job.StartedAt,
+		"finished_at": job.FinishedAt,
+	}
+}
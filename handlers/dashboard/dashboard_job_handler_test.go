@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/authz"
+	"zatrano/pkg/jobs"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestShowJobVisibility(t *testing.T) {
+	testutil.NewDB(t, &models.Job{})
+	jobs.Register("test.noop", func(context.Context, *jobs.Task) (any, error) { return nil, nil })
+	if err := jobs.Start(1); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = jobs.Shutdown(ctx)
+	})
+	job, err := jobs.Enqueue(requestctx.WithUserID(context.Background(), 1), "test.noop", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobPath := "/dashboard/jobs/" + strconv.FormatUint(uint64(job.ID), 10) + "?format=json"
+
+	cases := []struct {
+		name        string
+		userID      uint
+		permissions []string
+		path        string
+		want        int
+	}{
+		{"creator", 1, nil, jobPath, fiber.StatusOK},
+		{"other user", 2, nil, jobPath, fiber.StatusNotFound},
+		{"admin with jobs.view", 2, []string{models.PermJobsView}, jobPath, fiber.StatusOK},
+		{"invalid id", 1, nil, "/dashboard/jobs/abc?format=json", fiber.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/dashboard/jobs/:id", func(c *fiber.Ctx) error {
+				requestctx.SetUserID(c, tc.userID)
+				c.Locals(authz.LocalsKey, authz.NewSet(tc.permissions))
+				return c.Next()
+			}, NewJobHandler().ShowJob)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tc.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Errorf("status = %d, beklenen %d", resp.StatusCode, tc.want)
+			}
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
-	"strings"
+	"strconv"
+	"time"
 	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
 	"zatrano/models"
-	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/apierrors"
+	"zatrano/pkg/exporter"
 	"zatrano/pkg/queryparams"
 	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,35 +20,47 @@ import (
 )
 
 type UserHandler struct {
-	userService services.IUserService
+	userService          services.IUserService
+	sessionService       services.ISessionService
+	impersonationService services.IImpersonationService
 }
 
 func NewUserHandler() *UserHandler {
 	svc := services.NewUserService()
-	return &UserHandler{userService: svc}
+	return &UserHandler{
+		userService:          svc,
+		sessionService:       services.NewSessionService(),
+		impersonationService: services.NewImpersonationService(),
+	}
 }
 
-func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
+func parseUserListParams(c *fiber.Ctx) (queryparams.ListParams, error) {
 	var params queryparams.ListParams
 	if err := c.QueryParser(&params); err != nil {
 		configslog.Log.Warn("Kullanıcı listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
 		params = queryparams.DefaultListParams()
 	}
 
-	if params.Page <= 0 {
-		params.Page = queryparams.DefaultPage
-	}
-	if params.PerPage <= 0 || params.PerPage > queryparams.MaxPerPage {
-		params.PerPage = queryparams.DefaultPerPage
-	}
-	if params.SortBy == "" {
-		params.SortBy = queryparams.DefaultSortBy
-	}
-	if params.OrderBy == "" {
-		params.OrderBy = queryparams.DefaultOrderBy
+	filters, err := queryparams.ParseFilters(c.Queries())
+	if err != nil {
+		configslog.Log.Warn("Kullanıcı listesi: Filtre parametreleri geçersiz", zap.Error(err))
 	}
+	params.Filters = filters
+
+	params.Normalize()
+	return params, err
+}
+
+func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
+	params, filterErr := parseUserListParams(c)
 
-	paginatedResult, dbErr := h.userService.GetAllUsers(params)
+	var paginatedResult *queryparams.Paginated[models.User]
+	var dbErr error
+	if filterErr != nil {
+		dbErr = services.ErrInvalidFilter
+	} else {
+		paginatedResult, dbErr = h.userService.GetAllUsers(c.UserContext(), params)
+	}
 
 	renderData := fiber.Map{
 		"Title":  "Kullanıcılar",
@@ -53,16 +70,104 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	if dbErr != nil {
 		configslog.Log.Error("Kullanıcı listesi DB Hatası", zap.Error(dbErr))
 		renderData[renderer.FlashErrorKeyView] = "Kullanıcılar getirilirken bir hata oluştu."
-		renderData["Result"] = &queryparams.PaginatedResult{
-			Data: []models.User{},
-			Meta: queryparams.PaginationMeta{
-				CurrentPage: params.Page, PerPage: params.PerPage,
-			},
+		if errors.Is(dbErr, services.ErrQueryTimeout) {
+			renderData[renderer.FlashErrorKeyView] = "Sorgu çok uzun sürdü, lütfen filtreleri daraltıp tekrar deneyin."
 		}
+		if errors.Is(dbErr, services.ErrInvalidFilter) {
+			renderData[renderer.FlashErrorKeyView] = "Filtre parametreleri geçersiz, lütfen kontrol edip tekrar deneyin."
+		}
+		if errors.Is(dbErr, services.ErrInvalidDate) {
+			renderData[renderer.FlashErrorKeyView] = "Tarih filtresi geçersiz. Beklenen biçim: 2024-05-01 veya 2024-05-01T14:30."
+		}
+		renderData["Result"] = queryparams.NewPaginated([]models.User{}, 0, params)
+		return renderer.Render(c, "dashboard/users/list", "layouts/dashboard", renderData, apierrors.From(dbErr).Status)
 	}
+	renderData[renderer.JSONKey] = queryparams.MapPaginated(paginatedResult, toUserJSON)
+	renderer.SetListETag(c, paginatedResult.TotalCount, paginatedResult.Items, userListVersion)
 	return renderer.Render(c, "dashboard/users/list", "layouts/dashboard", renderData, http.StatusOK)
 }
 
+// Giriş ve kilit alanları UpdateColumn ile yazıldığı için updated_at'i değiştirmez; listede gösterildikleri
+// için sürüme katılırlar. Süresi dolan kilit hesaptan düşer ki rozet kalkınca etiket de değişsin.
+func userListVersion(user models.User) (uint, time.Time) {
+	changed := user.UpdatedAt
+	if user.LastLoginAt != nil && user.LastLoginAt.After(changed) {
+		changed = *user.LastLoginAt
+	}
+	if user.IsLocked() && user.LockedUntil.After(changed) {
+		changed = *user.LockedUntil
+	}
+	return user.ID, changed
+}
+
+func (h *UserHandler) ExportUsersCSV(c *fiber.Ctx) error {
+	params, err := parseUserListParams(c)
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Filtre parametreleri geçersiz, lütfen kontrol edip tekrar deneyin.", "/dashboard/users")
+	}
+	params.Fields = nil
+
+	header := []string{"ID", "Ad Soyad", "Hesap", "Kullanıcı Tipi", "Durum", "Oluşturma Tarihi"}
+	filename := "kullanicilar-" + time.Now().Format("20060102-150405") + ".csv"
+	// Akış handler döndükten sonra yazılır; o sırada fiber.Ctx yeniden kullanılmış olabileceği için context önceden alınır
+	ctx := c.UserContext()
+
+	return exporter.StreamCSV(c, filename, header, func(write exporter.WriteBatchFunc) error {
+		return h.userService.ExportUsers(ctx, params, func(batch []models.User) error {
+			rows := make([][]string, len(batch))
+			for i, user := range batch {
+				status := "Pasif"
+				if user.Status {
+					status = "Aktif"
+				}
+				rows[i] = []string{
+					strconv.FormatUint(uint64(user.ID), 10),
+					user.Name,
+					user.Account,
+					string(user.Type),
+					status,
+					user.CreatedAt.Format("02.01.2006 15:04"),
+				}
+			}
+			return write(rows)
+		})
+	})
+}
+
+func (h *UserHandler) ShowImportUsers(c *fiber.Ctx) error {
+	return renderer.Render(c, "dashboard/users/import", "layouts/dashboard", fiber.Map{
+		"Title": "Kullanıcıları İçe Aktar",
+	})
+}
+
+func (h *UserHandler) ImportUsers(c *fiber.Ctx) error {
+	renderData := fiber.Map{"Title": "Kullanıcıları İçe Aktar"}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Lütfen bir CSV dosyası seçin."
+		return renderer.Render(c, "dashboard/users/import", "layouts/dashboard", renderData, http.StatusBadRequest)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		configslog.Log.Error("İçe aktarma dosyası açılamadı", zap.Error(err))
+		renderData[renderer.FlashErrorKeyView] = "Dosya okunamadı."
+		return renderer.Render(c, "dashboard/users/import", "layouts/dashboard", renderData, http.StatusBadRequest)
+	}
+	defer file.Close()
+
+	// Büyük dosyalar isteği zaman aşımına uğratmasın diye içe aktarma arka planda çalışır
+	strict := c.FormValue("strict") == "true"
+	job, err := h.userService.EnqueueImportUsers(c.UserContext(), file, strict)
+	if err != nil {
+		renderData["Strict"] = strict
+		renderData[renderer.FlashErrorKeyView] = "İçe aktarma başlatılamadı."
+		return renderer.Render(c, "dashboard/users/import", "layouts/dashboard", renderData, http.StatusInternalServerError)
+	}
+
+	return renderer.RedirectSuccess(c, "İçe aktarma başlatıldı, ilerlemeyi bu sayfadan izleyebilirsiniz.", "/dashboard/jobs/"+strconv.FormatUint(uint64(job.ID), 10))
+}
+
 func (h *UserHandler) ShowCreateUser(c *fiber.Ctx) error {
 	return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", fiber.Map{
 		"Title": "Yeni Kullanıcı Ekle",
@@ -97,28 +202,50 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	}
 
 	if err := h.userService.CreateUser(c.UserContext(), user); err != nil {
-		return renderUserFormError("Yeni Kullanıcı Ekle", req, "Kullanıcı oluşturulamadı: "+err.Error(), c)
+		status, message := apierrors.Message(c, err, "Kullanıcı oluşturulamadı.")
+		if status >= http.StatusInternalServerError {
+			configslog.FromContext(c.UserContext()).Error("Kullanıcı oluşturulamadı", zap.Error(err))
+		}
+		return renderUserFormError("Yeni Kullanıcı Ekle", req, message, c)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla oluşturuldu.")
-	return c.Redirect("/dashboard/users", fiber.StatusFound)
+	return renderer.RedirectSuccess(c, "Kullanıcı başarıyla oluşturuldu.", "/dashboard/users")
+}
+
+// API için tek kullanıcı; düzenleme sayfasının görünüm verisi yerine DTO döner
+func (h *UserHandler) ShowUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.JSONError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", nil)
+	}
+	user, err := h.userService.GetUserByID(c.UserContext(), uint(id))
+	if err != nil {
+		return apierrors.Write(c, apierrors.From(err))
+	}
+	return c.JSON(toUserJSON(user))
 }
 
 func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
-	id, _ := c.ParamsInt("id")
-	user, err := h.userService.GetUserByID(uint(id))
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+	user, err := h.userService.GetUserByID(c.UserContext(), uint(id))
 	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kullanıcı bulunamadı.")
-		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+		return renderer.RedirectError(c, fiber.StatusNotFound, "Kullanıcı bulunamadı.", "/dashboard/users")
 	}
 	return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", fiber.Map{
-		"Title": "Kullanıcı Düzenle",
-		"User":  user,
+		"Title":          "Kullanıcı Düzenle",
+		"User":           user,
+		renderer.JSONKey: toUserJSON(user),
 	})
 }
 
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
-	id, _ := c.ParamsInt("id")
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
 	userID := uint(id)
 
 	var req struct {
@@ -127,11 +254,12 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		Password string `form:"password"`
 		Status   string `form:"status"`
 		Type     string `form:"type"`
+		Version  uint   `form:"version" json:"version"`
 	}
 	_ = c.BodyParser(&req)
 
-	if req.Name == "" || req.Account == "" || req.Type == "" {
-		user, _ := h.userService.GetUserByID(userID)
+	if req.Name == "" || req.Account == "" || req.Type == "" || req.Version == 0 {
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", fiber.Map{
 			"Title":                    "Kullanıcı Düzenle",
 			renderer.FlashErrorKeyView: "Zorunlu alanlar eksik.",
@@ -145,43 +273,209 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		Account: req.Account,
 		Status:  req.Status == "true",
 		Type:    models.UserType(req.Type),
+		Version: req.Version,
 	}
 	if req.Password != "" {
 		userData.Password = req.Password
 	}
 
 	if err := h.userService.UpdateUser(c.UserContext(), userID, userData); err != nil {
-		user, _ := h.userService.GetUserByID(userID)
+		// Form eski sürümle yeniden gösterilmez; sayfa güncel kayıtla açılır
+		if errors.Is(err, services.ErrUserModified) {
+			return renderer.RedirectError(c, fiber.StatusConflict,
+				"Kullanıcı siz düzenlerken başka bir yönetici tarafından değiştirildi. Güncel bilgiler yüklendi, değişikliklerinizi yeniden yapın.",
+				"/dashboard/users/update/"+strconv.Itoa(id))
+		}
+		status, message := apierrors.Message(c, err, "Kullanıcı güncellenemedi.")
+		if status >= http.StatusInternalServerError {
+			configslog.FromContext(c.UserContext()).Error("Kullanıcı güncellenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		}
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", fiber.Map{
 			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: "Güncelleme hatası: " + err.Error(),
+			renderer.FlashErrorKeyView: message,
 			renderer.FormDataKey:       req,
 			"User":                     user,
-		}, http.StatusInternalServerError)
+		}, status)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla güncellendi.")
-	return c.Redirect("/dashboard/users", fiber.StatusFound)
+	return renderer.RedirectSuccess(c, "Kullanıcı başarıyla güncellendi.", "/dashboard/users")
 }
 
 func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
-	id, _ := c.ParamsInt("id")
-	userID := uint(id)
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+
+	if err := h.userService.DeleteUser(c.UserContext(), uint(id)); err != nil {
+		return redirectUserError(c, "Kullanıcı silinemedi.", err)
+	}
 
-	if err := h.userService.DeleteUser(c.UserContext(), userID); err != nil {
-		errMsg := "Kullanıcı silinemedi: " + err.Error()
-		if strings.Contains(c.Get("Accept"), "application/json") {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+	return renderer.RedirectSuccess(c, "Kullanıcı başarıyla silindi.", "/dashboard/users")
+}
+
+func (h *UserHandler) UnlockUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+
+	if err := h.userService.UnlockUser(c.UserContext(), uint(id)); err != nil {
+		return redirectUserError(c, "Hesap kilidi kaldırılamadı.", err)
+	}
+
+	return renderer.RedirectSuccess(c, "Hesap kilidi kaldırıldı.", "/dashboard/users")
+}
+
+func (h *UserHandler) LogoutUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+
+	if err := h.userService.LogoutUser(c.UserContext(), uint(id)); err != nil {
+		return redirectUserError(c, "Oturumlar kapatılamadı.", err)
+	}
+
+	return renderer.RedirectSuccess(c, "Kullanıcının tüm oturumları kapatıldı.", "/dashboard/users")
+}
+
+func (h *UserHandler) DeactivateUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+	adminID, ok := requestctx.UserIDFromFiber(c)
+	if !ok {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.userService.DeactivateUser(c.UserContext(), adminID, uint(id)); err != nil {
+		return redirectUserError(c, "Hesap pasifleştirilemedi.", err)
+	}
+
+	return renderer.RedirectSuccess(c, "Hesap pasifleştirildi ve açık oturumları kapatıldı.", "/dashboard/users")
+}
+
+func (h *UserHandler) ReactivateUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+	adminID, ok := requestctx.UserIDFromFiber(c)
+	if !ok {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.userService.ReactivateUser(c.UserContext(), adminID, uint(id)); err != nil {
+		return redirectUserError(c, "Hesap aktifleştirilemedi.", err)
+	}
+
+	return renderer.RedirectSuccess(c, "Hesap yeniden aktifleştirildi.", "/dashboard/users")
+}
+
+// Mesajlar sabittir; beklenmeyen hatanın ayrıntısı yalnızca loglanır
+func redirectUserError(c *fiber.Ctx, message string, err error) error {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		return renderer.RedirectError(c, fiber.StatusNotFound, "Kullanıcı bulunamadı.", "/dashboard/users")
+	case errors.Is(err, services.ErrDeactivateSelf):
+		return renderer.RedirectError(c, fiber.StatusForbidden, "Kendi hesabınızı pasifleştiremezsiniz.", "/dashboard/users")
+	case errors.Is(err, services.ErrLastActiveAdmin):
+		return renderer.RedirectError(c, fiber.StatusForbidden, "Son aktif yönetici pasifleştirilemez.", "/dashboard/users")
+	default:
+		configslog.FromContext(c.UserContext()).Error(message, zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, message, "/dashboard/users")
+	}
+}
+
+func (h *UserHandler) ListUserSessions(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+	user, err := h.userService.GetUserByID(c.UserContext(), uint(id))
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusNotFound, "Kullanıcı bulunamadı.", "/dashboard/users")
+	}
+
+	sessions, err := h.sessionService.ListSessions(user.ID)
+	if err != nil {
+		configslog.Log.Error("Kullanıcı oturumları alınamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Oturumlar alınamadı.", "/dashboard/users")
+	}
+
+	return renderer.Render(c, "dashboard/users/sessions", "layouts/dashboard", fiber.Map{
+		"Title":    "Aktif Oturumlar",
+		"User":     user,
+		"Sessions": sessions,
+	})
+}
+
+func (h *UserHandler) RevokeUserSession(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+	redirectTarget := "/dashboard/users/sessions/" + strconv.Itoa(id)
+	sessionRecordID, err := c.ParamsInt("sessionId")
+	if err != nil || sessionRecordID <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz oturum kimliği.", redirectTarget)
+	}
+
+	var currentID string
+	if sess, err := configssession.SessionStart(c); err == nil {
+		currentID = sess.ID()
+	}
+
+	if err := h.sessionService.RevokeSession(uint(id), uint(sessionRecordID)); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			return renderer.RedirectError(c, fiber.StatusNotFound, "Oturum bulunamadı veya zaten sonlandırılmış.", redirectTarget)
+		}
+		configslog.FromContext(c.UserContext()).Error("Oturum sonlandırılamadı", zap.Int("user_id", id), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Oturum sonlandırılamadı.", redirectTarget)
+	}
+
+	// Yönetici kendi oturumunu kapattıysa çıkış yapmış sayılır
+	if errors.Is(h.sessionService.Validate(currentID), services.ErrSessionRevoked) {
+		return c.Redirect("/auth/logout", fiber.StatusSeeOther)
+	}
+
+	return renderer.RedirectSuccess(c, "Oturum sonlandırıldı.", redirectTarget)
+}
+
+// Yöneticinin kimliği oturumda saklanır ve hedef kullanıcının ana sayfasına geçilir; geri dönüş /auth/impersonate/stop ile yapılır
+func (h *UserHandler) ImpersonateUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+	impersonatorID, ok := requestctx.UserIDFromFiber(c)
+	if !ok {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	target, err := h.impersonationService.Start(c.UserContext(), impersonatorID, uint(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound):
+			return renderer.RedirectError(c, fiber.StatusNotFound, "Kullanıcı bulunamadı.", "/dashboard/users")
+		case errors.Is(err, services.ErrImpersonateSelf), errors.Is(err, services.ErrImpersonateAdmin),
+			errors.Is(err, services.ErrImpersonateInactive), errors.Is(err, services.ErrImpersonatorRevoked):
+			_, message := apierrors.Message(c, err, services.ErrImpersonateFailed.Error())
+			return renderer.RedirectError(c, fiber.StatusForbidden, message, "/dashboard/users")
+		default:
+			configslog.FromContext(c.UserContext()).Error("Kimliğe bürünme başlatılamadı", zap.Uint("impersonator_id", impersonatorID), zap.Error(err))
+			return renderer.RedirectError(c, fiber.StatusInternalServerError, services.ErrImpersonateFailed.Error(), "/dashboard/users")
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
-		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 
-	if strings.Contains(c.Get("Accept"), "application/json") {
-		return c.JSON(fiber.Map{"message": "Kullanıcı başarıyla silindi."})
+	if err := configssession.StartImpersonation(c, impersonatorID, target); err != nil {
+		configslog.Log.Error("Kimliğe bürünme oturuma yazılamadı", zap.Uint("impersonator_id", impersonatorID), zap.Uint("target_id", target.ID), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, services.ErrImpersonateFailed.Error(), "/dashboard/users")
 	}
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla silindi.")
-	return c.Redirect("/dashboard/users", fiber.StatusFound)
+	return c.Redirect("/panel/home", fiber.StatusSeeOther)
 }
 
 func renderUserFormError(title string, req any, message string, c *fiber.Ctx) error {
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configsdatabase"
+	"zatrano/middlewares"
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestShowUserJSON(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "hash", Status: true, Type: models.Panel}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/api/users/:id", NewUserHandler().ShowUser)
+
+	cases := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"existing", "/api/users/" + strconv.FormatUint(uint64(user.ID), 10), fiber.StatusOK},
+		{"missing", "/api/users/9999", fiber.StatusNotFound},
+		{"invalid id", "/api/users/abc", fiber.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tc.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Fatalf("status = %d, beklenen %d", resp.StatusCode, tc.want)
+			}
+			if tc.want != fiber.StatusOK {
+				return
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body["account"] != user.Account {
+				t.Errorf("account = %v, beklenen %q", body["account"], user.Account)
+			}
+			if _, leaked := body["password"]; leaked {
+				t.Error("yanıtta password alanı var")
+			}
+		})
+	}
+}
+
+func TestUserHandlersRejectInvalidIDs(t *testing.T) {
+	testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.UserSession{})
+	h := NewUserHandler()
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		requestctx.SetUserID(c, 1)
+		return c.Next()
+	})
+	app.Get("/users/update/:id", h.ShowUpdateUser)
+	app.Post("/users/update/:id", h.UpdateUser)
+	app.Delete("/users/delete/:id", h.DeleteUser)
+	app.Post("/users/unlock/:id", h.UnlockUser)
+	app.Post("/users/logout/:id", h.LogoutUser)
+	app.Get("/users/sessions/:id", h.ListUserSessions)
+	app.Post("/users/sessions/:id/revoke/:sessionId", h.RevokeUserSession)
+
+	for _, route := range []struct{ method, path string }{
+		{fiber.MethodGet, "/users/update/abc"},
+		{fiber.MethodPost, "/users/update/abc"},
+		{fiber.MethodDelete, "/users/delete/abc"},
+		{fiber.MethodPost, "/users/unlock/0"},
+		{fiber.MethodPost, "/users/logout/-3"},
+		{fiber.MethodGet, "/users/sessions/abc"},
+		{fiber.MethodPost, "/users/sessions/1/revoke/abc"},
+	} {
+		req := httptest.NewRequest(route.method, route.path, nil)
+		req.Header.Set("Accept", fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s %s: status = %d, beklenen 400", route.method, route.path, resp.StatusCode)
+		}
+	}
+}
+
+func TestDeleteUserDoesNotLeakDatabaseErrors(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "hash", Status: true, Type: models.Panel}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	h := NewUserHandler()
+	if err := configsdatabase.DB.Migrator().DropTable(&models.AuditLog{}); err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Delete("/users/delete/:id", func(c *fiber.Ctx) error {
+		requestctx.SetUserID(c, 1)
+		return c.Next()
+	}, h.DeleteUser)
+	req := httptest.NewRequest(fiber.MethodDelete, "/users/delete/"+strconv.FormatUint(uint64(user.ID), 10), nil)
+	req.Header.Set("Accept", fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("status = %d, beklenen 500", resp.StatusCode)
+	}
+	if body.Message == "" || strings.Contains(body.Message, "audit") || strings.Contains(body.Message, "table") {
+		t.Errorf("veritabanı hatası mesaja sızdı: %q", body.Message)
+	}
+}
+
+func TestAPIListUsersIsScopedToTokenTenant(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.APIToken{}, &models.Notification{})
+	cfg := configsapp.Get()
+	cfg.MultiTenant = true
+	t.Cleanup(func() { cfg.MultiTenant = false })
+
+	orgA, orgB := uint(1), uint(2)
+	users := []*models.User{
+		{Name: "A Yönetici", Account: "a-admin@example.com", Password: "x", Status: true, Type: models.Dashboard, OrganizationID: &orgA},
+		{Name: "A Üye", Account: "a-uye@example.com", Password: "x", Status: true, Type: models.Panel, OrganizationID: &orgA},
+		{Name: "B Üye", Account: "b-uye@example.com", Password: "x", Status: true, Type: models.Panel, OrganizationID: &orgB},
+	}
+	for _, user := range users {
+		if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	plain, _, err := services.NewAPITokenService().Issue(users[0].ID, "cli", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/api/v1/users", middlewares.TokenAuth, NewUserHandler().ListUsers)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/users", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+plain)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, beklenen 200", resp.StatusCode)
+	}
+	var body struct {
+		Data []struct {
+			Account string `json:"account"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	var accounts []string
+	for _, item := range body.Data {
+		accounts = append(accounts, item.Account)
+	}
+	if len(accounts) != 2 || strings.Contains(strings.Join(accounts, ","), "b-uye") {
+		t.Errorf("hesaplar = %v, yalnızca A kuruluşunun kullanıcıları bekleniyordu", accounts)
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestExportUsersCSVStreamsAllBatches(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	const total = 1201 // servis 500'lük partilerle okur; üç parti gerekir
+	users := make([]models.User, total)
+	for i := range users {
+		users[i] = models.User{Name: fmt.Sprintf("Kullanıcı %04d", i), Account: fmt.Sprintf("u%04d@example.com", i), Password: "hash", Status: true, Type: models.Panel}
+	}
+	// Filtre dışında kalan kayıtlar dosyaya girmemeli
+	for i := 0; i < 5; i++ {
+		users = append(users, models.User{Name: fmt.Sprintf("Yönetici %d", i), Account: fmt.Sprintf("admin%d@example.com", i), Password: "hash", Status: true, Type: models.Dashboard})
+	}
+	if err := db.CreateInBatches(users, 200).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/dashboard/users/export", NewUserHandler().ExportUsersCSV)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/dashboard/users/export?type=panel&sortBy=id&orderBy=asc", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(body, []byte{0xEF, 0xBB, 0xBF}) {
+		t.Fatal("UTF-8 BOM yok")
+	}
+	records, err := csv.NewReader(bytes.NewReader(body[3:])).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != total+1 {
+		t.Fatalf("satır sayısı = %d, beklenen %d", len(records), total+1)
+	}
+	if records[0][1] != "Ad Soyad" || records[1][1] != "Kullanıcı 0000" || records[total][1] != "Kullanıcı 1200" {
+		t.Errorf("beklenmeyen içerik: %v / %v / %v", records[0], records[1], records[total])
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"time"
+
+	"zatrano/models"
+)
+
+// JSON istemcilere açılan kullanıcı alanları; kilit sayacı, son giriş IP'si gibi güvenlik alanları bilerek dışarıda bırakılır
+type userJSON struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Account     string     `json:"account"`
+	Status      bool       `json:"status"`
+	Type        string     `json:"type"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	// PUT isteğinde geri gönderilir; arada başka biri güncellediyse 409 döner
+	Version uint `json:"version"`
+}
+
+func toUserJSON(user *models.User) userJSON {
+	return userJSON{
+		ID:          user.ID,
+		Name:        user.Name,
+		Account:     user.Account,
+		Status:      user.Status,
+		Type:        string(user.Type),
+		LastLoginAt: user.LastLoginAt,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		Version:     user.Version,
+	}
+}
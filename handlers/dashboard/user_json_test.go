@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func TestUserJSONOmitsSecurityFields(t *testing.T) {
+	now := time.Now()
+	user := &models.User{
+		Name:              "Ayşe",
+		Account:           "ayse",
+		Password:          "hash",
+		Status:            true,
+		Type:              models.Panel,
+		FailedLoginCount:  3,
+		LockedUntil:       &now,
+		PasswordChangedAt: &now,
+		LastLoginIP:       "10.0.0.7",
+		PreviousLoginAt:   &now,
+	}
+	raw, err := json.Marshal(toUserJSON(user))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Fatal(err)
+	}
+	allowed := map[string]bool{"id": true, "name": true, "account": true, "status": true, "type": true, "last_login_at": true, "created_at": true, "updated_at": true, "version": true}
+	for key := range fields {
+		if !allowed[key] {
+			t.Errorf("beklenmeyen alan %q", key)
+		}
+	}
+	if strings.Contains(string(raw), "10.0.0.7") || strings.Contains(string(raw), "hash") {
+		t.Errorf("gizli alan sızdı: %s", raw)
+	}
+}
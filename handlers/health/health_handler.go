@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+	"zatrano/pkg/health"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type HealthHandler struct {
+	timeout time.Duration
+}
+
+func NewHealthHandler() *HealthHandler {
+	return &HealthHandler{
+		timeout: configsapp.Get().HealthCheckTimeout,
+	}
+}
+
+// Süreç ayakta olduğu sürece 200 döner; bağımlılıklara dokunmaz
+func (h *HealthHandler) Liveness(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(fiber.Map{"status": health.StatusOK})
+}
+
+func (h *HealthHandler) Readiness(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	report := health.Run(c.UserContext(), h.timeout)
+	if report.Status != health.StatusOK {
+		configslog.Log.Warn("Hazırlık kontrolü başarısız", zap.Any("checks", report.Checks))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/apierrors"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const notificationsPath = "/notifications"
+
+type NotificationHandler struct {
+	service services.INotificationService
+}
+
+func NewNotificationHandler() *NotificationHandler {
+	return &NotificationHandler{service: services.NewNotificationService()}
+}
+
+// Bildirimler her iki kullanıcı tipinde de ortaktır; sayfa kullanıcının kendi yerleşimiyle çizilir
+func notificationLayout(c *fiber.Ctx) string {
+	if userType, ok := c.Locals("userType").(models.UserType); ok && userType == models.Dashboard {
+		return "layouts/dashboard"
+	}
+	return "layouts/panel"
+}
+
+func (h *NotificationHandler) List(c *fiber.Ctx) error {
+	userID, ok := requestctx.UserIDFromFiber(c)
+	if !ok {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	var params queryparams.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		params = queryparams.DefaultListParams()
+	}
+	params.SortBy = "created_at"
+	params.OrderBy = "desc"
+
+	result, err := h.service.ListForUser(c.UserContext(), userID, params)
+	if err != nil {
+		status, message := apierrors.Message(c, err, "Bildirimler alınamadı.")
+		if status >= http.StatusInternalServerError {
+			configslog.FromContext(c.UserContext()).Error("Bildirimler alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		}
+		result = queryparams.NewPaginated([]models.Notification{}, 0, params)
+		return renderer.Render(c, "notifications/list", notificationLayout(c), fiber.Map{
+			"Title":                    "Bildirimler",
+			"Result":                   result,
+			renderer.FlashErrorKeyView: message,
+		}, status)
+	}
+
+	// Okundu işaretlenen bildirim satırı değiştirir; ReadAt yoksa oluşturulma zamanı kullanılır
+	renderer.SetListETag(c, result.TotalCount, result.Items, func(n models.Notification) (uint, time.Time) {
+		if n.ReadAt != nil {
+			return n.ID, *n.ReadAt
+		}
+		return n.ID, n.CreatedAt
+	})
+	return renderer.Render(c, "notifications/list", notificationLayout(c), fiber.Map{
+		"Title":          "Bildirimler",
+		"Result":         result,
+		renderer.JSONKey: queryparams.MapPaginated(result, toNotificationJSON),
+	})
+}
+
+// Bildirim okundu işaretlenip bağlantısına gidilir; bağlantı yoksa listeye dönülür
+func (h *NotificationHandler) Open(c *fiber.Ctx) error {
+	userID, ok := requestctx.UserIDFromFiber(c)
+	if !ok {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz bildirim kimliği.", notificationsPath)
+	}
+
+	notification, err := h.service.Get(c.UserContext(), userID, uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			return renderer.RedirectError(c, fiber.StatusNotFound, "Bildirim bulunamadı.", notificationsPath)
+		}
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Bildirim açılamadı.", notificationsPath)
+	}
+
+	if err := h.service.MarkRead(c.UserContext(), userID, []uint{notification.ID}); err != nil {
+		configslog.Log.Warn("Bildirim okundu işaretlenemedi", zap.Uint("user_id", userID), zap.Uint("notification_id", notification.ID), zap.Error(err))
+	}
+	if notification.Link == "" {
+		return c.Redirect(notificationsPath, fiber.StatusSeeOther)
+	}
+	return c.Redirect(notification.Link, fiber.StatusSeeOther)
+}
+
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	userID, ok := requestctx.UserIDFromFiber(c)
+	if !ok {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.MarkRead(c.UserContext(), userID, parseIDList(c, "ids")); err != nil {
+		configslog.Log.Error("Bildirimler okundu işaretlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Bildirimler güncellenemedi.", notificationsPath)
+	}
+	return renderer.RedirectSuccess(c, "Seçilen bildirimler okundu olarak işaretlendi.", notificationsPath)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	userID, ok := requestctx.UserIDFromFiber(c)
+	if !ok {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.MarkAllRead(c.UserContext(), userID); err != nil {
+		configslog.Log.Error("Bildirimler okundu işaretlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Bildirimler güncellenemedi.", notificationsPath)
+	}
+	return renderer.RedirectSuccess(c, "Tüm bildirimler okundu olarak işaretlendi.", notificationsPath)
+}
+
+// Aynı adlı birden fazla checkbox değerini kimlik listesine çevirir
+func parseIDList(c *fiber.Ctx, field string) []uint {
+	values := c.Request().PostArgs().PeekMulti(field)
+	ids := make([]uint, 0, len(values))
+	for _, value := range values {
+		id, err := strconv.ParseUint(string(value), 10, 32)
+		if err != nil || id == 0 {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
+type notificationJSON struct {
+	ID        uint       `json:"id"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Link      string     `json:"link"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func toNotificationJSON(n *models.Notification) notificationJSON {
+	return notificationJSON{ID: n.ID, Title: n.Title, Body: n.Body, Link: n.Link, ReadAt: n.ReadAt, CreatedAt: n.CreatedAt}
+}
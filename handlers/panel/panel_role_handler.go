@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type RoleHandler struct {
+	permissionService services.IPermissionService
+	userService       services.IUserService
+}
+
+func NewRoleHandler() *RoleHandler {
+	return &RoleHandler{
+		permissionService: services.NewPermissionService(),
+		userService:       services.NewUserService(),
+	}
+}
+
+// Aynı adlı birden fazla checkbox değerini (ör. permission_ids) kimlik listesine çevirir
+func parseIDList(c *fiber.Ctx, field string) []uint {
+	values := c.Request().PostArgs().PeekMulti(field)
+	ids := make([]uint, 0, len(values))
+	for _, value := range values {
+		id, err := strconv.ParseUint(string(value), 10, 32)
+		if err != nil || id == 0 {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
+func idSet(ids []uint) map[uint]bool {
+	set := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func (h *RoleHandler) ListRoles(c *fiber.Ctx) error {
+	roles, err := h.permissionService.ListRoles()
+	if err != nil {
+		configslog.Log.Error("Roller alınamadı", zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Roller alınamadı.", "/")
+	}
+
+	renderer.SetListETag(c, int64(len(roles)), roles, func(role models.Role) (uint, time.Time) {
+		return role.ID, role.UpdatedAt
+	})
+	return renderer.Render(c, "panel/roles/list", "layouts/panel", fiber.Map{
+		"Title": "Roller",
+		"Roles": roles,
+	})
+}
+
+func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
+	role, err := h.permissionService.CreateRole(c.FormValue("name"), c.FormValue("description"))
+	if err != nil {
+		if errors.Is(err, services.ErrRoleNameRequired) {
+			return renderer.RedirectError(c, fiber.StatusBadRequest, "Rol adı boş olamaz.", "/panel/roles")
+		}
+		configslog.Log.Warn("Rol oluşturulamadı", zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusConflict, "Rol oluşturulamadı, aynı adda bir rol olabilir.", "/panel/roles")
+	}
+
+	return renderer.RedirectSuccess(c, "Rol oluşturuldu, izinlerini seçebilirsiniz.", "/panel/roles/update/"+strconv.Itoa(int(role.ID)))
+}
+
+func (h *RoleHandler) ShowUpdateRole(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz rol kimliği.", "/panel/roles")
+	}
+	role, err := h.permissionService.GetRole(uint(id))
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusNotFound, "Rol bulunamadı.", "/panel/roles")
+	}
+
+	permissions, err := h.permissionService.ListPermissions()
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "İzinler alınamadı.", "/panel/roles")
+	}
+	selected, err := h.permissionService.RolePermissionIDs(role.ID)
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Rol izinleri alınamadı.", "/panel/roles")
+	}
+
+	return renderer.Render(c, "panel/roles/update", "layouts/panel", fiber.Map{
+		"Title":       "Rol İzinleri",
+		"Role":        role,
+		"Permissions": permissions,
+		"Selected":    idSet(selected),
+	})
+}
+
+func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz rol kimliği.", "/panel/roles")
+	}
+
+	if err := h.permissionService.UpdateRolePermissions(uint(id), parseIDList(c, "permission_ids")); err != nil {
+		if errors.Is(err, services.ErrRoleNotFound) {
+			return renderer.RedirectError(c, fiber.StatusNotFound, "Rol bulunamadı.", "/panel/roles")
+		}
+		configslog.FromContext(c.UserContext()).Error("Rol izinleri güncellenemedi", zap.Int("role_id", id), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Rol izinleri güncellenemedi.", "/panel/roles")
+	}
+
+	return renderer.RedirectSuccess(c, "Rol izinleri güncellendi.", "/panel/roles")
+}
+
+func (h *RoleHandler) ShowUserRoles(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+	user, err := h.userService.GetUserByID(c.UserContext(), uint(id))
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusNotFound, "Kullanıcı bulunamadı.", "/dashboard/users")
+	}
+
+	roles, err := h.permissionService.ListRoles()
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Roller alınamadı.", "/dashboard/users")
+	}
+	selected, err := h.permissionService.UserRoleIDs(user.ID)
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Kullanıcı rolleri alınamadı.", "/dashboard/users")
+	}
+
+	return renderer.Render(c, "panel/users/roles", "layouts/panel", fiber.Map{
+		"Title":    "Kullanıcı Rolleri",
+		"User":     user,
+		"Roles":    roles,
+		"Selected": idSet(selected),
+	})
+}
+
+func (h *RoleHandler) UpdateUserRoles(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kullanıcı kimliği.", "/dashboard/users")
+	}
+	user, err := h.userService.GetUserByID(c.UserContext(), uint(id))
+	if err != nil {
+		return renderer.RedirectError(c, fiber.StatusNotFound, "Kullanıcı bulunamadı.", "/dashboard/users")
+	}
+
+	if err := h.permissionService.UpdateUserRoles(user.ID, parseIDList(c, "role_ids")); err != nil {
+		configslog.FromContext(c.UserContext()).Error("Kullanıcı rolleri güncellenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Kullanıcı rolleri güncellenemedi.", "/dashboard/users")
+	}
+
+	return renderer.RedirectSuccess(c, "Kullanıcı rolleri güncellendi.", "/dashboard/users")
+}
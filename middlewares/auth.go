@@ -1,11 +1,18 @@
 package middlewares
 
 import (
-	"context"
+	"errors"
+	"time"
+	"zatrano/configs/configslog"
 	"zatrano/configs/configssession"
+	"zatrano/pkg/authz"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/requestctx"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
 func AuthMiddleware(c *fiber.Ctx) error {
@@ -14,20 +21,106 @@ func AuthMiddleware(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login")
 	}
 
+	now := time.Now()
+	timedOut := false
 	userID, err := configssession.GetUserIDFromSession(sess)
+	if err == nil {
+		if err = configssession.CheckTimeouts(sess, now); err != nil {
+			timedOut = true
+			_ = services.NewSessionService().Revoke(sess.ID())
+			_ = sess.Destroy()
+		}
+	}
 	if err != nil {
+		if !restoreRememberedSession(c) {
+			if timedOut {
+				_ = flashmessages.SetFlashMessage(c, flashmessages.FlashWarningKey, i18n.T(c, "auth.session_timeout"))
+			}
+			return c.Redirect("/auth/login")
+		}
+		if sess, err = configssession.SessionStart(c); err != nil {
+			return c.Redirect("/auth/login")
+		}
+		if userID, err = configssession.GetUserIDFromSession(sess); err != nil {
+			return c.Redirect("/auth/login")
+		}
+	}
+
+	// Cookie geçerli olsa bile iptal edilmiş (şifre değişikliği, yönetici oturum kapatma) oturum reddedilir
+	sessionService := services.NewSessionService()
+	if err := sessionService.Validate(sess.ID()); errors.Is(err, services.ErrSessionUntracked) {
+		if err := sessionService.Track(userID, sess.ID(), c.IP(), c.Get(fiber.HeaderUserAgent)); err != nil {
+			configslog.Log.Warn("Oturum takibe alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		}
+	} else if err != nil {
+		_ = sess.Destroy()
 		return c.Redirect("/auth/login")
 	}
 
 	authService := services.NewAuthService()
-	_, err = authService.GetUserProfile(userID)
+	user, err := authService.GetUserProfile(userID)
 	if err != nil {
 		_ = sess.Destroy()
 		return c.Redirect("/auth/login")
 	}
 
-	ctx := context.WithValue(c.Context(), "user_id", userID)
-	c.SetUserContext(ctx)
+	// Kiracı kapsamlı sorgular (bürünme doğrulaması dahil) kiracıyı context'ten okur
+	if tenantID, ok := configssession.TenantIDFromSession(sess); ok {
+		requestctx.SetTenantID(c, tenantID)
+	}
+
+	// user_id / user_type etkin (bürünülen) kimliği gösterir; yetki ve tip kontrolleri ona göre yapılır
+	impersonatorID, impersonating := configssession.ImpersonatorFromSession(sess)
+	// Yönetici bürünme sırasında pasifleştirilir ya da izni geri alınırsa oturum hemen kapanır
+	if impersonating {
+		if _, err := services.NewImpersonationService().Verify(c.UserContext(), impersonatorID); err != nil {
+			configslog.Log.Warn("Kimliğe bürünme oturumu sonlandırıldı",
+				zap.Uint("impersonator_id", impersonatorID),
+				zap.Uint("user_id", userID),
+				zap.Error(err),
+			)
+			_ = services.NewSessionService().Revoke(sess.ID())
+			_ = sess.Destroy()
+			return c.Redirect("/auth/login")
+		}
+	}
+
+	// Şifre değiştiyse (güncelleme veya sıfırlama) öncesinde açılmış tüm oturumlar geçersizdir.
+	// Kimliğe bürünmede oturumu açan yönetici olduğu için hedef kullanıcının şifre değişikliği dikkate alınmaz.
+	if user.PasswordChangedAt != nil && !impersonating {
+		loggedInAt, _ := sess.Get("logged_in_at").(int64)
+		if loggedInAt < user.PasswordChangedAt.Unix() {
+			_ = sess.Destroy()
+			return c.Redirect("/auth/login")
+		}
+	}
+
+	userName, _ := sess.Get("user_name").(string)
+	if userName == "" {
+		userName = user.Name
+	}
+
+	// Save oturum nesnesini serbest bıraktığı için sess bu noktadan sonra kullanılmaz
+	if err := configssession.RefreshActivity(sess, now); err != nil {
+		configslog.Log.Warn("Oturum etkinliği güncellenemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	requestctx.SetUserID(c, userID)
+	c.Locals("userType", user.Type)
+	// Şablon başlığında gösterilir; profil güncellemesi oturumdaki değeri yeniler
+	c.Locals("userName", userName)
+	if impersonating {
+		requestctx.SetImpersonatorID(c, impersonatorID)
+	}
+
+	// İzinler istek başına bir kez yüklenir; RequirePermission ve şablonlar buradan okur
+	// Yüklenemezse istek izinsiz devam eder; korumalı sayfalar 403 döner
+	permissions, err := services.NewPermissionService().PermissionsFor(userID)
+	if err != nil {
+		configslog.FromContext(c.UserContext()).Error("Kullanıcı izinleri yüklenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		permissions = authz.Set{}
+	}
+	c.Locals(authz.LocalsKey, permissions)
 
 	return c.Next()
 }
@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// Dinamik yanıtlar her istekte sıkıştırıldığı için brotli'nin orta seviyesi seçilir
+	brotliLevel = 4
+	gzipLevel   = fasthttp.CompressDefaultCompression
+	// Bu boyutun altında sıkıştırma başlıkları ve CPU maliyeti kazançtan fazladır
+	minCompressSize = 200
+)
+
+// Zaten sıkıştırılmış içerikler; fasthttp application/* ve font/* türlerinin hepsini sıkıştırılabilir sayar
+var incompressibleTypes = []string{
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/pdf",
+	"application/octet-stream",
+	"application/vnd.openxmlformats-officedocument",
+	"font/woff",
+	"font/woff2",
+}
+
+// Accept-Encoding'e göre brotli, gzip veya deflate uygular. Content-Encoding'i olan, 200 bayttan küçük,
+// yönlendirme ve kısmi içerik yanıtları olduğu gibi bırakılır. Akış yanıtları tamponlanmadan sıkıştırılır.
+func Compression() fiber.Handler {
+	compress := fasthttp.CompressHandlerBrotliLevel(func(*fasthttp.RequestCtx) {}, brotliLevel, gzipLevel)
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if compressible(c) {
+			compress(c.Context())
+		}
+		return nil
+	}
+}
+
+func compressible(c *fiber.Ctx) bool {
+	status := c.Response().StatusCode()
+	if status < fiber.StatusOK || status == fiber.StatusNoContent || status == fiber.StatusPartialContent || status >= fiber.StatusMultipleChoices && status < fiber.StatusBadRequest {
+		return false
+	}
+	// Akış gövdesinin boyutu okunmadan bilinemez; okumak akışı tamponlamak olurdu
+	if !c.Response().IsBodyStream() && len(c.Response().Body()) < minCompressSize {
+		return false
+	}
+	contentType := strings.ToLower(string(c.Response().Header.ContentType()))
+	for _, t := range incompressibleTypes {
+		if strings.HasPrefix(contentType, t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package middlewares
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gofiber/fiber/v2"
+)
+
+var largeHTML = "<table>" + strings.Repeat("<tr><td>satır</td></tr>", 200) + "</table>"
+
+func newCompressionApp() *fiber.App {
+	app := fiber.New()
+	app.Use(Compression())
+	app.Get("/large", func(c *fiber.Ctx) error {
+		c.Type("html")
+		return c.SendString(largeHTML)
+	})
+	app.Get("/tiny", func(c *fiber.Ctx) error {
+		c.Type("html")
+		return c.SendString("<p>ok</p>")
+	})
+	app.Get("/zip", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "application/zip")
+		return c.SendString(largeHTML)
+	})
+	app.Get("/redirect", func(c *fiber.Ctx) error {
+		c.Type("html")
+		c.Status(fiber.StatusSeeOther).Set(fiber.HeaderLocation, "/large")
+		return c.SendString(largeHTML)
+	})
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/csv")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			for i := 0; i < 500; i++ {
+				_, _ = w.WriteString("1;Ayşe;ayse@example.com\n")
+				_ = w.Flush()
+			}
+		})
+		return nil
+	})
+	return app
+}
+
+func fetch(t *testing.T, app *fiber.App, path, acceptEncoding string) (int, string, string) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set(fiber.HeaderAcceptEncoding, acceptEncoding)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	encoding := resp.Header.Get(fiber.HeaderContentEncoding)
+	var reader io.Reader = resp.Body
+	switch encoding {
+	case "gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = gz
+	case "br":
+		reader = brotli.NewReader(resp.Body)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, encoding, string(body)
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	app := newCompressionApp()
+	cases := []struct {
+		name, path, accept, want string
+	}{
+		{"brotli preferred", "/large", "gzip, br", "br"},
+		{"gzip only", "/large", "gzip", "gzip"},
+		{"no Accept-Encoding", "/large", "", ""},
+		{"body under minimum size", "/tiny", "gzip, br", ""},
+		{"already compressed type", "/zip", "gzip, br", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, encoding, body := fetch(t, app, tc.path, tc.accept)
+			if status != fiber.StatusOK || encoding != tc.want {
+				t.Fatalf("status %d, Content-Encoding %q; beklenen %q", status, encoding, tc.want)
+			}
+			if tc.path == "/large" && body != largeHTML {
+				t.Error("açılan gövde özgün gövdeyle aynı değil")
+			}
+		})
+	}
+}
+
+func TestCompressionSkipsRedirects(t *testing.T) {
+	status, encoding, _ := fetch(t, newCompressionApp(), "/redirect", "gzip, br")
+	if status != fiber.StatusSeeOther || encoding != "" {
+		t.Errorf("status %d, Content-Encoding %q; yönlendirme sıkıştırılmamalı", status, encoding)
+	}
+}
+
+func TestCompressionStreamsBody(t *testing.T) {
+	status, encoding, body := fetch(t, newCompressionApp(), "/stream", "gzip")
+	if status != fiber.StatusOK || encoding != "gzip" {
+		t.Fatalf("status %d, Content-Encoding %q", status, encoding)
+	}
+	if want := strings.Repeat("1;Ayşe;ayse@example.com\n", 500); body != want {
+		t.Errorf("akış gövdesi eksik: %d bayt, beklenen %d", len(body), len(want))
+	}
+}
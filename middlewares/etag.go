@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"strings"
+
+	"zatrano/pkg/renderer"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Liste sayfaları içindir: etiketi handler'ın renderer.SetListETag ile verdiği liste özetinden alır,
+// If-None-Match eşleşirse gövdeyi atıp 304 döner. Gövde sıkıştırılabildiği için ETag zayıftır; sayfa oturuma
+// özel olduğundan paylaşılan önbelleklere kapatılır ve tarayıcı her seferinde doğrulamaya zorlanır.
+// Yalnızca 200 yanıtlar etiketlenir; yönlendirme, hata ve GET/HEAD dışı istekler olduğu gibi bırakılır.
+func ListETag() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+			return c.Next()
+		}
+		if err := c.Next(); err != nil {
+			return err
+		}
+		tag, ok := renderer.ListETag(c)
+		if !ok || c.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+		c.Set(fiber.HeaderETag, tag)
+		c.Set(fiber.HeaderCacheControl, "private, no-cache")
+		if etagMatches(c.Get(fiber.HeaderIfNoneMatch), tag) {
+			c.Context().ResetBody()
+			c.Status(fiber.StatusNotModified)
+		}
+		return nil
+	}
+}
+
+// If-None-Match zayıf karşılaştırılır (RFC 9110 13.1.2); liste ve "*" desteklenir
+func etagMatches(header, tag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
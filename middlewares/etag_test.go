@@ -0,0 +1,128 @@
+package middlewares
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type listRow struct {
+	id      uint
+	updated time.Time
+}
+
+// Gövde her istekte değişir (CSRF jetonu, bildirim rozeti gibi); etiket yalnızca satırlardan gelmelidir
+func newETagApp(rows *[]listRow) *fiber.App {
+	app := fiber.New()
+	calls := 0
+	app.Get("/list", ListETag(), func(c *fiber.Ctx) error {
+		calls++
+		renderer.SetListETag(c, int64(len(*rows)), *rows, func(r listRow) (uint, time.Time) {
+			return r.id, r.updated
+		})
+		return c.SendString(fmt.Sprintf("<html>jeton-%d</html>", calls))
+	})
+	app.Post("/list", ListETag(), func(c *fiber.Ctx) error {
+		renderer.SetListETag(c, 0, []listRow{}, func(r listRow) (uint, time.Time) { return r.id, r.updated })
+		return c.SendString("kaydedildi")
+	})
+	app.Get("/moved", ListETag(), func(c *fiber.Ctx) error {
+		renderer.SetListETag(c, 0, []listRow{}, func(r listRow) (uint, time.Time) { return r.id, r.updated })
+		return c.Redirect("/list", fiber.StatusSeeOther)
+	})
+	return app
+}
+
+func doRequest(t *testing.T, app *fiber.App, method, path string, headers map[string]string) (int, string, fiber.Map) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body), fiber.Map{
+		fiber.HeaderETag:            resp.Header.Get(fiber.HeaderETag),
+		fiber.HeaderCacheControl:    resp.Header.Get(fiber.HeaderCacheControl),
+		fiber.HeaderContentEncoding: resp.Header.Get(fiber.HeaderContentEncoding),
+		fiber.HeaderLocation:        resp.Header.Get(fiber.HeaderLocation),
+	}
+}
+
+func TestListETagNotModified(t *testing.T) {
+	testutil.Setup()
+	rows := []listRow{{1, time.Unix(100, 0)}, {2, time.Unix(200, 0)}}
+	app := newETagApp(&rows)
+
+	status, _, headers := doRequest(t, app, fiber.MethodGet, "/list?page=2", nil)
+	tag, _ := headers[fiber.HeaderETag].(string)
+	if status != fiber.StatusOK || tag == "" {
+		t.Fatalf("ilk istek: status %d, ETag %q", status, tag)
+	}
+	if headers[fiber.HeaderCacheControl] != "private, no-cache" {
+		t.Errorf("Cache-Control = %q", headers[fiber.HeaderCacheControl])
+	}
+
+	// Gövde değişse de liste aynıysa 304 döner
+	status, body, _ := doRequest(t, app, fiber.MethodGet, "/list?page=2", map[string]string{fiber.HeaderIfNoneMatch: tag})
+	if status != fiber.StatusNotModified || body != "" {
+		t.Fatalf("If-None-Match: status %d, gövde %q; beklenen boş 304", status, body)
+	}
+
+	// Farklı sayfa parametresi farklı etiket üretir
+	if _, _, other := doRequest(t, app, fiber.MethodGet, "/list?page=3", nil); other[fiber.HeaderETag] == tag {
+		t.Error("sayfa parametresi etikete girmedi")
+	}
+
+	rows[1].updated = time.Unix(300, 0)
+	status, _, headers = doRequest(t, app, fiber.MethodGet, "/list?page=2", map[string]string{fiber.HeaderIfNoneMatch: tag})
+	if status != fiber.StatusOK || headers[fiber.HeaderETag] == tag {
+		t.Errorf("satır güncellendikten sonra: status %d, ETag %v; beklenen yeni etiketle 200", status, headers[fiber.HeaderETag])
+	}
+}
+
+func TestListETagLeavesPostAndRedirectUntouched(t *testing.T) {
+	testutil.Setup()
+	rows := []listRow{}
+	app := newETagApp(&rows)
+
+	status, body, headers := doRequest(t, app, fiber.MethodPost, "/list", map[string]string{fiber.HeaderIfNoneMatch: "*"})
+	if status != fiber.StatusOK || body != "kaydedildi" || headers[fiber.HeaderETag] != "" {
+		t.Errorf("POST: status %d, gövde %q, ETag %v", status, body, headers[fiber.HeaderETag])
+	}
+
+	status, _, headers = doRequest(t, app, fiber.MethodGet, "/moved", map[string]string{fiber.HeaderIfNoneMatch: "*"})
+	if status != fiber.StatusSeeOther || headers[fiber.HeaderETag] != "" || headers[fiber.HeaderLocation] != "/list" {
+		t.Errorf("yönlendirme: status %d, ETag %v, Location %v", status, headers[fiber.HeaderETag], headers[fiber.HeaderLocation])
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	cases := []struct {
+		header string
+		want   bool
+	}{
+		{`W/"abc"`, true},
+		{`"abc"`, true},
+		{`"x", W/"abc"`, true},
+		{`*`, true},
+		{`W/"abd"`, false},
+		{``, false},
+	}
+	for _, tc := range cases {
+		if got := etagMatches(tc.header, `W/"abc"`); got != tc.want {
+			t.Errorf("etagMatches(%q) = %v, beklenen %v", tc.header, got, tc.want)
+		}
+	}
+}
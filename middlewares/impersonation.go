@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"zatrano/configs/configslog"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Kimliğe bürünme sırasında yapılmaması gereken işlemler (şifre, hesap adı, token, silme vb.) için
+// isteğe bağlı olarak eklenir; yalnızca gerçek kullanıcının kendi oturumunda izin verir
+func RequireRealIdentity(c *fiber.Ctx) error {
+	impersonatorID, impersonating := requestctx.ImpersonatorIDFromFiber(c)
+	if !impersonating {
+		return c.Next()
+	}
+
+	userID, _ := requestctx.UserIDFromFiber(c)
+	configslog.Log.Warn("Kimliğe bürünme sırasında engellenen işlem",
+		zap.Uint("impersonator_id", impersonatorID),
+		zap.Uint("user_id", userID),
+		zap.String("method", c.Method()),
+		zap.String("path", c.Path()),
+		zap.String("request_id", RequestID(c)),
+	)
+	userType, _ := userTypeFromRequest(c)
+	return renderer.RedirectError(c, fiber.StatusForbidden, i18n.T(c, "auth.impersonation_forbidden"), userHome(userType))
+}
@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
+	"zatrano/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Öncelik: ?lang= seçimi, session, cookie, Accept-Language; hiçbiri yoksa Türkçe
+func LocaleMiddleware(c *fiber.Ctx) error {
+	if locale := i18n.Normalize(c.Query("lang")); locale != "" {
+		persistLocale(c, locale)
+		c.Locals(i18n.LocaleKey, locale)
+		return c.Next()
+	}
+
+	locale := ""
+	if sess, err := configssession.SessionStart(c); err == nil {
+		if value, ok := sess.Get(i18n.LocaleKey).(string); ok {
+			locale = i18n.Normalize(value)
+		}
+	}
+	if locale == "" {
+		locale = i18n.Normalize(c.Cookies(i18n.LocaleKey))
+	}
+	if locale == "" {
+		locale = i18n.Normalize(c.AcceptsLanguages(i18n.Locales()...))
+	}
+	if locale == "" {
+		locale = i18n.DefaultLocale
+	}
+
+	c.Locals(i18n.LocaleKey, locale)
+	return c.Next()
+}
+
+// Cookie, oturum yok edildiğinde (çıkış, şifre değişikliği) seçimin kaybolmamasını sağlar
+func persistLocale(c *fiber.Ctx, locale string) {
+	c.Cookie(&fiber.Cookie{
+		Name:     i18n.LocaleKey,
+		Value:    locale,
+		Path:     "/",
+		Expires:  time.Now().AddDate(1, 0, 0),
+		SameSite: "Lax",
+	})
+
+	sess, err := configssession.SessionStart(c)
+	if err != nil {
+		configslog.Log.Warn("Dil seçimi session'a yazılamadı", zap.Error(err))
+		return
+	}
+	sess.Set(i18n.LocaleKey, locale)
+	if err := sess.Save(); err != nil {
+		configslog.Log.Warn("Dil seçimi session'a kaydedilemedi", zap.Error(err))
+	}
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HTML formları yalnızca GET/POST gönderebilir; POST formundaki gizli _method alanı rotadan önce yöntemi değiştirir.
+// GET gibi güvenli yöntemlere dönüşe izin verilmez ki CSRF denetimi atlanamasın.
+func MethodOverride(c *fiber.Ctx) error {
+	if c.Method() != fiber.MethodPost {
+		return c.Next()
+	}
+	switch method := strings.ToUpper(c.FormValue("_method")); method {
+	case fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		c.Method(method)
+	}
+	return c.Next()
+}
@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMethodOverride(t *testing.T) {
+	app := fiber.New()
+	app.Use(MethodOverride)
+	app.Delete("/items/1", func(c *fiber.Ctx) error { return c.SendString("silindi") })
+	app.Post("/items/1", func(c *fiber.Ctx) error { return c.SendString("post") })
+	app.Get("/items/1", func(c *fiber.Ctx) error { return c.SendString("get") })
+
+	cases := []struct {
+		name   string
+		method string
+		body   string
+		want   string
+	}{
+		{"form delete", fiber.MethodPost, "_method=delete", "silindi"},
+		{"plain post", fiber.MethodPost, "title=x", "post"},
+		{"no downgrade to get", fiber.MethodPost, "_method=GET", "post"},
+		{"get ignored", fiber.MethodGet, "", "get"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/items/1", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationForm)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != tc.want {
+				t.Errorf("yanıt = %q, beklenen %q", body, tc.want)
+			}
+		})
+	}
+}
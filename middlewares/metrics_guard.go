@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net"
+	"strings"
+
+	"zatrano/configs/configslog"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// /metrics iç ağ bilgisi (sorgu sayıları, havuz durumu, yollar) içerdiği için herkese açık değildir.
+// Bearer token eşleşirse ya da istemci adresi izin listesindeyse geçer; token boşsa yalnızca liste kullanılır.
+func MetricsGuard(token string, allowedIPs []string) fiber.Handler {
+	var nets []*net.IPNet
+	for _, item := range allowedIPs {
+		if _, ipNet, err := net.ParseCIDR(item); err == nil {
+			nets = append(nets, ipNet)
+			continue
+		}
+		if ip := net.ParseIP(item); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		if token != "" && bearerMatches(c.Get(fiber.HeaderAuthorization), token) {
+			return c.Next()
+		}
+		if ip := net.ParseIP(c.IP()); ip != nil {
+			for _, ipNet := range nets {
+				if ipNet.Contains(ip) {
+					return c.Next()
+				}
+			}
+		}
+		configslog.Log.Warn("Yetkisiz /metrics isteği reddedildi", zap.String("ip", c.IP()))
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+}
+
+func bearerMatches(header, token string) bool {
+	value, ok := strings.CutPrefix(header, "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1
+}
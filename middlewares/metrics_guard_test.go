@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMetricsGuard(t *testing.T) {
+	testutil.Setup()
+
+	cases := []struct {
+		name       string
+		token      string
+		allowedIPs []string
+		header     string
+		want       int
+	}{
+		{"no token, not allowlisted", "", []string{"127.0.0.1", "::1"}, "", fiber.StatusForbidden},
+		{"valid bearer token", "s3cret", nil, "Bearer s3cret", fiber.StatusOK},
+		{"wrong bearer token", "s3cret", nil, "Bearer nope", fiber.StatusForbidden},
+		{"token configured but header missing", "s3cret", nil, "", fiber.StatusForbidden},
+		{"empty token never matches", "", nil, "Bearer ", fiber.StatusForbidden},
+		// app.Test istekleri 0.0.0.0 adresinden gelir
+		{"allowlisted CIDR", "", []string{"0.0.0.0/8"}, "", fiber.StatusOK},
+		{"allowlisted exact IP", "", []string{"0.0.0.0"}, "", fiber.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/metrics", MetricsGuard(tc.token, tc.allowedIPs), func(c *fiber.Ctx) error {
+				return c.SendString("ok")
+			})
+			req := httptest.NewRequest("GET", "/metrics", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Errorf("status = %d, beklenen %d", resp.StatusCode, tc.want)
+			}
+		})
+	}
+}
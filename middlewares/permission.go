@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"zatrano/configs/configslog"
+	"zatrano/pkg/authz"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// İzin kümesi AuthMiddleware'in locals'a yazdığı değerden okunur; AuthMiddleware'den sonra kullanılmalıdır
+func RequirePermission(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := requestctx.UserIDFromFiber(c)
+		if !ok {
+			return renderer.RedirectError(c, fiber.StatusUnauthorized, i18n.T(c, "auth.invalid_session"), "/auth/login")
+		}
+
+		permissions, ok := authz.FromLocals(c)
+		if !ok {
+			var err error
+			if permissions, err = services.NewPermissionService().PermissionsFor(userID); err != nil {
+				return fiber.ErrInternalServerError
+			}
+			c.Locals(authz.LocalsKey, permissions)
+		}
+
+		if permissions.Has(permission) {
+			return c.Next()
+		}
+
+		configslog.Log.Warn("Yetkisiz erişim denemesi",
+			zap.Uint("user_id", userID),
+			zap.String("permission", permission),
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.String("request_id", RequestID(c)),
+		)
+		userType, _ := userTypeFromRequest(c)
+		return renderer.RedirectError(c, fiber.StatusForbidden, i18n.T(c, "auth.forbidden"), userHome(userType))
+	}
+}
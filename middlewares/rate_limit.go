@@ -0,0 +1,175 @@
+package middlewares
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/sessionstorage"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// IP + hesap adı başına giriş denemelerini sınırlar; sayaçlar paylaşılan storage varsa orada tutulur.
+// Yalnızca başarısız denemeler sayılır, başarılı giriş sayacı sıfırlar ki meşru kullanıcı kilitlenmesin.
+func LoginRateLimiter() fiber.Handler {
+	return newAttemptLimiter(true).handle
+}
+
+// Her istek e-posta gönderebildiği için sonucundan bağımsız sayılır
+func PasswordResetRateLimiter() fiber.Handler {
+	return newAttemptLimiter(false).handle
+}
+
+type attemptLimiter struct {
+	storage      counterStorage
+	max          int
+	window       time.Duration
+	resetOnLogin bool
+}
+
+// Sayaç artırmayı atomik yapabilen storage; paylaşılan storage bunu sağlamıyorsa süreç belleği kullanılır
+type counterStorage interface {
+	fiber.Storage
+	sessionstorage.Counter
+}
+
+func newAttemptLimiter(resetOnLogin bool) *attemptLimiter {
+	storage, ok := configssession.GetStorage().(counterStorage)
+	if !ok {
+		storage = newMemoryStorage()
+	}
+	settings := configsapp.Get().Auth
+	return &attemptLimiter{
+		storage:      storage,
+		max:          settings.LoginRateLimit,
+		window:       settings.LoginRateWindow,
+		resetOnLogin: resetOnLogin,
+	}
+}
+
+// Her rota kendi sayacını tutar ve sınır aşıldığında kendi formuna döner.
+// Deneme, yavaş şifre kontrolünden önce sayaçta ayrılır; böylece eşzamanlı istekler aynı sayacı görüp sınırı aşamaz.
+// Başarılı giriş ayrılan denemeyle birlikte sayacı siler.
+func (l *attemptLimiter) handle(c *fiber.Ctx) error {
+	key := "login:" + c.Path() + ":" + c.IP() + ":" + loginAccount(c)
+	count, resetAt, err := l.storage.Increment(key, l.window)
+	if err != nil {
+		// Storage arızası girişleri tamamen kilitlememeli
+		configslog.Log.Warn("Giriş denemesi sayacı artırılamadı", zap.Error(err))
+		return c.Next()
+	}
+	if count > l.max {
+		return l.limitReached(c, resetAt)
+	}
+
+	err = c.Next()
+	if _, loggedIn := requestctx.UserIDFromFiber(c); l.resetOnLogin && loggedIn {
+		_ = l.storage.Delete(key)
+	}
+	return err
+}
+
+func (l *attemptLimiter) limitReached(c *fiber.Ctx, resetAt time.Time) error {
+	configslog.Log.Warn("Giriş denemesi sınırı aşıldı",
+		zap.String("ip", c.IP()),
+		zap.String("account", loginAccount(c)),
+		zap.String("request_id", RequestID(c)),
+	)
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(time.Until(resetAt).Seconds())+1))
+	message := i18n.T(c, "auth.too_many_attempts")
+	if renderer.WantsJSON(c) {
+		return renderer.JSONError(c, fiber.StatusTooManyRequests, message, nil)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+	return c.Redirect(c.Path(), fiber.StatusSeeOther)
+}
+
+func loginAccount(c *fiber.Ctx) string {
+	return strings.ToLower(strings.TrimSpace(c.FormValue("account")))
+}
+
+// Paylaşılan storage yapılandırılmamışsa sayaçlar süreç belleğinde tutulur
+type memoryStorage struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{entries: make(map[string]memoryEntry)}
+}
+
+func (s *memoryStorage) Get(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, nil
+	}
+	return entry.value, nil
+}
+
+func (s *memoryStorage) Set(key string, value []byte, exp time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := memoryEntry{value: value}
+	if exp > 0 {
+		entry.expiresAt = time.Now().Add(exp)
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+// Süresi dolmuş sayaç yeni bir pencereyle baştan başlar
+func (s *memoryStorage) Increment(key string, window time.Duration) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	entry, ok := s.entries[key]
+	count := 0
+	if ok && now.Before(entry.expiresAt) {
+		count, _ = strconv.Atoi(string(entry.value))
+	} else {
+		entry.expiresAt = now.Add(window)
+	}
+	count++
+	entry.value = []byte(strconv.Itoa(count))
+	s.entries[key] = entry
+	return count, entry.expiresAt, nil
+}
+
+func (s *memoryStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *memoryStorage) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]memoryEntry)
+	return nil
+}
+
+func (s *memoryStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,186 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLoginRateLimiterCountsEachRouteSeparately(t *testing.T) {
+	setLoginRateLimit(t, 2, 5*time.Minute)
+
+	app := fiber.New()
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	app.Post("/auth/login", LoginRateLimiter(), ok)
+	app.Post("/auth/forgot-password", PasswordResetRateLimiter(), ok)
+
+	post := func(path string) int {
+		req := httptest.NewRequest("POST", path, strings.NewReader("account=ayse@example.com"))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationForm)
+		req.Header.Set("Accept", fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+
+	for i := 0; i < 2; i++ {
+		if status := post("/auth/forgot-password"); status != fiber.StatusNoContent {
+			t.Fatalf("istek %d: status %d", i+1, status)
+		}
+	}
+	if status := post("/auth/forgot-password"); status != fiber.StatusTooManyRequests {
+		t.Errorf("sınır aşıldığında status %d, beklenen 429", status)
+	}
+	if status := post("/auth/login"); status != fiber.StatusNoContent {
+		t.Errorf("giriş sayacı sıfırlama isteklerinden etkilenmemeli, status %d", status)
+	}
+}
+
+// Sınırlayıcı ayarları oluşturulurken okur; test bitince önceki değerler geri yüklenir
+func setLoginRateLimit(t *testing.T, max int, window time.Duration) {
+	t.Helper()
+	testutil.Setup()
+	settings := &configsapp.Get().Auth
+	previous := *settings
+	settings.LoginRateLimit = max
+	settings.LoginRateWindow = window
+	t.Cleanup(func() { *settings = previous })
+}
+
+// Başarılı giriş işleyicisi kullanıcıyı isteğe yazar; "fail" hesabı her zaman başarısız olur
+func loginApp() *fiber.App {
+	app := fiber.New()
+	app.Post("/auth/login", LoginRateLimiter(), func(c *fiber.Ctx) error {
+		if c.FormValue("password") == "dogru" {
+			requestctx.SetUserID(c, 1)
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	})
+	return app
+}
+
+func postLogin(t *testing.T, app *fiber.App, password string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/auth/login", strings.NewReader("account=ayse@example.com&password="+password))
+	req.Header.Set("Content-Type", fiber.MIMEApplicationForm)
+	req.Header.Set("Accept", fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestLoginRateLimiterBlocksFailedAttemptsUntilWindowEnds(t *testing.T) {
+	setLoginRateLimit(t, 3, time.Second)
+	app := loginApp()
+
+	for i := 0; i < 3; i++ {
+		if resp := postLogin(t, app, "yanlis"); resp.StatusCode != fiber.StatusSeeOther {
+			t.Fatalf("deneme %d: status %d", i+1, resp.StatusCode)
+		}
+	}
+	resp := postLogin(t, app, "dogru")
+	if resp.StatusCode != fiber.StatusTooManyRequests {
+		t.Fatalf("sınır aşıldığında status %d, beklenen 429", resp.StatusCode)
+	}
+	if resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+		t.Error("429 yanıtında Retry-After yok")
+	}
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Message == "" || body.Message == "auth.too_many_attempts" {
+		t.Errorf("mesaj çevrilmemiş: %q", body.Message)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	if resp := postLogin(t, app, "dogru"); resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("pencere dolduktan sonra status %d, beklenen 204", resp.StatusCode)
+	}
+}
+
+func TestLoginRateLimiterIgnoresSuccessfulLogins(t *testing.T) {
+	setLoginRateLimit(t, 2, 5*time.Minute)
+	app := loginApp()
+
+	for i := 0; i < 5; i++ {
+		if resp := postLogin(t, app, "dogru"); resp.StatusCode != fiber.StatusNoContent {
+			t.Fatalf("başarılı giriş %d: status %d", i+1, resp.StatusCode)
+		}
+	}
+	// Başarılı giriş önceki hatalı denemeleri de sıfırlar
+	postLogin(t, app, "yanlis")
+	postLogin(t, app, "dogru")
+	postLogin(t, app, "yanlis")
+	if resp := postLogin(t, app, "yanlis"); resp.StatusCode != fiber.StatusSeeOther {
+		t.Errorf("sıfırlanan sayaçla ikinci hatalı deneme status %d, beklenen 303", resp.StatusCode)
+	}
+}
+
+func TestLoginRateLimiterReservesConcurrentAttempts(t *testing.T) {
+	const max, requests = 3, 12
+	setLoginRateLimit(t, max, 5*time.Minute)
+
+	var reached atomic.Int32
+	release := make(chan struct{})
+	app := fiber.New()
+	app.Post("/auth/login", LoginRateLimiter(), func(c *fiber.Ctx) error {
+		reached.Add(1)
+		// Yavaş şifre kontrolü: sınırlayıcının sayacı handler bitmeden ayırmış olması gerekir
+		<-release
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	})
+
+	statuses := make(chan int, requests)
+	for i := 0; i < requests; i++ {
+		go func() {
+			req := httptest.NewRequest("POST", "/auth/login", strings.NewReader("account=ayse@example.com&password=yanlis"))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationForm)
+			req.Header.Set("Accept", fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			statuses <- resp.StatusCode
+		}()
+	}
+
+	// Sınırı aşan istekler handler'ı beklemeden döner
+	for i := 0; i < requests-max; i++ {
+		select {
+		case status := <-statuses:
+			if status != fiber.StatusTooManyRequests {
+				t.Errorf("beklemeden dönen istek status %d, beklenen 429", status)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%d istek hâlâ bekliyor; sınırı aşanlar reddedilmedi", requests-i)
+		}
+	}
+	close(release)
+	for i := 0; i < max; i++ {
+		if status := <-statuses; status != fiber.StatusSeeOther {
+			t.Errorf("sınır içindeki istek status %d, beklenen 303", status)
+		}
+	}
+	if got := reached.Load(); got != max {
+		t.Errorf("handler'a %d istek ulaştı, en fazla %d bekleniyordu", got, max)
+	}
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/requestctx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+var panicsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "http_panics_total",
+	Help: "Handler'larda yakalanan panic sayısı.",
+}, []string{"method"})
+
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{panicsTotal}
+}
+
+// Panic loglanır ve hata ErrorHandler'a devredilir; istemci bağlantı kopması yerine normal 500 yanıtını görür
+func Recover(c *fiber.Ctx) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		fields := []zap.Field{
+			zap.Any("panic", r),
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.String("request_id", RequestID(c)),
+			zap.String("stack", string(debug.Stack())),
+		}
+		if userID, ok := requestctx.UserID(c.UserContext()); ok {
+			fields = append(fields, zap.Uint("user_id", userID))
+		}
+		configslog.Log.Error("Handler panic yakalandı", fields...)
+		panicsTotal.WithLabelValues(c.Method()).Inc()
+
+		if e, ok := r.(error); ok {
+			err = fmt.Errorf("panic: %w", e)
+			return
+		}
+		err = fmt.Errorf("panic: %v", r)
+	}()
+
+	return c.Next()
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRecoverCatchesPanicsInLaterMiddleware(t *testing.T) {
+	testutil.Setup()
+	app := fiber.New()
+	app.Use(Recover)
+	app.Use(RequestIDMiddleware)
+	app.Use(func(c *fiber.Ctx) error {
+		panic("middleware patladı")
+	})
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("panic yakalanmadı: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("status = %d, beklenen 500", resp.StatusCode)
+	}
+	if resp.Header.Get(fiber.HeaderXRequestID) == "" {
+		t.Error("panic yanıtında X-Request-ID yok")
+	}
+}
@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"errors"
+	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Oturumu olmayan istekte geçerli "beni hatırla" cookie'si varsa yeni bir oturum açar.
+// Token her kullanımda döndürülür; başarısız denemelerde cookie silinir.
+func restoreRememberedSession(c *fiber.Ctx) bool {
+	value := c.Cookies(configssession.RememberCookieName)
+	if value == "" {
+		return false
+	}
+
+	rememberService := services.NewRememberService()
+	userID, newValue, expiresAt, err := rememberService.Consume(value)
+	if err != nil {
+		if !errors.Is(err, services.ErrRememberTokenInvalid) && !errors.Is(err, services.ErrRememberTokenTheft) {
+			configslog.Log.Error("Hatırlama token'ı doğrulanamadı", zap.Error(err))
+		}
+		configssession.ClearRememberCookie(c)
+		return false
+	}
+
+	user, err := services.NewAuthService().GetUserProfile(userID)
+	if err != nil || !user.Status || user.IsLocked() {
+		_ = rememberService.Forget(newValue)
+		configssession.ClearRememberCookie(c)
+		return false
+	}
+
+	sessionID, err := configssession.StartUserSession(c, user)
+	if err != nil {
+		configslog.Log.Error("Hatırlama token'ı ile oturum açılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return false
+	}
+	// Kayıt yazılamazsa AuthMiddleware bir sonraki istekte yeniden dener
+	if err := services.NewSessionService().Track(user.ID, sessionID, c.IP(), c.Get(fiber.HeaderUserAgent)); err != nil {
+		configslog.Log.Error("Hatırlanan oturum kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	configssession.SetRememberCookie(c, newValue, expiresAt)
+	configslog.Log.Info("Oturum hatırlama token'ı ile yeniden açıldı", zap.Uint("user_id", userID))
+	return true
+}
@@ -0,0 +1,103 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"zatrano/configs/configssession"
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+func newRememberApp(t *testing.T, status bool) (*fiber.App, string) {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.UserSession{}, &models.RememberToken{})
+	previous := configssession.Session
+	configssession.Session = session.New(session.Config{KeyLookup: "cookie:session_id"})
+	t.Cleanup(func() { configssession.Session = previous })
+
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Status: true, Type: models.Panel}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !status {
+		if err := db.Model(user).UpdateColumn("status", false).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	value, _, err := services.NewRememberService().Issue(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/panel/home", AuthMiddleware, func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+	return app, value
+}
+
+func getWithRememberCookie(t *testing.T, app *fiber.App, value string) (*http.Response, map[string]*http.Cookie) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodGet, "/panel/home", nil)
+	req.AddCookie(&http.Cookie{Name: configssession.RememberCookieName, Value: value})
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range resp.Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return resp, cookies
+}
+
+func TestRememberCookieRestoresSession(t *testing.T) {
+	app, value := newRememberApp(t, true)
+
+	resp, cookies := getWithRememberCookie(t, app, value)
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Fatalf("status %d, beklenen 204 (oturum hatırlanmadı)", resp.StatusCode)
+	}
+	if cookies["session_id"] == nil || cookies["session_id"].Value == "" {
+		t.Error("yeni oturum cookie'si yazılmadı")
+	}
+	rotated := cookies[configssession.RememberCookieName]
+	if rotated == nil || rotated.Value == "" || rotated.Value == value {
+		t.Fatalf("hatırlama cookie'si döndürülmedi: %+v", rotated)
+	}
+	if !rotated.HttpOnly {
+		t.Error("hatırlama cookie'si HttpOnly değil")
+	}
+
+	// Eski değer yeniden kullanılırsa giriş reddedilir
+	resp, cookies = getWithRememberCookie(t, app, value)
+	if resp.StatusCode != fiber.StatusFound || resp.Header.Get(fiber.HeaderLocation) != "/auth/login" {
+		t.Errorf("eski token ile yanıt %d %q, beklenen girişe yönlendirme", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+	}
+	if c := cookies[configssession.RememberCookieName]; c == nil || c.Value != "" {
+		t.Error("reddedilen hatırlama cookie'si silinmedi")
+	}
+
+	// Hırsızlık tespiti döndürülen token'ı da geçersiz kılar
+	if resp, _ := getWithRememberCookie(t, app, rotated.Value); resp.StatusCode != fiber.StatusFound {
+		t.Errorf("hırsızlık sonrası döndürülen token kabul edildi: %d", resp.StatusCode)
+	}
+}
+
+func TestRememberCookieIgnoredForInactiveUser(t *testing.T) {
+	app, value := newRememberApp(t, false)
+
+	resp, cookies := getWithRememberCookie(t, app, value)
+	if resp.StatusCode != fiber.StatusFound || resp.Header.Get(fiber.HeaderLocation) != "/auth/login" {
+		t.Errorf("pasif kullanıcı için yanıt %d %q", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+	}
+	if c := cookies[configssession.RememberCookieName]; c == nil || c.Value != "" {
+		t.Error("pasif kullanıcının hatırlama cookie'si silinmedi")
+	}
+}
@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"zatrano/configs/configslog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const maxRequestIDLength = 128
+
+// Gelen X-Request-ID geçerliyse korunur, değilse yeni bir UUID üretilir
+func RequestIDMiddleware(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	if !validRequestID(requestID) {
+		requestID = utils.UUIDv4()
+	}
+
+	c.Locals(configslog.RequestIDKey, requestID)
+	c.SetUserContext(configslog.WithRequestID(c.UserContext(), requestID))
+	c.Set(fiber.HeaderXRequestID, requestID)
+
+	return c.Next()
+}
+
+func RequestID(c *fiber.Ctx) string {
+	requestID, _ := c.Locals(configslog.RequestIDKey).(string)
+	return requestID
+}
+
+// Log ve başlıklara yazılacağı için yalnızca güvenli karakterlere izin verilir
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package middlewares
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	testutil.Setup()
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := configslog.Log
+	configslog.Log = zap.New(core)
+	t.Cleanup(func() { configslog.Log = previous })
+	return logs
+}
+
+func TestRequestIDRoundTrip(t *testing.T) {
+	logs := observeLogs(t)
+	app := fiber.New()
+	app.Use(RequestIDMiddleware)
+	app.Get("/", func(c *fiber.Ctx) error {
+		configslog.FromContext(c.UserContext()).Info("servis logu")
+		return c.SendString(RequestID(c))
+	})
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"geçerli kimlik korunur", "abc-123_X.y", true},
+		{"boş kimlik üretilir", "", false},
+		{"geçersiz karakterler reddedilir", "abc\r\nSet-Cookie: x", false},
+		{"çok uzun kimlik reddedilir", strings.Repeat("a", maxRequestIDLength+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(fiber.HeaderXRequestID, tt.incoming)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			header := resp.Header.Get(fiber.HeaderXRequestID)
+			if header == "" {
+				t.Fatal("yanıtta X-Request-ID yok")
+			}
+			if tt.keep && header != tt.incoming {
+				t.Errorf("X-Request-ID = %q, beklenen %q", header, tt.incoming)
+			}
+			if !tt.keep && header == tt.incoming {
+				t.Errorf("geçersiz kimlik %q olduğu gibi döndü", header)
+			}
+			body := make([]byte, len(header)+1)
+			n, _ := resp.Body.Read(body)
+			if string(body[:n]) != header {
+				t.Errorf("locals'taki kimlik %q, başlık %q", body[:n], header)
+			}
+
+			entries := logs.TakeAll()
+			if len(entries) != 1 {
+				t.Fatalf("%d log kaydı, beklenen 1", len(entries))
+			}
+			if got := entries[0].ContextMap()[configslog.RequestIDKey]; got != header {
+				t.Errorf("logdaki request_id = %v, beklenen %q", got, header)
+			}
+		})
+	}
+}
+
+func TestFromContextWithoutRequestID(t *testing.T) {
+	logs := observeLogs(t)
+	configslog.FromContext(context.Background()).Info("bağlamsız")
+	entries := logs.TakeAll()
+	if len(entries) != 1 {
+		t.Fatalf("%d log kaydı, beklenen 1", len(entries))
+	}
+	if _, ok := entries[0].ContextMap()[configslog.RequestIDKey]; ok {
+		t.Error("kimlik yokken request_id alanı eklendi")
+	}
+}
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"strings"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/requestctx"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Bu öneklerle başlayan yollar loglanmaz (statik dosyalar, sağlık kontrolleri vb.)
+func RequestLogger(skipPaths ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		for _, prefix := range skipPaths {
+			prefix = strings.TrimSpace(prefix)
+			if prefix != "" && strings.HasPrefix(path, prefix) {
+				return c.Next()
+			}
+		}
+
+		start := time.Now()
+		chainErr := c.Next()
+
+		// Zincirden dönen hata henüz ErrorHandler'dan geçmediği için durum kodu buradan çıkarılır
+		status := c.Response().StatusCode()
+		if chainErr != nil {
+			status = fiber.StatusInternalServerError
+			if e, ok := chainErr.(*fiber.Error); ok {
+				status = e.Code
+			}
+		}
+
+		fields := []zap.Field{
+			zap.String("method", c.Method()),
+			zap.String("path", path),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("bytes", len(c.Response().Body())),
+			zap.String("ip", c.IP()),
+			zap.String("request_id", RequestID(c)),
+		}
+		if userID, ok := requestctx.UserID(c.UserContext()); ok {
+			fields = append(fields, zap.Uint("user_id", userID))
+		}
+		if impersonatorID, ok := requestctx.ImpersonatorID(c.UserContext()); ok {
+			fields = append(fields, zap.Uint("impersonator_id", impersonatorID))
+		}
+
+		if ce := configslog.Log.Check(requestLogLevel(status), "HTTP isteği"); ce != nil {
+			ce.Write(fields...)
+		}
+		return chainErr
+	}
+}
+
+func requestLogLevel(status int) zapcore.Level {
+	switch {
+	case status >= fiber.StatusInternalServerError:
+		return zapcore.ErrorLevel
+	case status >= fiber.StatusBadRequest:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
@@ -0,0 +1,96 @@
+package middlewares
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/requestctx"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRequestLoggerFieldsAndLevels(t *testing.T) {
+	logs := observeLogs(t)
+	app := fiber.New()
+	app.Use(RequestIDMiddleware)
+	app.Use(func(c *fiber.Ctx) error {
+		if c.Path() == "/ok" {
+			c.SetUserContext(requestctx.WithUserID(c.UserContext(), 7))
+		}
+		return c.Next()
+	})
+	app.Use(RequestLogger("/static", "/healthz"))
+	app.Get("/ok", func(c *fiber.Ctx) error { return c.SendString("merhaba") })
+	app.Get("/missing", func(c *fiber.Ctx) error { return fiber.ErrNotFound })
+	app.Get("/teapot", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusTeapot) })
+	app.Get("/boom", func(c *fiber.Ctx) error { return fiber.ErrBadGateway })
+
+	tests := []struct {
+		path   string
+		status int
+		level  zapcore.Level
+	}{
+		{"/ok", fiber.StatusOK, zapcore.InfoLevel},
+		{"/missing", fiber.StatusNotFound, zapcore.WarnLevel},
+		{"/teapot", fiber.StatusTeapot, zapcore.WarnLevel},
+		{"/boom", fiber.StatusBadGateway, zapcore.ErrorLevel},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+		req.Header.Set(fiber.HeaderXRequestID, "istek-1")
+		if _, err := app.Test(req); err != nil {
+			t.Fatal(err)
+		}
+		entries := logs.TakeAll()
+		if len(entries) != 1 {
+			t.Fatalf("%s: %d log kaydı, beklenen 1", tt.path, len(entries))
+		}
+		entry := entries[0]
+		if entry.Level != tt.level {
+			t.Errorf("%s: seviye %s, beklenen %s", tt.path, entry.Level, tt.level)
+		}
+		fields := entry.ContextMap()
+		if fields["method"] != fiber.MethodGet || fields["path"] != tt.path {
+			t.Errorf("%s: method/path = %v %v", tt.path, fields["method"], fields["path"])
+		}
+		if fields["status"] != int64(tt.status) {
+			t.Errorf("%s: status = %v, beklenen %d", tt.path, fields["status"], tt.status)
+		}
+		if fields[configslog.RequestIDKey] != "istek-1" {
+			t.Errorf("%s: request_id = %v", tt.path, fields[configslog.RequestIDKey])
+		}
+		for _, key := range []string{"latency", "bytes", "ip"} {
+			if _, ok := fields[key]; !ok {
+				t.Errorf("%s: %s alanı yok", tt.path, key)
+			}
+		}
+		_, hasUser := fields["user_id"]
+		if want := tt.path == "/ok"; hasUser != want {
+			t.Errorf("%s: user_id alanı var = %v, beklenen %v", tt.path, hasUser, want)
+		}
+		if tt.path == "/ok" {
+			if fields["user_id"] != uint64(7) || fields["bytes"] != int64(len("merhaba")) {
+				t.Errorf("/ok: user_id = %v, bytes = %v", fields["user_id"], fields["bytes"])
+			}
+		}
+	}
+}
+
+func TestRequestLoggerSkipsConfiguredPaths(t *testing.T) {
+	logs := observeLogs(t)
+	app := fiber.New()
+	app.Use(RequestLogger(" /static ", "", "/healthz"))
+	app.Get("/*", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+	for _, path := range []string{"/static/css/app.css", "/healthz", "/users"} {
+		if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries := logs.TakeAll()
+	if len(entries) != 1 || entries[0].ContextMap()["path"] != "/users" {
+		t.Errorf("yalnızca /users loglanmalıydı, kayıtlar: %+v", entries)
+	}
+}
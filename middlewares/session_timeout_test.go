@@ -0,0 +1,130 @@
+package middlewares
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configssession"
+	"zatrano/models"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+// /seed?idle=..&age=.. oturumu verilen sürelerle geriye alınmış zaman bilgileriyle kurar
+func newTimeoutApp(t *testing.T) *fiber.App {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.UserSession{}, &models.RememberToken{})
+	previous := configssession.Session
+	configssession.Session = session.New(session.Config{KeyLookup: "cookie:session_id"})
+	t.Cleanup(func() { configssession.Session = previous })
+
+	cfg := configsapp.Get()
+	previousIdle, previousAbsolute := cfg.Session.IdleTimeout, cfg.Session.AbsoluteTimeout
+	cfg.Session.IdleTimeout, cfg.Session.AbsoluteTimeout = 30*time.Minute, 12*time.Hour
+	t.Cleanup(func() { cfg.Session.IdleTimeout, cfg.Session.AbsoluteTimeout = previousIdle, previousAbsolute })
+
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Status: true, Type: models.Panel}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/seed", func(c *fiber.Ctx) error {
+		idle, _ := time.ParseDuration(c.Query("idle"))
+		age, _ := time.ParseDuration(c.Query("age"))
+		sess, err := configssession.SessionStart(c)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		sess.Set("user_id", user.ID)
+		sess.Set("user_type", string(user.Type))
+		sess.Set("logged_in_at", now.Add(-age).Unix())
+		sess.Set("created_at", now.Add(-age).Unix())
+		sess.Set("last_activity_at", now.Add(-idle).Unix())
+		return sess.Save()
+	})
+	app.Get("/panel/home", AuthMiddleware, func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+	app.Get("/auth/login", func(c *fiber.Ctx) error {
+		messages, err := flashmessages.GetFlashMessages(c)
+		if err != nil {
+			return err
+		}
+		return c.SendString(strings.Join(messages.ByLevel[flashmessages.LevelWarning], "\n"))
+	})
+	return app
+}
+
+func timeoutRequest(t *testing.T, app *fiber.App, path, cookie string) (*http.Response, string) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	if cookie != "" {
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: cookie})
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range resp.Cookies() {
+		if c.Name == "session_id" && c.Value != "" {
+			cookie = c.Value
+		}
+	}
+	return resp, cookie
+}
+
+func TestAuthMiddlewareSessionTimeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		idle     time.Duration
+		age      time.Duration
+		timedOut bool
+	}{
+		{"etkin oturum", 5 * time.Minute, time.Hour, false},
+		{"boşta kalma aşıldı", 31 * time.Minute, time.Hour, true},
+		{"toplam süre aşıldı", time.Minute, 13 * time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTimeoutApp(t)
+			_, cookie := timeoutRequest(t, app, "/seed?idle="+tt.idle.String()+"&age="+tt.age.String(), "")
+
+			resp, next := timeoutRequest(t, app, "/panel/home", cookie)
+			if !tt.timedOut {
+				if resp.StatusCode != fiber.StatusNoContent {
+					t.Fatalf("etkin oturum reddedildi: %d", resp.StatusCode)
+				}
+				// Etkin oturum silinmez ve aynı kimlikle kullanılmaya devam eder
+				if raw, _ := configssession.Session.Storage.Get(cookie); raw == nil || next != cookie {
+					t.Error("etkin oturum storage'dan silindi ya da kimliği değişti")
+				}
+				return
+			}
+
+			if resp.StatusCode != fiber.StatusFound || resp.Header.Get(fiber.HeaderLocation) != "/auth/login" {
+				t.Fatalf("yanıt %d %q, beklenen girişe yönlendirme", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+			}
+			if raw, _ := configssession.Session.Storage.Get(cookie); raw != nil {
+				t.Error("zaman aşımına uğrayan oturum storage'da kaldı")
+			}
+			if next == cookie {
+				t.Fatal("zaman aşımından sonra yeni oturum açılmadı")
+			}
+			loginResp, _ := timeoutRequest(t, app, "/auth/login", next)
+			body, _ := io.ReadAll(loginResp.Body)
+			if !strings.Contains(string(body), "zaman aşımına uğradı") {
+				t.Errorf("giriş sayfasında zaman aşımı uyarısı yok: %q", body)
+			}
+		})
+	}
+}
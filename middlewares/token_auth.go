@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"errors"
+	"strings"
+	"zatrano/configs/configslog"
+	"zatrano/pkg/authz"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Authorization: Bearer başlığıyla kimlik doğrular ve AuthMiddleware ile aynı locals/context
+// anahtarlarını doldurur; böylece mevcut handler ve izin kontrolleri API altında da çalışır.
+// Oturum veya yönlendirme kullanılmaz, hatalar 401 JSON olarak döner.
+func TokenAuth(c *fiber.Ctx) error {
+	c.Locals(renderer.ForceJSONLocalsKey, true)
+
+	plain, ok := bearerToken(c)
+	if !ok {
+		return tokenUnauthorized(c)
+	}
+
+	token, err := services.NewAPITokenService().Authenticate(plain)
+	if err != nil {
+		if !errors.Is(err, services.ErrAPITokenInvalid) {
+			configslog.Log.Error("API token'ı doğrulanamadı", zap.Error(err))
+		}
+		return tokenUnauthorized(c)
+	}
+
+	user, err := services.NewAuthService().GetUserProfile(token.UserID)
+	if err != nil || !user.Status || user.IsLocked() {
+		configslog.Log.Warn("API token'ı kullanılamaz durumdaki hesaba ait",
+			zap.Uint("user_id", token.UserID),
+			zap.Uint("token_id", token.ID),
+			zap.String("request_id", RequestID(c)),
+		)
+		return tokenUnauthorized(c)
+	}
+
+	requestctx.SetUserID(c, user.ID)
+	c.Locals("userType", user.Type)
+	// Oturumsuz istekte kiracı kullanıcının kuruluşundan alınır; çok kiracılı kurulumda kullanıcı sorguları onu gerektirir
+	if user.OrganizationID != nil {
+		requestctx.SetTenantID(c, *user.OrganizationID)
+	}
+
+	permissions, err := services.NewPermissionService().PermissionsFor(user.ID)
+	if err != nil {
+		permissions = authz.Set{}
+	}
+	c.Locals(authz.LocalsKey, permissions)
+
+	return c.Next()
+}
+
+func bearerToken(c *fiber.Ctx) (string, bool) {
+	scheme, token, found := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func tokenUnauthorized(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="api"`)
+	return renderer.JSONError(c, fiber.StatusUnauthorized, i18n.T(c, "auth.api_token_required"), nil)
+}
@@ -0,0 +1,114 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Pasif hesaba ait token'ı da döner
+func newTokenApp(t *testing.T) (*fiber.App, *models.User, string, string) {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.APIToken{})
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Status: true, Type: models.Dashboard}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	plain, _, err := services.NewAPITokenService().Issue(user.ID, "cli", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inactive := &models.User{Name: "Veli", Account: "veli@example.com", Password: "x", Status: true, Type: models.Dashboard}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(inactive).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(inactive).UpdateColumn("status", false).Error; err != nil {
+		t.Fatal(err)
+	}
+	inactivePlain, _, err := services.NewAPITokenService().Issue(inactive.ID, "cli", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/api/v1/me", TokenAuth, func(c *fiber.Ctx) error {
+		userID, _ := requestctx.UserID(c.UserContext())
+		localsID, _ := requestctx.UserIDFromFiber(c)
+		userType, _ := c.Locals("userType").(models.UserType)
+		return c.SendString(strconv.FormatUint(uint64(userID), 10) + "/" + strconv.FormatUint(uint64(localsID), 10) + "/" + string(userType))
+	})
+	return app, user, plain, inactivePlain
+}
+
+func TestTokenAuthAcceptsBearerToken(t *testing.T) {
+	app, user, plain, _ := newTokenApp(t)
+
+	for _, header := range []string{"Bearer " + plain, "bearer  " + plain} {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/v1/me", nil)
+		req.Header.Set(fiber.HeaderAuthorization, header)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("%q: status %d, beklenen 200", header, resp.StatusCode)
+		}
+		buf := make([]byte, 64)
+		n, _ := resp.Body.Read(buf)
+		id := strconv.FormatUint(uint64(user.ID), 10)
+		if got, want := string(buf[:n]), id+"/"+id+"/"+string(models.Dashboard); got != want {
+			t.Errorf("%q: handler %q gördü, beklenen %q", header, got, want)
+		}
+	}
+}
+
+func TestTokenAuthRejectsWithJSON(t *testing.T) {
+	app, _, plain, inactivePlain := newTokenApp(t)
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"başlık yok", ""},
+		{"yanlış şema", "Basic " + plain},
+		{"boş token", "Bearer "},
+		{"bilinmeyen token", "Bearer zt_bilinmeyen"},
+		{"pasif kullanıcı", "Bearer " + inactivePlain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/api/v1/me", nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.header)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != fiber.StatusUnauthorized {
+				t.Fatalf("status %d, beklenen 401", resp.StatusCode)
+			}
+			if resp.Header.Get(fiber.HeaderWWWAuthenticate) == "" {
+				t.Error("WWW-Authenticate başlığı yok")
+			}
+			var body struct {
+				Code string `json:"code"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("gövde JSON değil: %v", err)
+			}
+			if body.Code != "unauthorized" {
+				t.Errorf("code = %q, beklenen unauthorized", body.Code)
+			}
+		})
+	}
+}
@@ -1,35 +1,70 @@
 package middlewares
 
 import (
+	"slices"
+	"zatrano/configs/configslog"
 	"zatrano/configs/configssession"
 	"zatrano/models"
-	"zatrano/services"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
-func TypeMiddleware(requiredType models.UserType) fiber.Handler {
+// Kullanıcı tipi AuthMiddleware'in yazdığı locals'tan, yoksa oturumdan okunur.
+// Tipi uymayan kullanıcı 403 ile kendi ana sayfasına yönlendirilir.
+func RequireUserType(types ...models.UserType) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		sess, err := configssession.SessionStart(c)
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).SendString("Oturum açılmamış")
+		userType, ok := userTypeFromRequest(c)
+		if !ok {
+			return renderer.RedirectError(c, fiber.StatusUnauthorized, i18n.T(c, "auth.invalid_session"), "/auth/login")
 		}
 
-		userID, err := configssession.GetUserIDFromSession(sess)
-		if err != nil {
-			return c.Status(fiber.StatusForbidden).SendString("Yetkisiz erişim")
+		if slices.Contains(types, userType) {
+			return c.Next()
 		}
 
-		authService := services.NewAuthService()
-		user, err := authService.GetUserProfile(userID)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı bilgileri alınamadı")
-		}
+		userID, _ := requestctx.UserIDFromFiber(c)
+		configslog.Log.Warn("Yetkisiz kullanıcı tipi ile erişim denemesi",
+			zap.Uint("user_id", userID),
+			zap.String("user_type", string(userType)),
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.String("request_id", RequestID(c)),
+		)
+		return renderer.RedirectError(c, fiber.StatusForbidden, i18n.T(c, "auth.forbidden"), userHome(userType))
+	}
+}
 
-		if user.Type != requiredType {
-			return c.Status(fiber.StatusForbidden).SendString("Bu işlem için yetkiniz yok")
-		}
+// Deprecated: RequireUserType kullanın.
+func TypeMiddleware(requiredType models.UserType) fiber.Handler {
+	return RequireUserType(requiredType)
+}
+
+func userTypeFromRequest(c *fiber.Ctx) (models.UserType, bool) {
+	if userType, ok := c.Locals("userType").(models.UserType); ok && userType != "" {
+		return userType, true
+	}
+	sess, err := configssession.SessionStart(c)
+	if err != nil {
+		return "", false
+	}
+	userType, err := configssession.GetUserTypeFromSession(sess)
+	if err != nil {
+		return "", false
+	}
+	return userType, true
+}
 
-		return c.Next()
+func userHome(userType models.UserType) string {
+	switch userType {
+	case models.Panel:
+		return "/panel/home"
+	case models.Dashboard:
+		return "/dashboard/home"
+	default:
+		return "/auth/login"
 	}
 }
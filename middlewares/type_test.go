@@ -0,0 +1,148 @@
+package middlewares
+
+import (
+	"encoding/gob"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"zatrano/configs/configssession"
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+func newUserTypeApp(t *testing.T) *fiber.App {
+	t.Helper()
+	previous := configssession.Session
+	configssession.Session = session.New(session.Config{KeyLookup: "cookie:session_id"})
+	t.Cleanup(func() { configssession.Session = previous })
+	// Eski oturumlarda tip models.UserType olarak saklanıyordu
+	gob.Register(models.UserType(""))
+	// InitSession'ın kaydettiği arama: locals boşsa kullanıcı oturumdan okunur
+	requestctx.RegisterSessionLookup(func(c *fiber.Ctx) (uint, bool) {
+		sess, err := configssession.SessionStart(c)
+		if err != nil {
+			return 0, false
+		}
+		userID, err := configssession.GetUserIDFromSession(sess)
+		return userID, err == nil
+	})
+	t.Cleanup(func() { requestctx.RegisterSessionLookup(nil) })
+
+	app := fiber.New()
+	app.Get("/seed/:mode", func(c *fiber.Ctx) error {
+		sess, err := configssession.SessionStart(c)
+		if err != nil {
+			return err
+		}
+		sess.Set("user_id", uint(5))
+		if c.Params("mode") == "typed" {
+			sess.Set("user_type", models.Dashboard)
+		} else {
+			sess.Set("user_type", string(models.Dashboard))
+		}
+		return sess.Save()
+	})
+	app.Use("/locals", func(c *fiber.Ctx) error {
+		requestctx.SetUserID(c, 5)
+		c.Locals("userType", models.Panel)
+		return c.Next()
+	})
+	app.Get("/locals/panel", RequireUserType(models.Panel), okHandler)
+	app.Get("/locals/dashboard", RequireUserType(models.Dashboard), okHandler)
+	app.Get("/panel/home", RequireUserType(models.Panel), okHandler)
+	app.Get("/dashboard/home", RequireUserType(models.Dashboard), okHandler)
+	app.Get("/shared", RequireUserType(models.Panel, models.Dashboard), okHandler)
+	return app
+}
+
+func okHandler(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+
+func userTypeRequest(t *testing.T, app *fiber.App, path, cookie string, json bool) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	if cookie != "" {
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: cookie})
+	}
+	if json {
+		req.Header.Set(fiber.HeaderAccept, fiber.MIMEApplicationJSON)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func seedUserTypeSession(t *testing.T, app *fiber.App, mode string) string {
+	t.Helper()
+	for _, c := range userTypeRequest(t, app, "/seed/"+mode, "", false).Cookies() {
+		if c.Name == "session_id" {
+			return c.Value
+		}
+	}
+	t.Fatal("oturum cookie'si yazılmadı")
+	return ""
+}
+
+func TestRequireUserTypeFromLocals(t *testing.T) {
+	observeLogs(t)
+	app := newUserTypeApp(t)
+
+	if resp := userTypeRequest(t, app, "/locals/panel", "", false); resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("izinli tip için status %d", resp.StatusCode)
+	}
+	resp := userTypeRequest(t, app, "/locals/dashboard", "", false)
+	if resp.StatusCode != fiber.StatusSeeOther || resp.Header.Get(fiber.HeaderLocation) != "/panel/home" {
+		t.Errorf("yanlış tip için yanıt %d %q, beklenen kendi ana sayfasına yönlendirme", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+	}
+}
+
+func TestRequireUserTypeFromSession(t *testing.T) {
+	for _, mode := range []string{"string", "typed"} {
+		t.Run(mode, func(t *testing.T) {
+			logs := observeLogs(t)
+			app := newUserTypeApp(t)
+			cookie := seedUserTypeSession(t, app, mode)
+
+			for _, path := range []string{"/dashboard/home", "/shared"} {
+				if resp := userTypeRequest(t, app, path, cookie, false); resp.StatusCode != fiber.StatusNoContent {
+					t.Errorf("%s: izinli tip için status %d", path, resp.StatusCode)
+				}
+			}
+
+			resp := userTypeRequest(t, app, "/panel/home", cookie, false)
+			if resp.StatusCode != fiber.StatusSeeOther || resp.Header.Get(fiber.HeaderLocation) != "/dashboard/home" {
+				t.Errorf("reddedilen istek %d %q, beklenen /dashboard/home", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+			}
+			if resp := userTypeRequest(t, app, "/panel/home", cookie, true); resp.StatusCode != fiber.StatusForbidden {
+				t.Errorf("JSON istekte status %d, beklenen 403", resp.StatusCode)
+			}
+
+			entries := logs.FilterMessage("Yetkisiz kullanıcı tipi ile erişim denemesi").All()
+			if len(entries) == 0 {
+				t.Fatal("reddedilen erişim loglanmadı")
+			}
+			fields := entries[0].ContextMap()
+			if fields["user_id"] != uint64(5) || fields["path"] != "/panel/home" {
+				t.Errorf("log alanları %v", fields)
+			}
+		})
+	}
+}
+
+func TestRequireUserTypeWithoutSession(t *testing.T) {
+	observeLogs(t)
+	app := newUserTypeApp(t)
+
+	resp := userTypeRequest(t, app, "/panel/home", "", false)
+	if resp.StatusCode != fiber.StatusSeeOther || resp.Header.Get(fiber.HeaderLocation) != "/auth/login" {
+		t.Errorf("oturumsuz istek %d %q, beklenen girişe yönlendirme", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+	}
+	if resp := userTypeRequest(t, app, "/panel/home", "", true); resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("oturumsuz JSON istek status %d, beklenen 401", resp.StatusCode)
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Token yalnızca oluşturulduğunda kullanıcıya bir kez gösterilir; veritabanında SHA-256 özeti tutulur
+type APIToken struct {
+	ID         uint   `gorm:"primarykey"`
+	UserID     uint   `gorm:"not null;index"`
+	Name       string `gorm:"size:100;not null"`
+	TokenHash  string `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	Prefix     string `gorm:"size:16;not null"`
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+func (t *APIToken) IsExpired() bool {
+	return t.ExpiresAt != nil && time.Now().After(*t.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditCreate      AuditAction = "create"
+	AuditUpdate      AuditAction = "update"
+	AuditDelete      AuditAction = "delete"
+	AuditRestore     AuditAction = "restore"
+	AuditForceDelete AuditAction = "force_delete"
+	// Satırın eklenip güncellendiği ayırt edilmez; tekil upsert güncellenen kolonların yeni değerlerini yazar
+	AuditUpsert AuditAction = "upsert"
+	// Kimliğe bürünme kayıtlarında EntityID hedef kullanıcı, ActorID yöneticidir
+	AuditImpersonateStart AuditAction = "impersonate_start"
+	AuditImpersonateStop  AuditAction = "impersonate_stop"
+	// Hesap durumu kayıtlarında EntityID hedef kullanıcı, ActorID işlemi yapan yöneticidir
+	AuditDeactivate AuditAction = "deactivate"
+	AuditReactivate AuditAction = "reactivate"
+)
+
+// Denetim kayıtları yalnızca eklenir; BaseModel'in güncelleme/silme alanlarına ihtiyaç yoktur
+type AuditLog struct {
+	ID         uint        `gorm:"primarykey"`
+	EntityType string      `gorm:"size:100;not null;index:idx_audit_logs_entity"`
+	EntityID   uint        `gorm:"not null;index:idx_audit_logs_entity"`
+	Action     AuditAction `gorm:"size:20;not null"`
+	ActorID    uint        `gorm:"index"`
+	// Kimliğe bürünme sırasında yapılan işlemlerde ActorID hedef kullanıcı, bu alan gerçek yöneticidir
+	ImpersonatorID *uint     `gorm:"index"`
+	Changes        string    `gorm:"type:text"`
+	CreatedAt      time.Time `gorm:"index"`
+}
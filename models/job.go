@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobQueued  JobStatus = "queued"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobFailed  JobStatus = "failed"
+)
+
+// Arka plan işi; Payload ve Result JSON olarak saklanır, Progress 0-100 arası yüzdedir
+type Job struct {
+	ID         uint      `gorm:"primarykey"`
+	Type       string    `gorm:"size:100;not null"`
+	Payload    string    `gorm:"type:text"`
+	Status     JobStatus `gorm:"size:20;not null;index"`
+	Progress   int       `gorm:"not null;default:0" zatrano:"aggregatable"`
+	Result     string    `gorm:"type:text"`
+	Error      string    `gorm:"type:text"`
+	CreatedBy  uint      `gorm:"index"`
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (j *Job) IsFinished() bool {
+	return j.Status == JobDone || j.Status == JobFailed
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Uygulama içi bildirim; ReadAt nil ise okunmamıştır. Link yalnızca uygulama içi bir yol olabilir
+type Notification struct {
+	ID        uint       `gorm:"primarykey"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_read"`
+	Title     string     `gorm:"size:200;not null"`
+	Body      string     `gorm:"type:text"`
+	Link      string     `gorm:"size:500"`
+	ReadAt    *time.Time `gorm:"index:idx_notifications_user_read"`
+	CreatedAt time.Time  `gorm:"index"`
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
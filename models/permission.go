@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+const (
+	PermUsersView   = "users.view"
+	PermUsersCreate = "users.create"
+	PermUsersUpdate = "users.update"
+	PermUsersDelete = "users.delete"
+	PermAuditView   = "audit.view"
+	PermRolesManage = "roles.manage"
+	PermJobsView    = "jobs.view"
+
+	PermUsersImpersonate = "users.impersonate"
+)
+
+const (
+	RoleAdmin    = "admin"
+	RoleEditor   = "editor"
+	RoleReporter = "reporter"
+)
+
+type Permission struct {
+	ID          uint   `gorm:"primarykey"`
+	Name        string `gorm:"size:100;not null;uniqueIndex"`
+	Description string `gorm:"size:255"`
+	CreatedAt   time.Time
+}
+
+type Role struct {
+	ID          uint   `gorm:"primarykey"`
+	Name        string `gorm:"size:100;not null;uniqueIndex"`
+	Description string `gorm:"size:255"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type RolePermission struct {
+	RoleID       uint `gorm:"primaryKey"`
+	PermissionID uint `gorm:"primaryKey;index"`
+}
+
+type UserRole struct {
+	UserID uint `gorm:"primaryKey"`
+	RoleID uint `gorm:"primaryKey;index"`
+}
+
+// Seeder'ın oluşturduğu izinler; yeni izinler buraya eklenir
+var DefaultPermissions = []Permission{
+	{Name: PermUsersView, Description: "Kullanıcıları listeleme ve dışa aktarma"},
+	{Name: PermUsersCreate, Description: "Kullanıcı oluşturma ve içe aktarma"},
+	{Name: PermUsersUpdate, Description: "Kullanıcı düzenleme, kilit ve oturum yönetimi"},
+	{Name: PermUsersDelete, Description: "Kullanıcı silme"},
+	{Name: PermAuditView, Description: "İşlem geçmişini görüntüleme"},
+	{Name: PermRolesManage, Description: "Rol ve izin yönetimi"},
+	{Name: PermJobsView, Description: "Tüm kullanıcıların arka plan işlerini görüntüleme"},
+	{Name: PermUsersImpersonate, Description: "Destek için kullanıcının yerine geçerek görüntüleme"},
+}
+
+// Varsayılan rollerin izinleri; admin rolü her zaman tüm izinlere sahiptir
+var DefaultRolePermissions = map[string][]string{
+	RoleAdmin:    nil,
+	RoleEditor:   {PermUsersView, PermUsersCreate, PermUsersUpdate, PermAuditView},
+	RoleReporter: {PermUsersView, PermAuditView},
+}
+
+var DefaultRoleDescriptions = map[string]string{
+	RoleAdmin:    "Tüm yetkiler",
+	RoleEditor:   "Kullanıcıları yönetir, silemez",
+	RoleReporter: "Salt okunur raporlama",
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Cookie'de "selector:validator" çifti bulunur; veritabanında validator'ın yalnızca SHA-256 özeti tutulur
+type RememberToken struct {
+	ID            uint      `gorm:"primarykey"`
+	UserID        uint      `gorm:"not null;index"`
+	Selector      string    `gorm:"size:32;not null;uniqueIndex"`
+	ValidatorHash string    `gorm:"size:64;not null"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	LastUsedAt    *time.Time
+	CreatedAt     time.Time
+}
+
+func (t *RememberToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
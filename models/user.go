@@ -1,7 +1,11 @@
 package models
 
 import (
-	"golang.org/x/crypto/bcrypt"
+	"errors"
+	"time"
+
+	"zatrano/pkg/passwordhash"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
@@ -16,6 +20,8 @@ const (
 func (UserType) GormDataType() string {
 	return "user_type"
 }
+
+// Enum tipi yalnızca Postgres'te vardır; diğer sürücülerde kolon varchar olur
 func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 	if db.Dialector.Name() == "postgres" {
 		return "user_type"
@@ -25,22 +31,47 @@ func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 
 type User struct {
 	BaseModel
-	Name     string   `gorm:"size:100;not null;index"`
-	Account  string   `gorm:"size:100;unique;not null"`
-	Password string   `gorm:"size:255;not null"`
-	Status   bool     `gorm:"default:true;index"`
-	Type     UserType `gorm:"type:user_type;not null;default:'panel';index"`
+	Name     string   `gorm:"size:100;not null;index" zatrano:"sortable,filterable,searchable"`
+	Account  string   `gorm:"size:100;unique;not null" zatrano:"sortable,filterable,searchable"`
+	Password string   `gorm:"size:255;not null" json:"-"`
+	Status   bool     `gorm:"default:true;index" zatrano:"sortable,filterable"`
+	Type     UserType `gorm:"not null;default:'panel';index" zatrano:"sortable,filterable"`
+	// Çok kiracılı kurulumda kullanıcının kuruluşu; tek kiracılıda boş kalır
+	OrganizationID *uint `gorm:"index" json:"-"`
+	// İyimser kilit; her güncellemede artar, panel formu açıldığı andaki değeri geri gönderir
+	Version uint `gorm:"not null;default:1"`
+
+	FailedLoginCount  int        `gorm:"not null;default:0" zatrano:"aggregatable"`
+	LockedUntil       *time.Time `gorm:"index"`
+	PasswordChangedAt *time.Time
+	LastLoginAt       *time.Time `zatrano:"sortable"`
+	LastLoginIP       string     `gorm:"size:45"`
+	PreviousLoginAt   *time.Time
+}
+
+// Kilit süresi dolmuş hesaplar kilitli sayılmaz
+func (u *User) IsLocked() bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(time.Now())
 }
 
+var ErrPasswordMismatch = errors.New("şifre hatalı")
+
 func (u *User) CheckPassword(password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
+	ok, _, err := passwordhash.Verify(u.Password, password)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPasswordMismatch
+	}
+	return nil
 }
 
 func (u *User) SetPassword(password string) error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := passwordhash.Hash(password)
 	if err != nil {
 		return err
 	}
-	u.Password = string(hashedPassword)
+	u.Password = hashedPassword
 	return nil
 }
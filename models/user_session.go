@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Sunucu tarafında, kullanıcıya ait tüm oturumları listeleyip iptal edebilmek için tutulur
+type UserSession struct {
+	ID             uint      `gorm:"primarykey"`
+	SessionID      string    `gorm:"size:64;not null;uniqueIndex"`
+	UserID         uint      `gorm:"not null;index"`
+	IPAddress      string    `gorm:"size:45"`
+	UserAgent      string    `gorm:"size:255"`
+	LastActivityAt time.Time `gorm:"not null"`
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+func (s *UserSession) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
@@ -0,0 +1,156 @@
+package apierrors
+
+import (
+	"errors"
+	"net/http"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const (
+	CodeBadRequest       = "bad_request"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeConflict         = "conflict"
+	CodeValidation       = "validation_failed"
+	CodeAccountLocked    = "account_locked"
+	CodeTooManyRequests  = "too_many_requests"
+	CodeTimeout          = "timeout"
+	CodeInternal         = "internal_error"
+)
+
+const genericMessageKey = "common.unexpected_error"
+
+// JSON API'lerin tek hata gövdesi; fields boş olsa da {} olarak yazılır
+type Error struct {
+	Status    int               `json:"-"`
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields"`
+	RequestID string            `json:"request_id"`
+
+	messageKey  string
+	messageArgs []interface{}
+	detail      userMessager
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Kod durumdan türetilir
+func New(status int, message string) *Error {
+	return &Error{Status: status, Code: codeForStatus(status), Message: message}
+}
+
+func Validation(message string, fields map[string]string) *Error {
+	e := New(fiber.StatusUnprocessableEntity, message)
+	e.Fields = fields
+	return e
+}
+
+// Eşlenmemiş hatalar 500 olur; mesajları istemciye yazılmaz
+func From(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		copied := *apiErr
+		return &copied
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return New(fiberErr.Code, fiberErr.Message)
+	}
+	if e := fromMapping(err); e != nil {
+		return e
+	}
+	return New(fiber.StatusInternalServerError, "")
+}
+
+// request_id locals'ta yoksa (RequestIDMiddleware'den önce dönen yanıtlar) burada üretilir.
+// 5xx yanıtlarda mesaj her zaman genel mesajla değiştirilir.
+func Write(c *fiber.Ctx, e *Error) error {
+	body := *e
+	if body.Status == 0 {
+		body.Status = fiber.StatusInternalServerError
+	}
+	if body.Code == "" {
+		body.Code = codeForStatus(body.Status)
+	}
+	body.Message = body.localizedMessage(c)
+	if body.Message == "" {
+		body.Message = i18n.T(c, genericMessageKey)
+	}
+	if body.Fields == nil {
+		body.Fields = map[string]string{}
+	}
+	body.RequestID = requestID(c)
+	return c.Status(body.Status).JSON(body)
+}
+
+// Flash ve form mesajları içindir: kayıtlı 4xx hatalar kendi mesajıyla, diğerleri verilen sabit mesajla gösterilir
+func Message(c *fiber.Ctx, err error, fallback string) (int, string) {
+	e := From(err)
+	if message := e.localizedMessage(c); message != "" {
+		return e.Status, message
+	}
+	return e.Status, fallback
+}
+
+// 5xx yanıtlarda boş döner ki iç ayrıntı istemciye yazılmasın
+func (e *Error) localizedMessage(c *fiber.Ctx) string {
+	if e.Status == 0 || e.Status >= fiber.StatusInternalServerError {
+		return ""
+	}
+	if e.detail != nil {
+		return e.detail.UserMessage(i18n.Locale(c))
+	}
+	if e.messageKey != "" {
+		return i18n.T(c, e.messageKey, e.messageArgs...)
+	}
+	return e.Message
+}
+
+func requestID(c *fiber.Ctx) string {
+	if id, ok := c.Locals(configslog.RequestIDKey).(string); ok && id != "" {
+		return id
+	}
+	id := utils.UUIDv4()
+	c.Locals(configslog.RequestIDKey, id)
+	c.Set(fiber.HeaderXRequestID, id)
+	return id
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusLocked:
+		return CodeAccountLocked
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
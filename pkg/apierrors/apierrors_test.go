@@ -0,0 +1,127 @@
+package apierrors_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/apierrors"
+	"zatrano/pkg/jobs"
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newApp(err error) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+		return apierrors.Write(c, apierrors.From(err))
+	}})
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.Locals(configslog.RequestIDKey, "req-1")
+		return err
+	})
+	return app
+}
+
+func TestEnvelopeShapeAndStatus(t *testing.T) {
+	testutil.Setup()
+
+	cases := []struct {
+		name   string
+		err    error
+		status int
+		body   string
+	}{
+		{"repository not found", fmt.Errorf("GetByID: %w", repositories.ErrNotFound), 404,
+			`{"code":"not_found","message":"kayıt bulunamadı","fields":{},"request_id":"req-1"}`},
+		{"service not found", services.ErrUserNotFound, 404,
+			`{"code":"not_found","message":"Kullanıcı bulunamadı, lütfen tekrar giriş yapın.","fields":{},"request_id":"req-1"}`},
+		{"job not found", jobs.ErrNotFound, 404,
+			`{"code":"not_found","message":"iş bulunamadı","fields":{},"request_id":"req-1"}`},
+		{"invalid credentials", services.ErrInvalidCredentials, 401,
+			`{"code":"unauthorized","message":"Kullanıcı adı veya şifre hatalı.","fields":{},"request_id":"req-1"}`},
+		{"account locked", &services.AccountLockedError{Until: time.Now().Add(7 * time.Minute)}, 423,
+			`{"code":"account_locked","message":"Çok fazla hatalı deneme nedeniyle hesabınız kilitlendi. Lütfen 7 dakika sonra tekrar deneyin.","fields":{},"request_id":"req-1"}`},
+		{"validation with fields", apierrors.Validation("Lütfen alanları kontrol edin.", map[string]string{"name": "Bu alan zorunludur."}), 422,
+			`{"code":"validation_failed","message":"Lütfen alanları kontrol edin.","fields":{"name":"Bu alan zorunludur."},"request_id":"req-1"}`},
+		{"conflict", repositories.ErrVersionConflict, 409,
+			`{"code":"conflict","message":"kayıt başka bir kullanıcı tarafından değiştirildi","fields":{},"request_id":"req-1"}`},
+		{"unmapped error", errors.New("pq: connection refused 10.0.0.1"), 500,
+			`{"code":"internal_error","message":"İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin.","fields":{},"request_id":"req-1"}`},
+		{"fiber 5xx message hidden", fiber.NewError(500, "secret detail"), 500,
+			`{"code":"internal_error","message":"İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin.","fields":{},"request_id":"req-1"}`},
+		{"wrapped detail not leaked", fmt.Errorf("%w: internal_column", repositories.ErrProtectedColumn), 422,
+			`{"code":"validation_failed","message":"kolon güncellenemez","fields":{},"request_id":"req-1"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := newApp(tc.err).Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tc.status {
+				t.Errorf("status = %d, beklenen %d", resp.StatusCode, tc.status)
+			}
+			if string(body) != tc.body {
+				t.Errorf("gövde\n got: %s\nwant: %s", body, tc.body)
+			}
+		})
+	}
+}
+
+func TestRequestIDGeneratedWhenMissing(t *testing.T) {
+	testutil.Setup()
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return apierrors.Write(c, apierrors.New(fiber.StatusNotFound, "yok"))
+	})
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := resp.Header.Get(fiber.HeaderXRequestID)
+	body, _ := io.ReadAll(resp.Body)
+	want := `{"code":"not_found","message":"yok","fields":{},"request_id":"` + header + `"}`
+	if header == "" || string(body) != want {
+		t.Errorf("header %q, gövde %s", header, body)
+	}
+}
+
+func TestMessageHidesUnmappedErrors(t *testing.T) {
+	testutil.Setup()
+	cases := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{"registered sentinel", fmt.Errorf("UpdateUser: %w", services.ErrAccountTaken), 409, "Bu hesap adı zaten kullanılıyor."},
+		{"translated sentinel", services.ErrInvalidCredentials, 401, "Kullanıcı adı veya şifre hatalı."},
+		{"unmapped error", errors.New("pq: relation users does not exist"), 500, "Kayıt silinemedi."},
+		{"fiber 5xx", fiber.NewError(500, "secret detail"), 500, "Kayıt silinemedi."},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				status, message := apierrors.Message(c, tc.err, "Kayıt silinemedi.")
+				return c.Status(status).SendString(message)
+			})
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tc.status || string(body) != tc.message {
+				t.Errorf("Message = %d %q, beklenen %d %q", resp.StatusCode, body, tc.status, tc.message)
+			}
+		})
+	}
+}
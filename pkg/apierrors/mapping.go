@@ -0,0 +1,68 @@
+package apierrors
+
+import (
+	"errors"
+	"sync"
+)
+
+// Hata zincirinde bunu sağlayan bir hata varsa mesaj i18n anahtarından üretilir
+type messageKeyer interface {
+	MessageKey() string
+}
+
+// Çeviriye biçim argümanı gereken hatalar içindir (ör. kalan kilit süresi)
+type messageArger interface {
+	MessageArgs() []interface{}
+}
+
+// Sentinel metni yerine kullanıcıya gösterilecek ayrıntılı mesaj (ör. ihlal edilen şifre kuralları); isteğin dilinde üretilir
+type userMessager interface {
+	UserMessage(locale string) string
+}
+
+type mapping struct {
+	status int
+	target error
+}
+
+var (
+	mappingsMu sync.RWMutex
+	mappings   []mapping
+)
+
+// Servis ve repository paketleri kendi sentinel hatalarını init'te kaydeder; bu paket onları tanımaz.
+// İlk kaydedilen eşleşme kazanır, sarmalanmış hatalar errors.Is ile yakalanır.
+func Register(status int, targets ...error) {
+	mappingsMu.Lock()
+	defer mappingsMu.Unlock()
+	for _, target := range targets {
+		mappings = append(mappings, mapping{status: status, target: target})
+	}
+}
+
+// Mesaj sarmalayan hatanın değil eşleşen sentinel'in metninden alınır ki eklenen iç ayrıntılar sızmasın
+func fromMapping(err error) *Error {
+	mappingsMu.RLock()
+	defer mappingsMu.RUnlock()
+	for _, m := range mappings {
+		if !errors.Is(err, m.target) {
+			continue
+		}
+		e := New(m.status, m.target.Error())
+		var keyed messageKeyer
+		if errors.As(err, &keyed) {
+			e.messageKey = keyed.MessageKey()
+		}
+		var withArgs messageArger
+		if errors.As(err, &withArgs) {
+			e.messageArgs = withArgs.MessageArgs()
+		}
+		var detailed userMessager
+		if errors.As(err, &detailed) {
+			e.messageKey = ""
+			e.detail = detailed
+		}
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package authz
+
+import "github.com/gofiber/fiber/v2"
+
+// AuthMiddleware'in kullanıcının izinlerini istek başına bir kez yazdığı locals anahtarı
+const LocalsKey = "permissions"
+
+type Set map[string]bool
+
+func NewSet(names []string) Set {
+	set := make(Set, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+func (s Set) Has(permission string) bool {
+	return s[permission]
+}
+
+func FromLocals(c *fiber.Ctx) (Set, bool) {
+	set, ok := c.Locals(LocalsKey).(Set)
+	return set, ok
+}
+
+// Şablon yardımcısı: {{if can .Permissions (perms).UsersDelete}}
+func Can(set Set, permission string) bool {
+	return set.Has(permission)
+}
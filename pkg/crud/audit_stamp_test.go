@@ -0,0 +1,141 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"zatrano/middlewares"
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type memo struct {
+	models.BaseModel
+	Title string
+}
+
+// X-User başlığı oturum middleware'inin yerini tutar; başlık yoksa istek kullanıcısız kalır
+func newMemoApp(t *testing.T) (*fiber.App, *repositories.BaseRepository[memo], *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t, &memo{})
+	repo := repositories.NewBaseRepository[memo](db)
+	h := NewHandler(Config[memo]{
+		Repository:  repo,
+		ViewPrefix:  "dashboard/memos",
+		RoutePrefix: "/dashboard/memos",
+		Title:       "Notlar",
+		Bind: func(c *fiber.Ctx, entity *memo) error {
+			entity.Title = c.FormValue("title")
+			return nil
+		},
+		UpdateData: func(m *memo) map[string]interface{} {
+			return map[string]interface{}{"title": m.Title}
+		},
+	})
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if id, err := strconv.ParseUint(c.Get("X-User"), 10, 64); err == nil {
+			requestctx.SetUserID(c, uint(id))
+		}
+		return c.Next()
+	})
+	app.Use(middlewares.MethodOverride)
+	h.RegisterRoutes(app.Group("/dashboard/memos"))
+	return app, repo, db
+}
+
+func postMemo(t *testing.T, app *fiber.App, path, user, title string) int {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader("title="+title))
+	req.Header.Set("Content-Type", fiber.MIMEApplicationForm)
+	req.Header.Set("Accept", fiber.MIMEApplicationJSON)
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+func TestMiddlewareUserStampsAuditColumns(t *testing.T) {
+	app, _, db := newMemoApp(t)
+
+	if status := postMemo(t, app, "/dashboard/memos/create", "7", "ilk"); status != fiber.StatusOK {
+		t.Fatalf("oluşturma durumu = %d", status)
+	}
+	var created memo
+	if err := db.First(&created).Error; err != nil {
+		t.Fatal(err)
+	}
+	if created.CreatedBy != 7 || created.UpdatedBy != 7 {
+		t.Fatalf("created_by = %d, updated_by = %d; beklenen 7", created.CreatedBy, created.UpdatedBy)
+	}
+
+	path := "/dashboard/memos/update/" + strconv.FormatUint(uint64(created.ID), 10)
+	if status := postMemo(t, app, path, "9", "ikinci"); status != fiber.StatusOK {
+		t.Fatalf("güncelleme durumu = %d", status)
+	}
+	var updated memo
+	if err := db.First(&updated, created.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if updated.CreatedBy != 7 || updated.UpdatedBy != 9 {
+		t.Fatalf("created_by = %d, updated_by = %d; beklenen 7 ve 9", updated.CreatedBy, updated.UpdatedBy)
+	}
+}
+
+func TestAuditedWritesWithoutUserAreRejected(t *testing.T) {
+	app, repo, _ := newMemoApp(t)
+
+	if status := postMemo(t, app, "/dashboard/memos/create", "", "sahipsiz"); status != fiber.StatusInternalServerError {
+		t.Fatalf("kullanıcısız oluşturma durumu = %d", status)
+	}
+	if err := repo.Create(context.Background(), &memo{Title: "sahipsiz"}); !errors.Is(err, repositories.ErrMissingUserID) {
+		t.Fatalf("Create hatası = %v, beklenen ErrMissingUserID", err)
+	}
+
+	existing := &memo{Title: "ilk"}
+	if err := repo.Create(requestctx.WithUserID(context.Background(), 7), existing); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Update(context.Background(), existing.ID, map[string]interface{}{"title": "yeni"}); !errors.Is(err, repositories.ErrMissingUserID) {
+		t.Fatalf("Update hatası = %v, beklenen ErrMissingUserID", err)
+	}
+
+	// created_by/updated_by taşımayan modeller kullanıcısız da yazılabilir
+	notes := repositories.NewBaseRepository[note](testutil.NewDB(t, &note{}))
+	if err := notes.Create(context.Background(), &note{Title: "serbest"}); err != nil {
+		t.Fatalf("denetimsiz model oluşturulamadı: %v", err)
+	}
+}
+
+func TestDeleteFromHTMLFormWithMethodOverride(t *testing.T) {
+	app, repo, db := newMemoApp(t)
+	existing := &memo{Title: "silinecek"}
+	if err := repo.Create(requestctx.WithUserID(context.Background(), 7), existing); err != nil {
+		t.Fatal(err)
+	}
+
+	path := "/dashboard/memos/delete/" + strconv.FormatUint(uint64(existing.ID), 10)
+	if status := postMemo(t, app, path, "7", "x&_method=DELETE"); status != fiber.StatusOK {
+		t.Fatalf("silme durumu = %d", status)
+	}
+	var deleted memo
+	if err := db.Unscoped().First(&deleted, existing.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !deleted.DeletedAt.Valid || deleted.DeletedBy == nil || *deleted.DeletedBy != 7 {
+		t.Errorf("silme damgası = %v / %v", deleted.DeletedAt, deleted.DeletedBy)
+	}
+}
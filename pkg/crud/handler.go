@@ -0,0 +1,254 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"zatrano/configs/configslog"
+	"zatrano/pkg/apierrors"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/requestctx"
+	"zatrano/repositories"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+var errInvalidID = errors.New("geçersiz kayıt kimliği")
+
+type Config[T any] struct {
+	Repository repositories.IBaseRepository[T]
+	// Şablon klasörü, örn. "dashboard/products"; list, show, create ve update şablonları aranır
+	ViewPrefix string
+	Layout     string
+	// Yönlendirmelerde kullanılan tam yol, örn. "/dashboard/products"
+	RoutePrefix string
+	Title       string
+	// Form gövdesini varlığa bağlar; yalnızca *apierrors.Error ya da kayıtlı 4xx hataların mesajı kullanıcıya gösterilir
+	Bind func(c *fiber.Ctx, entity *T) error
+	// Güncellemede yazılacak kolonları üretir
+	UpdateData func(entity *T) map[string]interface{}
+	// JSON istemcilere yazılacak DTO'yu üretir; verilmezse liste ve kayıt verisi JSON'da yer almaz
+	JSON func(entity *T) interface{}
+}
+
+type Handler[T any] struct {
+	cfg Config[T]
+}
+
+func NewHandler[T any](cfg Config[T]) *Handler[T] {
+	return &Handler[T]{cfg: cfg}
+}
+
+func (h *Handler[T]) RegisterRoutes(router fiber.Router) {
+	router.Get("/", h.List)
+	router.Get("/create", h.New)
+	router.Post("/create", h.Create)
+	router.Get("/update/:id", h.Edit)
+	router.Post("/update/:id", h.Update)
+	router.Get("/:id", h.Show)
+	router.Delete("/delete/:id", h.Delete)
+}
+
+func (h *Handler[T]) List(c *fiber.Ctx) error {
+	var params queryparams.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		params = queryparams.DefaultListParams()
+	}
+	filters, filterErr := queryparams.ParseFilters(c.Queries())
+	params.Filters = filters
+	params.Normalize()
+
+	renderData := fiber.Map{
+		"Title":    h.cfg.Title,
+		"Params":   params,
+		"BasePath": h.cfg.RoutePrefix,
+	}
+
+	if filterErr != nil {
+		renderData[renderer.FlashErrorKeyView] = "Filtre parametreleri geçersiz, lütfen kontrol edip tekrar deneyin."
+		renderData["Result"] = queryparams.NewPaginated([]T{}, 0, params)
+		return renderer.Render(c, h.view("list"), h.cfg.Layout, renderData, http.StatusBadRequest)
+	}
+
+	items, total, err := h.cfg.Repository.GetAll(c.UserContext(), params)
+	if err != nil {
+		configslog.Log.Error("CRUD listesi alınamadı", zap.String("path", h.cfg.RoutePrefix), zap.Error(err))
+		renderData[renderer.FlashErrorKeyView] = "Kayıtlar getirilirken bir hata oluştu."
+		renderData["Result"] = queryparams.NewPaginated([]T{}, 0, params)
+		return renderer.Render(c, h.view("list"), h.cfg.Layout, renderData, apierrors.From(err).Status)
+	}
+	result := queryparams.NewPaginated(items, total, params)
+	renderData["Result"] = result
+	if h.cfg.JSON != nil {
+		renderData[renderer.JSONKey] = queryparams.MapPaginated(result, h.cfg.JSON)
+	}
+	return renderer.Render(c, h.view("list"), h.cfg.Layout, renderData, http.StatusOK)
+}
+
+func (h *Handler[T]) Show(c *fiber.Ctx) error {
+	item, err := h.find(c)
+	if err != nil {
+		return h.redirectLookupError(c, err)
+	}
+	data := fiber.Map{
+		"Title":    h.cfg.Title,
+		"Item":     item,
+		"BasePath": h.cfg.RoutePrefix,
+	}
+	if h.cfg.JSON != nil {
+		data[renderer.JSONKey] = h.cfg.JSON(item)
+	}
+	return renderer.Render(c, h.view("show"), h.cfg.Layout, data)
+}
+
+func (h *Handler[T]) New(c *fiber.Ctx) error {
+	return renderer.Render(c, h.view("create"), h.cfg.Layout, fiber.Map{
+		"Title":    h.cfg.Title,
+		"BasePath": h.cfg.RoutePrefix,
+	})
+}
+
+func (h *Handler[T]) Create(c *fiber.Ctx) error {
+	var entity T
+	if err := h.cfg.Bind(c, &entity); err != nil {
+		return h.renderForm(c, "create", &entity, bindErrorMessage(c, err), http.StatusBadRequest)
+	}
+
+	ctx, _ := actorContext(c)
+	if err := h.cfg.Repository.Create(ctx, &entity); err != nil {
+		configslog.Log.Error("CRUD kaydı oluşturulamadı", zap.String("path", h.cfg.RoutePrefix), zap.Error(err))
+		return h.renderForm(c, "create", &entity, "Kayıt oluşturulamadı.", http.StatusInternalServerError)
+	}
+
+	return renderer.RedirectSuccess(c, "Kayıt başarıyla oluşturuldu.", h.cfg.RoutePrefix)
+}
+
+func (h *Handler[T]) Edit(c *fiber.Ctx) error {
+	item, err := h.find(c)
+	if err != nil {
+		return h.redirectLookupError(c, err)
+	}
+	return renderer.Render(c, h.view("update"), h.cfg.Layout, fiber.Map{
+		"Title":    h.cfg.Title,
+		"Item":     item,
+		"BasePath": h.cfg.RoutePrefix,
+	})
+}
+
+func (h *Handler[T]) Update(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return h.redirectLookupError(c, errInvalidID)
+	}
+
+	var entity T
+	if err := h.cfg.Bind(c, &entity); err != nil {
+		return h.renderForm(c, "update", &entity, bindErrorMessage(c, err), http.StatusBadRequest)
+	}
+
+	ctx, _ := actorContext(c)
+	if _, err := h.cfg.Repository.Update(ctx, id, h.cfg.UpdateData(&entity)); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return h.redirectLookupError(c, err)
+		}
+		if errors.Is(err, repositories.ErrEmptyUpdate) {
+			return h.renderForm(c, "update", &entity, "Güncellenecek alan bulunamadı.", http.StatusBadRequest)
+		}
+		if errors.Is(err, repositories.ErrMissingVersion) {
+			return h.renderForm(c, "update", &entity, "Kayıt sürümü eksik; lütfen sayfayı yenileyip tekrar deneyin.", http.StatusBadRequest)
+		}
+		// Kolon adı yalnızca loglanır; UpdateData'nın korunan kolon yazması yapılandırma hatasıdır
+		if errors.Is(err, repositories.ErrProtectedColumn) {
+			configslog.Log.Error("CRUD güncellemesi korunan kolonu yazmaya çalıştı", zap.String("path", h.cfg.RoutePrefix), zap.Error(err))
+			return h.renderForm(c, "update", &entity, "Bu kayıtta değiştirilemeyen bir alan güncellenmeye çalışıldı.", http.StatusBadRequest)
+		}
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return h.renderForm(c, "update", &entity, "Kayıt siz düzenlerken başka bir kullanıcı tarafından değiştirildi; lütfen sayfayı yenileyin.", http.StatusConflict)
+		}
+		configslog.Log.Error("CRUD kaydı güncellenemedi", zap.String("path", h.cfg.RoutePrefix), zap.Uint("id", id), zap.Error(err))
+		return h.renderForm(c, "update", &entity, "Kayıt güncellenemedi.", http.StatusInternalServerError)
+	}
+
+	return renderer.RedirectSuccess(c, "Kayıt başarıyla güncellendi.", h.cfg.RoutePrefix)
+}
+
+func (h *Handler[T]) Delete(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return h.redirectLookupError(c, errInvalidID)
+	}
+
+	ctx, _ := actorContext(c)
+	if err := h.cfg.Repository.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return h.redirectLookupError(c, err)
+		}
+		configslog.Log.Error("CRUD kaydı silinemedi", zap.String("path", h.cfg.RoutePrefix), zap.Uint("id", id), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Kayıt silinemedi.", h.cfg.RoutePrefix)
+	}
+
+	return renderer.RedirectSuccess(c, "Kayıt başarıyla silindi.", h.cfg.RoutePrefix)
+}
+
+func (h *Handler[T]) find(c *fiber.Ctx) (*T, error) {
+	id, ok := parseID(c)
+	if !ok {
+		return nil, errInvalidID
+	}
+	return h.cfg.Repository.GetByID(c.UserContext(), id)
+}
+
+func parseID(c *fiber.Ctx) (uint, bool) {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *Handler[T]) view(name string) string {
+	return h.cfg.ViewPrefix + "/" + name
+}
+
+func (h *Handler[T]) renderForm(c *fiber.Ctx, name string, entity *T, message string, status int) error {
+	return renderer.Render(c, h.view(name), h.cfg.Layout, fiber.Map{
+		"Title":                    h.cfg.Title,
+		"Item":                     entity,
+		"BasePath":                 h.cfg.RoutePrefix,
+		renderer.FlashErrorKeyView: message,
+		renderer.FormDataKey:       entity,
+	}, status)
+}
+
+func bindErrorMessage(c *fiber.Ctx, err error) string {
+	_, message := apierrors.Message(c, err, "Form verileri geçersiz, lütfen kontrol edip tekrar deneyin.")
+	return message
+}
+
+// Mesajlar sabittir; beklenmeyen hatanın ayrıntısı yalnızca loglanır
+func (h *Handler[T]) redirectLookupError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errInvalidID):
+		return renderer.RedirectError(c, fiber.StatusBadRequest, "Geçersiz kayıt kimliği.", h.cfg.RoutePrefix)
+	case errors.Is(err, repositories.ErrNotFound):
+		return renderer.RedirectError(c, fiber.StatusNotFound, "Kayıt bulunamadı.", h.cfg.RoutePrefix)
+	default:
+		configslog.Log.Error("CRUD kaydı alınamadı", zap.String("path", h.cfg.RoutePrefix), zap.Error(err))
+		return renderer.RedirectError(c, fiber.StatusInternalServerError, "Kayıt getirilirken bir hata oluştu.", h.cfg.RoutePrefix)
+	}
+}
+
+// AuthMiddleware kullanıcıyı UserContext'e koyar; yoksa Locals'taki ya da oturumdaki kimlik context'e taşınır
+func actorContext(c *fiber.Ctx) (context.Context, uint) {
+	ctx := c.UserContext()
+	if userID, ok := requestctx.UserID(ctx); ok {
+		return ctx, userID
+	}
+	if userID, ok := requestctx.UserIDFromFiber(c); ok {
+		return requestctx.WithUserID(ctx, userID), userID
+	}
+	return ctx, 0
+}
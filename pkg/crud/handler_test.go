@@ -0,0 +1,129 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type note struct {
+	ID        uint `gorm:"primarykey"`
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func newNoteApp(t *testing.T, updateData func(*note) map[string]interface{}) (*fiber.App, *repositories.BaseRepository[note], uint) {
+	t.Helper()
+	db := testutil.NewDB(t, &note{})
+	repo := repositories.NewBaseRepository[note](db)
+	existing := &note{Title: "ilk"}
+	if err := repo.Create(context.Background(), existing); err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler(Config[note]{
+		Repository:  repo,
+		ViewPrefix:  "dashboard/notes",
+		RoutePrefix: "/dashboard/notes",
+		Title:       "Notlar",
+		Bind: func(c *fiber.Ctx, entity *note) error {
+			entity.Title = c.FormValue("title")
+			if entity.Title == "bozuk" {
+				return errors.New("sql: notes.title iç ayrıntı")
+			}
+			return nil
+		},
+		UpdateData: updateData,
+	})
+	app := fiber.New()
+	h.RegisterRoutes(app.Group("/dashboard/notes"))
+	return app, repo, existing.ID
+}
+
+func titleData(n *note) map[string]interface{} {
+	return map[string]interface{}{"title": n.Title}
+}
+
+func postUpdate(t *testing.T, app *fiber.App, id string, title string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, "/dashboard/notes/update/"+id, strings.NewReader("title="+title))
+	req.Header.Set("Content-Type", fiber.MIMEApplicationForm)
+	req.Header.Set("Accept", fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Message string `json:"message"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	return resp.StatusCode, body.Message
+}
+
+func TestUpdateStatuses(t *testing.T) {
+	app, repo, id := newNoteApp(t, titleData)
+	idStr := strconv.FormatUint(uint64(id), 10)
+
+	cases := []struct {
+		name  string
+		id    string
+		title string
+		want  int
+	}{
+		{"missing id", "9999", "yeni", fiber.StatusNotFound},
+		{"malformed id", "abc", "yeni", fiber.StatusBadRequest},
+		{"identical values", idStr, "ilk", fiber.StatusOK},
+		{"success", idStr, "ikinci", fiber.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if status, message := postUpdate(t, app, tc.id, tc.title); status != tc.want {
+				t.Errorf("status = %d (%q), beklenen %d", status, message, tc.want)
+			}
+		})
+	}
+
+	stored, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Title != "ikinci" {
+		t.Errorf("kayıt başlığı = %q, beklenen %q", stored.Title, "ikinci")
+	}
+}
+
+func TestUpdateProtectedColumnHidesColumnName(t *testing.T) {
+	app, _, id := newNoteApp(t, func(n *note) map[string]interface{} {
+		return map[string]interface{}{"title": n.Title, "created_by": uint(1)}
+	})
+
+	status, message := postUpdate(t, app, strconv.FormatUint(uint64(id), 10), "yeni")
+	if status != fiber.StatusBadRequest {
+		t.Errorf("status = %d, beklenen 400", status)
+	}
+	if message == "" || strings.Contains(message, "created_by") {
+		t.Errorf("mesaj = %q; sabit mesaj ve kolon adı olmadan beklenir", message)
+	}
+}
+
+func TestBindErrorIsNotShownToUser(t *testing.T) {
+	app, _, id := newNoteApp(t, titleData)
+
+	status, message := postUpdate(t, app, strconv.FormatUint(uint64(id), 10), "bozuk")
+	if status != fiber.StatusBadRequest {
+		t.Errorf("status = %d, beklenen 400", status)
+	}
+	if message == "" || strings.Contains(message, "sql") {
+		t.Errorf("mesaj = %q; bağlama hatasının ayrıntısı gösterilmemeli", message)
+	}
+}
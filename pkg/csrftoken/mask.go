@@ -0,0 +1,39 @@
+package csrftoken
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// Sayfaya yazılan jeton her yanıtta rastgele bir pad ile XOR'lanır: sıkıştırılmış HTML'de jetonun baytları
+// yanıttan yanıta değiştiği için BREACH tarzı uzunluk ölçümüyle tahmin edilemez.
+// Biçim: base64url(pad || pad XOR jeton).
+func Mask(token string) string {
+	if token == "" {
+		return ""
+	}
+	pad := make([]byte, len(token))
+	if _, err := rand.Read(pad); err != nil {
+		return token
+	}
+	masked := make([]byte, 2*len(token))
+	copy(masked, pad)
+	for i := range token {
+		masked[len(token)+i] = pad[i] ^ token[i]
+	}
+	return base64.RawURLEncoding.EncodeToString(masked)
+}
+
+// Maskelenmemiş ya da bozuk değerlerde ok false döner; doğrulamayı csrf middleware'i yapar
+func Unmask(value string) (string, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(decoded) == 0 || len(decoded)%2 != 0 {
+		return "", false
+	}
+	half := len(decoded) / 2
+	token := make([]byte, half)
+	for i := range token {
+		token[i] = decoded[i] ^ decoded[half+i]
+	}
+	return string(token), true
+}
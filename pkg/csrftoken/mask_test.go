@@ -0,0 +1,27 @@
+package csrftoken
+
+import "testing"
+
+func TestMaskRoundTrip(t *testing.T) {
+	const token = "0b1f6c2e-7d4a-4c1e-9f3b-2a5d8e6c1b70"
+
+	first, second := Mask(token), Mask(token)
+	if first == second {
+		t.Fatal("aynı jeton iki yanıtta aynı maskeyle yazıldı")
+	}
+	for _, masked := range []string{first, second} {
+		got, ok := Unmask(masked)
+		if !ok || got != token {
+			t.Errorf("Unmask(%q) = %q, %v; beklenen %q", masked, got, ok, token)
+		}
+	}
+}
+
+func TestUnmaskRejectsRawToken(t *testing.T) {
+	if _, ok := Unmask("0b1f6c2e-7d4a-4c1e-9f3b-2a5d8e6c1b70"); ok {
+		t.Error("maskelenmemiş jeton çözülmüş sayıldı")
+	}
+	if _, ok := Unmask(""); ok {
+		t.Error("boş değer çözülmüş sayıldı")
+	}
+}
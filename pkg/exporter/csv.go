@@ -0,0 +1,48 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"mime"
+
+	"zatrano/configs/configslog"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Excel'in Türkçe karakterleri doğru açması için dosya başına yazılır
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type WriteBatchFunc func(rows [][]string) error
+
+// Yanıtı akış olarak yazar; produce her partiyi write ile verir ve her partiden sonra istemciye flush edilir.
+// Akış başladıktan sonra oluşan hatalar yalnızca loglanır, çünkü başlıklar gönderilmiş olur.
+func StreamCSV(c *fiber.Ctx, filename string, header []string, produce func(write WriteBatchFunc) error) error {
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if _, err := w.Write(utf8BOM); err != nil {
+			return
+		}
+
+		csvWriter := csv.NewWriter(w)
+		write := func(rows [][]string) error {
+			if err := csvWriter.WriteAll(rows); err != nil {
+				return err
+			}
+			return w.Flush()
+		}
+
+		if err := write([][]string{header}); err != nil {
+			configslog.Log.Warn("CSV başlığı yazılamadı", zap.String("filename", filename), zap.Error(err))
+			return
+		}
+		if err := produce(write); err != nil {
+			configslog.Log.Error("CSV dışa aktarımı yarıda kesildi", zap.String("filename", filename), zap.Error(err))
+		}
+	})
+	return nil
+}
@@ -1,6 +1,9 @@
 package flashmessages
 
 import (
+	"encoding/gob"
+	"strings"
+
 	"zatrano/configs/configslog"
 	"zatrano/configs/configssession"
 
@@ -20,13 +23,99 @@ const (
 )
 
 const (
-	FlashSuccessKey = "flash_success_message"
-	FlashErrorKey   = "flash_error_message"
+	FlashSuccessKey     = "flash_success_message"
+	FlashErrorKey       = "flash_error_message"
+	FlashWarningKey     = "flash_warning_message"
+	FlashInfoKey        = "flash_info_message"
+	FlashMessagesKey    = "flash_messages"
+	FlashFieldErrorsKey = "flash_field_errors"
+	FlashFormValuesKey  = "flash_form_values"
 )
 
+const (
+	LevelSuccess = "success"
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelInfo    = "info"
+)
+
+var levelsByKey = map[string]string{
+	FlashSuccessKey: LevelSuccess,
+	FlashErrorKey:   LevelError,
+	FlashWarningKey: LevelWarning,
+	FlashInfoKey:    LevelInfo,
+}
+
+type Message struct {
+	Level string
+	Text  string
+}
+
 type FlashMessagesData struct {
-	Success string
-	Error   string
+	Success     string
+	Error       string
+	Warning     string
+	Info        string
+	Messages    []Message
+	ByLevel     map[string][]string
+	FieldErrors map[string]string
+	FormValues  map[string]string
+}
+
+// Bu parçaları içeren alan adları eski girdi olarak session'a asla yazılmaz
+var sensitiveInputMarkers = []string{"password", "sifre", "şifre", "token", "csrf", "secret"}
+
+func init() {
+	gob.Register([]Message{})
+}
+
+func isSensitiveInput(field string) bool {
+	field = strings.ToLower(field)
+	for _, marker := range sensitiveInputMarkers {
+		if strings.Contains(field, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func filterOldInput(values map[string]string) map[string]string {
+	filtered := make(map[string]string, len(values))
+	for field, value := range values {
+		if !isSensitiveInput(field) {
+			filtered[field] = value
+		}
+	}
+	return filtered
+}
+
+// Gönderilen form alanlarını toplar; hassas alanlar SetOldInput tarafından ayrıca elenir
+func FormInput(c *fiber.Ctx) map[string]string {
+	values := make(map[string]string)
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		values[string(key)] = string(value)
+	})
+	return values
+}
+
+// Form değerlerini yalnızca bir sonraki istek için saklar; şifre vb. alanlar otomatik çıkarılır
+func SetOldInput(c *fiber.Ctx, values map[string]string) error {
+	filtered := filterOldInput(values)
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	sess, err := configssession.SessionStart(c)
+	if err != nil {
+		configslog.Log.Error("Eski form girdisi için session başlatılamadı", zap.Error(err))
+		return ErrSessionStartFailed
+	}
+	sess.Set(FlashFormValuesKey, filtered)
+	if err := sess.Save(); err != nil {
+		configslog.Log.Error("Eski form girdisi için session kaydedilemedi", zap.Error(err))
+		return ErrSessionSaveFailed
+	}
+	return nil
 }
 
 func SetFlashMessage(c *fiber.Ctx, key string, message string) error {
@@ -35,7 +124,12 @@ func SetFlashMessage(c *fiber.Ctx, key string, message string) error {
 		configslog.Log.Error("Flash mesajı için session başlatılamadı", zap.Error(err))
 		return ErrSessionStartFailed
 	}
-	sess.Set(key, message)
+	level, ok := levelsByKey[key]
+	if !ok {
+		level = LevelInfo
+	}
+	messages, _ := sess.Get(FlashMessagesKey).([]Message)
+	sess.Set(FlashMessagesKey, append(messages, Message{Level: level, Text: message}))
 	if err := sess.Save(); err != nil {
 		configslog.Log.Error("Flash mesajı için session kaydedilemedi", zap.Error(err))
 		return ErrSessionSaveFailed
@@ -43,8 +137,63 @@ func SetFlashMessage(c *fiber.Ctx, key string, message string) error {
 	return nil
 }
 
+// Yalnızca bir sonraki sayfada bir kez gösterilecek değer (ör. yeni API token'ı); TakeOnce okuduğu anda silinir
+func SetOnce(c *fiber.Ctx, key string, value string) error {
+	sess, err := configssession.SessionStart(c)
+	if err != nil {
+		configslog.Log.Error("Tek seferlik değer için session başlatılamadı", zap.Error(err))
+		return ErrSessionStartFailed
+	}
+	sess.Set(key, value)
+	if err := sess.Save(); err != nil {
+		configslog.Log.Error("Tek seferlik değer için session kaydedilemedi", zap.Error(err))
+		return ErrSessionSaveFailed
+	}
+	return nil
+}
+
+func TakeOnce(c *fiber.Ctx, key string) (string, error) {
+	sess, err := configssession.SessionStart(c)
+	if err != nil {
+		configslog.Log.Error("Tek seferlik değer için session başlatılamadı", zap.Error(err))
+		return "", ErrSessionStartFailed
+	}
+	value, ok := sess.Get(key).(string)
+	if !ok {
+		return "", nil
+	}
+	sess.Delete(key)
+	if err := sess.Save(); err != nil {
+		configslog.Log.Error("Tek seferlik değer okunduktan sonra session kaydedilemedi", zap.Error(err))
+		return "", ErrSessionSaveFailed
+	}
+	return value, nil
+}
+
+// Alan bazlı hataları ve gönderilen değerleri bir sonraki isteğe taşır; şifre gibi hassas alanlar values'a konmamalıdır
+func SetFieldErrors(c *fiber.Ctx, fieldErrors map[string]string, values map[string]string) error {
+	sess, err := configssession.SessionStart(c)
+	if err != nil {
+		configslog.Log.Error("Alan hataları için session başlatılamadı", zap.Error(err))
+		return ErrSessionStartFailed
+	}
+	sess.Set(FlashFieldErrorsKey, fieldErrors)
+	if filtered := filterOldInput(values); len(filtered) > 0 {
+		sess.Set(FlashFormValuesKey, filtered)
+	}
+	if err := sess.Save(); err != nil {
+		configslog.Log.Error("Alan hataları için session kaydedilemedi", zap.Error(err))
+		return ErrSessionSaveFailed
+	}
+	return nil
+}
+
 func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
-	messages := FlashMessagesData{}
+	messages := FlashMessagesData{
+		ByLevel:     map[string][]string{},
+		FieldErrors: map[string]string{},
+		FormValues:  map[string]string{},
+	}
 	sess, err := configssession.SessionStart(c)
 	if err != nil {
 		configslog.Log.Error("Flash mesajları alınırken session başlatılamadı", zap.Error(err))
@@ -53,20 +202,29 @@ func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
 
 	var sessionNeedsSave bool
 
-	if success := sess.Get(FlashSuccessKey); success != nil {
-		if msg, ok := success.(string); ok {
-			messages.Success = msg
-			sess.Delete(FlashSuccessKey)
-			sessionNeedsSave = true
+	if stored, ok := sess.Get(FlashMessagesKey).([]Message); ok {
+		messages.Messages = stored
+		for _, msg := range stored {
+			messages.ByLevel[msg.Level] = append(messages.ByLevel[msg.Level], msg.Text)
 		}
+		messages.Success = strings.Join(messages.ByLevel[LevelSuccess], " | ")
+		messages.Error = strings.Join(messages.ByLevel[LevelError], " | ")
+		messages.Warning = strings.Join(messages.ByLevel[LevelWarning], " | ")
+		messages.Info = strings.Join(messages.ByLevel[LevelInfo], " | ")
+		sess.Delete(FlashMessagesKey)
+		sessionNeedsSave = true
 	}
 
-	if errorFlash := sess.Get(FlashErrorKey); errorFlash != nil {
-		if msg, ok := errorFlash.(string); ok {
-			messages.Error = msg
-			sess.Delete(FlashErrorKey)
-			sessionNeedsSave = true
-		}
+	if fieldErrors, ok := sess.Get(FlashFieldErrorsKey).(map[string]string); ok {
+		messages.FieldErrors = fieldErrors
+		sess.Delete(FlashFieldErrorsKey)
+		sessionNeedsSave = true
+	}
+
+	if values, ok := sess.Get(FlashFormValuesKey).(map[string]string); ok {
+		messages.FormValues = values
+		sess.Delete(FlashFormValuesKey)
+		sessionNeedsSave = true
 	}
 
 	if sessionNeedsSave {
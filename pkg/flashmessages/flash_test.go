@@ -0,0 +1,96 @@
+package flashmessages_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"zatrano/configs/configssession"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/templatehelpers"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/gofiber/template/html/v2"
+)
+
+func newFlashApp(t *testing.T) *fiber.App {
+	t.Helper()
+	testutil.Setup()
+	previous := configssession.Session
+	configssession.Session = session.New()
+	t.Cleanup(func() { configssession.Session = previous })
+
+	engine := html.New("../../views", ".html")
+	engine.AddFuncMap(templatehelpers.TemplateHelpers())
+	app := fiber.New(fiber.Config{Views: engine})
+	app.Post("/save", func(c *fiber.Ctx) error {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kayıt oluşturuldu.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "E-posta gönderilemedi.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashWarningKey, "Kota dolmak üzere.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Dosya eklenemedi.")
+		return c.Redirect("/show")
+	})
+	app.Get("/data", func(c *fiber.Ctx) error {
+		data, err := flashmessages.GetFlashMessages(c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(data.ByLevel)
+	})
+	app.Get("/show", func(c *fiber.Ctx) error {
+		return renderer.Render(c, "partials/flash_messages", "", fiber.Map{})
+	})
+	return app
+}
+
+func request(t *testing.T, app *fiber.App, method, path, cookie string) (string, string) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	if cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	for _, c := range resp.Cookies() {
+		if cookie == "" {
+			cookie = c.Name + "=" + c.Value
+		}
+	}
+	return string(body), cookie
+}
+
+func TestMixedLevelsSurviveOneRedirectAndAreReadOnce(t *testing.T) {
+	app := newFlashApp(t)
+
+	_, cookie := request(t, app, fiber.MethodPost, "/save", "")
+	if cookie == "" {
+		t.Fatal("session cookie'si yazılmadı")
+	}
+	body, _ := request(t, app, fiber.MethodGet, "/data", cookie)
+	want := `{"error":["E-posta gönderilemedi.","Dosya eklenemedi."],"success":["Kayıt oluşturuldu."],"warning":["Kota dolmak üzere."]}`
+	if body != want {
+		t.Errorf("ilk okuma = %s\nbeklenen    %s", body, want)
+	}
+	if body, _ := request(t, app, fiber.MethodGet, "/data", cookie); body != `{}` {
+		t.Errorf("ikinci okuma boş olmalı, got %s", body)
+	}
+}
+
+func TestLayoutRendersEveryLevel(t *testing.T) {
+	app := newFlashApp(t)
+
+	_, cookie := request(t, app, fiber.MethodPost, "/save", "")
+	body, _ := request(t, app, fiber.MethodGet, "/show", cookie)
+	for _, text := range []string{"Kayıt oluşturuldu.", "E-posta gönderilemedi.", "Dosya eklenemedi.", "Kota dolmak üzere."} {
+		if !strings.Contains(body, text) {
+			t.Errorf("sayfada %q yok", text)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	DefaultLocale = "tr"
+	// Seçili dil hem c.Locals hem session hem de cookie içinde bu adla tutulur
+	LocaleKey = "locale"
+)
+
+var (
+	mu       sync.RWMutex
+	catalogs = map[string]map[string]string{}
+)
+
+// Bir dil için mesajları ekler; aynı anahtar tekrar verilirse üzerine yazılır
+func Register(locale string, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = make(map[string]string, len(messages))
+		catalogs[locale] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+}
+
+func Supported(locale string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := catalogs[locale]
+	return ok
+}
+
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// Eksik çeviri önce Türkçeye, o da yoksa anahtarın kendisine düşer
+func Translate(locale string, key string, args ...interface{}) string {
+	mu.RLock()
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+	}
+	mu.RUnlock()
+
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+func Locale(c *fiber.Ctx) string {
+	if locale, ok := c.Locals(LocaleKey).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+func T(c *fiber.Ctx, key string, args ...interface{}) string {
+	return Translate(Locale(c), key, args...)
+}
+
+// "en-US" gibi bölgesel etiketleri desteklenen dile indirger
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locale = locale[:i]
+	}
+	if Supported(locale) {
+		return locale
+	}
+	return ""
+}
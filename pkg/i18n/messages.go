@@ -0,0 +1,133 @@
+package i18n
+
+func init() {
+	Register("tr", map[string]string{
+		"common.unexpected_error":      "İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin.",
+		"common.check_fields":          "Lütfen işaretli alanları kontrol edin.",
+		"common.csrf_failed":           "Güvenlik doğrulaması başarısız oldu. Lütfen sayfayı yenileyip tekrar deneyin.",
+		"auth.login_title":             "Giriş",
+		"auth.profile_title":           "Profilim",
+		"auth.login_required_fields":   "Lütfen hesap adı ve şifre alanlarını doldurun.",
+		"auth.invalid_credentials":     "Kullanıcı adı veya şifre hatalı.",
+		"auth.user_inactive":           "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin.",
+		"auth.user_not_found":          "Kullanıcı bulunamadı, lütfen tekrar giriş yapın.",
+		"auth.current_password_wrong":  "Mevcut şifreniz hatalı.",
+		"auth.password_same_as_old":    "Yeni şifre mevcut şifre ile aynı olamaz.",
+		"auth.password_mismatch":       "Yeni şifreler uyuşmuyor.",
+		"auth.password_policy":         "Yeni şifre, şifre kurallarını karşılamıyor.",
+		"password.min_length":          "Şifre en az %d karakter olmalıdır.",
+		"password.require_digit":       "Şifre en az bir rakam içermelidir.",
+		"password.require_letter":      "Şifre en az bir harf içermelidir.",
+		"password.require_upper":       "Şifre en az bir büyük harf içermelidir.",
+		"password.require_symbol":      "Şifre en az bir özel karakter içermelidir.",
+		"password.contains_account":    "Şifre hesap adınızı içeremez.",
+		"auth.password_fields":         "Lütfen tüm şifre alanlarını doldurun.",
+		"auth.password_updated":        "Şifre başarıyla güncellendi. Lütfen yeni şifrenizle tekrar giriş yapın.",
+		"auth.invalid_session":         "Geçersiz oturum, lütfen tekrar giriş yapın.",
+		"auth.session_timeout":         "Oturumunuz zaman aşımına uğradı. Lütfen tekrar giriş yapın.",
+		"auth.role_missing":            "Hesabınız için tanımlanmış bir rol bulunamadı.",
+		"auth.forbidden":               "Bu sayfaya erişim yetkiniz yok.",
+		"auth.login_success":           "Başarıyla giriş yapıldı.",
+		"auth.logout_success":          "Başarıyla çıkış yapıldı.",
+		"auth.generic":                 "Kimlik doğrulaması sırasında bir hata oluştu.",
+		"auth.profile_generic":         "Profil bilgileri alınırken hata oluştu.",
+		"auth.update_password_generic": "Şifre güncellenirken bir hata oluştu.",
+		"auth.hashing_failed":          "Yeni şifre oluşturulurken hata oluştu.",
+		"auth.database_update_failed":  "Veritabanı güncellemesi başarısız oldu.",
+		"auth.account_locked":          "Çok fazla hatalı deneme nedeniyle hesabınız kilitlendi. Lütfen %d dakika sonra tekrar deneyin.",
+		"auth.too_many_attempts":       "Çok fazla deneme yaptınız, lütfen daha sonra tekrar deneyin.",
+		"auth.forgot_title":            "Şifremi Unuttum",
+		"auth.forgot_link":             "Şifremi unuttum",
+		"auth.forgot_submit":           "Sıfırlama Bağlantısı Gönder",
+		"auth.reset_title":             "Yeni Şifre Belirle",
+		"auth.reset_submit":            "Şifreyi Sıfırla",
+		"auth.reset_link_sent":         "Hesap mevcutsa şifre sıfırlama bağlantısı e-posta adresine gönderildi.",
+		"auth.reset_token_invalid":     "Şifre sıfırlama bağlantısı geçersiz veya süresi dolmuş.",
+		"auth.reset_request_failed":    "Şifre sıfırlama isteği işlenemedi. Lütfen tekrar deneyin.",
+		"auth.reset_success":           "Şifreniz sıfırlandı. Lütfen yeni şifrenizle giriş yapın.",
+		"auth.new_password":            "Yeni Şifre",
+		"auth.confirm_password":        "Yeni Şifre (Tekrar)",
+		"auth.sign_in":                 "Giriş Yap",
+		"auth.account":                 "E-posta",
+		"auth.password":                "Şifre",
+		"auth.remember_me":             "Beni hatırla",
+		"auth.session_revoked":         "Oturum sonlandırıldı.",
+		"auth.session_not_found":       "Oturum bulunamadı veya zaten sonlandırılmış.",
+		"auth.api_token_required":      "Geçerli bir API token'ı gerekli.",
+		"auth.api_token_created":       "API token'ı oluşturuldu. Bu değeri şimdi kopyalayın; bir daha gösterilmeyecek.",
+		"auth.api_token_revoked":       "API token'ı iptal edildi.",
+		"auth.api_token_not_found":     "API token'ı bulunamadı.",
+		"auth.api_token_name_required": "Token adı boş olamaz.",
+		"auth.profile_updated":         "Profil bilgileriniz güncellendi.",
+		"auth.name_required":           "Ad alanı boş olamaz.",
+		"auth.account_invalid":         "Hesap adı geçerli bir e-posta adresi olmalıdır.",
+		"auth.account_taken":           "Bu hesap adı zaten kullanılıyor.",
+		"auth.impersonation_forbidden": "Bu işlem başka bir kullanıcının yerine geçmişken yapılamaz.",
+		"auth.impersonation_stopped":   "Kendi hesabınıza geri döndünüz.",
+	})
+
+	Register("en", map[string]string{
+		"common.unexpected_error":      "Something went wrong. Please try again.",
+		"common.check_fields":          "Please check the highlighted fields.",
+		"common.csrf_failed":           "Security verification failed. Please refresh the page and try again.",
+		"auth.login_title":             "Sign in",
+		"auth.profile_title":           "My profile",
+		"auth.login_required_fields":   "Please fill in the account and password fields.",
+		"auth.invalid_credentials":     "Invalid account name or password.",
+		"auth.user_inactive":           "Your account is not active. Please contact your administrator.",
+		"auth.user_not_found":          "User not found, please sign in again.",
+		"auth.current_password_wrong":  "Your current password is incorrect.",
+		"auth.password_same_as_old":    "The new password cannot be the same as the current one.",
+		"auth.password_mismatch":       "The new passwords do not match.",
+		"auth.password_policy":         "The new password does not meet the password rules.",
+		"password.min_length":          "The password must be at least %d characters long.",
+		"password.require_digit":       "The password must contain at least one digit.",
+		"password.require_letter":      "The password must contain at least one letter.",
+		"password.require_upper":       "The password must contain at least one uppercase letter.",
+		"password.require_symbol":      "The password must contain at least one special character.",
+		"password.contains_account":    "The password cannot contain your account name.",
+		"auth.password_fields":         "Please fill in all password fields.",
+		"auth.password_updated":        "Password updated. Please sign in again with your new password.",
+		"auth.invalid_session":         "Invalid session, please sign in again.",
+		"auth.session_timeout":         "Your session has timed out. Please sign in again.",
+		"auth.role_missing":            "No role is defined for your account.",
+		"auth.forbidden":               "You are not allowed to access this page.",
+		"auth.login_success":           "Signed in successfully.",
+		"auth.logout_success":          "Signed out successfully.",
+		"auth.generic":                 "An error occurred during authentication.",
+		"auth.profile_generic":         "An error occurred while loading the profile.",
+		"auth.update_password_generic": "An error occurred while updating the password.",
+		"auth.hashing_failed":          "An error occurred while creating the new password.",
+		"auth.database_update_failed":  "The database update failed.",
+		"auth.account_locked":          "Your account is locked after too many failed attempts. Please try again in %d minutes.",
+		"auth.too_many_attempts":       "Too many attempts, please try again later.",
+		"auth.forgot_title":            "Forgot password",
+		"auth.forgot_link":             "Forgot your password?",
+		"auth.forgot_submit":           "Send reset link",
+		"auth.reset_title":             "Set a new password",
+		"auth.reset_submit":            "Reset password",
+		"auth.reset_link_sent":         "If the account exists, a password reset link has been sent to its email address.",
+		"auth.reset_token_invalid":     "The password reset link is invalid or has expired.",
+		"auth.reset_request_failed":    "The password reset request could not be processed. Please try again.",
+		"auth.reset_success":           "Your password has been reset. Please sign in with your new password.",
+		"auth.new_password":            "New password",
+		"auth.confirm_password":        "New password (again)",
+		"auth.sign_in":                 "Sign in",
+		"auth.account":                 "Email",
+		"auth.password":                "Password",
+		"auth.remember_me":             "Remember me",
+		"auth.session_revoked":         "The session has been signed out.",
+		"auth.session_not_found":       "The session was not found or has already ended.",
+		"auth.api_token_required":      "A valid API token is required.",
+		"auth.api_token_created":       "API token created. Copy it now; it will not be shown again.",
+		"auth.api_token_revoked":       "The API token has been revoked.",
+		"auth.api_token_not_found":     "The API token was not found.",
+		"auth.api_token_name_required": "Token name cannot be empty.",
+		"auth.profile_updated":         "Your profile has been updated.",
+		"auth.name_required":           "Name cannot be empty.",
+		"auth.account_invalid":         "The account name must be a valid email address.",
+		"auth.account_taken":           "This account name is already in use.",
+		"auth.impersonation_forbidden": "This action is not allowed while impersonating another user.",
+		"auth.impersonation_stopped":   "You are back on your own account.",
+	})
+}
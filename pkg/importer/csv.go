@@ -0,0 +1,223 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingColumn  = errors.New("CSV dosyasında zorunlu kolon eksik")
+	ErrStrictRejected = errors.New("katı modda hatalı satır bulunduğu için içe aktarma geri alındı")
+)
+
+const defaultBatchSize = 500
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "02.01.2006 15:04", "02.01.2006"}
+
+type RowError struct {
+	Row    int
+	Column string
+	Reason string
+}
+
+type Result struct {
+	Inserted int
+	Errors   []RowError
+}
+
+type Options[T any] struct {
+	// CSV başlığı -> struct alan adı
+	Mapping   map[string]string
+	Required  []string
+	Validate  func(row int, item *T) []RowError
+	BatchSize int
+	Strict    bool
+}
+
+// Dosyayı satır satır okur; geçerli satırları partiler halinde insert'e verir, hatalı satırları Result.Errors'a toplar.
+// Strict modda ilk hatadan sonra insert çağrılmaz ve sonuçta ErrStrictRejected döner ki çağıran transaction'ı geri alsın.
+// Strict olmayan modda bir parti eklenemezse satırlar tek tek denenir; eklenemeyen satırlar hata olarak raporlanır.
+// Bu durumda insert her çağrıda kendi transaction'ını kullanmalıdır.
+func Import[T any](r io.Reader, opts Options[T], insert func(batch []T) error) (*Result, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[int]string, len(header))
+	present := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, ok := opts.Mapping[name]; ok {
+			columns[i] = name
+			present[name] = true
+		}
+	}
+	for _, name := range opts.Required {
+		if !present[name] {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, name)
+		}
+	}
+
+	result := &Result{}
+	batch := make([]T, 0, batchSize)
+	batchRows := make([]int, 0, batchSize)
+	flush := func() error {
+		defer func() {
+			batch = batch[:0]
+			batchRows = batchRows[:0]
+		}()
+		if len(batch) == 0 || (opts.Strict && len(result.Errors) > 0) {
+			return nil
+		}
+		err := insert(batch)
+		if err == nil {
+			result.Inserted += len(batch)
+			return nil
+		}
+		if opts.Strict {
+			return err
+		}
+		for i := range batch {
+			if err := insert(batch[i : i+1]); err != nil {
+				result.Errors = append(result.Errors, RowError{Row: batchRows[i], Reason: "satır kaydedilemedi"})
+				continue
+			}
+			result.Inserted++
+		}
+		return nil
+	}
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, RowError{Row: row, Reason: err.Error()})
+			continue
+		}
+
+		var item T
+		rowErrors := decodeRow(row, record, columns, opts.Mapping, &item)
+		if len(rowErrors) == 0 && opts.Validate != nil {
+			rowErrors = opts.Validate(row, &item)
+		}
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+
+		batch = append(batch, item)
+		batchRows = append(batchRows, row)
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	if opts.Strict && len(result.Errors) > 0 {
+		result.Inserted = 0
+		return result, ErrStrictRejected
+	}
+	return result, nil
+}
+
+func decodeRow[T any](row int, record []string, columns map[int]string, mapping map[string]string, item *T) []RowError {
+	var rowErrors []RowError
+	target := reflect.ValueOf(item).Elem()
+	for i, value := range record {
+		column, ok := columns[i]
+		if !ok {
+			continue
+		}
+
+		field := target.FieldByName(mapping[column])
+		if !field.IsValid() || !field.CanSet() {
+			rowErrors = append(rowErrors, RowError{Row: row, Column: column, Reason: "eşlenen alan bulunamadı"})
+			continue
+		}
+		if err := setField(field, strings.TrimSpace(value)); err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Column: column, Reason: err.Error()})
+		}
+	}
+	return rowErrors
+}
+
+func setField(field reflect.Value, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("geçersiz tarih: %q", value)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("geçersiz tam sayı: %q", value)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("geçersiz pozitif sayı: %q", value)
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("geçersiz ondalık sayı: %q", value)
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("desteklenmeyen alan tipi: %s", field.Type())
+	}
+	return nil
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "1", "true", "evet", "aktif":
+		return true, nil
+	case "0", "false", "hayır", "hayir", "pasif":
+		return false, nil
+	}
+	return false, fmt.Errorf("geçersiz mantıksal değer: %q", value)
+}
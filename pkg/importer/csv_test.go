@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type row struct {
+	Name string
+	Age  int
+}
+
+var rowMapping = map[string]string{"Ad": "Name", "Yaş": "Age"}
+
+const rowCSV = "Ad,Yaş\nali,30\nbozuk,40\nveli,x\nayşe,25\n"
+
+// "bozuk" adlı satırı reddeden, diğerlerini kaydeden insert
+func failingInsert(saved *[]row) func(batch []row) error {
+	return func(batch []row) error {
+		for _, r := range batch {
+			if r.Name == "bozuk" {
+				return errors.New("unique constraint")
+			}
+		}
+		*saved = append(*saved, batch...)
+		return nil
+	}
+}
+
+func TestImportNonStrictFallsBackToPerRowInsert(t *testing.T) {
+	var saved []row
+	result, err := Import(strings.NewReader(rowCSV), Options[row]{Mapping: rowMapping, BatchSize: 10}, failingInsert(&saved))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Inserted != 2 || len(saved) != 2 {
+		t.Fatalf("Inserted = %d, kaydedilen = %d; beklenen 2", result.Inserted, len(saved))
+	}
+	rows := map[int]bool{}
+	for _, e := range result.Errors {
+		rows[e.Row] = true
+	}
+	if len(result.Errors) != 2 || !rows[3] || !rows[4] {
+		t.Fatalf("hatalar = %+v; beklenen 3. ve 4. satırlar", result.Errors)
+	}
+}
+
+func TestImportStrictRejectsOnRowError(t *testing.T) {
+	var saved []row
+	result, err := Import(strings.NewReader(rowCSV), Options[row]{Mapping: rowMapping, Strict: true}, failingInsert(&saved))
+	if !errors.Is(err, ErrStrictRejected) {
+		t.Fatalf("err = %v, beklenen ErrStrictRejected", err)
+	}
+	if result.Inserted != 0 {
+		t.Errorf("Inserted = %d, beklenen 0", result.Inserted)
+	}
+}
+
+func TestImportStrictReturnsInsertError(t *testing.T) {
+	var saved []row
+	_, err := Import(strings.NewReader("Ad,Yaş\nbozuk,40\n"), Options[row]{Mapping: rowMapping, Strict: true}, failingInsert(&saved))
+	if err == nil || errors.Is(err, ErrStrictRejected) {
+		t.Fatalf("err = %v, beklenen insert hatası", err)
+	}
+}
+
+func TestImportMissingRequiredColumn(t *testing.T) {
+	_, err := Import(strings.NewReader("Yaş\n30\n"), Options[row]{Mapping: rowMapping, Required: []string{"Ad"}}, func([]row) error { return nil })
+	if !errors.Is(err, ErrMissingColumn) {
+		t.Fatalf("err = %v, beklenen ErrMissingColumn", err)
+	}
+}
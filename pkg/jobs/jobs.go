@@ -0,0 +1,288 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/apierrors"
+	"zatrano/pkg/requestctx"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUnknownType = errors.New("tanımsız iş tipi")
+	ErrNotStarted  = errors.New("iş çalıştırıcı başlatılmadı")
+	ErrNotFound    = errors.New("iş bulunamadı")
+)
+
+func init() {
+	apierrors.Register(http.StatusNotFound, ErrNotFound)
+}
+
+const (
+	// Uyandırma sinyali kaçırılsa ya da başka süreç iş eklese de sıradaki işler bu aralıkla yoklanır
+	pollInterval     = 5 * time.Second
+	progressInterval = time.Second
+
+	interruptedMessage = "Uygulama iş sürerken yeniden başlatıldı"
+)
+
+// Dönen sonuç JSON olarak Result'a yazılır; hata dönse de sonuç varsa saklanır.
+// ctx işi kuyruğa ekleyen kullanıcıyı taşır ve kapatma zaman aşımında iptal edilir.
+type Handler func(ctx context.Context, task *Task) (any, error)
+
+type Task struct {
+	ID        uint
+	Type      string
+	CreatedBy uint
+	payload   string
+	progress  atomic.Int32
+}
+
+func (t *Task) Decode(v any) error {
+	return json.Unmarshal([]byte(t.payload), v)
+}
+
+// Çağıranı bekletmez; son değer periyodik olarak veritabanına yazılır
+func (t *Task) SetProgress(percent int) {
+	t.progress.Store(int32(min(max(percent, 0), 100)))
+}
+
+var handlers = map[string]Handler{}
+
+// İş tipleri Start'tan önce, başlangıçta kaydedilir
+func Register(jobType string, handler Handler) {
+	handlers[jobType] = handler
+}
+
+type runner struct {
+	repo   repositories.IJobRepository
+	wake   chan struct{}
+	stop   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var current *runner
+
+// Önceki çalışmadan running kalan işler başarısız sayılır: yarıda kesilen bir işin tekrar çalıştırılması
+// güvenli olmayabilir. Sırada bekleyen işler worker'lar başlayınca alınır.
+// Kurtarma tek uygulama süreci varsayar; aynı veritabanını paylaşan süreçler aynı anda başlatılmamalıdır.
+func Start(concurrency int) error {
+	r := &runner{
+		repo: repositories.NewJobRepository(),
+		wake: make(chan struct{}, concurrency),
+		stop: make(chan struct{}),
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+
+	failed, err := r.repo.FailRunning(r.ctx, interruptedMessage, time.Now())
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		configslog.Log.Warn("Yarıda kalan işler başarısız olarak işaretlendi", zap.Int64("count", failed))
+	}
+
+	for range concurrency {
+		r.wg.Add(1)
+		go r.work()
+	}
+	current = r
+	configslog.Log.Info("İş çalıştırıcı başlatıldı", zap.Int("concurrency", concurrency))
+	return nil
+}
+
+// Yeni iş alınmaz, süren işlerin bitmesi beklenir; ctx dolarsa işlerin context'i iptal edilir ve
+// worker'ların çıkması yine beklenir ki veritabanı süren bir işin altından kapatılmasın.
+// İptal edilen işler sonucunu yazamazsa bir sonraki başlangıçta başarısız sayılır.
+func Shutdown(ctx context.Context) error {
+	r := current
+	if r == nil {
+		return nil
+	}
+	close(r.stop)
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		r.cancel()
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// payload JSON'a çevrilir; iş, ctx'teki kullanıcı adına kuyruğa eklenir
+func Enqueue(ctx context.Context, jobType string, payload any) (*models.Job, error) {
+	r := current
+	if r == nil {
+		return nil, ErrNotStarted
+	}
+	if _, ok := handlers[jobType]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, jobType)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.Job{Type: jobType, Payload: string(data), Status: models.JobQueued}
+	if err := r.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+func Get(ctx context.Context, id uint) (*models.Job, error) {
+	r := current
+	if r == nil {
+		return nil, ErrNotStarted
+	}
+	job, err := r.repo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return job, err
+}
+
+func (r *runner) work() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		job, err := r.repo.ClaimNext(r.ctx)
+		if err != nil {
+			configslog.Log.Error("Sıradaki iş alınamadı", zap.Error(err))
+		}
+		if job != nil {
+			r.run(job)
+			continue
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-r.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *runner) run(job *models.Job) {
+	log := configslog.Log.With(zap.Uint("job_id", job.ID), zap.String("job_type", job.Type))
+	task := &Task{ID: job.ID, Type: job.Type, CreatedBy: job.CreatedBy, payload: job.Payload}
+
+	start := time.Now()
+	stopProgress := r.trackProgress(task, log)
+	result, err := r.execute(task, log)
+	stopProgress()
+
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	job.Progress = int(task.progress.Load())
+	job.Status = models.JobDone
+	if err != nil {
+		job.Status = models.JobFailed
+		job.Error = err.Error()
+	} else {
+		job.Progress = 100
+	}
+	if result != nil {
+		data, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			log.Error("İş sonucu JSON'a çevrilemedi", zap.Error(marshalErr))
+		}
+		job.Result = string(data)
+	}
+
+	// Kapatmada işin context'i iptal edilmiş olsa da sonuç yazılır
+	if err := r.repo.Finish(context.WithoutCancel(r.ctx), job); err != nil {
+		log.Error("İş sonucu kaydedilemedi", zap.Error(err))
+	}
+	if job.Status == models.JobFailed {
+		log.Warn("İş başarısız oldu", zap.Duration("duration", time.Since(start)), zap.String("error", job.Error))
+		return
+	}
+	log.Info("İş tamamlandı", zap.Duration("duration", time.Since(start)))
+}
+
+func (r *runner) execute(task *Task, log *zap.Logger) (result any, err error) {
+	handler, ok := handlers[task.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, task.Type)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			log.Error("İş panic ile sonlandı", zap.Any("panic", p), zap.Stack("stack"))
+			err = fmt.Errorf("beklenmeyen hata: %v", p)
+		}
+	}()
+	ctx := r.ctx
+	if task.CreatedBy > 0 {
+		ctx = requestctx.WithUserID(ctx, task.CreatedBy)
+	}
+	return handler(ctx, task)
+}
+
+// İlerleme ayrı bir goroutine'de yazılır ki veritabanı yavaşken iş beklemesin
+func (r *runner) trackProgress(task *Task, log *zap.Logger) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+
+		written := int32(0)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			progress := task.progress.Load()
+			if progress == written {
+				continue
+			}
+			if err := r.repo.UpdateProgress(r.ctx, task.ID, int(progress)); err != nil {
+				log.Warn("İş ilerlemesi kaydedilemedi", zap.Error(err))
+				continue
+			}
+			written = progress
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,146 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+)
+
+func startRunner(t *testing.T) {
+	t.Helper()
+	testutil.NewDB(t, &models.Job{})
+	if err := Start(1); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = Shutdown(ctx)
+		current = nil
+	})
+}
+
+func waitFinished(t *testing.T, id uint) *models.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := Get(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.IsFinished() {
+			return job
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("iş %d zamanında bitmedi", id)
+	return nil
+}
+
+func TestEnqueueRunsToCompletion(t *testing.T) {
+	startRunner(t)
+	Register("test.echo", func(ctx context.Context, task *Task) (any, error) {
+		var payload struct{ Name string }
+		if err := task.Decode(&payload); err != nil {
+			return nil, err
+		}
+		userID, _ := requestctx.UserID(ctx)
+		task.SetProgress(50)
+		return map[string]any{"name": payload.Name, "user_id": userID}, nil
+	})
+
+	ctx := requestctx.WithUserID(context.Background(), 42)
+	queued, err := Enqueue(ctx, "test.echo", map[string]string{"Name": "Ayşe"})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if queued.Status != models.JobQueued || queued.CreatedBy != 42 {
+		t.Fatalf("kuyruğa eklenen iş = %+v", queued)
+	}
+
+	job := waitFinished(t, queued.ID)
+	if job.Status != models.JobDone || job.Progress != 100 || job.Error != "" {
+		t.Fatalf("iş = %+v; beklenen %%100 tamamlanmış", job)
+	}
+	if job.Result != `{"name":"Ayşe","user_id":42}` {
+		t.Errorf("Result = %s", job.Result)
+	}
+}
+
+func TestFailedAndPanickingJobsAreRecorded(t *testing.T) {
+	startRunner(t)
+	Register("test.fail", func(context.Context, *Task) (any, error) {
+		return map[string]int{"inserted": 1}, errors.New("satır 3 geçersiz")
+	})
+	Register("test.panic", func(context.Context, *Task) (any, error) {
+		panic("beklenmedik")
+	})
+
+	ctx := requestctx.WithUserID(context.Background(), 1)
+	failing, err := Enqueue(ctx, "test.fail", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	panicking, err := Enqueue(ctx, "test.panic", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := waitFinished(t, failing.ID)
+	if job.Status != models.JobFailed || job.Error != "satır 3 geçersiz" || job.Result != `{"inserted":1}` {
+		t.Errorf("başarısız iş = %+v; hata ve kısmi sonuç saklanmalı", job)
+	}
+	job = waitFinished(t, panicking.ID)
+	if job.Status != models.JobFailed || job.Error == "" {
+		t.Errorf("panic eden iş = %+v; başarısız sayılmalı", job)
+	}
+
+	if _, err := Enqueue(ctx, "test.unknown", nil); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("tanımsız tip: err = %v", err)
+	}
+}
+
+// Süre dolunca işler iptal edilir ama Shutdown, worker'lar çıkmadan dönmez
+func TestShutdownWaitsForCancelledJobs(t *testing.T) {
+	startRunner(t)
+	started := make(chan struct{})
+	var exited atomic.Bool
+	Register("test.slow", func(ctx context.Context, _ *Task) (any, error) {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond)
+		exited.Store(true)
+		return nil, ctx.Err()
+	})
+
+	queued, err := Enqueue(requestctx.WithUserID(context.Background(), 1), "test.slow", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	repo := current.repo
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown err = %v, beklenen DeadlineExceeded", err)
+	}
+	current = nil
+	if !exited.Load() {
+		t.Fatal("Shutdown iptal edilen iş bitmeden döndü")
+	}
+
+	job, err := repo.GetByID(context.Background(), queued.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != models.JobFailed {
+		t.Errorf("iptal edilen iş durumu %s, beklenen failed", job.Status)
+	}
+}
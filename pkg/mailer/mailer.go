@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+
+	"go.uber.org/zap"
+)
+
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type SMTPMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	return smtp.SendMail(net.JoinHostPort(m.host, m.port), auth, m.from, msg.To, m.build(msg))
+}
+
+func (m *SMTPMailer) build(msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+	return []byte(b.String())
+}
+
+// SMTP yapılandırılmamış ortamlarda (geliştirme) mailler gönderilmek yerine loglanır.
+// Gövde sıfırlama bağlantısı gibi gizli değerler taşıyabildiği için loga yazılmaz.
+type LogMailer struct{}
+
+func (LogMailer) Send(_ context.Context, msg Message) error {
+	configslog.Log.Info("E-posta (gönderilmedi, SMTP yapılandırılmamış)",
+		zap.Strings("to", msg.To),
+		zap.String("subject", msg.Subject),
+	)
+	return nil
+}
+
+func New(settings configsapp.MailSettings) Mailer {
+	if settings.Host == "" {
+		return LogMailer{}
+	}
+	return NewSMTPMailer(settings.Host, strconv.Itoa(settings.Port), settings.Username, settings.Password, settings.From)
+}
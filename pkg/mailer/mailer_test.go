@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/configs/configslog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogMailerDoesNotLogBody(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	previous := configslog.Log
+	configslog.Log = zap.New(core)
+	t.Cleanup(func() { configslog.Log = previous })
+
+	err := LogMailer{}.Send(context.Background(), Message{
+		To:      []string{"ayse@example.com"},
+		Subject: "Şifre sıfırlama",
+		Body:    "https://example.com/auth/reset-password?token=secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("%d log kaydı, beklenen 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if _, ok := fields["body"]; ok {
+		t.Error("e-posta gövdesi loga yazıldı")
+	}
+	if fields["subject"] != "Şifre sıfırlama" {
+		t.Errorf("subject = %v", fields["subject"])
+	}
+}
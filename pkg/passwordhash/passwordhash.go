@@ -0,0 +1,175 @@
+package passwordhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"zatrano/configs/configsapp"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	SchemeArgon2id = "argon2id"
+	SchemeBcrypt   = "bcrypt"
+)
+
+var (
+	ErrUnknownScheme = errors.New("şifre özeti tanınmayan bir biçimde")
+	ErrMalformedHash = errors.New("şifre özeti bozuk")
+)
+
+type Params struct {
+	Scheme      string
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+	BcryptCost  int
+}
+
+func DefaultParams() Params {
+	return Params{
+		Scheme:      SchemeArgon2id,
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+		BcryptCost:  bcrypt.DefaultCost,
+	}
+}
+
+var (
+	mu      sync.RWMutex
+	current = DefaultParams()
+)
+
+func Configure(p Params) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = p
+}
+
+func Current() Params {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Tuz ve anahtar uzunlukları yapılandırılamaz, varsayılanlardan alınır
+func FromSettings(settings configsapp.PasswordSettings) Params {
+	d := DefaultParams()
+	return Params{
+		Scheme:      settings.HashScheme,
+		Memory:      uint32(settings.Argon2MemoryKB),
+		Iterations:  uint32(settings.Argon2Iterations),
+		Parallelism: uint8(settings.Argon2Parallelism),
+		SaltLength:  d.SaltLength,
+		KeyLength:   d.KeyLength,
+		BcryptCost:  settings.BcryptCost,
+	}
+}
+
+// Geçerli şemayla özet üretir
+func Hash(password string) (string, error) {
+	p := Current()
+	if p.Scheme == SchemeBcrypt {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), p.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hashed), nil
+	}
+
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Şema özetin önekinden anlaşılır; needsRehash, şifre doğruysa ve özet geçerli ayarlarla üretilmemişse true olur
+func Verify(hash, password string) (ok bool, needsRehash bool, err error) {
+	p := Current()
+
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		decoded, err := decodeArgon2(hash)
+		if err != nil {
+			return false, false, err
+		}
+		key := argon2.IDKey([]byte(password), decoded.salt, decoded.iterations, decoded.memory, decoded.parallelism, uint32(len(decoded.key)))
+		if subtle.ConstantTimeCompare(key, decoded.key) != 1 {
+			return false, false, nil
+		}
+		stale := p.Scheme != SchemeArgon2id ||
+			decoded.memory != p.Memory ||
+			decoded.iterations != p.Iterations ||
+			decoded.parallelism != p.Parallelism ||
+			uint32(len(decoded.key)) != p.KeyLength
+		return true, stale, nil
+
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+		if p.Scheme != SchemeBcrypt {
+			return true, true, nil
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return true, err == nil && cost != p.BcryptCost, nil
+	}
+
+	return false, false, ErrUnknownScheme
+}
+
+type argon2Hash struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func decodeArgon2(hash string) (*argon2Hash, error) {
+	// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrMalformedHash
+	}
+
+	var h argon2Hash
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.memory, &h.iterations, &h.parallelism); err != nil {
+		return nil, ErrMalformedHash
+	}
+
+	var err error
+	if h.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrMalformedHash
+	}
+	if h.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(h.key) == 0 {
+		return nil, ErrMalformedHash
+	}
+	return &h, nil
+}
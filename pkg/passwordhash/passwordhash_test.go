@@ -0,0 +1,139 @@
+package passwordhash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Testlerde argon2 maliyeti düşük tutulur
+func fastArgon2() Params {
+	p := DefaultParams()
+	p.Memory = 1024
+	p.Iterations = 1
+	p.Parallelism = 1
+	p.BcryptCost = bcrypt.MinCost
+	return p
+}
+
+func useParams(t *testing.T, p Params) {
+	t.Helper()
+	previous := Current()
+	Configure(p)
+	t.Cleanup(func() { Configure(previous) })
+}
+
+func TestHashAndVerifyArgon2id(t *testing.T) {
+	useParams(t, fastArgon2())
+
+	hash, err := Hash("gizli-şifre")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Fatalf("özet = %q, beklenen argon2id öneki", hash)
+	}
+
+	ok, needsRehash, err := Verify(hash, "gizli-şifre")
+	if err != nil || !ok || needsRehash {
+		t.Errorf("Verify(doğru) = %v, %v, %v; beklenen true, false, nil", ok, needsRehash, err)
+	}
+	ok, needsRehash, err = Verify(hash, "yanlış")
+	if err != nil || ok || needsRehash {
+		t.Errorf("Verify(yanlış) = %v, %v, %v; beklenen false, false, nil", ok, needsRehash, err)
+	}
+}
+
+func TestVerifyLegacyBcrypt(t *testing.T) {
+	useParams(t, fastArgon2())
+	legacy, err := bcrypt.GenerateFromPassword([]byte("eski-şifre"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, needsRehash, err := Verify(string(legacy), "eski-şifre")
+	if err != nil || !ok {
+		t.Fatalf("Verify(bcrypt) = %v, %v; beklenen true, nil", ok, err)
+	}
+	if !needsRehash {
+		t.Error("argon2id şemasındayken bcrypt özeti yenilenmek istenmedi")
+	}
+
+	ok, needsRehash, err = Verify(string(legacy), "yanlış")
+	if err != nil || ok || needsRehash {
+		t.Errorf("Verify(bcrypt, yanlış) = %v, %v, %v", ok, needsRehash, err)
+	}
+}
+
+func TestVerifyFlagsChangedParameters(t *testing.T) {
+	useParams(t, fastArgon2())
+	hash, err := Hash("şifre")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(*Params)
+	}{
+		{"bellek", func(p *Params) { p.Memory = 2048 }},
+		{"yineleme", func(p *Params) { p.Iterations = 2 }},
+		{"paralellik", func(p *Params) { p.Parallelism = 2 }},
+		{"anahtar uzunluğu", func(p *Params) { p.KeyLength = 64 }},
+		{"şema", func(p *Params) { p.Scheme = SchemeBcrypt }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := fastArgon2()
+			tt.change(&p)
+			Configure(p)
+			ok, needsRehash, err := Verify(hash, "şifre")
+			if err != nil || !ok {
+				t.Fatalf("Verify = %v, %v; eski parametrelerle üretilen özet doğrulanmalı", ok, err)
+			}
+			if !needsRehash {
+				t.Error("parametre değiştiği halde needsRehash false")
+			}
+		})
+	}
+}
+
+func TestVerifyBcryptCostChange(t *testing.T) {
+	p := fastArgon2()
+	p.Scheme = SchemeBcrypt
+	useParams(t, p)
+	hash, err := Hash("şifre")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, needsRehash, _ := Verify(hash, "şifre"); needsRehash {
+		t.Error("aynı maliyetle üretilen bcrypt özeti yenilenmek istendi")
+	}
+
+	p.BcryptCost = bcrypt.MinCost + 1
+	Configure(p)
+	if _, needsRehash, _ := Verify(hash, "şifre"); !needsRehash {
+		t.Error("bcrypt maliyeti değiştiği halde needsRehash false")
+	}
+}
+
+func TestVerifyRejectsUnknownAndMalformedHashes(t *testing.T) {
+	useParams(t, fastArgon2())
+	tests := []struct {
+		hash string
+		want error
+	}{
+		{"düz-metin", ErrUnknownScheme},
+		{"$argon2id$v=19$m=1024,t=1,p=1$tuz", ErrMalformedHash},
+		{"$argon2id$v=18$m=1024,t=1,p=1$c2FsdA$a2V5", ErrMalformedHash},
+		{"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5", ErrMalformedHash},
+		{"$argon2id$v=19$m=1024,t=1,p=1$!!$a2V5", ErrMalformedHash},
+	}
+	for _, tt := range tests {
+		if ok, _, err := Verify(tt.hash, "şifre"); ok || !errors.Is(err, tt.want) {
+			t.Errorf("Verify(%q) = %v, %v; beklenen %v", tt.hash, ok, err, tt.want)
+		}
+	}
+}
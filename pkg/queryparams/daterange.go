@@ -0,0 +1,41 @@
+package queryparams
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDate = errors.New("geçersiz tarih değeri")
+
+const dateOnlyLayout = "2006-01-02"
+
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// Sadece tarih verilen "to" değerleri ertesi günün başlangıcına çekilir, böylece < ile gün sonu dahil olur
+func ParseDateBound(value string, loc *time.Location, upper bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if t, err := time.ParseInLocation(dateOnlyLayout, value, loc); err == nil {
+		if upper {
+			return t.AddDate(0, 0, 1), nil
+		}
+		return t, nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, value)
+}
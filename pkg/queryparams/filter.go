@@ -0,0 +1,96 @@
+package queryparams
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidFilter = errors.New("geçersiz filtre parametresi")
+
+const (
+	OpEq   = "eq"
+	OpNeq  = "neq"
+	OpGt   = "gt"
+	OpGte  = "gte"
+	OpLt   = "lt"
+	OpLte  = "lte"
+	OpIn   = "in"
+	OpLike = "like"
+)
+
+var filterOperators = map[string]string{
+	OpEq:   "=",
+	OpNeq:  "<>",
+	OpGt:   ">",
+	OpGte:  ">=",
+	OpLt:   "<",
+	OpLte:  "<=",
+	OpIn:   "IN",
+	OpLike: "ILIKE",
+}
+
+// Kullanıcı değerindeki joker karakterler düz metin olarak aranır
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+type Filter struct {
+	Column   string
+	Operator string
+	Value    string
+}
+
+// filter[kolon][operatör]=değer biçimindeki parametreleri okur; operatör yoksa eq kabul edilir
+func ParseFilters(queries map[string]string) ([]Filter, error) {
+	var filters []Filter
+	for key, value := range queries {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+
+		parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]"), "][")
+		if len(parts) == 0 || len(parts) > 2 || parts[0] == "" {
+			return nil, ErrInvalidFilter
+		}
+
+		operator := OpEq
+		if len(parts) == 2 {
+			operator = strings.ToLower(parts[1])
+		}
+		if _, ok := filterOperators[operator]; !ok {
+			return nil, ErrInvalidFilter
+		}
+
+		filters = append(filters, Filter{Column: parts[0], Operator: operator, Value: value})
+	}
+	return filters, nil
+}
+
+// dialect GORM sürücü adıdır; ILIKE yalnızca Postgres'te vardır, MySQL/SQLite LIKE zaten büyük/küçük harf duyarsızdır.
+// Boş in listesi koşul üretmez; dönen parça boşsa filtre atlanmalıdır.
+func (f Filter) SQL(dialect string) (string, []interface{}) {
+	switch f.Operator {
+	case OpIn:
+		values := strings.Split(f.Value, ",")
+		args := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				args = append(args, v)
+			}
+		}
+		if len(args) == 0 {
+			return "", nil
+		}
+		return f.Column + " IN ?", []interface{}{args}
+	case OpLike:
+		pattern := "%" + likeEscaper.Replace(f.Value) + "%"
+		switch dialect {
+		case "sqlite":
+			// SQLite'ta LIKE için varsayılan kaçış karakteri yoktur
+			return f.Column + ` LIKE ? ESCAPE '\'`, []interface{}{pattern}
+		case "mysql":
+			return f.Column + " LIKE ?", []interface{}{pattern}
+		}
+		return f.Column + " ILIKE ?", []interface{}{pattern}
+	default:
+		return f.Column + " " + filterOperators[f.Operator] + " ?", []interface{}{f.Value}
+	}
+}
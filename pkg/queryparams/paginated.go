@@ -0,0 +1,108 @@
+package queryparams
+
+import (
+	"net/url"
+	"strconv"
+)
+
+type Paginated[T any] struct {
+	Items      []T
+	TotalCount int64
+	Page       int
+	PerPage    int
+	TotalPages int
+	HasPrev    bool
+	HasNext    bool
+	NextCursor string
+
+	params ListParams
+}
+
+// Sayfa hesapları için params burada da normalize edilir; çağıranın ayrıca Normalize çağırması gerekmez
+func NewPaginated[T any](items []T, totalCount int64, params ListParams) *Paginated[T] {
+	params.Normalize()
+	totalPages := CalculateTotalPages(totalCount, params.PerPage)
+	return &Paginated[T]{
+		Items:      items,
+		TotalCount: totalCount,
+		Page:       params.Page,
+		PerPage:    params.PerPage,
+		TotalPages: totalPages,
+		HasPrev:    params.Page > 1,
+		HasNext:    params.Page < totalPages,
+		params:     params,
+	}
+}
+
+func (p *Paginated[T]) FirstItem() int {
+	if len(p.Items) == 0 {
+		return 0
+	}
+	return (p.Page-1)*p.PerPage + 1
+}
+
+func (p *Paginated[T]) LastItem() int {
+	return (p.Page-1)*p.PerPage + len(p.Items)
+}
+
+// JSON yanıtlarda öğeler verilen dönüştürücüyle DTO'ya çevrilir; model alanları doğrudan yazılmaz
+func MapPaginated[T any, R any](p *Paginated[T], convert func(*T) R) PaginatedResult {
+	data := make([]R, len(p.Items))
+	for i := range p.Items {
+		data[i] = convert(&p.Items[i])
+	}
+	return PaginatedResult{
+		Data: data,
+		Meta: PaginationMeta{
+			CurrentPage: p.Page,
+			PerPage:     p.PerPage,
+			TotalItems:  p.TotalCount,
+			TotalPages:  p.TotalPages,
+			NextCursor:  p.NextCursor,
+		},
+	}
+}
+
+// Mevcut filtre ve sıralamayı koruyarak verilen sayfanın query string'ini üretir
+func (p *Paginated[T]) PageURL(page int) string {
+	values := p.params.QueryValues()
+	values.Set("page", strconv.Itoa(page))
+	return "?" + values.Encode()
+}
+
+func (p ListParams) QueryValues() url.Values {
+	values := url.Values{}
+	for key, value := range map[string]string{
+		"name":        p.Name,
+		"q":           p.Query,
+		"type":        p.Type,
+		"status":      p.Status,
+		"createdFrom": p.CreatedFrom,
+		"createdTo":   p.CreatedTo,
+		"updatedFrom": p.UpdatedFrom,
+		"updatedTo":   p.UpdatedTo,
+		"sortBy":      p.SortBy,
+		"orderBy":     p.OrderBy,
+		"sort":        p.Sort,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	if p.PerPage > 0 {
+		values.Set("perPage", strconv.Itoa(p.PerPage))
+	}
+	for _, field := range p.Fields {
+		values.Add("fields", field)
+	}
+	for _, filter := range p.Filters {
+		values.Add("filter["+filter.Column+"]["+filter.Operator+"]", filter.Value)
+	}
+	if p.IncludeDeleted {
+		values.Set("includeDeleted", "true")
+	}
+	if p.OnlyDeleted {
+		values.Set("onlyDeleted", "true")
+	}
+	return values
+}
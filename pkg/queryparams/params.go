@@ -1,6 +1,9 @@
 package queryparams
 
-import "math"
+import (
+	"math"
+	"strings"
+)
 
 const (
 	DefaultOrderBy = "desc"
@@ -10,23 +13,50 @@ const (
 	MaxPerPage     = 100
 )
 
+var maxPerPage = MaxPerPage
+
+func SetMaxPerPage(max int) {
+	if max <= 0 {
+		max = MaxPerPage
+	}
+	maxPerPage = max
+}
+
 type ListParams struct {
 	Name   string `query:"name"`
+	Query  string `query:"q"`
 	Type   string `query:"type"`
 	Status string `query:"status"`
 
+	CreatedFrom string `query:"createdFrom"`
+	CreatedTo   string `query:"createdTo"`
+	UpdatedFrom string `query:"updatedFrom"`
+	UpdatedTo   string `query:"updatedTo"`
+
 	SortBy  string `query:"sortBy"`
 	OrderBy string `query:"orderBy"`
+	Sort    string `query:"sort"`
 
 	Page    int `query:"page"`
 	PerPage int `query:"perPage"`
+
+	Cursor    string `query:"cursor"`
+	UseCursor bool   `query:"useCursor"`
+
+	Fields []string `query:"fields"`
+
+	Filters []Filter `query:"-"`
+
+	IncludeDeleted bool `query:"includeDeleted"`
+	OnlyDeleted    bool `query:"onlyDeleted"`
 }
 
 type PaginationMeta struct {
-	CurrentPage int   `json:"current_page"`
-	PerPage     int   `json:"per_page"`
-	TotalItems  int64 `json:"total_items"`
-	TotalPages  int   `json:"total_pages"`
+	CurrentPage int    `json:"current_page"`
+	PerPage     int    `json:"per_page"`
+	TotalItems  int64  `json:"total_items"`
+	TotalPages  int    `json:"total_pages"`
+	NextCursor  string `json:"next_cursor,omitempty"`
 }
 
 type PaginatedResult struct {
@@ -34,6 +64,33 @@ type PaginatedResult struct {
 	Meta PaginationMeta `json:"meta"`
 }
 
+func (p *ListParams) Normalize() {
+	for _, field := range []*string{
+		&p.Name, &p.Query, &p.Type, &p.Status,
+		&p.CreatedFrom, &p.CreatedTo, &p.UpdatedFrom, &p.UpdatedTo,
+		&p.SortBy, &p.OrderBy, &p.Sort, &p.Cursor,
+	} {
+		*field = strings.TrimSpace(*field)
+	}
+
+	if p.Page < 1 {
+		p.Page = DefaultPage
+	}
+	if p.PerPage <= 0 {
+		p.PerPage = DefaultPerPage
+	}
+	if p.PerPage > maxPerPage {
+		p.PerPage = maxPerPage
+	}
+	if p.SortBy == "" {
+		p.SortBy = DefaultSortBy
+	}
+	p.OrderBy = strings.ToLower(p.OrderBy)
+	if p.OrderBy != "asc" && p.OrderBy != "desc" {
+		p.OrderBy = DefaultOrderBy
+	}
+}
+
 func (p *ListParams) CalculateOffset() int {
 	if p.Page <= 0 {
 		p.Page = 1
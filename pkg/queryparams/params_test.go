@@ -0,0 +1,72 @@
+package queryparams
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   ListParams
+		want ListParams
+	}{
+		{
+			name: "sıfır değerler varsayılana döner",
+			in:   ListParams{},
+			want: ListParams{Page: DefaultPage, PerPage: DefaultPerPage, SortBy: DefaultSortBy, OrderBy: DefaultOrderBy},
+		},
+		{
+			name: "negatif sayfa ve boyut",
+			in:   ListParams{Page: -3, PerPage: -10},
+			want: ListParams{Page: DefaultPage, PerPage: DefaultPerPage, SortBy: DefaultSortBy, OrderBy: DefaultOrderBy},
+		},
+		{
+			name: "çok büyük sayfa boyutu sınırlanır",
+			in:   ListParams{Page: 5, PerPage: 100000},
+			want: ListParams{Page: 5, PerPage: MaxPerPage, SortBy: DefaultSortBy, OrderBy: DefaultOrderBy},
+		},
+		{
+			name: "geçersiz sıralama yönü",
+			in:   ListParams{Page: 1, PerPage: 10, SortBy: "name", OrderBy: "asc; DROP TABLE users"},
+			want: ListParams{Page: 1, PerPage: 10, SortBy: "name", OrderBy: DefaultOrderBy},
+		},
+		{
+			name: "büyük harfli yön ve boşluklar",
+			in:   ListParams{Page: 2, PerPage: 10, Name: "  ali ", SortBy: " name ", OrderBy: " ASC "},
+			want: ListParams{Page: 2, PerPage: 10, Name: "ali", SortBy: "name", OrderBy: "asc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.in
+			got.Normalize()
+			if got.Page != tt.want.Page || got.PerPage != tt.want.PerPage || got.Name != tt.want.Name ||
+				got.SortBy != tt.want.SortBy || got.OrderBy != tt.want.OrderBy {
+				t.Fatalf("Normalize() = %+v, beklenen %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetMaxPerPage(t *testing.T) {
+	t.Cleanup(func() { SetMaxPerPage(MaxPerPage) })
+
+	SetMaxPerPage(25)
+	params := ListParams{PerPage: 50}
+	params.Normalize()
+	if params.PerPage != 25 {
+		t.Fatalf("PerPage = %d, beklenen 25", params.PerPage)
+	}
+
+	SetMaxPerPage(0)
+	params = ListParams{PerPage: 500}
+	params.Normalize()
+	if params.PerPage != MaxPerPage {
+		t.Fatalf("PerPage = %d, beklenen %d", params.PerPage, MaxPerPage)
+	}
+}
+
+func TestNewPaginatedNormalizesParams(t *testing.T) {
+	p := NewPaginated([]int{1, 2}, 45, ListParams{Page: 0, PerPage: 0})
+	if p.Page != DefaultPage || p.PerPage != DefaultPerPage || p.TotalPages != 3 || !p.HasNext {
+		t.Fatalf("beklenmeyen sayfalama: %+v", p)
+	}
+}
@@ -0,0 +1,34 @@
+package queryparams
+
+import "strings"
+
+type SortField struct {
+	Column    string
+	Direction string
+}
+
+func ParseSort(expression string) []SortField {
+	var fields []SortField
+	for _, part := range strings.Split(expression, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		column, direction, _ := strings.Cut(part, ":")
+		column = strings.TrimSpace(column)
+		direction = strings.ToLower(strings.TrimSpace(direction))
+		if column == "" {
+			continue
+		}
+		if direction == "" {
+			direction = DefaultOrderBy
+		}
+		if direction != "asc" && direction != "desc" {
+			continue
+		}
+
+		fields = append(fields, SortField{Column: column, Direction: direction})
+	}
+	return fields
+}
@@ -0,0 +1,24 @@
+package queryparams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       []SortField
+	}{
+		{"", nil},
+		{"status:asc,name:desc", []SortField{{"status", "asc"}, {"name", "desc"}}},
+		{" created_at : DESC , id ", []SortField{{"created_at", "desc"}, {"id", DefaultOrderBy}}},
+		{"status:yukarı,name:asc", []SortField{{"name", "asc"}}},
+		{",,:asc,name", []SortField{{"name", DefaultOrderBy}}},
+	}
+	for _, tt := range tests {
+		if got := ParseSort(tt.expression); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSort(%q) = %v, beklenen %v", tt.expression, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package renderer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"sort"
+	"time"
+
+	"zatrano/pkg/authz"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/requestctx"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const listETagLocalsKey = "listETag"
+
+// Liste sayfalarının ETag'i render edilen gövdeden değil listenin verisinden üretilir; gövdedeki CSRF jetonu
+// ve bildirim rozeti listeyle ilgisiz olarak her istekte değişir. Özete yol ve query string (sayfa, filtre,
+// sıralama), yanıt biçimi, kullanıcı, dil, yetkiler, toplam kayıt sayısı ve her satırın kimliği ile
+// değişiklik zamanı girer. Etiketi yanıta middlewares.ListETag yazar.
+func SetListETag[T any](c *fiber.Ctx, total int64, rows []T, version func(T) (uint, time.Time)) {
+	h := sha256.New()
+	writeString(h, c.Path())
+	writeString(h, string(c.Request().URI().QueryString()))
+	writeString(h, i18n.Locale(c))
+	if WantsJSON(c) {
+		writeUint(h, 1)
+	} else {
+		writeUint(h, 0)
+	}
+	userID, _ := requestctx.UserIDFromFiber(c)
+	writeUint(h, uint64(userID))
+	impersonatorID, _ := requestctx.ImpersonatorIDFromFiber(c)
+	writeUint(h, uint64(impersonatorID))
+	permissions, _ := authz.FromLocals(c)
+	names := make([]string, 0, len(permissions))
+	for name, granted := range permissions {
+		if granted {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeString(h, name)
+	}
+
+	writeUint(h, uint64(total))
+	for _, row := range rows {
+		id, changed := version(row)
+		writeUint(h, uint64(id))
+		writeUint(h, uint64(changed.UnixNano()))
+	}
+	c.Locals(listETagLocalsKey, `W/"`+hex.EncodeToString(h.Sum(nil)[:16])+`"`)
+}
+
+// Handler SetListETag çağırmadıysa (ör. hata sayfası) ok false döner
+func ListETag(c *fiber.Ctx) (string, bool) {
+	tag, ok := c.Locals(listETagLocalsKey).(string)
+	return tag, ok && tag != ""
+}
+
+// Alanlar uzunluk önekiyle yazılır ki "ab"+"c" ile "a"+"bc" aynı özeti vermesin
+func writeString(h hash.Hash, s string) {
+	writeUint(h, uint64(len(s)))
+	h.Write([]byte(s))
+}
+
+func writeUint(h hash.Hash, v uint64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	h.Write(buf[:])
+}
@@ -1,8 +1,15 @@
 package renderer
 
 import (
+	"context"
 	"net/http"
+	"zatrano/configs/configslog"
+	"zatrano/pkg/apierrors"
+	"zatrano/pkg/authz"
+	"zatrano/pkg/csrftoken"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/requestctx"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
@@ -10,22 +17,146 @@ import (
 )
 
 const (
-	CsrfTokenKey        = "CsrfToken"
-	FlashSuccessKeyView = "Success"
-	FlashErrorKeyView   = "Error"
-	FormDataKey         = "FormData"
+	CsrfTokenKey           = "CsrfToken"
+	FlashSuccessKeyView    = "Success"
+	FlashErrorKeyView      = "Error"
+	FlashWarningKeyView    = "Warning"
+	FlashInfoKeyView       = "Info"
+	FlashMessagesKey       = "Flash"
+	FormDataKey            = "FormData"
+	FieldErrorsKey         = "FieldErrors"
+	OldInputKey            = "OldInput"
+	LocaleKey              = "Locale"
+	PermissionsKey         = "Permissions"
+	UserNameKey            = "UserName"
+	ImpersonatingKey       = "Impersonating"
+	QueryStringKey         = "QueryString"
+	UnreadNotificationsKey = "UnreadNotifications"
+
+	// JSON istemcilere yalnızca bu anahtardaki değer yazılır; şablon verisi hiçbir zaman serileştirilmez
+	JSONKey = "JSON"
+
+	// API rotalarında locals'a yazılır; istemcinin Accept başlığından bağımsız JSON döndürülür
+	ForceJSONLocalsKey = "forceJSON"
+
+	unreadNotificationsLocalsKey = "unreadNotifications"
 )
 
+// ?format=json|html açık tercihi önceliklidir; yoksa Accept başlığına bakılır
+func WantsJSON(c *fiber.Ctx) bool {
+	if force, ok := c.Locals(ForceJSONLocalsKey).(bool); ok && force {
+		return true
+	}
+	switch c.Query("format") {
+	case "json":
+		return true
+	case "html":
+		return false
+	}
+	return c.Accepts(fiber.MIMETextHTML, fiber.MIMEApplicationJSON) == fiber.MIMEApplicationJSON
+}
+
+// Alan hatalarını HTML istemcilerde flash ile geri taşır, JSON istemcilere 422 ve alan ayrıntılarıyla döner
+func RedirectFieldErrors(c *fiber.Ctx, message string, fieldErrors map[string]string, values map[string]string, location string) error {
+	if WantsJSON(c) {
+		return JSONError(c, fiber.StatusUnprocessableEntity, message, fieldErrors)
+	}
+	_ = flashmessages.SetFieldErrors(c, fieldErrors, values)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+	return c.Redirect(location, fiber.StatusSeeOther)
+}
+
+// Gövde apierrors zarfıdır; 5xx durumlarda mesaj genel mesajla değiştirilir
+func JSONError(c *fiber.Ctx, status int, message string, fields map[string]string) error {
+	e := apierrors.New(status, message)
+	e.Fields = fields
+	return apierrors.Write(c, e)
+}
+
+// HTML istemcilere flash mesajıyla yönlendirme, JSON istemcilere yapılandırılmış hata döner
+func RedirectError(c *fiber.Ctx, status int, message string, location string) error {
+	if WantsJSON(c) {
+		return JSONError(c, status, message, nil)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+	return c.Redirect(location, fiber.StatusSeeOther)
+}
+
+func RedirectSuccess(c *fiber.Ctx, message string, location string) error {
+	if WantsJSON(c) {
+		return c.JSON(fiber.Map{"message": message})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, message)
+	return c.Redirect(location, fiber.StatusFound)
+}
+
+// Handler JSONKey ile açık bir DTO vermediyse gövdede yalnızca başarı mesajı bulunur.
+// Modeller doğrudan serileştirilmez; yeni bir alan eklemek DTO'yu değiştirmeyi gerektirir.
+func jsonData(data fiber.Map) interface{} {
+	if payload, ok := data[JSONKey]; ok && payload != nil {
+		return payload
+	}
+	body := fiber.Map{}
+	if successStr, ok := data[FlashSuccessKeyView].(string); ok && successStr != "" {
+		body["message"] = successStr
+	}
+	return body
+}
+
+var unreadCounter func(ctx context.Context, userID uint) (int64, error)
+
+// Başlıktaki bildirim rozetinin sayacı; başlangıçta NotificationService'e bağlanır
+func SetUnreadNotificationCounter(counter func(ctx context.Context, userID uint) (int64, error)) {
+	unreadCounter = counter
+}
+
+// Aynı istekte birden fazla render yapılsa da sayım bir kez yapılır
+func unreadNotifications(c *fiber.Ctx) int64 {
+	if count, ok := c.Locals(unreadNotificationsLocalsKey).(int64); ok {
+		return count
+	}
+	userID, ok := requestctx.UserIDFromFiber(c)
+	if !ok || unreadCounter == nil {
+		return 0
+	}
+	count, err := unreadCounter(c.UserContext(), userID)
+	if err != nil {
+		configslog.Log.Warn("Okunmamış bildirim sayısı alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		count = 0
+	}
+	c.Locals(unreadNotificationsLocalsKey, count)
+	return count
+}
+
 func prepareRenderData(c *fiber.Ctx, data fiber.Map) fiber.Map {
 	renderData := make(fiber.Map)
 
-	renderData[CsrfTokenKey] = c.Locals("csrf")
+	// Sıkıştırılan HTML'de jeton sabit kalırsa BREACH ile sızdırılabilir; her yanıtta yeniden maskelenir
+	token, _ := c.Locals("csrf").(string)
+	renderData[CsrfTokenKey] = csrftoken.Mask(token)
+	renderData[LocaleKey] = i18n.Locale(c)
+	permissions, _ := authz.FromLocals(c)
+	renderData[PermissionsKey] = permissions
+	renderData[UserNameKey], _ = c.Locals("userName").(string)
+	// Şablonlar "X olarak görüntülüyorsunuz" bandını gösterir; X etkin kullanıcının adıdır
+	_, renderData[ImpersonatingKey] = requestctx.ImpersonatorIDFromFiber(c)
+	// paginate bu query string'den sayfa bağlantılarını üretir; filtre ve sıralama korunur
+	renderData[QueryStringKey] = string(c.Request().URI().QueryString())
+	renderData[UnreadNotificationsKey] = unreadNotifications(c)
 
 	flashData, flashErr := flashmessages.GetFlashMessages(c)
 	if flashErr != nil {
 		log.Warn("Render helper: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
 	renderData[FlashSuccessKeyView] = flashData.Success
+	renderData[FlashWarningKeyView] = flashData.Warning
+	renderData[FlashInfoKeyView] = flashData.Info
+	renderData[FlashMessagesKey] = flashData.ByLevel
+	renderData[FieldErrorsKey] = flashData.FieldErrors
+	renderData[OldInputKey] = flashData.FormValues
+	if len(flashData.FormValues) > 0 {
+		renderData[FormDataKey] = flashData.FormValues
+	}
 
 	var handlerError string
 	if data == nil {
@@ -68,6 +199,15 @@ func Render(c *fiber.Ctx, template string, layout string, data fiber.Map, status
 		status = statusCode[0]
 	}
 
+	if WantsJSON(c) {
+		if status >= http.StatusBadRequest {
+			message, _ := data[FlashErrorKeyView].(string)
+			fields, _ := data[FieldErrorsKey].(map[string]string)
+			return JSONError(c, status, message, fields)
+		}
+		return c.Status(status).JSON(jsonData(data))
+	}
+
 	finalData := prepareRenderData(c, data)
 
 	if layout == "" {
@@ -0,0 +1,56 @@
+package renderer_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func renderJSON(t *testing.T, data fiber.Map) string {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return renderer.Render(c, "unused", "", data)
+	})
+	resp, err := app.Test(httptest.NewRequest("GET", "/?format=json", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestRenderJSONNeverSerializesTemplateData(t *testing.T) {
+	testutil.Setup()
+	locked := time.Now().Add(time.Hour)
+	user := &models.User{Name: "Ayşe", FailedLoginCount: 4, LockedUntil: &locked, LastLoginIP: "10.0.0.7"}
+
+	body := renderJSON(t, fiber.Map{"Title": "Kullanıcı", "User": user, renderer.CsrfTokenKey: "secret"})
+	if body != `{}` {
+		t.Errorf("DTO verilmeden gövde boş olmalı, got %s", body)
+	}
+}
+
+func TestRenderJSONWritesOnlyExplicitPayload(t *testing.T) {
+	testutil.Setup()
+	user := &models.User{Name: "Ayşe", LastLoginIP: "10.0.0.7"}
+
+	body := renderJSON(t, fiber.Map{
+		"User":           user,
+		renderer.JSONKey: fiber.Map{"name": user.Name},
+	})
+	if body != `{"name":"Ayşe"}` {
+		t.Errorf("got %s", body)
+	}
+	if strings.Contains(body, "10.0.0.7") {
+		t.Errorf("son giriş IP'si sızdı: %s", body)
+	}
+}
@@ -0,0 +1,112 @@
+package requestctx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type contextKey int
+
+const (
+	userIDKey contextKey = iota
+	impersonatorIDKey
+	tenantIDKey
+)
+
+// AuthMiddleware ve TokenAuth kullanıcı kimliğini bu anahtarla Locals'a da yazar
+const UserIDLocalsKey = "userID"
+
+// Kimliğe bürünme sırasında gerçek kullanıcının (yöneticinin) kimliği bu anahtarla Locals'a yazılır
+const ImpersonatorIDLocalsKey = "impersonatorID"
+
+// Çok kiracılı kurulumda AuthMiddleware oturumdaki kiracı kimliğini bu anahtarla Locals'a yazar
+const TenantIDLocalsKey = "tenantID"
+
+var ErrMissingUserID = errors.New("context içinde geçerli kullanıcı kimliği yok")
+
+// Oturumdan kullanıcı kimliği okuyan fonksiyon; paket bağımlılığı döngüsü olmaması için configssession kaydeder
+var sessionUserID func(c *fiber.Ctx) (uint, bool)
+
+func RegisterSessionLookup(lookup func(c *fiber.Ctx) (uint, bool)) {
+	sessionUserID = lookup
+}
+
+func WithUserID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// Kimlik yoksa veya 0 ise false döner
+func UserID(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	userID, ok := ctx.Value(userIDKey).(uint)
+	return userID, ok && userID != 0
+}
+
+// Kimliği hem Locals'a hem de UserContext'e yazar
+func SetUserID(c *fiber.Ctx, userID uint) {
+	c.Locals(UserIDLocalsKey, userID)
+	c.SetUserContext(WithUserID(c.UserContext(), userID))
+}
+
+// Sırasıyla Locals, UserContext ve oturuma bakar
+func UserIDFromFiber(c *fiber.Ctx) (uint, bool) {
+	if userID, ok := c.Locals(UserIDLocalsKey).(uint); ok && userID != 0 {
+		return userID, true
+	}
+	if userID, ok := UserID(c.UserContext()); ok {
+		return userID, true
+	}
+	if sessionUserID != nil {
+		return sessionUserID(c)
+	}
+	return 0, false
+}
+
+// UserID etkin (bürünülen) kimliği taşır; gerçek kullanıcı ayrıca bu anahtarla tutulur
+func WithImpersonatorID(ctx context.Context, impersonatorID uint) context.Context {
+	return context.WithValue(ctx, impersonatorIDKey, impersonatorID)
+}
+
+// Kimliğe bürünme yoksa false döner
+func ImpersonatorID(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	impersonatorID, ok := ctx.Value(impersonatorIDKey).(uint)
+	return impersonatorID, ok && impersonatorID != 0
+}
+
+func SetImpersonatorID(c *fiber.Ctx, impersonatorID uint) {
+	c.Locals(ImpersonatorIDLocalsKey, impersonatorID)
+	c.SetUserContext(WithImpersonatorID(c.UserContext(), impersonatorID))
+}
+
+func ImpersonatorIDFromFiber(c *fiber.Ctx) (uint, bool) {
+	if impersonatorID, ok := c.Locals(ImpersonatorIDLocalsKey).(uint); ok && impersonatorID != 0 {
+		return impersonatorID, true
+	}
+	return ImpersonatorID(c.UserContext())
+}
+
+// Kiracı kapsamlı repository'ler kiracıyı buradan okur
+func WithTenantID(ctx context.Context, tenantID uint) context.Context {
+	return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+// Kiracı yoksa veya 0 ise false döner
+func TenantID(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	tenantID, ok := ctx.Value(tenantIDKey).(uint)
+	return tenantID, ok && tenantID != 0
+}
+
+func SetTenantID(c *fiber.Ctx, tenantID uint) {
+	c.Locals(TenantIDLocalsKey, tenantID)
+	c.SetUserContext(WithTenantID(c.UserContext(), tenantID))
+}
@@ -0,0 +1,14 @@
+package sessionstorage
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCounterContention = errors.New("sayaç eşzamanlı güncellemeler nedeniyle artırılamadı")
+
+// Giriş denemesi gibi sayaçlar içindir: oku-artır-yaz tek adımda yapılır ki eşzamanlı istekler aynı değeri görüp
+// sınırı aşamasın. Pencere ilk artırmada başlar; dönen zaman sayacın sıfırlanacağı andır.
+type Counter interface {
+	Increment(key string, window time.Duration) (int, time.Time, error)
+}
@@ -0,0 +1,78 @@
+package sessionstorage
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Eşzamanlı artırmalar 1..n arasındaki her değeri tam bir kez dönmelidir
+func assertAtomicIncrements(t *testing.T, counter Counter, n int) {
+	t.Helper()
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		counts []int
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count, resetAt, err := counter.Increment("deneme", time.Minute)
+			if err != nil {
+				t.Errorf("Increment: %v", err)
+				return
+			}
+			if until := time.Until(resetAt); until <= 0 || until > time.Minute+time.Second {
+				t.Errorf("sıfırlanma %v sonra, pencere 1 dakika", until)
+			}
+			mu.Lock()
+			counts = append(counts, count)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Ints(counts)
+	for i, count := range counts {
+		if count != i+1 {
+			t.Fatalf("dönen sayılar %v, 1..%d bekleniyordu", counts, n)
+		}
+	}
+}
+
+func TestRedisIncrementIsAtomicAndExpires(t *testing.T) {
+	store, server := newTestRedis(t, "app:")
+	assertAtomicIncrements(t, store, 20)
+
+	if ttl := server.TTL("app:deneme"); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("TTL = %v, pencere kadar bekleniyordu", ttl)
+	}
+	server.FastForward(time.Minute + time.Second)
+	if count, _, err := store.Increment("deneme", time.Minute); err != nil || count != 1 {
+		t.Errorf("pencere sonrası Increment = %d, %v; beklenen 1", count, err)
+	}
+}
+
+func TestDatabaseIncrementIsAtomicAndExpires(t *testing.T) {
+	store := newTestDatabase(t, "app:")
+	assertAtomicIncrements(t, store, 20)
+
+	if err := store.query().Where("id = ?", "app:deneme").Update("expires_at", time.Now().Add(-time.Second).Unix()).Error; err != nil {
+		t.Fatal(err)
+	}
+	count, resetAt, err := store.Increment("deneme", time.Minute)
+	if err != nil || count != 1 {
+		t.Fatalf("pencere sonrası Increment = %d, %v; beklenen 1", count, err)
+	}
+	if time.Until(resetAt) < 58*time.Second {
+		t.Errorf("yeni pencere %v sonra bitiyor", time.Until(resetAt))
+	}
+	if err := store.Delete("deneme"); err != nil {
+		t.Fatal(err)
+	}
+	if count, _, _ := store.Increment("deneme", time.Minute); count != 1 {
+		t.Errorf("silinen sayaç %d'den başladı", count)
+	}
+}
@@ -0,0 +1,183 @@
+package sessionstorage
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DatabaseConfig struct {
+	Table  string
+	Prefix string
+	// Süresi dolmuş kayıtların silinme sıklığı
+	GCInterval time.Duration
+}
+
+type storageRecord struct {
+	ID        string `gorm:"primaryKey;size:255"`
+	Data      []byte `gorm:"not null"`
+	ExpiresAt int64  `gorm:"not null;default:0;index"`
+}
+
+// fiber.Storage arayüzünü uygulamanın kendi veritabanı üzerinde uygular; küçük kurulumlarda ayrı bir Redis gerekmez
+type Database struct {
+	db        *gorm.DB
+	table     string
+	prefix    string
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewDatabase(db *gorm.DB, cfg DatabaseConfig) (*Database, error) {
+	if db == nil {
+		return nil, errors.New("veritabanı bağlantısı başlatılmamış")
+	}
+	if cfg.Table == "" {
+		cfg.Table = "sessions"
+	}
+	if cfg.GCInterval <= 0 {
+		cfg.GCInterval = 10 * time.Minute
+	}
+
+	if err := db.Table(cfg.Table).AutoMigrate(&storageRecord{}); err != nil {
+		return nil, err
+	}
+
+	s := &Database{db: db, table: cfg.Table, prefix: cfg.Prefix, done: make(chan struct{})}
+	go s.gcLoop(cfg.GCInterval)
+	return s, nil
+}
+
+func (s *Database) query() *gorm.DB {
+	return s.db.Table(s.table)
+}
+
+func (s *Database) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, nil
+	}
+	var record storageRecord
+	err := s.query().
+		Where("id = ? AND (expires_at = 0 OR expires_at > ?)", s.prefix+key, time.Now().Unix()).
+		Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return record.Data, nil
+}
+
+func (s *Database) Set(key string, value []byte, exp time.Duration) error {
+	if key == "" || len(value) == 0 {
+		return nil
+	}
+	var expiresAt int64
+	if exp > 0 {
+		expiresAt = time.Now().Add(exp).Unix()
+	}
+	record := storageRecord{ID: s.prefix + key, Data: value, ExpiresAt: expiresAt}
+	return s.query().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"data", "expires_at"}),
+		}).
+		Create(&record).Error
+}
+
+// Yarışı kaybeden güncelleme yeniden denenir; bu kadar denemede yazılamayan sayaç hata döner
+const maxIncrementAttempts = 10
+
+// Kayıt karşılaştır-ve-değiştir ile güncellenir: UPDATE yalnızca okunan değer hâlâ duruyorsa etkili olur.
+// Böylece kilit desteği olmayan SQLite dahil tüm sürücülerde ve birden çok sunucuda sayım kaybolmaz.
+func (s *Database) Increment(key string, window time.Duration) (int, time.Time, error) {
+	id := s.prefix + key
+	for attempt := 0; attempt < maxIncrementAttempts; attempt++ {
+		now := time.Now()
+		var record storageRecord
+		err := s.query().Where("id = ?", id).Take(&record).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			resetAt := now.Add(window)
+			result := s.query().
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&storageRecord{ID: id, Data: []byte("1"), ExpiresAt: resetAt.Unix()})
+			if result.Error != nil {
+				return 0, time.Time{}, result.Error
+			}
+			if result.RowsAffected == 1 {
+				return 1, resetAt, nil
+			}
+			continue
+		}
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+
+		count, resetAt := 0, time.Unix(record.ExpiresAt, 0)
+		if record.ExpiresAt > now.Unix() {
+			count, _ = strconv.Atoi(string(record.Data))
+		} else {
+			resetAt = now.Add(window)
+		}
+		count++
+		result := s.query().
+			Where("id = ? AND data = ? AND expires_at = ?", id, record.Data, record.ExpiresAt).
+			Updates(map[string]interface{}{"data": []byte(strconv.Itoa(count)), "expires_at": resetAt.Unix()})
+		if result.Error != nil {
+			return 0, time.Time{}, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return count, resetAt, nil
+		}
+	}
+	return 0, time.Time{}, ErrCounterContention
+}
+
+func (s *Database) Delete(key string) error {
+	if key == "" {
+		return nil
+	}
+	return s.query().Where("id = ?", s.prefix+key).Delete(&storageRecord{}).Error
+}
+
+func (s *Database) Reset() error {
+	if s.prefix == "" {
+		return s.query().Where("1 = 1").Delete(&storageRecord{}).Error
+	}
+	condition := "id LIKE ?"
+	// SQLite'ta LIKE için varsayılan kaçış karakteri yoktur
+	if s.db.Dialector.Name() == "sqlite" {
+		condition += ` ESCAPE '\'`
+	}
+	return s.query().Where(condition, escapeLike(s.prefix)+"%").Delete(&storageRecord{}).Error
+}
+
+func (s *Database) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	return nil
+}
+
+func (s *Database) gcLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.query().
+				Where("expires_at > 0 AND expires_at <= ?", time.Now().Unix()).
+				Delete(&storageRecord{})
+		}
+	}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,124 @@
+package sessionstorage
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisTimeout = 5 * time.Second
+
+type RedisConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	DB       int
+	TLS      bool
+	// Aynı Redis'i paylaşan uygulamaların anahtarları karışmasın diye her anahtarın önüne eklenir
+	Prefix string
+}
+
+// fiber.Storage arayüzünü Redis üzerinde uygular
+type Redis struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedis(cfg RedisConfig) (*Redis, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("redis host boş olamaz")
+	}
+
+	options := &redis.Options{
+		Addr:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Username: cfg.Username,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	}
+	if cfg.TLS {
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, ServerName: cfg.Host}
+	}
+
+	client := redis.NewClient(options)
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &Redis{client: client, prefix: cfg.Prefix}, nil
+}
+
+func (r *Redis) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	value, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return value, err
+}
+
+func (r *Redis) Set(key string, value []byte, exp time.Duration) error {
+	if key == "" || len(value) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	return r.client.Set(ctx, r.prefix+key, value, exp).Err()
+}
+
+func (r *Redis) Delete(key string) error {
+	if key == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	return r.client.Del(ctx, r.prefix+key).Err()
+}
+
+// INCR ve PEXPIRE aynı betikte çalışır; süresiz kalmış bir sayaç da pencereye bağlanır
+var incrementScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+func (r *Redis) Increment(key string, window time.Duration) (int, time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	values, err := incrementScript.Run(ctx, r.client, []string{r.prefix + key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return int(values[0]), time.Now().Add(time.Duration(values[1]) * time.Millisecond), nil
+}
+
+// Paylaşılan veritabanını boşaltmamak için yalnızca prefix'e sahip anahtarlar silinir
+func (r *Redis) Reset() error {
+	ctx := context.Background()
+	iter := r.client.Scan(ctx, 0, r.prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := r.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,203 @@
+package sessionstorage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"zatrano/pkg/testutil"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+func newTestRedis(t *testing.T, prefix string) (*Redis, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	port, err := strconv.Atoi(server.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewRedis(RedisConfig{Host: server.Host(), Port: port, Prefix: prefix})
+	if err != nil {
+		t.Fatalf("NewRedis: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store, server
+}
+
+func newTestDatabase(t *testing.T, prefix string) *Database {
+	t.Helper()
+	store, err := NewDatabase(testutil.NewDB(t), DatabaseConfig{Table: "sessions", Prefix: prefix})
+	if err != nil {
+		t.Fatalf("NewDatabase: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+// Oturum verisi fiber session store üzerinden yazılıp okunur ve yok edilir
+func TestSessionRoundTripThroughStorage(t *testing.T) {
+	redisStore, _ := newTestRedis(t, "app:")
+	backends := map[string]fiber.Storage{
+		"redis":      redisStore,
+		"veritabanı": newTestDatabase(t, "app:"),
+	}
+	for name, storage := range backends {
+		t.Run(name, func(t *testing.T) {
+			store := session.New(session.Config{Storage: storage, KeyLookup: "cookie:session_id"})
+			app := fiber.New()
+			app.Get("/set", func(c *fiber.Ctx) error {
+				sess, err := store.Get(c)
+				if err != nil {
+					return err
+				}
+				sess.Set("user_id", uint(7))
+				return sess.Save()
+			})
+			app.Get("/get", func(c *fiber.Ctx) error {
+				sess, err := store.Get(c)
+				if err != nil {
+					return err
+				}
+				userID, _ := sess.Get("user_id").(uint)
+				return c.SendString(strconv.FormatUint(uint64(userID), 10))
+			})
+			app.Get("/destroy", func(c *fiber.Ctx) error {
+				sess, err := store.Get(c)
+				if err != nil {
+					return err
+				}
+				return sess.Destroy()
+			})
+
+			get := func(path, cookie string) *http.Response {
+				req := httptest.NewRequest(fiber.MethodGet, path, nil)
+				if cookie != "" {
+					req.AddCookie(&http.Cookie{Name: "session_id", Value: cookie})
+				}
+				resp, err := app.Test(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return resp
+			}
+			readUserID := func(cookie string) string {
+				resp := get("/get", cookie)
+				buf := make([]byte, 16)
+				n, _ := resp.Body.Read(buf)
+				return string(buf[:n])
+			}
+
+			var sessionID string
+			for _, cookie := range get("/set", "").Cookies() {
+				if cookie.Name == "session_id" {
+					sessionID = cookie.Value
+				}
+			}
+			if sessionID == "" {
+				t.Fatal("oturum cookie'si yazılmadı")
+			}
+			if raw, err := storage.Get(sessionID); err != nil || raw == nil {
+				t.Fatalf("oturum storage'a yazılmadı: %v", err)
+			}
+			if got := readUserID(sessionID); got != "7" {
+				t.Errorf("okunan user_id %q, beklenen 7", got)
+			}
+
+			get("/destroy", sessionID)
+			if raw, err := storage.Get(sessionID); err != nil || raw != nil {
+				t.Errorf("yok edilen oturum storage'da kaldı: %v", err)
+			}
+			if got := readUserID(sessionID); got != "0" {
+				t.Errorf("yok edilen oturumdan user_id %q okundu", got)
+			}
+		})
+	}
+}
+
+func TestRedisPrefixAndExpiry(t *testing.T) {
+	store, server := newTestRedis(t, "app:")
+	if err := server.Set("baska:anahtar", "dokunma"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Set("oturum", []byte("veri"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if !server.Exists("app:oturum") {
+		t.Fatal("anahtar prefix ile yazılmadı")
+	}
+	if ttl := server.TTL("app:oturum"); ttl != time.Minute {
+		t.Errorf("TTL %v, beklenen 1m", ttl)
+	}
+
+	server.FastForward(2 * time.Minute)
+	if raw, err := store.Get("oturum"); err != nil || raw != nil {
+		t.Errorf("süresi dolmuş oturum okundu: %q, %v", raw, err)
+	}
+
+	if err := store.Set("oturum", []byte("veri"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if server.Exists("app:oturum") {
+		t.Error("Reset prefix'li anahtarı silmedi")
+	}
+	if !server.Exists("baska:anahtar") {
+		t.Error("Reset başka uygulamanın anahtarını sildi")
+	}
+}
+
+func TestNewRedisFailsWithoutServer(t *testing.T) {
+	server := miniredis.RunT(t)
+	port, _ := strconv.Atoi(server.Port())
+	server.Close()
+
+	if _, err := NewRedis(RedisConfig{Host: "127.0.0.1", Port: port}); err == nil {
+		t.Error("kapalı sunucuya bağlantı hata vermedi")
+	}
+	if _, err := NewRedis(RedisConfig{}); err == nil {
+		t.Error("boş host kabul edildi")
+	}
+}
+
+func TestDatabasePrefixAndExpiry(t *testing.T) {
+	store := newTestDatabase(t, "a_")
+	other, err := NewDatabase(store.db, DatabaseConfig{Table: "sessions", Prefix: "ab"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = other.Close() })
+
+	if err := store.Set("kisa", []byte("veri"), time.Nanosecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("kalici", []byte("veri"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Set("kalici", []byte("diger"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	if raw, err := store.Get("kisa"); err != nil || raw != nil {
+		t.Errorf("süresi dolmuş oturum okundu: %q, %v", raw, err)
+	}
+
+	// "_" LIKE joker karakteri olarak yorumlanırsa "ab" önekli kayıt da silinir
+	if err := store.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if raw, _ := store.Get("kalici"); raw != nil {
+		t.Error("Reset prefix'li kaydı silmedi")
+	}
+	if raw, _ := other.Get("kalici"); string(raw) != "diger" {
+		t.Errorf("Reset başka prefix'in kaydını sildi: %q", raw)
+	}
+}
@@ -0,0 +1,80 @@
+package templatehelpers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "02.01.2006"
+	dateTimeLayout = "02.01.2006 15:04"
+)
+
+var displayLocation = time.Local
+
+// Tarihler bu bölgeye çevrilerek yazılır; başlangıçta bir kez ayarlanır, nil sürecin yerel saat dilimine döner
+func SetLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+	displayLocation = loc
+}
+
+// time.Time ya da *time.Time kabul eder; nil ve sıfır zaman boş metin döner
+func formatTimeValue(value any, layout string) string {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		t = *v
+	default:
+		return ""
+	}
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(displayLocation).Format(layout)
+}
+
+func formatDate(value any) string {
+	return formatTimeValue(value, dateLayout)
+}
+
+func formatDateTime(value any) string {
+	return formatTimeValue(value, dateTimeLayout)
+}
+
+type Badge struct {
+	Label string
+	Class string
+}
+
+var statusBadges = map[string]Badge{
+	"true":     {Label: "Aktif", Class: "text-bg-success"},
+	"false":    {Label: "Pasif", Class: "text-bg-secondary"},
+	"active":   {Label: "Aktif", Class: "text-bg-success"},
+	"inactive": {Label: "Pasif", Class: "text-bg-secondary"},
+	"locked":   {Label: "Kilitli", Class: "text-bg-danger"},
+	"pending":  {Label: "Beklemede", Class: "text-bg-warning"},
+	"expired":  {Label: "Süresi dolmuş", Class: "text-bg-secondary"},
+	"revoked":  {Label: "İptal edilmiş", Class: "text-bg-danger"},
+	"queued":   {Label: "Sırada", Class: "text-bg-secondary"},
+	"running":  {Label: "Çalışıyor", Class: "text-bg-info"},
+	"done":     {Label: "Tamamlandı", Class: "text-bg-success"},
+	"failed":   {Label: "Başarısız", Class: "text-bg-danger"},
+}
+
+// {{with statusBadge .Status}}<span class="badge {{.Class}}">{{.Label}}</span>{{end}};
+// bilinmeyen değerler kendi metniyle nötr rozet olarak gösterilir
+func statusBadge(value any) Badge {
+	key := strings.ToLower(strings.TrimSpace(fmt.Sprint(value)))
+	if badge, ok := statusBadges[key]; ok {
+		return badge
+	}
+	return Badge{Label: fmt.Sprint(value), Class: "text-bg-light"}
+}
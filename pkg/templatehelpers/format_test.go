@@ -0,0 +1,57 @@
+package templatehelpers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDateUsesDisplayLocation(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetLocation(istanbul)
+	t.Cleanup(func() { SetLocation(nil) })
+
+	// 21:30 UTC İstanbul'da ertesi günün 00:30'udur
+	utc := time.Date(2024, 5, 1, 21, 30, 0, 0, time.UTC)
+	if got := formatDate(utc); got != "02.05.2024" {
+		t.Errorf("formatDate = %q, beklenen 02.05.2024", got)
+	}
+	if got := formatDateTime(&utc); got != "02.05.2024 00:30" {
+		t.Errorf("formatDateTime = %q, beklenen 02.05.2024 00:30", got)
+	}
+}
+
+func TestFormatDateHandlesEmptyValues(t *testing.T) {
+	var nilTime *time.Time
+	zero := time.Time{}
+	for name, value := range map[string]any{
+		"nil işaretçi":       nilTime,
+		"sıfır zaman":        zero,
+		"sıfır işaretçi":     &zero,
+		"desteklenmeyen tür": "2024-05-01",
+		"nil":                nil,
+	} {
+		if got := formatDateTime(value); got != "" {
+			t.Errorf("%s: %q, beklenen boş", name, got)
+		}
+	}
+}
+
+func TestStatusBadge(t *testing.T) {
+	tests := []struct {
+		value any
+		want  Badge
+	}{
+		{true, Badge{Label: "Aktif", Class: "text-bg-success"}},
+		{false, Badge{Label: "Pasif", Class: "text-bg-secondary"}},
+		{" FAILED ", Badge{Label: "Başarısız", Class: "text-bg-danger"}},
+		{"arşiv", Badge{Label: "arşiv", Class: "text-bg-light"}},
+	}
+	for _, tt := range tests {
+		if got := statusBadge(tt.value); got != tt.want {
+			t.Errorf("statusBadge(%v) = %+v, beklenen %+v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package templatehelpers
 
 import (
+	htmltemplate "html/template"
 	"net/url"
 	"text/template"
 	"time"
+
+	"zatrano/pkg/authz"
+	"zatrano/pkg/i18n"
 )
 
 func TemplateHelpers() template.FuncMap {
@@ -35,7 +39,15 @@ func TemplateHelpers() template.FuncMap {
 			}
 			return items
 		},
-		"urlquery": func(s string) string { return url.QueryEscape(s) },
+		"PageWindow":  pageWindow,
+		"paginate":    paginate,
+		"statusBadge": statusBadge,
+		"old":         oldInput,
+		"t":           i18n.Translate,
+		"can":         authz.Can,
+		"perms":       perms,
+		"csrfMeta":    csrfMeta,
+		"urlquery":    func(s string) string { return url.QueryEscape(s) },
 		"dict": func(values ...interface{}) map[string]interface{} {
 			dict := make(map[string]interface{})
 			if len(values)%2 != 0 {
@@ -58,19 +70,60 @@ func TemplateHelpers() template.FuncMap {
 			return t.Format(layout)
 		},
 
-		"FormatDate": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
-			}
-			return t.Format("02.01.2006")
-		},
-
-		"FormatDateTime": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
-			}
-			return t.Format("02.01.2006 15:04")
-		},
+		"formatDate":     formatDate,
+		"formatDateTime": formatDateTime,
 	}
 	return fm
 }
+
+// 1 … 4 5 6 … 20 biçimindeki sayfa listesini üretir; 0 değerleri atlanan aralığı temsil eder
+func pageWindow(current, total int) []int {
+	const window = 2
+	if total <= 0 {
+		return []int{}
+	}
+
+	start := current - window
+	if start < 1 {
+		start = 1
+	}
+	end := current + window
+	if end > total {
+		end = total
+	}
+
+	pages := make([]int, 0, 2*window+5)
+	if start > 1 {
+		pages = append(pages, 1)
+		if start > 2 {
+			pages = append(pages, 0)
+		}
+	}
+	for i := start; i <= end; i++ {
+		pages = append(pages, i)
+	}
+	if end < total {
+		if end < total-1 {
+			pages = append(pages, 0)
+		}
+		pages = append(pages, total)
+	}
+	return pages
+}
+
+// {{old .OldInput "alan" "varsayılan"}}: önceki başarısız gönderimde girilen değeri ya da varsayılanı döner
+func oldInput(input map[string]string, field string, defaultValue ...string) string {
+	if value, ok := input[field]; ok {
+		return value
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return ""
+}
+
+// Ön yüz JS'inin okuyup X-CSRF-Token başlığıyla göndermesi için <meta name="csrf-token"> etiketi üretir
+func csrfMeta(token any) htmltemplate.HTML {
+	value, _ := token.(string)
+	return htmltemplate.HTML(`<meta name="csrf-token" content="` + htmltemplate.HTMLEscapeString(value) + `">`)
+}
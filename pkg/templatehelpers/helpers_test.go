@@ -0,0 +1,19 @@
+package templatehelpers
+
+import "testing"
+
+func TestCSRFMetaEscapesToken(t *testing.T) {
+	tests := []struct {
+		token any
+		want  string
+	}{
+		{"abc-123", `<meta name="csrf-token" content="abc-123">`},
+		{`"><script>`, `<meta name="csrf-token" content="&#34;&gt;&lt;script&gt;">`},
+		{nil, `<meta name="csrf-token" content="">`},
+	}
+	for _, tt := range tests {
+		if got := string(csrfMeta(tt.token)); got != tt.want {
+			t.Errorf("csrfMeta(%v) = %s, beklenen %s", tt.token, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package templatehelpers
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Şablonun tek başına çizebileceği sayfalama yapısı; URL'ler mevcut filtre ve sıralamayı korur
+type Pagination struct {
+	Page       int
+	TotalPages int
+	TotalCount int64
+	FirstItem  int64
+	LastItem   int64
+	Prev       *PageLink
+	Next       *PageLink
+	Links      []PageLink
+}
+
+// Gap true ise bağlantı değil atlanan aralığı gösteren "…" öğesidir
+type PageLink struct {
+	Number int
+	URL    string
+	Active bool
+	Gap    bool
+}
+
+// {{with paginate .Result.TotalCount .Result.Page .Result.PerPage .QueryString}}: query string'deki
+// page dışındaki tüm parametreler korunur; sayfa aralık dışındaysa ilk/son sayfaya çekilir
+func paginate(total int64, page, perPage int, query string) Pagination {
+	if perPage <= 0 {
+		perPage = 1
+	}
+	totalPages := int((total + int64(perPage) - 1) / int64(perPage))
+	if page < 1 {
+		page = 1
+	}
+	if totalPages > 0 && page > totalPages {
+		page = totalPages
+	}
+
+	p := Pagination{Page: page, TotalPages: totalPages, TotalCount: total}
+	if total > 0 {
+		p.FirstItem = int64(page-1)*int64(perPage) + 1
+		p.LastItem = min(int64(page)*int64(perPage), total)
+	}
+	if totalPages == 0 {
+		return p
+	}
+
+	values, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		values = url.Values{}
+	}
+	pageURL := func(n int) string {
+		values.Set("page", strconv.Itoa(n))
+		return "?" + values.Encode()
+	}
+
+	if page > 1 {
+		p.Prev = &PageLink{Number: page - 1, URL: pageURL(page - 1)}
+	}
+	if page < totalPages {
+		p.Next = &PageLink{Number: page + 1, URL: pageURL(page + 1)}
+	}
+	for _, n := range pageWindow(page, totalPages) {
+		if n == 0 {
+			p.Links = append(p.Links, PageLink{Gap: true})
+			continue
+		}
+		p.Links = append(p.Links, PageLink{Number: n, URL: pageURL(n), Active: n == page})
+	}
+	return p
+}
@@ -0,0 +1,113 @@
+package templatehelpers
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func linkNumbers(p Pagination) []int {
+	numbers := make([]int, 0, len(p.Links))
+	for _, link := range p.Links {
+		numbers = append(numbers, link.Number)
+	}
+	return numbers
+}
+
+func TestPaginateWindowEdges(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       int
+		want       []int
+		prev, next bool
+	}{
+		{name: "ilk sayfa", page: 1, want: []int{1, 2, 3, 0, 10}, next: true},
+		{name: "ikinci sayfa boşluk bırakmaz", page: 3, want: []int{1, 2, 3, 4, 5, 0, 10}, prev: true, next: true},
+		{name: "orta sayfa", page: 5, want: []int{1, 0, 3, 4, 5, 6, 7, 0, 10}, prev: true, next: true},
+		{name: "son sayfa", page: 10, want: []int{1, 0, 8, 9, 10}, prev: true},
+		{name: "aralık dışı sayfa son sayfaya çekilir", page: 99, want: []int{1, 0, 8, 9, 10}, prev: true},
+		{name: "negatif sayfa ilk sayfaya çekilir", page: -2, want: []int{1, 2, 3, 0, 10}, next: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := paginate(200, tt.page, 20, "")
+			if got := linkNumbers(p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bağlantılar = %v, beklenen %v", got, tt.want)
+			}
+			if (p.Prev != nil) != tt.prev || (p.Next != nil) != tt.next {
+				t.Errorf("prev = %v, next = %v", p.Prev != nil, p.Next != nil)
+			}
+			for _, link := range p.Links {
+				if link.Gap != (link.Number == 0) {
+					t.Errorf("boşluk öğesi yanlış işaretlendi: %+v", link)
+				}
+				if link.Active != (link.Number == p.Page) {
+					t.Errorf("etkin sayfa yanlış: %+v (sayfa %d)", link, p.Page)
+				}
+			}
+		})
+	}
+}
+
+func TestPaginateItemRange(t *testing.T) {
+	p := paginate(45, 3, 20, "")
+	if p.TotalPages != 3 || p.FirstItem != 41 || p.LastItem != 45 {
+		t.Errorf("son sayfa aralığı = %d-%d / %d sayfa", p.FirstItem, p.LastItem, p.TotalPages)
+	}
+
+	empty := paginate(0, 1, 20, "page=1")
+	if empty.TotalPages != 0 || empty.FirstItem != 0 || empty.Links != nil || empty.Prev != nil || empty.Next != nil {
+		t.Errorf("boş sonuç: %+v", empty)
+	}
+
+	single := paginate(5, 1, 0, "")
+	if single.TotalPages != 5 {
+		t.Errorf("perPage 0 için toplam sayfa = %d, beklenen 5", single.TotalPages)
+	}
+}
+
+func TestPaginatePreservesQuery(t *testing.T) {
+	query := "?q=%C3%A7i%C4%9Fdem+y&type=panel&sortBy=name&orderBy=asc&perPage=20&page=4&filter%5Bstatus%5D%5Beq%5D=true"
+	p := paginate(200, 4, 20, query)
+
+	for _, link := range append(p.Links, *p.Prev, *p.Next) {
+		if link.Gap {
+			continue
+		}
+		if link.URL[0] != '?' {
+			t.Fatalf("URL ? ile başlamıyor: %q", link.URL)
+		}
+		values, err := url.ParseQuery(link.URL[1:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, want := range map[string]string{
+			"q":                  "çiğdem y",
+			"type":               "panel",
+			"sortBy":             "name",
+			"orderBy":            "asc",
+			"perPage":            "20",
+			"filter[status][eq]": "true",
+		} {
+			if got := values.Get(key); got != want {
+				t.Errorf("%s: %s = %q, beklenen %q", link.URL, key, got, want)
+			}
+		}
+		if pages := values["page"]; len(pages) != 1 {
+			t.Errorf("%s: page %d kez yazılmış", link.URL, len(pages))
+		}
+	}
+	if p.Prev.URL == p.Next.URL {
+		t.Error("önceki ve sonraki bağlantılar aynı")
+	}
+	if got, _ := url.ParseQuery(p.Next.URL[1:]); got.Get("page") != "5" {
+		t.Errorf("sonraki sayfa = %q, beklenen 5", got.Get("page"))
+	}
+}
+
+func TestPaginateIgnoresMalformedQuery(t *testing.T) {
+	p := paginate(50, 1, 20, "q=%zz")
+	if p.Next == nil || p.Next.URL != "?page=2" {
+		t.Errorf("bozuk query string ile sonraki = %+v", p.Next)
+	}
+}
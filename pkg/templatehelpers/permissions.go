@@ -0,0 +1,31 @@
+package templatehelpers
+
+import "zatrano/models"
+
+// Şablonlar izin adlarını elle yazmaz, models sabitlerini buradan okur: {{if can .Permissions (perms).UsersDelete}}.
+// Yanlış yazılan alan adı sessizce false dönmez, şablon çalıştırılırken hata verir.
+type permissionNames struct {
+	UsersView        string
+	UsersCreate      string
+	UsersUpdate      string
+	UsersDelete      string
+	UsersImpersonate string
+	AuditView        string
+	RolesManage      string
+	JobsView         string
+}
+
+var permissions = permissionNames{
+	UsersView:        models.PermUsersView,
+	UsersCreate:      models.PermUsersCreate,
+	UsersUpdate:      models.PermUsersUpdate,
+	UsersDelete:      models.PermUsersDelete,
+	UsersImpersonate: models.PermUsersImpersonate,
+	AuditView:        models.PermAuditView,
+	RolesManage:      models.PermRolesManage,
+	JobsView:         models.PermJobsView,
+}
+
+func perms() permissionNames {
+	return permissions
+}
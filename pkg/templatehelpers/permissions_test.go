@@ -0,0 +1,36 @@
+package templatehelpers
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/authz"
+)
+
+func renderPermission(t *testing.T, field string, set authz.Set) (string, error) {
+	t.Helper()
+	tmpl := template.Must(template.New("t").Funcs(template.FuncMap(TemplateHelpers())).
+		Parse(`{{if can .Permissions (perms).` + field + `}}göster{{else}}gizle{{end}}`))
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]interface{}{"Permissions": set})
+	return b.String(), err
+}
+
+func TestPermsUsesModelConstants(t *testing.T) {
+	set := authz.NewSet([]string{models.PermUsersDelete})
+
+	if out, err := renderPermission(t, "UsersDelete", set); err != nil || out != "göster" {
+		t.Errorf("UsersDelete = %q, %v; beklenen göster", out, err)
+	}
+	if out, err := renderPermission(t, "RolesManage", set); err != nil || out != "gizle" {
+		t.Errorf("RolesManage = %q, %v; beklenen gizle", out, err)
+	}
+}
+
+func TestPermsRejectsUnknownName(t *testing.T) {
+	if _, err := renderPermission(t, "UsersDestroy", authz.Set{}); err == nil {
+		t.Error("tanımsız izin adı hata vermedi")
+	}
+}
@@ -0,0 +1,77 @@
+package templatehelpers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+
+	"github.com/gofiber/template/html/v2"
+)
+
+// Sayfalama parçası tüm liste şablonlarında paylaşılır; denetim listesi daha önce
+// Paginated'ı doğrudan parçaya verip boş bağlantılar üretiyordu
+func TestAuditListRendersSharedPagination(t *testing.T) {
+	engine := html.New("../../views", ".html")
+	engine.AddFuncMap(TemplateHelpers())
+	if err := engine.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := []models.AuditLog{{ID: 41, Action: "update", CreatedAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}}
+	result := queryparams.NewPaginated(logs, 45, queryparams.ListParams{Page: 3, PerPage: 20})
+
+	var b strings.Builder
+	err := engine.Render(&b, "dashboard/audit/list", map[string]interface{}{
+		"Title":       "İşlem Geçmişi",
+		"EntityType":  "User",
+		"EntityID":    7,
+		"Result":      result,
+		"QueryString": "perPage=20&page=3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{`href="?page=2&amp;perPage=20"`, `href="?page=1&amp;perPage=20"`, `aria-label="Sayfalama"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("çıktıda %s yok", want)
+		}
+	}
+	if !strings.Contains(out, `href="#" aria-label="Sonraki"`) {
+		t.Error("son sayfada sonraki bağlantısı devre dışı değil")
+	}
+}
+
+func TestProfileShowsLoginHistory(t *testing.T) {
+	engine := html.New("../../views", ".html")
+	engine.AddFuncMap(TemplateHelpers())
+	if err := engine.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	last := time.Date(2024, 5, 2, 8, 15, 0, 0, time.UTC)
+	previous := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		user models.User
+		want []string
+	}{
+		{"ilk giriş", models.User{}, []string{"Son giriş: -", "Önceki giriş: -"}},
+		{"sonraki giriş", models.User{LastLoginAt: &last, LastLoginIP: "10.0.0.1", PreviousLoginAt: &previous},
+			[]string{"Son giriş: " + formatDateTime(&last) + " (10.0.0.1)", "Önceki giriş: " + formatDateTime(&previous)}},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := engine.Render(&b, "auth/profile", map[string]interface{}{"User": tt.user, "FieldErrors": map[string]string{}, "OldInput": map[string]string{}}); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(b.String(), want) {
+				t.Errorf("%s: çıktıda %q yok", tt.name, want)
+			}
+		}
+	}
+}
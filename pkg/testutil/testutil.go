@@ -0,0 +1,69 @@
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configslog"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var setupOnce sync.Once
+
+// Yapılandırma ortamdan değil sabit test değerlerinden yüklenir; loglar susturulur
+func Setup() {
+	setupOnce.Do(func() {
+		for key, value := range map[string]string{
+			"APP_ENV":      "development",
+			"DB_DRIVER":    "sqlite",
+			"DB_DATABASE":  ":memory:",
+			"DB_LOG_LEVEL": "silent",
+			"APP_TIMEZONE": "Europe/Istanbul",
+		} {
+			os.Setenv(key, value)
+		}
+		configsapp.Get()
+		configslog.Log = zap.NewNop()
+		configslog.SLog = configslog.Log.Sugar()
+	})
+}
+
+// Test başına ayrı bir sqlite dosyası açar, verilen modelleri migrate eder ve configsdatabase.DB'ye bağlar.
+// Test bitince önceki bağlantı geri yüklenir. sqlite sürücüsü CGO gerektirir (CGO_ENABLED=1 ve gcc).
+func NewDB(t testing.TB, models ...interface{}) *gorm.DB {
+	t.Helper()
+	Setup()
+
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:  logger.Discard,
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
+	if err != nil {
+		t.Fatalf("sqlite açılamadı: %v", err)
+	}
+	if len(models) > 0 {
+		if err := db.AutoMigrate(models...); err != nil {
+			t.Fatalf("migrate başarısız: %v", err)
+		}
+	}
+
+	previous := configsdatabase.DB
+	configsdatabase.DB = db
+	t.Cleanup(func() {
+		configsdatabase.DB = previous
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
@@ -2,14 +2,37 @@ package turkishsearch
 
 import (
 	"strings"
+	"sync/atomic"
 	"unicode"
 )
 
+// Veritabanı tarafında normalize ile aynı katlamayı yapmak için translate() eşlemesi
+const (
+	sqlFoldFrom = "İIıŞşĞğÇçÖöÜü"
+	sqlFoldTo   = "iiissggccoouu"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+var unaccentEnabled atomic.Bool
+
+// Postgres'te unaccent eklentisi kuruluysa açılır; Türkçe dışındaki aksanlı harfler (é, ñ) de katlanır
+func SetUnaccent(enabled bool) {
+	unaccentEnabled.Store(enabled)
+}
+
+// Dialect GORM sürücü adıdır (postgres, mysql, sqlite); boş değer postgres kabul edilir
+const (
+	dialectMySQL  = "mysql"
+	dialectSQLite = "sqlite"
+)
+
 func normalize(str string) string {
 	replacements := map[rune]rune{
 		'ç': 'c', 'Ç': 'C',
 		'ğ': 'g', 'Ğ': 'G',
 		'ı': 'i', 'I': 'I',
+		'İ': 'i',
 		'ö': 'o', 'Ö': 'O',
 		'ş': 's', 'Ş': 'S',
 		'ü': 'u', 'Ü': 'U',
@@ -32,12 +55,74 @@ func MatchNormalized(text, keyword string) bool {
 	return strings.Contains(normText, normKeyword)
 }
 
-func SQLFilter(columnName, search string) (string, []interface{}) {
-	filterValue := "%" + strings.ToLower(search) + "%"
+// Postgres'te unaccent eklentisi varsa onu da kullanır; yoksa ve diğer sürücülerde Türkçe katlamalı LIKE'a düşer
+func SQLFilter(dialect, columnName, search string) (string, []interface{}) {
+	return likeFragment(dialect, columnName), []interface{}{"%" + likeEscaper.Replace(normalize(search)) + "%"}
+}
+
+func isPostgres(dialect string) bool {
+	return dialect != dialectMySQL && dialect != dialectSQLite
+}
+
+// unaccent Türkçe ı'yı katlamadığından önce translate eşlemesi, sonra unaccent uygulanır
+func likeFragment(dialect, columnName string) string {
+	if isPostgres(dialect) && unaccentEnabled.Load() {
+		return "unaccent(" + foldColumn(dialect, columnName) + ") LIKE unaccent(?)"
+	}
+	return foldColumn(dialect, columnName) + " LIKE ?" + likeEscape(dialect)
+}
+
+// MySQL ve SQLite'ta translate() olmadığından aynı eşleme iç içe REPLACE ile yapılır
+func foldColumn(dialect, columnName string) string {
+	if isPostgres(dialect) {
+		return "lower(translate(" + columnName + ", '" + sqlFoldFrom + "', '" + sqlFoldTo + "'))"
+	}
+	expr := columnName
+	to := []rune(sqlFoldTo)
+	for i, from := range []rune(sqlFoldFrom) {
+		expr = "REPLACE(" + expr + ", '" + string(from) + "', '" + string(to[i]) + "')"
+	}
+	return "lower(" + expr + ")"
+}
+
+// SQLite'ta LIKE için varsayılan kaçış karakteri yoktur
+func likeEscape(dialect string) string {
+	if dialect == dialectSQLite {
+		return ` ESCAPE '\'`
+	}
+	return ""
+}
+
+func terms(search string) []string {
+	fields := strings.Fields(search)
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, "%"+likeEscaper.Replace(normalize(field))+"%")
+	}
+	return result
+}
 
-	query := "unaccent(lower(" + columnName + ")) ILIKE unaccent($1)"
+// Aramayı boşluklardan böler; her terim kolonda herhangi bir sırada geçmelidir
+func SQLFilterAll(dialect, columnName, search string) (string, []interface{}) {
+	return SQLFilterAllColumns(dialect, []string{columnName}, search)
+}
 
-	params := []interface{}{filterValue}
+// Her terim verilen kolonlardan en az birinde geçmelidir (terimler AND, kolonlar OR)
+func SQLFilterAllColumns(dialect string, columnNames []string, search string) (string, []interface{}) {
+	searchTerms := terms(search)
+	if len(searchTerms) == 0 || len(columnNames) == 0 {
+		return "1 = 1", nil
+	}
 
-	return query, params
+	termFragments := make([]string, 0, len(searchTerms))
+	var params []interface{}
+	for _, term := range searchTerms {
+		columnFragments := make([]string, 0, len(columnNames))
+		for _, columnName := range columnNames {
+			columnFragments = append(columnFragments, likeFragment(dialect, columnName))
+			params = append(params, term)
+		}
+		termFragments = append(termFragments, "("+strings.Join(columnFragments, " OR ")+")")
+	}
+	return strings.Join(termFragments, " AND "), params
 }
@@ -0,0 +1,70 @@
+package turkishsearch_test
+
+import (
+	"strings"
+	"testing"
+
+	"zatrano/pkg/testutil"
+	"zatrano/pkg/turkishsearch"
+)
+
+type city struct {
+	ID   uint `gorm:"primarykey"`
+	Name string
+}
+
+func TestSQLFilterAllFoldsTurkishLetters(t *testing.T) {
+	db := testutil.NewDB(t, &city{})
+	for _, name := range []string{"İSTANBUL Şişli", "Iğdır Çarşı", "Ankara Ümitköy", "Muğla Ortaca"} {
+		if err := db.Create(&city{Name: name}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := map[string][]string{
+		"istanbul şişli": {"İSTANBUL Şişli"},
+		"şİşli İstanbul": {"İSTANBUL Şişli"},
+		"igdir carsi":    {"Iğdır Çarşı"},
+		"ÜMİTKÖY":        {"Ankara Ümitköy"},
+		"MUĞLA":          {"Muğla Ortaca"},
+		"ankara muğla":   nil,
+	}
+	for search, want := range cases {
+		fragment, args := turkishsearch.SQLFilterAll("sqlite", "name", search)
+		var got []city
+		if err := db.Where(fragment, args...).Order("id").Find(&got).Error; err != nil {
+			t.Fatalf("%q: %v", search, err)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%q: %d kayıt, beklenen %v", search, len(got), want)
+			continue
+		}
+		for i := range want {
+			if got[i].Name != want[i] {
+				t.Errorf("%q: %q, beklenen %q", search, got[i].Name, want[i])
+			}
+		}
+	}
+}
+
+func TestPostgresUsesUnaccentOnlyWhenAvailable(t *testing.T) {
+	t.Cleanup(func() { turkishsearch.SetUnaccent(false) })
+
+	fragment, _ := turkishsearch.SQLFilterAll("postgres", "name", "josé")
+	if strings.Contains(fragment, "unaccent") || !strings.Contains(fragment, "translate(") {
+		t.Errorf("eklentisiz parça = %q; translate katlaması beklenir", fragment)
+	}
+
+	turkishsearch.SetUnaccent(true)
+	fragment, args := turkishsearch.SQLFilterAll("postgres", "name", "josé")
+	if !strings.Contains(fragment, "unaccent(lower(translate(") || !strings.Contains(fragment, "unaccent(?)") {
+		t.Errorf("eklentili parça = %q; translate sonrası unaccent beklenir", fragment)
+	}
+	if len(args) != 1 || args[0] != "%josé%" {
+		t.Errorf("argümanlar = %v", args)
+	}
+
+	if fragment, _ := turkishsearch.SQLFilterAll("mysql", "name", "josé"); strings.Contains(fragment, "unaccent") {
+		t.Errorf("MySQL parçası unaccent içermemeli: %q", fragment)
+	}
+}
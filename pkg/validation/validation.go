@@ -0,0 +1,60 @@
+package validation
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type Errors map[string]string
+
+type Validator struct {
+	errors Errors
+}
+
+func New() *Validator {
+	return &Validator{errors: Errors{}}
+}
+
+// Alan başına yalnızca ilk hata tutulur
+func (v *Validator) Check(ok bool, field string, message string) {
+	if ok {
+		return
+	}
+	if _, exists := v.errors[field]; !exists {
+		v.errors[field] = message
+	}
+}
+
+func (v *Validator) Required(field string, value string) {
+	v.Check(strings.TrimSpace(value) != "", field, "Bu alan zorunludur.")
+}
+
+func (v *Validator) MinLength(field string, value string, min int) {
+	v.Check(utf8.RuneCountInString(value) >= min, field, "En az "+strconv.Itoa(min)+" karakter olmalıdır.")
+}
+
+func (v *Validator) MaxLength(field string, value string, max int) {
+	v.Check(utf8.RuneCountInString(value) <= max, field, "En fazla "+strconv.Itoa(max)+" karakter olabilir.")
+}
+
+func (v *Validator) Matches(field string, value string, other string, message string) {
+	v.Check(value == other, field, message)
+}
+
+func (v *Validator) In(field string, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.Check(false, field, "Geçersiz bir değer seçildi.")
+}
+
+func (v *Validator) Valid() bool {
+	return len(v.errors) == 0
+}
+
+func (v *Validator) Errors() Errors {
+	return v.errors
+}
@@ -0,0 +1,34 @@
+// CSRF token'ı layout'taki <meta name="csrf-token"> etiketinden okunur.
+// Aynı kökene giden GET dışı fetch isteklerine X-CSRF-Token başlığı otomatik eklenir;
+// XMLHttpRequest kullanan kodlar window.csrfToken() ile değeri alabilir.
+(function () {
+  window.csrfToken = function () {
+    const meta = document.querySelector('meta[name="csrf-token"]');
+    return meta ? meta.getAttribute('content') : '';
+  };
+
+  const safeMethods = ['GET', 'HEAD', 'OPTIONS', 'TRACE'];
+  const originalFetch = window.fetch.bind(window);
+
+  window.fetch = function (input, init) {
+    init = init || {};
+    const request = input instanceof Request ? input : null;
+    const method = (init.method || (request && request.method) || 'GET').toUpperCase();
+    const url = new URL(request ? request.url : input, window.location.href);
+
+    if (safeMethods.includes(method) || url.origin !== window.location.origin) {
+      return originalFetch(input, init);
+    }
+
+    const headers = new Headers(init.headers || (request && request.headers) || {});
+    const token = window.csrfToken();
+    if (token && !headers.has('X-CSRF-Token')) {
+      headers.set('X-CSRF-Token', token);
+    }
+    if (!headers.has('X-Requested-With')) {
+      headers.set('X-Requested-With', 'XMLHttpRequest');
+    }
+    init.headers = headers;
+    return originalFetch(input, init);
+  };
+})();
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"net/http"
+
+	"zatrano/pkg/apierrors"
+	"zatrano/pkg/queryparams"
+)
+
+// JSON hata yanıtlarında repository hatalarının HTTP durum karşılıkları
+func init() {
+	apierrors.Register(http.StatusNotFound, ErrNotFound)
+	apierrors.Register(http.StatusConflict, ErrVersionConflict)
+	// queryparams saf bir pakettir; filtre hatası onu tüketen katmanda kaydedilir
+	apierrors.Register(http.StatusUnprocessableEntity,
+		ErrFilterNotAllowed, ErrColumnNotAllowed, ErrProtectedColumn, queryparams.ErrInvalidFilter)
+	apierrors.Register(http.StatusServiceUnavailable, ErrQueryTimeout)
+}
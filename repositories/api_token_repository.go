@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IAPITokenRepository interface {
+	Create(token *models.APIToken) error
+	FindByHash(tokenHash string) (*models.APIToken, error)
+	ListForUser(userID uint) ([]models.APIToken, error)
+	DeleteForUser(userID uint, id uint) error
+	DeleteAllForUser(userID uint) (int64, error)
+	Touch(id uint, at time.Time) error
+}
+
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository() IAPITokenRepository {
+	return &APITokenRepository{db: configsdatabase.GetDB()}
+}
+
+func (r *APITokenRepository) Create(token *models.APIToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *APITokenRepository) FindByHash(tokenHash string) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *APITokenRepository) ListForUser(userID uint) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *APITokenRepository) DeleteForUser(userID uint, id uint) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.APIToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *APITokenRepository) DeleteAllForUser(userID uint) (int64, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&models.APIToken{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *APITokenRepository) Touch(id uint, at time.Time) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const redactedAuditValue = "***"
+
+// Diff'e yazılmayan, her güncellemede zaten değişen kolonlar
+var auditIgnoredColumns = map[string]bool{
+	"updated_at": true,
+	"updated_by": true,
+}
+
+type auditChange struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new"`
+}
+
+type auditActionKey struct{}
+
+// Güncellemenin denetim kaydı genel "update" yerine verilen eylemle yazılır (ör. pasifleştirme);
+// ayrıca bir kayıt yazmaya gerek kalmaz, değişiklik farkı da aynı kayıtta kalır
+func WithAuditAction(ctx context.Context, action models.AuditAction) context.Context {
+	return context.WithValue(ctx, auditActionKey{}, action)
+}
+
+// Denetim isteğe bağlıdır; yüksek hacimli tablolarda etkinleştirilmemelidir
+func (r *BaseRepository[T]) EnableAudit(auditRepo IAuditRepository) {
+	r.auditor = auditRepo
+}
+
+// Denetim açıksa mutasyon ve denetim kaydı aynı transaction içinde çalışır
+func (r *BaseRepository[T]) audited(ctx context.Context, fn func(repo *BaseRepository[T]) error) error {
+	if r.auditor == nil {
+		return fn(r)
+	}
+	return Transaction(ctx, r.db, func(tx *gorm.DB) error {
+		bound := *r
+		bound.db = tx
+		bound.readDB = tx
+		return fn(&bound)
+	})
+}
+
+func (r *BaseRepository[T]) auditEntityType() string {
+	var t T
+	return reflect.TypeOf(t).Name()
+}
+
+// Güncelleme öncesi satırın kolon -> değer hali
+func (r *BaseRepository[T]) auditSnapshot(ctx context.Context, id uint) (map[string]interface{}, error) {
+	if r.auditor == nil {
+		return nil, nil
+	}
+	var t T
+	before := map[string]interface{}{}
+	if err := r.scoped(r.db.WithContext(ctx)).Model(&t).Where("id = ?", id).Take(&before).Error; err != nil {
+		return nil, err
+	}
+	return before, nil
+}
+
+// Koşula uyan id'ler; denetim kapalıysa yalnızca hook'lar için gerektiğinde (forHooks) sorgulanır
+func (r *BaseRepository[T]) auditIDs(ctx context.Context, condition map[string]interface{}, forHooks bool) ([]uint, error) {
+	if r.auditor == nil && !forHooks {
+		return nil, nil
+	}
+	var t T
+	var ids []uint
+	err := r.scoped(r.db.WithContext(ctx)).Model(&t).Where(condition).Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *BaseRepository[T]) recordAudit(ctx context.Context, action models.AuditAction, ids []uint, changes map[string]auditChange) error {
+	if r.auditor == nil || len(ids) == 0 {
+		return nil
+	}
+
+	var payload string
+	if len(changes) > 0 {
+		encoded, err := json.Marshal(changes)
+		if err != nil {
+			return err
+		}
+		payload = string(encoded)
+	}
+
+	if override, ok := ctx.Value(auditActionKey{}).(models.AuditAction); ok && action == models.AuditUpdate {
+		action = override
+	}
+
+	actorID, _ := requestctx.UserID(ctx)
+	entityType := r.auditEntityType()
+	entries := make([]models.AuditLog, len(ids))
+	for i, id := range ids {
+		entries[i] = models.AuditLog{
+			EntityType: entityType,
+			EntityID:   id,
+			Action:     action,
+			ActorID:    actorID,
+			Changes:    payload,
+		}
+	}
+	return r.auditor.WithTx(r.db).Record(ctx, entries...)
+}
+
+// before nil ise (toplu güncelleme) yalnızca yeni değerler yazılır
+func (r *BaseRepository[T]) auditDiff(before map[string]interface{}, data map[string]interface{}) map[string]auditChange {
+	changes := make(map[string]auditChange, len(data))
+	for column, value := range data {
+		if auditIgnoredColumns[column] || column == r.versionColumn {
+			continue
+		}
+		if _, isExpr := value.(clause.Expr); isExpr {
+			continue
+		}
+
+		old, known := before[column]
+		if known && fmt.Sprint(old) == fmt.Sprint(value) {
+			continue
+		}
+
+		change := auditChange{New: value}
+		if known {
+			change.Old = old
+		}
+		if strings.Contains(column, "password") {
+			change = auditChange{New: redactedAuditValue}
+			if known {
+				change.Old = redactedAuditValue
+			}
+		}
+		changes[column] = change
+	}
+	return changes
+}
+
+func entityIDs[T any](entities ...*T) []uint {
+	ids := make([]uint, 0, len(entities))
+	for _, entity := range entities {
+		// DoNothing ile atlanan satırın id'si boş kalır
+		if e, ok := any(entity).(identifiable); ok && e.GetID() != 0 {
+			ids = append(ids, e.GetID())
+		}
+	}
+	return ids
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/requestctx"
+
+	"gorm.io/gorm"
+)
+
+type IAuditRepository interface {
+	Record(ctx context.Context, entries ...models.AuditLog) error
+	ListForEntity(ctx context.Context, entityType string, entityID uint, params queryparams.ListParams) ([]models.AuditLog, int64, error)
+	WithTx(tx *gorm.DB) IAuditRepository
+}
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository() IAuditRepository {
+	return &AuditRepository{db: configsdatabase.GetDB()}
+}
+
+func (r *AuditRepository) WithTx(tx *gorm.DB) IAuditRepository {
+	return &AuditRepository{db: tx}
+}
+
+// Context'te kimliğe bürünen yönetici varsa her kayda yazılır
+func (r *AuditRepository) Record(ctx context.Context, entries ...models.AuditLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if impersonatorID, ok := requestctx.ImpersonatorID(ctx); ok {
+		for i := range entries {
+			if entries[i].ImpersonatorID == nil {
+				entries[i].ImpersonatorID = &impersonatorID
+			}
+		}
+	}
+	return r.db.WithContext(ctx).CreateInBatches(&entries, defaultBulkBatchSize).Error
+}
+
+func (r *AuditRepository) ListForEntity(ctx context.Context, entityType string, entityID uint, params queryparams.ListParams) ([]models.AuditLog, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.AuditLog{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []models.AuditLog
+	err := query.Order("created_at DESC").Order("id DESC").
+		Offset(params.CalculateOffset()).Limit(params.PerPage).
+		Find(&logs).Error
+	return logs, totalCount, err
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+)
+
+func TestAuditRecordStoresImpersonator(t *testing.T) {
+	db := testutil.NewDB(t, &models.AuditLog{})
+	repo := NewAuditRepository()
+
+	ctx := requestctx.WithImpersonatorID(requestctx.WithUserID(context.Background(), 7), 3)
+	if err := repo.Record(ctx, models.AuditLog{EntityType: "User", EntityID: 9, Action: models.AuditUpdate, ActorID: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Record(context.Background(), models.AuditLog{EntityType: "User", EntityID: 9, Action: models.AuditUpdate, ActorID: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs []models.AuditLog
+	db.Order("id").Find(&logs)
+	if len(logs) != 2 {
+		t.Fatalf("%d kayıt", len(logs))
+	}
+	if logs[0].ImpersonatorID == nil || *logs[0].ImpersonatorID != 3 {
+		t.Errorf("bürünme sırasındaki kayıtta impersonator_id = %v, beklenen 3", logs[0].ImpersonatorID)
+	}
+	if logs[1].ImpersonatorID != nil {
+		t.Errorf("normal kayıtta impersonator_id = %v, beklenen nil", *logs[1].ImpersonatorID)
+	}
+}
+
+func TestRestoreRequiresUserAndIsAudited(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	repo := NewBaseRepository[models.User](db)
+	repo.EnableAudit(NewAuditRepository())
+
+	ctx := requestctx.WithUserID(context.Background(), 7)
+	user := &models.User{Name: "A", Account: "a@example.com", Password: "x", Type: models.Panel, Status: true}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.Restore(context.Background(), user.ID); !errors.Is(err, ErrMissingUserID) {
+		t.Fatalf("kullanıcısız Restore hatası = %v, beklenen ErrMissingUserID", err)
+	}
+	if err := repo.Restore(requestctx.WithUserID(context.Background(), 9), user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var restored models.User
+	if err := db.First(&restored, user.ID).Error; err != nil {
+		t.Fatalf("geri yüklenen kayıt okunamadı: %v", err)
+	}
+	if restored.DeletedBy != nil || restored.UpdatedBy != 9 {
+		t.Errorf("deleted_by = %v, updated_by = %d; beklenen nil ve 9", restored.DeletedBy, restored.UpdatedBy)
+	}
+	var audit models.AuditLog
+	if err := db.Where("action = ?", models.AuditRestore).First(&audit).Error; err != nil {
+		t.Fatalf("geri yükleme denetim kaydı yok: %v", err)
+	}
+	if audit.EntityID != user.ID || audit.ActorID != 9 {
+		t.Errorf("denetim kaydı = %+v", audit)
+	}
+}
+
+func TestUpsertIsAudited(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	repo := NewBaseRepository[models.User](db)
+	repo.EnableAudit(NewAuditRepository())
+	ctx := requestctx.WithUserID(context.Background(), 7)
+
+	existing := &models.User{Name: "A", Account: "a@example.com", Password: "x", Type: models.Panel, Status: true}
+	if err := repo.Create(ctx, existing); err != nil {
+		t.Fatal(err)
+	}
+
+	user := &models.User{Name: "B", Account: "a@example.com", Password: "y", Type: models.Panel, Status: true}
+	if err := repo.Upsert(ctx, user, []string{"account"}, []string{"name", "password"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	var audit models.AuditLog
+	if err := db.Where("action = ?", models.AuditUpsert).First(&audit).Error; err != nil {
+		t.Fatalf("upsert denetim kaydı yok: %v", err)
+	}
+	if audit.EntityID != existing.ID || audit.ActorID != 7 {
+		t.Errorf("denetim kaydı = %+v", audit)
+	}
+	if want := `{"name":{"new":"B"},"password":{"new":"***"}}`; audit.Changes != want {
+		t.Errorf("changes = %s, beklenen %s", audit.Changes, want)
+	}
+
+	// Çakışmada hiçbir şey yapılmazsa kayıt değişmediğinden denetim yazılmaz
+	skipped := &models.User{Name: "C", Account: "a@example.com", Password: "z", Type: models.Panel}
+	if err := repo.Upsert(ctx, skipped, []string{"account"}, nil); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	users := []models.User{
+		{Name: "D", Account: "a@example.com", Password: "x", Type: models.Panel},
+		{Name: "E", Account: "e@example.com", Password: "x", Type: models.Panel},
+	}
+	if err := repo.BulkUpsert(ctx, users, []string{"account"}, []string{"name"}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	var ids []uint
+	db.Model(&models.AuditLog{}).Where("action = ?", models.AuditUpsert).Order("id").Pluck("entity_id", &ids)
+	if len(ids) != 3 || ids[0] != existing.ID || ids[1] != existing.ID || ids[2] != users[1].ID {
+		t.Errorf("upsert denetim id'leri = %v, beklenen [%d %d %d]", ids, existing.ID, existing.ID, users[1].ID)
+	}
+}
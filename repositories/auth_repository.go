@@ -1,9 +1,13 @@
 package repositories
 
 import (
-	"davet.link/configs/configsdatabase"
-	"davet.link/configs/configslog"
-	"davet.link/models"
+	"context"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -13,14 +17,26 @@ type IAuthRepository interface {
 	FindUserByAccount(account string) (*models.User, error)
 	FindUserByID(id uint) (*models.User, error)
 	UpdateUser(user *models.User) error
+	UpdateProfile(ctx context.Context, id uint, name, account string) (*models.User, error)
+	AccountExists(account string, exceptID uint) (bool, error)
+	IncrementFailedLogins(id uint) (int, error)
+	LockUser(id uint, until time.Time) error
+	ResetFailedLogins(id uint) error
+	UpdatePassword(id uint, passwordHash string) error
+	RehashPassword(id uint, passwordHash string) error
+	RecordLogin(id uint, ip string, at time.Time) error
 }
 
+// Giriş ve oturum kontrolleri (durum, kilit, password_changed_at) replika gecikmesini kabul etmez; tüm okumalar
+// birincil veritabanından yapılır. Kullanıcı kimliği oturumdan ya da tekil hesap adından geldiği için
+// okumalara kiracı kapsamı uygulanmaz; kiracı oturuma kullanıcı kaydından yazılır.
 type AuthRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	users *CachedRepository[models.User]
 }
 
 func NewAuthRepository() IAuthRepository {
-	return &AuthRepository{db: configsdatabase.GetDB()}
+	return &AuthRepository{db: configsdatabase.GetDB(), users: sharedUserBase()}
 }
 
 func (r *AuthRepository) executeQuery(query *gorm.DB, operation string, fields ...zap.Field) error {
@@ -49,15 +65,24 @@ func (r *AuthRepository) FindUserByAccount(account string) (*models.User, error)
 	)
 }
 
+// Her istekte AuthMiddleware'den çağrılır; önbellek ıskası birincilden doldurulur
 func (r *AuthRepository) FindUserByID(id uint) (*models.User, error) {
-	return r.findUser(
-		r.db.Where("id = ?", id),
-		"Kullanıcı sorgulama (ID)",
-		zap.Uint("user_id", id),
-	)
+	user, err := r.users.GetOrLoad(id, func() (*models.User, error) {
+		var user models.User
+		if err := r.db.First(&user, id).Error; err != nil {
+			return nil, err
+		}
+		return &user, nil
+	})
+	if err != nil {
+		configslog.Log.Error("Kullanıcı sorgulama (ID) hatası", zap.Uint("user_id", id), zap.Error(err))
+		return nil, err
+	}
+	return user, nil
 }
 
 func (r *AuthRepository) UpdateUser(user *models.User) error {
+	defer r.users.Invalidate(user.ID)
 	return r.executeQuery(
 		r.db.Save(user),
 		"Kullanıcı güncelleme",
@@ -66,4 +91,111 @@ func (r *AuthRepository) UpdateUser(user *models.User) error {
 	)
 }
 
+// Kullanıcı kendi kaydını değiştirdiği için updated_by onun id'siyle damgalanır;
+// denetim kaydı ve önbellek temizliği taban repository üzerinden yapılır. Profil formu sürüm taşımaz,
+// sürüm yine artar ki paneldeki açık düzenleme formu çakışmayı görsün.
+func (r *AuthRepository) UpdateProfile(ctx context.Context, id uint, name, account string) (*models.User, error) {
+	ctx = WithoutVersionCheck(requestctx.WithUserID(ctx, id))
+	user, err := r.users.Update(ctx, id, map[string]interface{}{
+		"name":    name,
+		"account": account,
+	})
+	if err != nil {
+		configslog.FromContext(ctx).Error("Profil güncelleme hatası", zap.Uint("user_id", id), zap.String("account", account), zap.Error(err))
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *AuthRepository) AccountExists(account string, exceptID uint) (bool, error) {
+	var count int64
+	err := r.executeQuery(
+		r.db.Model(&models.User{}).Where("account = ? AND id <> ?", account, exceptID).Count(&count),
+		"Hesap adı kontrolü",
+		zap.String("account", account),
+	)
+	return count > 0, err
+}
+
+// Sayaç alanları hook'ları tetiklememek için UpdateColumn(s) ile yazılır; giriş anında oturum kullanıcısı yoktur
+func (r *AuthRepository) IncrementFailedLogins(id uint) (int, error) {
+	defer r.users.Invalidate(id)
+
+	err := r.executeQuery(
+		r.db.Model(&models.User{}).Where("id = ?", id).
+			UpdateColumn("failed_login_count", gorm.Expr("failed_login_count + 1")),
+		"Başarısız giriş sayacı artırma",
+		zap.Uint("user_id", id),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = r.executeQuery(
+		r.db.Model(&models.User{}).Where("id = ?", id).Select("failed_login_count").Scan(&count),
+		"Başarısız giriş sayacı okuma",
+		zap.Uint("user_id", id),
+	)
+	return count, err
+}
+
+func (r *AuthRepository) LockUser(id uint, until time.Time) error {
+	defer r.users.Invalidate(id)
+	return r.executeQuery(
+		r.db.Model(&models.User{}).Where("id = ?", id).UpdateColumn("locked_until", until),
+		"Hesap kilitleme",
+		zap.Uint("user_id", id),
+	)
+}
+
+func (r *AuthRepository) ResetFailedLogins(id uint) error {
+	defer r.users.Invalidate(id)
+	return r.executeQuery(
+		r.db.Model(&models.User{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+			"failed_login_count": 0,
+			"locked_until":       nil,
+		}),
+		"Başarısız giriş sayacı sıfırlama",
+		zap.Uint("user_id", id),
+	)
+}
+
+// password_changed_at öncesinde açılmış tüm oturumlar AuthMiddleware tarafından reddedilir
+func (r *AuthRepository) UpdatePassword(id uint, passwordHash string) error {
+	defer r.users.Invalidate(id)
+	return r.executeQuery(
+		r.db.Model(&models.User{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+			"password":            passwordHash,
+			"password_changed_at": time.Now(),
+		}),
+		"Parola güncelleme",
+		zap.Uint("user_id", id),
+	)
+}
+
+// Şifre değişmediği için password_changed_at'e dokunulmaz; açık oturumlar geçerli kalır
+func (r *AuthRepository) RehashPassword(id uint, passwordHash string) error {
+	defer r.users.Invalidate(id)
+	return r.executeQuery(
+		r.db.Model(&models.User{}).Where("id = ?", id).UpdateColumn("password", passwordHash),
+		"Parola yeniden hashleme",
+		zap.Uint("user_id", id),
+	)
+}
+
+// Önceki giriş zamanı, üzerine yazılmadan önce previous_login_at'e taşınır
+func (r *AuthRepository) RecordLogin(id uint, ip string, at time.Time) error {
+	defer r.users.Invalidate(id)
+	return r.executeQuery(
+		r.db.Model(&models.User{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+			"previous_login_at": gorm.Expr("last_login_at"),
+			"last_login_at":     at,
+			"last_login_ip":     ip,
+		}),
+		"Son giriş bilgisi güncelleme",
+		zap.Uint("user_id", id),
+	)
+}
+
 var _ IAuthRepository = (*AuthRepository)(nil)
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// Ayrı bir sqlite dosyası geride kalmış replikayı taklit eder; yazılar yalnız birincile gider
+func withStaleReplica(t *testing.T) *gorm.DB {
+	t.Helper()
+	primary := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.Notification{})
+	replica := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.Notification{})
+	configsdatabase.DB = primary
+	configsdatabase.ReadDB = replica
+	t.Cleanup(func() { configsdatabase.ReadDB = nil })
+	return primary
+}
+
+func createAuthUser(t *testing.T, db *gorm.DB, account string) *models.User {
+	t.Helper()
+	user := &models.User{Name: "Eski", Account: account, Password: "x", Type: models.Panel, Status: true}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 99)).Create(user).Error; err != nil {
+		t.Fatalf("kullanıcı oluşturulamadı: %v", err)
+	}
+	return user
+}
+
+func TestAuthFindUserByIDReadsPrimaryAfterInvalidate(t *testing.T) {
+	primary := withStaleReplica(t)
+	user := createAuthUser(t, primary, "auth@example.com")
+	repo := NewAuthRepository()
+
+	if _, err := repo.FindUserByID(user.ID); err != nil {
+		t.Fatalf("replikada olmayan kullanıcı okunamadı: %v", err)
+	}
+	if err := primary.WithContext(requestctx.WithUserID(context.Background(), 99)).Model(&models.User{}).Where("id = ?", user.ID).Update("status", false).Error; err != nil {
+		t.Fatal(err)
+	}
+	sharedUserBase().Invalidate(user.ID)
+
+	found, err := repo.FindUserByID(user.ID)
+	if err != nil {
+		t.Fatalf("FindUserByID: %v", err)
+	}
+	if found.Status {
+		t.Error("önbellek ıskası birincil yerine replikadan dolduruldu")
+	}
+}
+
+func TestAuthFindUserByIDIgnoresTenantScope(t *testing.T) {
+	testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.Notification{})
+	cfg := configsapp.Get()
+	cfg.MultiTenant = true
+	t.Cleanup(func() { cfg.MultiTenant = false })
+
+	user := &models.User{Name: "A", Account: "a@example.com", Password: "x", Type: models.Panel, Status: true}
+	if err := NewUserRepository().CreateUser(tenantContext(tenantA), user); err != nil {
+		t.Fatal(err)
+	}
+	// Oturum doğrulaması kiracı bilinmeden yapılır
+	found, err := NewAuthRepository().FindUserByID(user.ID)
+	if err != nil {
+		t.Fatalf("FindUserByID: %v", err)
+	}
+	if found.OrganizationID == nil || *found.OrganizationID != tenantA {
+		t.Errorf("organization_id = %v, beklenen %d", found.OrganizationID, tenantA)
+	}
+	// Kapsamsız kopya kiracıya bağlı okumalara sızmamalı
+	if _, err := NewUserBaseRepository().GetByID(context.Background(), user.ID); err == nil {
+		t.Error("kiracısız GetByID önbellekten kayıt döndü")
+	}
+}
+
+func TestAccountTakenReadsPrimary(t *testing.T) {
+	primary := withStaleReplica(t)
+	createAuthUser(t, primary, "yeni@example.com")
+
+	taken, err := NewUserRepository().AccountTaken(context.Background(), "yeni@example.com")
+	if err != nil {
+		t.Fatalf("AccountTaken: %v", err)
+	}
+	if !taken {
+		t.Error("birincilde olan hesap replikadan okunduğu için boş göründü")
+	}
+}
+
+func TestRecordLoginKeepsPreviousLogin(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.Notification{})
+	user := createAuthUser(t, db, "giris@example.com")
+	repo := NewAuthRepository()
+
+	fresh, err := repo.FindUserByID(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.LastLoginAt != nil || fresh.PreviousLoginAt != nil || fresh.LastLoginIP != "" {
+		t.Fatalf("hiç giriş yapmamış kullanıcıda son giriş dolu: %v %v %q", fresh.LastLoginAt, fresh.PreviousLoginAt, fresh.LastLoginIP)
+	}
+
+	first := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	if err := repo.RecordLogin(user.ID, "10.0.0.1", first); err != nil {
+		t.Fatalf("ilk RecordLogin: %v", err)
+	}
+	afterFirst, err := repo.FindUserByID(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if afterFirst.LastLoginAt == nil || !afterFirst.LastLoginAt.Equal(first) || afterFirst.LastLoginIP != "10.0.0.1" {
+		t.Errorf("ilk giriş = %v %q, beklenen %v 10.0.0.1", afterFirst.LastLoginAt, afterFirst.LastLoginIP, first)
+	}
+	if afterFirst.PreviousLoginAt != nil {
+		t.Errorf("ilk girişte önceki giriş = %v, beklenen nil", afterFirst.PreviousLoginAt)
+	}
+
+	second := first.Add(26 * time.Hour)
+	if err := repo.RecordLogin(user.ID, "2001:db8::1", second); err != nil {
+		t.Fatalf("ikinci RecordLogin: %v", err)
+	}
+	// Önbellek RecordLogin'de temizlendiği için yeni değerler okunmalı
+	afterSecond, err := repo.FindUserByID(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if afterSecond.LastLoginAt == nil || !afterSecond.LastLoginAt.Equal(second) || afterSecond.LastLoginIP != "2001:db8::1" {
+		t.Errorf("ikinci giriş = %v %q, beklenen %v 2001:db8::1", afterSecond.LastLoginAt, afterSecond.LastLoginIP, second)
+	}
+	if afterSecond.PreviousLoginAt == nil || !afterSecond.PreviousLoginAt.Equal(first) {
+		t.Errorf("önceki giriş = %v, beklenen %v", afterSecond.PreviousLoginAt, first)
+	}
+}
@@ -2,47 +2,184 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configslog"
+	"zatrano/models"
 	"zatrano/pkg/queryparams"
+	"zatrano/pkg/requestctx"
 	"zatrano/pkg/turkishsearch"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
-const userIDKey = "user_id"
+const (
+	createdByColumn      = "created_by"
+	updatedByColumn      = "updated_by"
+	defaultBulkBatchSize = 500
+	maxIDsPerQuery       = 10000
+)
 
 var (
-	ErrNotFound      = errors.New("kayıt bulunamadı")
-	ErrMissingUserID = errors.New("context içinde geçerli user_id yok")
+	ErrNotFound         = errors.New("kayıt bulunamadı")
+	ErrMissingUserID    = requestctx.ErrMissingUserID
+	ErrNoIDAccessor     = errors.New("varlık ID değerini sağlamıyor")
+	ErrEmptyCondition   = errors.New("toplu işlem için koşul belirtilmedi")
+	ErrMissingVersion   = errors.New("güncelleme için beklenen sürüm değeri belirtilmedi")
+	ErrVersionConflict  = errors.New("kayıt başka bir kullanıcı tarafından değiştirildi")
+	ErrColumnNotAllowed = errors.New("kolon bu işlem için izinli değil")
+	ErrFilterNotAllowed = errors.New("filtre kolonu izinli değil")
+	ErrNotInTransaction = errors.New("satır kilidi yalnızca WithTx ile bağlanmış repository üzerinde kullanılabilir")
+	ErrEmptyUpdate      = errors.New("güncellenecek alan belirtilmedi")
+	ErrProtectedColumn  = errors.New("kolon güncellenemez")
 )
 
+// Update ve BulkUpdate ile değiştirilemeyen kolonlar
+var protectedColumns = map[string]bool{"id": true, "created_at": true, createdByColumn: true}
+
+type LockOption string
+
+const (
+	SkipLocked LockOption = clause.LockingOptionsSkipLocked
+	NoWait     LockOption = clause.LockingOptionsNoWait
+)
+
+type identifiable interface {
+	GetID() uint
+}
+
 type IBaseRepository[T any] interface {
-	GetAll(params queryparams.ListParams) ([]T, int64, error)
-	GetByID(id uint) (*T, error)
+	GetAll(ctx context.Context, params queryparams.ListParams) ([]T, int64, error)
+	GetAllScoped(ctx context.Context, params queryparams.ListParams, scopes ...func(*gorm.DB) *gorm.DB) ([]T, int64, error)
+	GetAllCursor(ctx context.Context, params queryparams.ListParams) ([]T, string, error)
+	GetByID(ctx context.Context, id uint) (*T, error)
+	GetByIDs(ctx context.Context, ids []uint) (map[uint]*T, error)
+	GetByIDForUpdate(ctx context.Context, id uint, options ...LockOption) (*T, error)
 	Create(ctx context.Context, entity *T) error
 	BulkCreate(ctx context.Context, entities []T) error
-	Update(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
-	BulkUpdate(ctx context.Context, condition map[string]interface{}, data map[string]interface{}, updatedBy uint) error
+	FirstOrCreate(ctx context.Context, condition map[string]interface{}, entity *T) (bool, error)
+	Upsert(ctx context.Context, entity *T, conflictColumns []string, updateColumns []string) error
+	BulkUpsert(ctx context.Context, entities []T, conflictColumns []string, updateColumns []string) error
+	// updated_by kolonu olan modellerde context'te user_id bulunmalıdır
+	Update(ctx context.Context, id uint, data map[string]interface{}) (*T, error)
+	BulkUpdate(ctx context.Context, condition map[string]interface{}, data map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, id uint) error
-	BulkDelete(ctx context.Context, condition map[string]interface{}) error
-	GetCount() (int64, error)
+	Restore(ctx context.Context, id uint) error
+	ForceDelete(ctx context.Context, id uint) error
+	BulkDelete(ctx context.Context, condition map[string]interface{}) (int64, error)
+	GetCount(ctx context.Context, params queryparams.ListParams) (int64, error)
+	CountWhere(ctx context.Context, condition map[string]interface{}) (int64, error)
+	Exists(ctx context.Context, condition map[string]interface{}) (bool, error)
+	ForEachBatch(ctx context.Context, condition map[string]interface{}, batchSize int, fn func(batch []T) error) error
+	ForEachListBatch(ctx context.Context, params queryparams.ListParams, batchSize int, fn func(batch []T) error) error
+	PluckIDs(ctx context.Context, condition map[string]interface{}) ([]uint, error)
+	PluckStrings(ctx context.Context, column string, condition map[string]interface{}) ([]string, error)
+	SumWhere(ctx context.Context, column string, condition map[string]interface{}) (float64, error)
+	MinWhere(ctx context.Context, column string, condition map[string]interface{}) (float64, error)
+	MaxWhere(ctx context.Context, column string, condition map[string]interface{}) (float64, error)
+	AvgWhere(ctx context.Context, column string, condition map[string]interface{}) (float64, error)
+	WithTx(tx *gorm.DB) IBaseRepository[T]
+	WithTenant(tenantID uint) IBaseRepository[T]
+	WithoutTenantScope() IBaseRepository[T]
 }
 
 type BaseRepository[T any] struct {
-	db                 *gorm.DB
-	allowedSortColumns map[string]bool
+	db                      *gorm.DB
+	readDB                  *gorm.DB
+	allowedSortColumns      map[string]bool
+	allowedAggregateColumns map[string]bool
+	allowedFilterColumns    map[string]bool
+	searchColumns           []string
+	bulkBatchSize           int
+	versionColumn           string
+	defaultScopes           []func(*gorm.DB) *gorm.DB
+	retryMaxAttempts        int
+	retryBackoff            time.Duration
+	retryCreates            bool
+	queryTimeout            time.Duration
+	auditor                 IAuditRepository
+	hasCreatedBy            bool
+	hasUpdatedBy            bool
+	hooks                   *repositoryHooks[T]
+	tenantColumn            string
+	tenantID                uint
+	tenantUnscoped          bool
 }
 
 func NewBaseRepository[T any](db *gorm.DB) *BaseRepository[T] {
-	return &BaseRepository[T]{
-		db: db,
-		allowedSortColumns: map[string]bool{
-			"id":         true,
-			"created_at": true,
-		},
+	return NewBaseRepositoryWithReadDB[T](db, db)
+}
+
+func NewBaseRepositoryWithReadDB[T any](db *gorm.DB, readDB *gorm.DB) *BaseRepository[T] {
+	r := &BaseRepository[T]{
+		db:            db,
+		readDB:        readDB,
+		bulkBatchSize: defaultBulkBatchSize,
+		queryTimeout:  configsdatabase.GetQueryTimeout(),
+		hooks:         &repositoryHooks[T]{},
+	}
+
+	columns := discoverColumns[T]()
+	r.SetAllowedSortColumns(columns.sortable)
+	r.SetAllowedFilterColumns(columns.filterable)
+	r.SetSearchColumns(columns.searchable)
+	r.SetAllowedAggregateColumns(columns.aggregatable)
+	r.SetRetryPolicy(configsdatabase.GetRetryPolicy())
+	r.hasCreatedBy = columns.createdBy
+	r.hasUpdatedBy = columns.updatedBy
+	return r
+}
+
+func (r *BaseRepository[T]) WithTx(tx *gorm.DB) IBaseRepository[T] {
+	bound := *r
+	bound.db = tx
+	bound.readDB = tx
+	return &bound
+}
+
+func (r *BaseRepository[T]) RegisterDefaultScope(scope func(*gorm.DB) *gorm.DB) {
+	r.defaultScopes = append(r.defaultScopes, scope)
+}
+
+// Varsayılan kapsamlar sorgunun context'ini okur; WithTenant ve WithoutTenantScope bağlamaları oraya taşınır
+func (r *BaseRepository[T]) scoped(db *gorm.DB) *gorm.DB {
+	return db.WithContext(r.scopeContext(db.Statement.Context)).Scopes(r.defaultScopes...)
+}
+
+func (r *BaseRepository[T]) EnableOptimisticLocking(column string) {
+	r.versionColumn = column
+}
+
+type versionCheckKey struct{}
+
+// Formdan gelmeyen sistem yazmaları (durum değişikliği, kilit açma) beklenen sürümü bilmez; bu yazmalarda
+// sürüm kontrol edilmeden artırılır, böylece o sırada açık olan düzenleme formları yine çakışma alır
+func WithoutVersionCheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, versionCheckKey{}, true)
+}
+
+func versionCheckSkipped(ctx context.Context) bool {
+	skipped, _ := ctx.Value(versionCheckKey{}).(bool)
+	return skipped
+}
+
+func (r *BaseRepository[T]) SetBulkBatchSize(size int) {
+	if size <= 0 {
+		size = defaultBulkBatchSize
 	}
+	r.bulkBatchSize = size
 }
 
 func (r *BaseRepository[T]) SetAllowedSortColumns(columns []string) {
@@ -52,23 +189,115 @@ func (r *BaseRepository[T]) SetAllowedSortColumns(columns []string) {
 	}
 }
 
-func (r *BaseRepository[T]) GetAll(params queryparams.ListParams) ([]T, int64, error) {
-	var results []T
-	var totalCount int64
+func (r *BaseRepository[T]) SetAllowedFilterColumns(columns []string) {
+	r.allowedFilterColumns = make(map[string]bool)
+	for _, col := range columns {
+		r.allowedFilterColumns[col] = true
+	}
+}
 
-	var t T
-	query := r.db.Model(&t)
+func (r *BaseRepository[T]) SetSearchColumns(columns []string) {
+	r.searchColumns = columns
+}
+
+func (r *BaseRepository[T]) SetAllowedAggregateColumns(columns []string) {
+	r.allowedAggregateColumns = make(map[string]bool)
+	for _, col := range columns {
+		r.allowedAggregateColumns[col] = true
+	}
+}
 
+func (r *BaseRepository[T]) applyListFilters(query *gorm.DB, params queryparams.ListParams) *gorm.DB {
+	if params.OnlyDeleted {
+		query = query.Unscoped().Where("deleted_at IS NOT NULL")
+	} else if params.IncludeDeleted {
+		query = query.Unscoped()
+	}
 	if params.Name != "" {
-		sqlFragment, args := turkishsearch.SQLFilter("name", params.Name)
+		sqlFragment, args := turkishsearch.SQLFilterAll(query.Dialector.Name(), "name", params.Name)
+		query = query.Where(sqlFragment, args...)
+	}
+	if params.Query != "" {
+		sqlFragment, args := r.searchFilter(query.Dialector.Name(), params.Query)
 		query = query.Where(sqlFragment, args...)
 	}
 	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
+		// "true"/"false" SQLite ve MySQL'de sayısal kolonla eşleşmez; bool olarak bağlanır
+		if status, err := strconv.ParseBool(params.Status); err == nil {
+			query = query.Where("status = ?", status)
+		} else {
+			query = query.Where("status = ?", params.Status)
+		}
 	}
 	if params.Type != "" {
 		query = query.Where("type = ?", params.Type)
 	}
+	for _, bound := range []struct {
+		column string
+		op     string
+		value  string
+		upper  bool
+	}{
+		{"created_at", ">=", params.CreatedFrom, false},
+		{"created_at", "<", params.CreatedTo, true},
+		{"updated_at", ">=", params.UpdatedFrom, false},
+		{"updated_at", "<", params.UpdatedTo, true},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		// Yalnızca tarih verilen sınırlar kullanıcının gününe göre yorumlanır; kolonlar UTC tutulduğundan UTC'ye çevrilir
+		t, err := queryparams.ParseDateBound(bound.value, configsapp.Location(), bound.upper)
+		if err != nil {
+			query.AddError(err)
+			return query
+		}
+		query = query.Where(bound.column+" "+bound.op+" ?", t.UTC())
+	}
+	for _, filter := range params.Filters {
+		if _, ok := r.allowedFilterColumns[filter.Column]; !ok {
+			query.AddError(ErrFilterNotAllowed)
+			return query
+		}
+		sqlFragment, args := filter.SQL(query.Dialector.Name())
+		if sqlFragment == "" {
+			continue
+		}
+		query = query.Where(sqlFragment, args...)
+	}
+	return query
+}
+
+func (r *BaseRepository[T]) searchFilter(dialect, search string) (string, []interface{}) {
+	columns := r.searchColumns
+	if len(columns) == 0 {
+		columns = []string{"name"}
+	}
+	return turkishsearch.SQLFilterAllColumns(dialect, columns, search)
+}
+
+func (r *BaseRepository[T]) GetAll(ctx context.Context, params queryparams.ListParams) (_ []T, _ int64, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	return r.getAll(r.readDB.WithContext(ctx), params)
+}
+
+func (r *BaseRepository[T]) GetAllScoped(ctx context.Context, params queryparams.ListParams, scopes ...func(*gorm.DB) *gorm.DB) (_ []T, _ int64, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	return r.getAll(r.readDB.WithContext(ctx), params, scopes...)
+}
+
+func (r *BaseRepository[T]) getAll(db *gorm.DB, params queryparams.ListParams, scopes ...func(*gorm.DB) *gorm.DB) ([]T, int64, error) {
+	var results []T
+	var totalCount int64
+
+	params.Normalize()
+
+	var t T
+	query := r.applyListFilters(r.scoped(db).Model(&t).Scopes(scopes...), params)
 
 	err := query.Count(&totalCount).Error
 	if err != nil {
@@ -78,6 +307,76 @@ func (r *BaseRepository[T]) GetAll(params queryparams.ListParams) ([]T, int64, e
 		return results, 0, nil
 	}
 
+	query = r.applyListOrder(query, params)
+
+	offset := params.CalculateOffset()
+	query = query.Limit(params.PerPage).Offset(offset)
+
+	err = query.Find(&results).Error
+	return results, totalCount, err
+}
+
+func (r *BaseRepository[T]) applyListOrder(query *gorm.DB, params queryparams.ListParams) *gorm.DB {
+	sorts := r.resolveSorts(params)
+	sortColumns := make([]string, len(sorts))
+	for i, sort := range sorts {
+		sortColumns[i] = sort.Column
+	}
+	if columns := r.selectColumns(params.Fields, sortColumns...); len(columns) > 0 {
+		query = query.Select(columns)
+	}
+	for _, sort := range sorts {
+		query = query.Order(sort.Column + " " + sort.Direction)
+	}
+	return query
+}
+
+func (r *BaseRepository[T]) GetAllCursor(ctx context.Context, params queryparams.ListParams) (_ []T, _ string, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	var results []T
+	var t T
+
+	params.Normalize()
+	perPage := params.PerPage
+
+	sortBy, orderBy := r.resolveSort(params)
+	query := r.applyListFilters(r.scoped(r.readDB.WithContext(ctx)).Model(&t), params)
+	if columns := r.selectColumns(params.Fields, sortBy); len(columns) > 0 {
+		query = query.Select(columns)
+	}
+
+	if params.Cursor != "" {
+		cursor, err := queryparams.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		comparator := ">"
+		if orderBy == "desc" {
+			comparator = "<"
+		}
+		query = query.Where("("+sortBy+", id) "+comparator+" (?, ?)", cursor.Value, cursor.ID)
+	}
+
+	query = query.Order(sortBy + " " + orderBy).Order("id " + orderBy).Limit(perPage + 1)
+	if err := query.Find(&results).Error; err != nil {
+		return nil, "", err
+	}
+
+	if len(results) <= perPage {
+		return results, "", nil
+	}
+	results = results[:perPage]
+
+	nextCursor, err := r.cursorFor(&results[perPage-1], sortBy)
+	if err != nil {
+		return nil, "", err
+	}
+	return results, nextCursor, nil
+}
+
+func (r *BaseRepository[T]) resolveSort(params queryparams.ListParams) (string, string) {
 	sortBy := params.SortBy
 	orderBy := strings.ToLower(params.OrderBy)
 	if orderBy != "asc" && orderBy != "desc" {
@@ -86,105 +385,952 @@ func (r *BaseRepository[T]) GetAll(params queryparams.ListParams) ([]T, int64, e
 	if _, ok := r.allowedSortColumns[sortBy]; !ok {
 		sortBy = queryparams.DefaultSortBy
 	}
-	query = query.Order(sortBy + " " + orderBy)
+	return sortBy, orderBy
+}
 
-	offset := params.CalculateOffset()
-	query = query.Limit(params.PerPage).Offset(offset)
+func (r *BaseRepository[T]) resolveSorts(params queryparams.ListParams) []queryparams.SortField {
+	var sorts []queryparams.SortField
+	for _, sort := range queryparams.ParseSort(params.Sort) {
+		if _, ok := r.allowedSortColumns[sort.Column]; ok {
+			sorts = append(sorts, sort)
+		}
+	}
+	if len(sorts) > 0 {
+		return sorts
+	}
 
-	err = query.Find(&results).Error
-	return results, totalCount, err
+	sortBy, orderBy := r.resolveSort(params)
+	return []queryparams.SortField{{Column: sortBy, Direction: orderBy}}
+}
+
+func (r *BaseRepository[T]) selectColumns(fields []string, sortColumns ...string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	columns := []string{"id"}
+	for _, field := range fields {
+		if _, ok := r.allowedSortColumns[field]; ok {
+			columns = appendMissingColumn(columns, field)
+		}
+	}
+	if len(columns) == 1 {
+		return nil
+	}
+	for _, col := range sortColumns {
+		columns = appendMissingColumn(columns, col)
+	}
+	return columns
 }
 
-func (r *BaseRepository[T]) GetByID(id uint) (*T, error) {
+func (r *BaseRepository[T]) cursorFor(entity *T, sortBy string) (string, error) {
+	identity, ok := any(entity).(identifiable)
+	if !ok {
+		return "", ErrNoIDAccessor
+	}
+
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(entity); err != nil {
+		return "", err
+	}
+	field := stmt.Schema.LookUpField(sortBy)
+	if field == nil {
+		return "", errors.New("sıralama kolonu modelde bulunamadı: " + sortBy)
+	}
+
+	value, _ := field.ValueOf(context.Background(), reflect.ValueOf(entity).Elem())
+	return queryparams.EncodeCursor(value, identity.GetID())
+}
+
+func (r *BaseRepository[T]) GetByID(ctx context.Context, id uint) (_ *T, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
 	var result T
-	err := r.db.First(&result, id).Error
+	err = r.scoped(r.readDB.WithContext(ctx)).First(&result, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ErrNotFound
 	}
 	return &result, err
 }
 
-func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) error {
+func (r *BaseRepository[T]) GetByIDForUpdate(ctx context.Context, id uint, options ...LockOption) (_ *T, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if !inTransaction(r.db) {
+		return nil, ErrNotInTransaction
+	}
+
+	locking := clause.Locking{Strength: clause.LockingStrengthUpdate}
+	if len(options) > 0 {
+		locking.Options = string(options[0])
+	}
+
+	var result T
+	err = r.scoped(r.db.WithContext(ctx)).Clauses(locking).First(&result, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *BaseRepository[T]) GetByIDs(ctx context.Context, ids []uint) (_ map[uint]*T, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	seen := make(map[uint]struct{}, len(ids))
+	uniqueIDs := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		uniqueIDs = append(uniqueIDs, id)
+	}
+
+	found := make(map[uint]*T, len(uniqueIDs))
+	tx := r.scoped(r.readDB.WithContext(ctx))
+
+	for start := 0; start < len(uniqueIDs); start += maxIDsPerQuery {
+		end := start + maxIDsPerQuery
+		if end > len(uniqueIDs) {
+			end = len(uniqueIDs)
+		}
+
+		var chunk []T
+		if err := tx.Where("id IN ?", uniqueIDs[start:end]).Find(&chunk).Error; err != nil {
+			return nil, err
+		}
+		for i := range chunk {
+			entity, ok := any(&chunk[i]).(identifiable)
+			if !ok {
+				return nil, ErrNoIDAccessor
+			}
+			found[entity.GetID()] = &chunk[i]
+		}
+	}
+
+	return found, nil
+}
+
+func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) (err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if err := r.runBeforeCreate(ctx, entity); err != nil {
+		return err
+	}
+	create := func() error {
+		return r.audited(ctx, func(repo *BaseRepository[T]) error {
+			if err := repo.create(ctx, entity); err != nil {
+				return err
+			}
+			return repo.recordAudit(ctx, models.AuditCreate, entityIDs(entity), nil)
+		})
+	}
+	if r.retryCreates {
+		err = r.withRetry(ctx, "Create", create)
+	} else {
+		err = create()
+	}
+	if err != nil {
+		return err
+	}
+	r.runAfterCreate(ctx, entity)
+	return nil
+}
+
+// Kolonları olan modellerde created_by ve updated_by context'teki kullanıcıyla doldurulur.
+// updated_by taşıyan (denetlenen) modellerde kullanıcı yoksa yazma reddedilir; yalnızca created_by
+// taşıyan modeller (ör. sistemin de kuyruğa eklediği Job) kullanıcısız da oluşturulabilir.
+func (r *BaseRepository[T]) stampCreated(ctx context.Context, entities ...*T) error {
+	if err := r.stampTenant(ctx, entities...); err != nil {
+		return err
+	}
+	if (!r.hasCreatedBy && !r.hasUpdatedBy) || len(entities) == 0 {
+		return nil
+	}
+	userID, ok := requestctx.UserID(ctx)
+	if !ok {
+		if r.hasUpdatedBy {
+			return ErrMissingUserID
+		}
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(entities[0]); err != nil {
+		return err
+	}
+	var fields []*schema.Field
+	for _, column := range []string{createdByColumn, updatedByColumn} {
+		if field := stmt.Schema.LookUpField(column); field != nil {
+			fields = append(fields, field)
+		}
+	}
+	for _, entity := range entities {
+		value := reflect.ValueOf(entity).Elem()
+		for _, field := range fields {
+			if err := field.Set(ctx, value, userID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *BaseRepository[T]) stampUpdated(ctx context.Context, data map[string]interface{}) error {
+	if !r.hasUpdatedBy {
+		return nil
+	}
+	userID, ok := requestctx.UserID(ctx)
+	if !ok {
+		return ErrMissingUserID
+	}
+	data[updatedByColumn] = userID
+	return nil
+}
+
+func (r *BaseRepository[T]) create(ctx context.Context, entity *T) error {
+	if err := r.stampCreated(ctx, entity); err != nil {
+		return err
+	}
 	return r.db.WithContext(ctx).Create(entity).Error
 }
 
-func (r *BaseRepository[T]) BulkCreate(ctx context.Context, entities []T) error {
-	return r.db.WithContext(ctx).Create(&entities).Error
+func (r *BaseRepository[T]) BulkCreate(ctx context.Context, entities []T) (err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if len(entities) == 0 {
+		return nil
+	}
+	created := make([]*T, len(entities))
+	for i := range entities {
+		created[i] = &entities[i]
+	}
+	if err := r.runBeforeCreate(ctx, created...); err != nil {
+		return err
+	}
+	if err := r.stampCreated(ctx, created...); err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(&entities, r.bulkBatchSize).Error; err != nil {
+			return err
+		}
+
+		bound := *r
+		bound.db = tx
+		return bound.recordAudit(ctx, models.AuditCreate, entityIDs(created...), nil)
+	})
+	if err != nil {
+		return err
+	}
+	r.runAfterCreate(ctx, created...)
+	return nil
+}
+
+func (r *BaseRepository[T]) FirstOrCreate(ctx context.Context, condition map[string]interface{}, entity *T) (_ bool, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	tx := r.db.WithContext(ctx)
+
+	err = r.scoped(tx).Where(condition).First(entity).Error
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	if err := r.runBeforeCreate(ctx, entity); err != nil {
+		return false, err
+	}
+	if err := r.stampCreated(ctx, entity); err != nil {
+		return false, err
+	}
+
+	created := false
+	err = r.audited(ctx, func(repo *BaseRepository[T]) error {
+		result := repo.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(entity)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Eşzamanlı bir istek kaydı bizden önce oluşturdu; onu döndür.
+			// Çakışan kayıt başka kiracınınsa görünmez ve ErrNotFound döner.
+			err := repo.scoped(repo.db.WithContext(ctx)).Where(condition).First(entity).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		created = true
+		return repo.recordAudit(ctx, models.AuditCreate, entityIDs(entity), nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	if created {
+		r.runAfterCreate(ctx, entity)
+	}
+	return created, nil
+}
+
+func (r *BaseRepository[T]) Upsert(ctx context.Context, entity *T, conflictColumns []string, updateColumns []string) (err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if err := r.stampCreated(ctx, entity); err != nil {
+		return err
+	}
+	onConflict, err := r.tenantUpsertClause(ctx, r.versionedUpsert(upsertClause(conflictColumns, r.upsertUpdateColumns(ctx, updateColumns))))
+	if err != nil {
+		return err
+	}
+	return r.audited(ctx, func(repo *BaseRepository[T]) error {
+		result := repo.db.WithContext(ctx).Clauses(onConflict).Create(entity)
+		if result.Error != nil {
+			return result.Error
+		}
+		// DoNothing çakışmasında satır eklenmez ve birincil anahtar boş kalır; mevcut kayıt okunup entity'ye yazılır
+		if result.RowsAffected == 0 && onConflict.DoNothing {
+			return repo.reloadByColumns(ctx, entity, conflictColumns)
+		}
+		var changes map[string]auditChange
+		if repo.auditor != nil {
+			changes = repo.auditDiff(nil, repo.columnValues(ctx, entity, updateColumns))
+		}
+		return repo.recordAudit(ctx, models.AuditUpsert, entityIDs(entity), changes)
+	})
+}
+
+// Modelde karşılığı olmayan kolonlar atlanır
+func (r *BaseRepository[T]) columnValues(ctx context.Context, entity *T, columns []string) map[string]interface{} {
+	values := make(map[string]interface{}, len(columns))
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(entity); err != nil {
+		return values
+	}
+	value := reflect.ValueOf(entity).Elem()
+	for _, column := range columns {
+		if field := stmt.Schema.LookUpField(column); field != nil {
+			values[field.DBName], _ = field.ValueOf(ctx, value)
+		}
+	}
+	return values
+}
+
+func (r *BaseRepository[T]) reloadByColumns(ctx context.Context, entity *T, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(entity); err != nil {
+		return err
+	}
+	value := reflect.ValueOf(entity).Elem()
+	query := r.scoped(r.db.WithContext(ctx))
+	for _, column := range columns {
+		field := stmt.Schema.LookUpField(column)
+		if field == nil {
+			return errors.New("çakışma kolonu modelde bulunamadı: " + column)
+		}
+		fieldValue, _ := field.ValueOf(ctx, value)
+		query = query.Where(clause.Eq{Column: clause.Column{Name: field.DBName}, Value: fieldValue})
+	}
+
+	var existing T
+	if err := query.First(&existing).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+	*entity = existing
+	return nil
+}
+
+// DoNothing çakışmasında atlanan kayıtların birincil anahtarı doldurulmaz; gerekiyorsa çağıran yeniden okumalıdır.
+// Denetimde satır başına değer farkı yerine yalnızca etkilenen id'ler yazılır
+func (r *BaseRepository[T]) BulkUpsert(ctx context.Context, entities []T, conflictColumns []string, updateColumns []string) (err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if len(entities) == 0 {
+		return nil
+	}
+	stamped := make([]*T, len(entities))
+	for i := range entities {
+		stamped[i] = &entities[i]
+	}
+	if err := r.stampCreated(ctx, stamped...); err != nil {
+		return err
+	}
+	onConflict, err := r.tenantUpsertClause(ctx, r.versionedUpsert(upsertClause(conflictColumns, r.upsertUpdateColumns(ctx, updateColumns))))
+	if err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(onConflict).CreateInBatches(&entities, r.bulkBatchSize).Error; err != nil {
+			return err
+		}
+
+		bound := *r
+		bound.db = tx
+		return bound.recordAudit(ctx, models.AuditUpsert, entityIDs(stamped...), nil)
+	})
+}
+
+func (r *BaseRepository[T]) upsertUpdateColumns(ctx context.Context, updateColumns []string) []string {
+	if len(updateColumns) == 0 {
+		return updateColumns
+	}
+	columns := append([]string{}, updateColumns...)
+	columns = appendMissingColumn(columns, "updated_at")
+	if _, ok := requestctx.UserID(ctx); ok && r.hasUpdatedBy {
+		columns = appendMissingColumn(columns, updatedByColumn)
+	}
+	return columns
+}
+
+func appendMissingColumn(columns []string, column string) []string {
+	for _, col := range columns {
+		if col == column {
+			return columns
+		}
+	}
+	return append(columns, column)
+}
+
+// Upsert ile güncellenen satırın sürümü de artar; kolon tabloyla nitelenir çünkü Postgres'te
+// çıplak ad EXCLUDED satırıyla karışır
+func (r *BaseRepository[T]) versionedUpsert(onConflict clause.OnConflict) clause.OnConflict {
+	if r.versionColumn == "" || onConflict.DoNothing {
+		return onConflict
+	}
+	onConflict.DoUpdates = append(onConflict.DoUpdates, clause.Assignment{
+		Column: clause.Column{Name: r.versionColumn},
+		Value:  gorm.Expr("? + 1", clause.Column{Table: clause.CurrentTable, Name: r.versionColumn}),
+	})
+	return onConflict
+}
+
+func upsertClause(conflictColumns []string, updateColumns []string) clause.OnConflict {
+	columns := make([]clause.Column, len(conflictColumns))
+	for i, col := range conflictColumns {
+		columns[i] = clause.Column{Name: col}
+	}
+	if len(updateColumns) == 0 {
+		return clause.OnConflict{Columns: columns, DoNothing: true}
+	}
+	return clause.OnConflict{
+		Columns:   columns,
+		DoUpdates: clause.AssignmentColumns(updateColumns),
+	}
+}
+
+// Güncellenmiş kaydı döndürür. Kayıt yoksa ErrNotFound döner; değerler zaten aynıysa güncelleme başarılı sayılır.
+func (r *BaseRepository[T]) Update(ctx context.Context, id uint, data map[string]interface{}) (_ *T, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if err := r.validateUpdateData(data); err != nil {
+		return nil, err
+	}
+	if err := r.runBeforeUpdate(ctx, []uint{id}, data); err != nil {
+		return nil, err
+	}
+
+	var updated *T
+	err = r.auditedWithRetry(ctx, "Update", func(repo *BaseRepository[T]) error {
+		before, err := repo.auditSnapshot(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if updated, err = repo.update(ctx, id, data); err != nil {
+			return err
+		}
+		return repo.recordAudit(ctx, models.AuditUpdate, []uint{id}, repo.auditDiff(before, data))
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.runAfterUpdate(ctx, []uint{id}, data)
+	return updated, nil
+}
+
+func (r *BaseRepository[T]) validateUpdateData(data map[string]interface{}) error {
+	if len(data) == 0 {
+		return ErrEmptyUpdate
+	}
+	for column := range data {
+		if protectedColumns[column] || (column == r.tenantColumn && r.tenantScoped()) {
+			return fmt.Errorf("%w: %s", ErrProtectedColumn, column)
+		}
+	}
+	return nil
+}
+
+// Çağıranın haritası değiştirilmez; damgalar ve sürüm artışı kopyaya yazılır
+func (r *BaseRepository[T]) updateValues(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+	values := maps.Clone(data)
+	if err := r.stampUpdated(ctx, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (r *BaseRepository[T]) update(ctx context.Context, id uint, data map[string]interface{}) (_ *T, err error) {
+	values, err := r.updateValues(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var expectedVersion interface{}
+	checkVersion := false
+	if r.versionColumn != "" {
+		expected, ok := values[r.versionColumn]
+		if !ok && !versionCheckSkipped(ctx) {
+			return nil, ErrMissingVersion
+		}
+		expectedVersion, checkVersion = expected, ok
+		values[r.versionColumn] = gorm.Expr(r.versionColumn + " + 1")
+	}
+
+	// Postgres güncellenen satırı RETURNING ile döndürür; diğer sürücülerde kayıt yeniden okunur
+	returning := r.db.Dialector.Name() == configsdatabase.DriverPostgres
+	var entity T
+	query := r.scoped(r.db.WithContext(ctx)).Model(&entity).Where("id = ?", id)
+	if checkVersion {
+		query = query.Where(r.versionColumn+" = ?", expectedVersion)
+	}
+	if returning {
+		query = query.Clauses(clause.Returning{})
+	}
+	result := query.Updates(values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	rowsAffected := result.RowsAffected
+
+	if rowsAffected == 0 {
+		// MySQL değişmeyen satırları etkilenmiş saymaz; kaydın varlığı ayrıca kontrol edilir.
+		// Replika gecikmesinden etkilenmemek için kontrol birincil bağlantı üzerinden yapılır.
+		var count int64
+		if err := r.scoped(r.db.WithContext(ctx)).Model(new(T)).Where("id = ?", id).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, ErrNotFound
+		}
+		if checkVersion {
+			return nil, ErrVersionConflict
+		}
+		returning = false
+	}
+
+	if !returning {
+		if err := r.scoped(r.db.WithContext(ctx)).Where("id = ?", id).Take(&entity).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &entity, nil
+}
+
+// Koşula uyan satırların sayısını döndürür
+func (r *BaseRepository[T]) BulkUpdate(ctx context.Context, condition map[string]interface{}, data map[string]interface{}) (_ int64, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if len(condition) == 0 {
+		return 0, ErrEmptyCondition
+	}
+	if err := r.validateUpdateData(data); err != nil {
+		return 0, err
+	}
+
+	var affected int64
+	var ids []uint
+	err = r.auditedWithRetry(ctx, "BulkUpdate", func(repo *BaseRepository[T]) (err error) {
+		ids, err = repo.auditIDs(ctx, condition, repo.needsUpdateIDs())
+		if err != nil {
+			return err
+		}
+		if err := repo.runBeforeUpdate(ctx, ids, data); err != nil {
+			return err
+		}
+		values, err := repo.updateValues(ctx, data)
+		if err != nil {
+			return err
+		}
+		if repo.versionColumn != "" {
+			values[repo.versionColumn] = gorm.Expr(repo.versionColumn + " + 1")
+		}
+		var t T
+		result := repo.scoped(repo.db.WithContext(ctx)).Model(&t).Where(condition).Updates(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return repo.recordAudit(ctx, models.AuditUpdate, ids, repo.auditDiff(nil, data))
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.runAfterUpdate(ctx, ids, data)
+	return affected, nil
 }
 
-func (r *BaseRepository[T]) Update(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
-	if updatedBy > 0 {
-		data["updated_by"] = updatedBy
+func (r *BaseRepository[T]) Delete(ctx context.Context, id uint) (err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	userID, ok := requestctx.UserID(ctx)
+	if !ok {
+		return ErrMissingUserID
+	}
+
+	err = r.auditedWithRetry(ctx, "Delete", func(repo *BaseRepository[T]) error {
+		if err := repo.delete(ctx, id, userID); err != nil {
+			return err
+		}
+		return repo.recordAudit(ctx, models.AuditDelete, []uint{id}, nil)
+	})
+	if err != nil {
+		return err
 	}
+	r.runAfterDelete(ctx, []uint{id})
+	return nil
+}
+
+func (r *BaseRepository[T]) delete(ctx context.Context, id uint, userID uint) error {
 	var t T
-	result := r.db.WithContext(ctx).Model(&t).Where("id = ?", id).Updates(data)
+	result := r.scoped(r.db.WithContext(ctx)).Model(&t).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"deleted_by": userID,
+		"deleted_at": r.db.NowFunc(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
 	if result.RowsAffected == 0 {
 		return ErrNotFound
 	}
-	return result.Error
+	return nil
 }
 
-func (r *BaseRepository[T]) BulkUpdate(ctx context.Context, condition map[string]interface{}, data map[string]interface{}, updatedBy uint) error {
-	if updatedBy > 0 {
-		data["updated_by"] = updatedBy
+// Silme gibi geri yükleme de kimin yaptığı bilinmeden yapılamaz; denetim kaydı ve log yazılır
+func (r *BaseRepository[T]) Restore(ctx context.Context, id uint) (err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	userID, ok := requestctx.UserID(ctx)
+	if !ok {
+		return ErrMissingUserID
 	}
+
 	var t T
-	return r.db.WithContext(ctx).Model(&t).Where(condition).Updates(data).Error
+	values := map[string]interface{}{
+		"deleted_at": nil,
+		"deleted_by": nil,
+	}
+	if r.hasUpdatedBy {
+		values[updatedByColumn] = userID
+	}
+	err = r.audited(ctx, func(repo *BaseRepository[T]) error {
+		result := repo.scoped(repo.db.WithContext(ctx)).Unscoped().Model(&t).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			UpdateColumns(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return repo.recordAudit(ctx, models.AuditRestore, []uint{id}, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	configslog.FromContext(ctx).Info("Silinen kayıt geri yüklendi",
+		zap.String("model", reflect.TypeOf(t).Name()),
+		zap.Uint("id", id),
+		zap.Uint("user_id", userID),
+	)
+	return nil
 }
 
-func (r *BaseRepository[T]) Delete(ctx context.Context, id uint) error {
-	var entity T
+func (r *BaseRepository[T]) ForceDelete(ctx context.Context, id uint) (err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
 
-	userID, ok := ctx.Value(userIDKey).(uint)
-	if !ok || userID == 0 {
+	userID, ok := requestctx.UserID(ctx)
+	if !ok {
 		return ErrMissingUserID
 	}
 
-	tx := r.db.WithContext(ctx)
-
-	if err := tx.First(&entity, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	var t T
+	err = r.audited(ctx, func(repo *BaseRepository[T]) error {
+		result := repo.scoped(repo.db.WithContext(ctx)).Unscoped().Delete(&t, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
 			return ErrNotFound
 		}
+		return repo.recordAudit(ctx, models.AuditForceDelete, []uint{id}, nil)
+	})
+	if err != nil {
 		return err
 	}
 
-	if err := tx.Model(&entity).Update("deleted_by", userID).Error; err != nil {
+	configslog.FromContext(ctx).Info("Kayıt kalıcı olarak silindi",
+		zap.String("model", reflect.TypeOf(t).Name()),
+		zap.Uint("id", id),
+		zap.Uint("user_id", userID),
+	)
+	r.runAfterDelete(ctx, []uint{id})
+	return nil
+}
+
+func (r *BaseRepository[T]) BulkDelete(ctx context.Context, condition map[string]interface{}) (_ int64, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if len(condition) == 0 {
+		return 0, ErrEmptyCondition
+	}
+
+	userID, ok := requestctx.UserID(ctx)
+	if !ok {
+		return 0, ErrMissingUserID
+	}
+
+	var t T
+	var deleted int64
+	var ids []uint
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		bound := *r
+		bound.db = tx
+		ids, err = bound.auditIDs(ctx, condition, bound.needsDeleteIDs())
+		if err != nil {
+			return err
+		}
+
+		if err := r.scoped(tx).Model(&t).Where(condition).Update("deleted_by", userID).Error; err != nil {
+			return err
+		}
+		result := r.scoped(tx).Where(condition).Delete(&t)
+		deleted = result.RowsAffected
+		if result.Error != nil {
+			return result.Error
+		}
+		return bound.recordAudit(ctx, models.AuditDelete, ids, nil)
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.runAfterDelete(ctx, ids)
+	return deleted, nil
+}
+
+func (r *BaseRepository[T]) GetCount(ctx context.Context, params queryparams.ListParams) (_ int64, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	var totalCount int64
+	var t T
+	err = r.applyListFilters(r.scoped(r.readDB.WithContext(ctx)).Model(&t), params).Count(&totalCount).Error
+	return totalCount, err
+}
+
+func (r *BaseRepository[T]) CountWhere(ctx context.Context, condition map[string]interface{}) (_ int64, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	var totalCount int64
+	var t T
+	err = r.scoped(r.readDB.WithContext(ctx)).Model(&t).Where(condition).Count(&totalCount).Error
+	return totalCount, err
+}
+
+func (r *BaseRepository[T]) Exists(ctx context.Context, condition map[string]interface{}) (_ bool, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	var found int
+	var t T
+	result := r.scoped(r.readDB.WithContext(ctx)).Model(&t).Select("1").Where(condition).Limit(1).Scan(&found)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Her parti birincil anahtardan devam eden ayrı bir sorgudur; sorgu zaman aşımı parti başına uygulanır,
+// ctx her partiden önce denetlenir ki iptal edilen istek bir sonraki partiyi okumasın
+func (r *BaseRepository[T]) ForEachBatch(ctx context.Context, condition map[string]interface{}, batchSize int, fn func(batch []T) error) error {
+	if batchSize <= 0 {
+		batchSize = r.bulkBatchSize
+	}
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(new(T)); err != nil {
 		return err
 	}
+	primaryKey := stmt.Schema.PrioritizedPrimaryField
+	if primaryKey == nil {
+		return errors.New("parti okuması için birincil anahtar gerekli")
+	}
 
-	return tx.Delete(&entity).Error
+	var after interface{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch, err := r.nextBatch(ctx, condition, primaryKey.DBName, after, batchSize)
+		if err != nil || len(batch) == 0 {
+			return err
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		after, _ = primaryKey.ValueOf(ctx, reflect.ValueOf(&batch[len(batch)-1]).Elem())
+	}
 }
 
-func (r *BaseRepository[T]) BulkDelete(ctx context.Context, condition map[string]interface{}) error {
-	var entities []T
+func (r *BaseRepository[T]) nextBatch(ctx context.Context, condition map[string]interface{}, primaryKey string, after interface{}, size int) (_ []T, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
 
-	userID, ok := ctx.Value(userIDKey).(uint)
-	if !ok || userID == 0 {
-		return ErrMissingUserID
+	column := clause.Column{Table: clause.CurrentTable, Name: primaryKey}
+	query := r.scoped(r.readDB.WithContext(ctx)).Where(condition)
+	if after != nil {
+		query = query.Where(clause.Gt{Column: column, Value: after})
 	}
+	var batch []T
+	err = query.Order(clause.OrderByColumn{Column: column}).Limit(size).Find(&batch).Error
+	return batch, err
+}
 
-	tx := r.db.WithContext(ctx)
+// Liste filtrelerini ve sıralamayı koruyarak sonuçları satır satır okur; tablo belleğe alınmadan parti parti fn'e verilir
+func (r *BaseRepository[T]) ForEachListBatch(ctx context.Context, params queryparams.ListParams, batchSize int, fn func(batch []T) error) error {
+	if batchSize <= 0 {
+		batchSize = r.bulkBatchSize
+	}
+	params.Normalize()
+
+	var t T
+	query := r.applyListFilters(r.scoped(r.readDB.WithContext(ctx)).Model(&t), params)
+	query = r.applyListOrder(query, params)
 
-	if err := tx.Where(condition).Find(&entities).Error; err != nil {
+	rows, err := query.Rows()
+	if err != nil {
 		return err
 	}
+	defer rows.Close()
 
-	for _, entity := range entities {
-		if err := tx.Model(&entity).Update("deleted_by", userID).Error; err != nil {
+	batch := make([]T, 0, batchSize)
+	for rows.Next() {
+		var item T
+		if err := query.ScanRows(rows, &item); err != nil {
+			return err
+		}
+		batch = append(batch, item)
+		if len(batch) < batchSize {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
 			return err
 		}
-		if err := tx.Delete(&entity).Error; err != nil {
+		if err := fn(batch); err != nil {
 			return err
 		}
+		batch = make([]T, 0, batchSize)
+	}
+	if err := rows.Err(); err != nil {
+		return err
 	}
 
+	if len(batch) > 0 {
+		return fn(batch)
+	}
 	return nil
 }
 
-func (r *BaseRepository[T]) GetCount() (int64, error) {
-	var totalCount int64
+func (r *BaseRepository[T]) PluckIDs(ctx context.Context, condition map[string]interface{}) (_ []uint, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	var ids []uint
+	err = r.pluckQuery(ctx, condition).Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *BaseRepository[T]) PluckStrings(ctx context.Context, column string, condition map[string]interface{}) (_ []string, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if _, ok := r.allowedSortColumns[column]; !ok {
+		return nil, ErrColumnNotAllowed
+	}
+	var values []string
+	err = r.pluckQuery(ctx, condition).Pluck(column, &values).Error
+	return values, err
+}
+
+func (r *BaseRepository[T]) pluckQuery(ctx context.Context, condition map[string]interface{}) *gorm.DB {
 	var t T
-	err := r.db.Model(&t).Count(&totalCount).Error
-	return totalCount, err
+	return r.scoped(r.readDB.WithContext(ctx)).Model(&t).
+		Where(condition).
+		Order(queryparams.DefaultSortBy + " " + queryparams.DefaultOrderBy)
+}
+
+func (r *BaseRepository[T]) SumWhere(ctx context.Context, column string, condition map[string]interface{}) (float64, error) {
+	return r.aggregate(ctx, "SUM", column, condition)
+}
+
+func (r *BaseRepository[T]) MinWhere(ctx context.Context, column string, condition map[string]interface{}) (float64, error) {
+	return r.aggregate(ctx, "MIN", column, condition)
+}
+
+func (r *BaseRepository[T]) MaxWhere(ctx context.Context, column string, condition map[string]interface{}) (float64, error) {
+	return r.aggregate(ctx, "MAX", column, condition)
+}
+
+func (r *BaseRepository[T]) AvgWhere(ctx context.Context, column string, condition map[string]interface{}) (float64, error) {
+	return r.aggregate(ctx, "AVG", column, condition)
+}
+
+func (r *BaseRepository[T]) aggregate(ctx context.Context, function string, column string, condition map[string]interface{}) (_ float64, err error) {
+	ctx, done := r.operationContext(ctx, &err)
+	defer done()
+
+	if _, ok := r.allowedAggregateColumns[column]; !ok {
+		return 0, ErrColumnNotAllowed
+	}
+
+	var value sql.NullFloat64
+	var t T
+	err = r.scoped(r.readDB.WithContext(ctx)).Model(&t).
+		Select(function + "(" + column + ")").
+		Where(condition).
+		Scan(&value).Error
+	if err != nil {
+		return 0, err
+	}
+	return value.Float64, nil
 }
@@ -0,0 +1,319 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+)
+
+func seedFailedLogins(t *testing.T, repo *BaseRepository[models.User], counts ...int) {
+	t.Helper()
+	ctx := requestctx.WithUserID(context.Background(), 99)
+	for i, count := range counts {
+		user := &models.User{
+			Name:             "U",
+			Account:          string(rune('a'+i)) + "@example.com",
+			Password:         "x",
+			Type:             models.Panel,
+			Status:           true,
+			FailedLoginCount: count,
+		}
+		if i%2 == 1 {
+			user.Type = models.Dashboard
+		}
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("kullanıcı oluşturulamadı: %v", err)
+		}
+	}
+}
+
+func TestAggregatesOverTaggedColumn(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	repo := NewBaseRepository[models.User](db)
+	seedFailedLogins(t, repo, 2, 5, 4, 9)
+	ctx := context.Background()
+	panel := map[string]interface{}{"type": models.Panel}
+
+	cases := []struct {
+		name string
+		fn   func(context.Context, string, map[string]interface{}) (float64, error)
+		want float64
+	}{
+		{"SumWhere", repo.SumWhere, 6},
+		{"MinWhere", repo.MinWhere, 2},
+		{"MaxWhere", repo.MaxWhere, 4},
+		{"AvgWhere", repo.AvgWhere, 3},
+	}
+	for _, tc := range cases {
+		got, err := tc.fn(ctx, "failed_login_count", panel)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s = %v, beklenen %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAggregateWithoutRowsIsZero(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	repo := NewBaseRepository[models.User](db)
+
+	for name, fn := range map[string]func(context.Context, string, map[string]interface{}) (float64, error){
+		"SumWhere": repo.SumWhere,
+		"MaxWhere": repo.MaxWhere,
+		"AvgWhere": repo.AvgWhere,
+	} {
+		got, err := fn(context.Background(), "failed_login_count", nil)
+		if err != nil || got != 0 {
+			t.Errorf("%s = %v, %v; beklenen 0, nil", name, got, err)
+		}
+	}
+}
+
+func TestAggregateRejectsUntaggedColumn(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	repo := NewBaseRepository[models.User](db)
+
+	for _, column := range []string{"password", "id); DROP TABLE users; --"} {
+		if _, err := repo.SumWhere(context.Background(), column, nil); !errors.Is(err, ErrColumnNotAllowed) {
+			t.Errorf("SumWhere(%q) err = %v, beklenen ErrColumnNotAllowed", column, err)
+		}
+	}
+}
+
+// Sürüm kolonu taşıyan en küçük model; iyimser kilit testleri için
+type versionedNote struct {
+	ID        uint   `gorm:"primarykey"`
+	Title     string `gorm:"uniqueIndex"`
+	Body      string
+	Version   uint `gorm:"not null;default:1"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func newVersionedRepo(t *testing.T) (*BaseRepository[versionedNote], uint) {
+	t.Helper()
+	db := testutil.NewDB(t, &versionedNote{})
+	repo := NewBaseRepository[versionedNote](db)
+	repo.EnableOptimisticLocking("version")
+	note := &versionedNote{Title: "ilk"}
+	if err := repo.Create(context.Background(), note); err != nil {
+		t.Fatalf("kayıt oluşturulamadı: %v", err)
+	}
+	return repo, note.ID
+}
+
+func TestUpdateDistinguishesMissingFromUnchanged(t *testing.T) {
+	repo := NewBaseRepository[versionedNote](testutil.NewDB(t, &versionedNote{}))
+	note := &versionedNote{Title: "ilk", Body: "gövde"}
+	if err := repo.Create(context.Background(), note); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Update(context.Background(), note.ID+100, map[string]interface{}{"body": "yeni"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("olmayan kayıt err = %v, beklenen ErrNotFound", err)
+	}
+
+	same, err := repo.Update(context.Background(), note.ID, map[string]interface{}{"title": "ilk", "body": "gövde"})
+	if err != nil {
+		t.Fatalf("aynı değerlerle Update: %v", err)
+	}
+	if same.Title != "ilk" || same.Body != "gövde" {
+		t.Errorf("aynı değerlerle dönen kayıt = %+v", same)
+	}
+
+	updated, err := repo.Update(context.Background(), note.ID, map[string]interface{}{"body": "yeni"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.ID != note.ID || updated.Title != "ilk" || updated.Body != "yeni" {
+		t.Errorf("dönen kayıt = %+v; güncel durum beklenir", updated)
+	}
+}
+
+func TestUpdateLeavesCallerMapUntouched(t *testing.T) {
+	repo, id := newVersionedRepo(t)
+	data := map[string]interface{}{"title": "ikinci", "version": uint(1)}
+
+	if _, err := repo.Update(context.Background(), id, data); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(data) != 2 || data["version"] != uint(1) {
+		t.Errorf("çağıranın haritası değişti: %v", data)
+	}
+
+	users := NewBaseRepository[models.User](testutil.NewDB(t, &models.User{}, &models.AuditLog{}))
+	seedFailedLogins(t, users, 0)
+	bulk := map[string]interface{}{"name": "Toplu"}
+	ctx := requestctx.WithUserID(context.Background(), 7)
+	if _, err := users.BulkUpdate(ctx, map[string]interface{}{"type": models.Panel}, bulk); err != nil {
+		t.Fatalf("BulkUpdate: %v", err)
+	}
+	if _, stamped := bulk[updatedByColumn]; stamped || len(bulk) != 1 {
+		t.Errorf("BulkUpdate çağıranın haritasını değiştirdi: %v", bulk)
+	}
+}
+
+func TestConcurrentUpdatesSecondConflicts(t *testing.T) {
+	repo, id := newVersionedRepo(t)
+	ctx := context.Background()
+
+	// İki yönetici formu aynı sürümle açtı
+	first := map[string]interface{}{"title": "A", "version": uint(1)}
+	second := map[string]interface{}{"title": "B", "version": uint(1)}
+
+	updated, err := repo.Update(ctx, id, first)
+	if err != nil {
+		t.Fatalf("ilk Update: %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("sürüm = %d, beklenen 2", updated.Version)
+	}
+	if _, err := repo.Update(ctx, id, second); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("ikinci Update err = %v, beklenen ErrVersionConflict", err)
+	}
+
+	stored, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Title != "A" || stored.Version != 2 {
+		t.Errorf("kayıt = %q/v%d, beklenen A/v2", stored.Title, stored.Version)
+	}
+	if _, err := repo.Update(ctx, id, map[string]interface{}{"title": "C"}); !errors.Is(err, ErrMissingVersion) {
+		t.Errorf("sürümsüz Update err = %v, beklenen ErrMissingVersion", err)
+	}
+}
+
+// Formdan gelmeyen yazmalar sürümü kontrol etmeden artırır
+func TestSystemWritesBumpVersion(t *testing.T) {
+	repo, id := newVersionedRepo(t)
+	ctx := WithoutVersionCheck(context.Background())
+
+	if _, err := repo.Update(ctx, id, map[string]interface{}{"body": "sistem"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := repo.BulkUpdate(context.Background(), map[string]interface{}{"id": id}, map[string]interface{}{"body": "toplu"}); err != nil {
+		t.Fatalf("BulkUpdate: %v", err)
+	}
+	note := versionedNote{Title: "ilk", Body: "upsert"}
+	if err := repo.Upsert(context.Background(), &note, []string{"title"}, []string{"body"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	stored, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Version != 4 || stored.Body != "upsert" {
+		t.Errorf("kayıt = %q/v%d, beklenen upsert/v4", stored.Body, stored.Version)
+	}
+	if _, err := repo.Update(context.Background(), id, map[string]interface{}{"body": "eski form", "version": uint(1)}); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("eski sürümle Update err = %v, beklenen ErrVersionConflict", err)
+	}
+}
+
+func TestUpsertDoNothingLoadsExistingRow(t *testing.T) {
+	repo, id := newVersionedRepo(t)
+
+	note := versionedNote{Title: "ilk", Body: "yok sayılır"}
+	if err := repo.Upsert(context.Background(), &note, []string{"title"}, nil); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if note.ID != id || note.Body != "" {
+		t.Errorf("dönen kayıt = id %d / %q, beklenen mevcut kayıt id %d", note.ID, note.Body, id)
+	}
+
+	fresh := versionedNote{Title: "yeni"}
+	if err := repo.Upsert(context.Background(), &fresh, []string{"title"}, nil); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if fresh.ID == 0 || fresh.ID == id {
+		t.Errorf("yeni kayıt id = %d", fresh.ID)
+	}
+}
+
+func TestUpsertInsertsNewRowAndUpdatesOnConflict(t *testing.T) {
+	repo, id := newVersionedRepo(t)
+	ctx := context.Background()
+
+	fresh := versionedNote{Title: "yeni", Body: "ilk gövde"}
+	if err := repo.Upsert(ctx, &fresh, []string{"title"}, []string{"body"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if fresh.ID == 0 || fresh.ID == id {
+		t.Fatalf("yeni kayıt id = %d", fresh.ID)
+	}
+
+	existing := versionedNote{Title: "ilk", Body: "güncel"}
+	if err := repo.Upsert(ctx, &existing, []string{"title"}, []string{"body"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if total, err := repo.CountWhere(ctx, map[string]interface{}{}); err != nil || total != 2 {
+		t.Fatalf("kayıt sayısı = %d, err = %v; beklenen 2", total, err)
+	}
+	stored, err := repo.GetByID(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Body != "güncel" || stored.Version != 2 {
+		t.Errorf("çakışan kayıt = %q/v%d, beklenen güncel/v2", stored.Body, stored.Version)
+	}
+}
+
+func TestBulkUpsertMixesInsertsAndUpdates(t *testing.T) {
+	repo, id := newVersionedRepo(t)
+	ctx := context.Background()
+
+	notes := []versionedNote{{Title: "ilk", Body: "güncel"}, {Title: "ikinci", Body: "yeni"}}
+	if err := repo.BulkUpsert(ctx, notes, []string{"title"}, []string{"body"}); err != nil {
+		t.Fatalf("BulkUpsert: %v", err)
+	}
+
+	items, _, err := repo.GetAll(ctx, queryparams.ListParams{SortBy: "id", OrderBy: "asc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("kayıt sayısı = %d, beklenen 2", len(items))
+	}
+	if items[0].ID != id || items[0].Body != "güncel" {
+		t.Errorf("mevcut kayıt = id %d / %q, beklenen id %d / güncel", items[0].ID, items[0].Body, id)
+	}
+	if items[1].Title != "ikinci" || items[1].Body != "yeni" {
+		t.Errorf("eklenen kayıt = %q / %q", items[1].Title, items[1].Body)
+	}
+}
+
+func TestFirstOrCreateReturnsExistingOrCreates(t *testing.T) {
+	repo, id := newVersionedRepo(t)
+	ctx := context.Background()
+
+	var found versionedNote
+	created, err := repo.FirstOrCreate(ctx, map[string]interface{}{"title": "ilk"}, &found)
+	if err != nil {
+		t.Fatalf("FirstOrCreate: %v", err)
+	}
+	if created || found.ID != id {
+		t.Errorf("mevcut kayıt: created = %v, id = %d; beklenen false, %d", created, found.ID, id)
+	}
+
+	fresh := versionedNote{Title: "yeni", Body: "gövde"}
+	created, err = repo.FirstOrCreate(ctx, map[string]interface{}{"title": "yeni"}, &fresh)
+	if err != nil {
+		t.Fatalf("FirstOrCreate: %v", err)
+	}
+	if !created || fresh.ID == 0 || fresh.ID == id {
+		t.Errorf("yeni kayıt: created = %v, id = %d", created, fresh.ID)
+	}
+	if total, err := repo.CountWhere(ctx, map[string]interface{}{}); err != nil || total != 2 {
+		t.Errorf("kayıt sayısı = %d, err = %v; beklenen 2", total, err)
+	}
+}
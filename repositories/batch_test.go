@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"zatrano/pkg/testutil"
+)
+
+func newLabelledRepo(t *testing.T, count int) *BaseRepository[labelled] {
+	t.Helper()
+	db := testutil.NewDB(t, &labelled{})
+	for i := 0; i < count; i++ {
+		if err := db.Create(&labelled{Title: fmt.Sprintf("k%02d", i)}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewBaseRepository[labelled](db)
+}
+
+func TestForEachBatchReadsEveryRowOnce(t *testing.T) {
+	repo := newLabelledRepo(t, 25)
+
+	var sizes []int
+	seen := map[uint]bool{}
+	err := repo.ForEachBatch(context.Background(), nil, 10, func(batch []labelled) error {
+		sizes = append(sizes, len(batch))
+		for _, item := range batch {
+			if seen[item.ID] {
+				t.Errorf("kayıt %d iki kez okundu", item.ID)
+			}
+			seen[item.ID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sizes) != "[10 10 5]" || len(seen) != 25 {
+		t.Errorf("parti boyutları = %v, okunan = %d", sizes, len(seen))
+	}
+}
+
+func TestForEachBatchStopsWhenContextEnds(t *testing.T) {
+	repo := newLabelledRepo(t, 25)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	err := repo.ForEachBatch(ctx, nil, 10, func([]labelled) error {
+		calls++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("hata = %v, çağrı = %d; beklenen context.Canceled ve tek parti", err, calls)
+	}
+}
+
+func TestForEachBatchAppliesQueryTimeoutPerBatch(t *testing.T) {
+	repo := newLabelledRepo(t, 3)
+	repo.SetQueryTimeout(time.Nanosecond)
+
+	err := repo.ForEachBatch(context.Background(), nil, 10, func([]labelled) error { return nil })
+	if !errors.Is(err, ErrQueryTimeout) {
+		t.Errorf("hata = %v, beklenen ErrQueryTimeout", err)
+	}
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"zatrano/pkg/testutil"
+)
+
+func numberedNotes(n int) []versionedNote {
+	notes := make([]versionedNote, n)
+	for i := range notes {
+		notes[i] = versionedNote{Title: "not-" + strconv.Itoa(i)}
+	}
+	return notes
+}
+
+func TestBulkCreateInsertsEveryRow(t *testing.T) {
+	db := testutil.NewDB(t, &versionedNote{})
+	repo := NewBaseRepository[versionedNote](db)
+	notes := numberedNotes(10000)
+
+	if err := repo.BulkCreate(context.Background(), notes); err != nil {
+		t.Fatalf("BulkCreate: %v", err)
+	}
+	if n := countRows(t, db, &versionedNote{}); n != 10000 {
+		t.Errorf("%d satır, beklenen 10000", n)
+	}
+	if notes[0].ID == 0 || notes[9999].ID == 0 {
+		t.Error("eklenen varlıkların ID'leri doldurulmadı")
+	}
+}
+
+func TestBulkCreateRollsBackEarlierBatchesOnFailure(t *testing.T) {
+	db := testutil.NewDB(t, &versionedNote{})
+	repo := NewBaseRepository[versionedNote](db)
+	repo.SetBulkBatchSize(500)
+
+	// Üçüncü partideki kayıt ilk partideki başlığı tekrarlar ve benzersizlik kısıtına takılır
+	notes := numberedNotes(1500)
+	notes[1200].Title = notes[10].Title
+
+	if err := repo.BulkCreate(context.Background(), notes); err == nil {
+		t.Fatal("tekrarlanan başlık hata vermedi")
+	}
+	if n := countRows(t, db, &versionedNote{}); n != 0 {
+		t.Errorf("başarısız toplu eklemeden sonra %d satır kaldı; önceki partiler geri alınmalı", n)
+	}
+}
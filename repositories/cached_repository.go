@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type cacheEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// Kiracı kapsamlı repository'de aynı kimlik başka kiracı için okunamaz; kiracısız kopyalar 0 altında tutulur.
+// GetOrLoad'ın kapsam dışı kopyaları ayrı tutulur ki kiracıya bağlı okumalara sızmasın.
+type cacheKey struct {
+	tenantID uint
+	id       uint
+	unscoped bool
+}
+
+type cacheStore[T any] struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[cacheKey]cacheEntry[T]
+}
+
+type CachedRepository[T any] struct {
+	IBaseRepository[T]
+	store    *cacheStore[T]
+	tenantID uint
+	// WithTx ile bağlanan bağlantı; transaction içindeyse önbellek commit'e göre yönetilir
+	tx *gorm.DB
+}
+
+func NewCachedRepository[T any](inner IBaseRepository[T], ttl time.Duration) *CachedRepository[T] {
+	return &CachedRepository[T]{
+		IBaseRepository: inner,
+		store: &cacheStore[T]{
+			ttl:     ttl,
+			entries: make(map[cacheKey]cacheEntry[T]),
+		},
+	}
+}
+
+// Transaction içindeki okuma commit edilmemiş satırı görebilir; önbelleğe yazılmaz ve önbellekten okunmaz
+func (r *CachedRepository[T]) GetByID(ctx context.Context, id uint) (*T, error) {
+	if r.inTransaction() {
+		return r.IBaseRepository.GetByID(ctx, id)
+	}
+	key := cacheKey{tenantID: r.tenantID, id: id}
+	if value, ok := r.store.get(key); ok {
+		return &value, nil
+	}
+
+	result, err := r.IBaseRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.store.set(key, *result)
+	return result, nil
+}
+
+func (r *CachedRepository[T]) WithTx(tx *gorm.DB) IBaseRepository[T] {
+	return &CachedRepository[T]{
+		IBaseRepository: r.IBaseRepository.WithTx(tx),
+		store:           r.store,
+		tenantID:        r.tenantID,
+		tx:              tx,
+	}
+}
+
+// Kiracıya bağlanan kopya önbelleği paylaşır ama kayıtları kiracıyla anahtarlar
+func (r *CachedRepository[T]) WithTenant(tenantID uint) IBaseRepository[T] {
+	return &CachedRepository[T]{
+		IBaseRepository: r.IBaseRepository.WithTenant(tenantID),
+		store:           r.store,
+		tenantID:        tenantID,
+		tx:              r.tx,
+	}
+}
+
+// Kapsamı kaldırılan kopyanın okuduğu kayıt hiçbir kiracının önbelleğine yazılmamalı; önbellek atlanır
+func (r *CachedRepository[T]) WithoutTenantScope() IBaseRepository[T] {
+	return r.IBaseRepository.WithoutTenantScope()
+}
+
+func (r *CachedRepository[T]) Upsert(ctx context.Context, entity *T, conflictColumns []string, updateColumns []string) error {
+	defer r.flushAfterWrite()
+	return r.IBaseRepository.Upsert(ctx, entity, conflictColumns, updateColumns)
+}
+
+func (r *CachedRepository[T]) BulkUpsert(ctx context.Context, entities []T, conflictColumns []string, updateColumns []string) error {
+	defer r.flushAfterWrite()
+	return r.IBaseRepository.BulkUpsert(ctx, entities, conflictColumns, updateColumns)
+}
+
+func (r *CachedRepository[T]) Update(ctx context.Context, id uint, data map[string]interface{}) (*T, error) {
+	defer r.invalidateAfterWrite(id)
+	return r.IBaseRepository.Update(ctx, id, data)
+}
+
+func (r *CachedRepository[T]) BulkUpdate(ctx context.Context, condition map[string]interface{}, data map[string]interface{}) (int64, error) {
+	defer r.flushAfterWrite()
+	return r.IBaseRepository.BulkUpdate(ctx, condition, data)
+}
+
+func (r *CachedRepository[T]) Delete(ctx context.Context, id uint) error {
+	defer r.invalidateAfterWrite(id)
+	return r.IBaseRepository.Delete(ctx, id)
+}
+
+func (r *CachedRepository[T]) Restore(ctx context.Context, id uint) error {
+	defer r.invalidateAfterWrite(id)
+	return r.IBaseRepository.Restore(ctx, id)
+}
+
+func (r *CachedRepository[T]) ForceDelete(ctx context.Context, id uint) error {
+	defer r.invalidateAfterWrite(id)
+	return r.IBaseRepository.ForceDelete(ctx, id)
+}
+
+func (r *CachedRepository[T]) BulkDelete(ctx context.Context, condition map[string]interface{}) (int64, error) {
+	defer r.flushAfterWrite()
+	return r.IBaseRepository.BulkDelete(ctx, condition)
+}
+
+// Önbelleği atlayıp verilen bağlantıdan (genellikle birincil veritabanı) okur ve önbelleği tazeler
+func (r *CachedRepository[T]) Refresh(ctx context.Context, db *gorm.DB, id uint) (*T, error) {
+	result, err := r.IBaseRepository.WithTx(db).GetByID(ctx, id)
+	if err != nil {
+		r.Invalidate(id)
+		return nil, err
+	}
+	r.store.set(cacheKey{tenantID: r.tenantID, id: id}, *result)
+	return result, nil
+}
+
+// Kimlik doğrulama okumaları içindir: ıska repository'den değil load'dan (birincil veritabanına doğrudan sorgu)
+// doldurulur, kiracı kapsamı uygulanmaz. Yazmalar Invalidate ile bu kopyayı da siler; böylece yazmadan hemen
+// sonraki okuma gecikmeli replikadan eski kaydı önbelleğe geri taşıyamaz.
+func (r *CachedRepository[T]) GetOrLoad(id uint, load func() (*T, error)) (*T, error) {
+	key := cacheKey{id: id, unscoped: true}
+	if value, ok := r.store.get(key); ok {
+		return &value, nil
+	}
+	result, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.store.set(key, *result)
+	return result, nil
+}
+
+func (r *CachedRepository[T]) inTransaction() bool {
+	return r.tx != nil && inTransaction(r.tx)
+}
+
+// Önbellek yazmadan hemen sonra temizlenir; transaction içindeyse commit sonrasında bir kez daha temizlenir.
+// Aksi halde commit'e kadar eşzamanlı bir okuma eski satırı yeniden önbelleğe yazar ve o satır TTL boyunca kalırdı.
+func (r *CachedRepository[T]) invalidateAfterWrite(id uint) {
+	r.Invalidate(id)
+	if r.inTransaction() {
+		afterCommit(r.tx, func() { r.Invalidate(id) })
+	}
+}
+
+func (r *CachedRepository[T]) flushAfterWrite() {
+	r.Flush()
+	if r.inTransaction() {
+		afterCommit(r.tx, r.Flush)
+	}
+}
+
+// Kaydın tüm kiracılar altındaki kopyaları silinir
+func (r *CachedRepository[T]) Invalidate(id uint) {
+	r.store.mu.Lock()
+	for key := range r.store.entries {
+		if key.id == id {
+			delete(r.store.entries, key)
+		}
+	}
+	r.store.mu.Unlock()
+}
+
+func (r *CachedRepository[T]) Flush() {
+	r.store.mu.Lock()
+	r.store.entries = make(map[cacheKey]cacheEntry[T])
+	r.store.mu.Unlock()
+}
+
+func (s *cacheStore[T]) get(key cacheKey) (T, bool) {
+	s.mu.RLock()
+	entry, ok := s.entries[key]
+	s.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (s *cacheStore[T]) set(key cacheKey, value T) {
+	s.mu.Lock()
+	s.entries[key] = cacheEntry[T]{value: value, expiresAt: time.Now().Add(s.ttl)}
+	s.mu.Unlock()
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+func newCachedUsers(t *testing.T) (*gorm.DB, *CachedRepository[models.User], uint) {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{})
+	repo := NewCachedRepository[models.User](NewBaseRepository[models.User](db), time.Minute)
+	user := &models.User{Name: "Eski", Account: "ayse@example.com", Password: "x", Type: models.Panel, Status: true}
+	if err := repo.Create(requestctx.WithUserID(context.Background(), 1), user); err != nil {
+		t.Fatal(err)
+	}
+	return db, repo, user.ID
+}
+
+func cachedName(t *testing.T, repo IBaseRepository[models.User], id uint) string {
+	t.Helper()
+	user, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	return user.Name
+}
+
+func TestCachedRepositoryInvalidatesAfterCommit(t *testing.T) {
+	db, repo, id := newCachedUsers(t)
+	ctx := requestctx.WithUserID(context.Background(), 1)
+
+	err := Transaction(ctx, db, func(tx *gorm.DB) error {
+		if _, err := repo.WithTx(tx).Update(ctx, id, map[string]interface{}{"name": "Yeni"}); err != nil {
+			return err
+		}
+		// Transaction dışındaki eşzamanlı okuma commit edilmiş eski satırı yeniden önbelleğe yazar
+		if name := cachedName(t, repo, id); name != "Eski" {
+			t.Errorf("commit öncesi dış okuma %q gördü", name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name := cachedName(t, repo, id); name != "Yeni" {
+		t.Errorf("commit sonrası önbellekten %q okundu, beklenen Yeni", name)
+	}
+}
+
+func TestCachedRepositoryFlushesBulkUpdateAfterCommit(t *testing.T) {
+	db, repo, id := newCachedUsers(t)
+	ctx := requestctx.WithUserID(context.Background(), 1)
+
+	err := Transaction(ctx, db, func(tx *gorm.DB) error {
+		if _, err := repo.WithTx(tx).BulkUpdate(ctx, map[string]interface{}{"id": id}, map[string]interface{}{"name": "Toplu"}); err != nil {
+			return err
+		}
+		cachedName(t, repo, id)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name := cachedName(t, repo, id); name != "Toplu" {
+		t.Errorf("commit sonrası önbellekten %q okundu, beklenen Toplu", name)
+	}
+}
+
+func TestCachedRepositoryIgnoresRolledBackWrites(t *testing.T) {
+	db, repo, id := newCachedUsers(t)
+	ctx := requestctx.WithUserID(context.Background(), 1)
+	errRollback := errors.New("geri al")
+
+	err := Transaction(ctx, db, func(tx *gorm.DB) error {
+		txRepo := repo.WithTx(tx)
+		if _, err := txRepo.Update(ctx, id, map[string]interface{}{"name": "Yeni"}); err != nil {
+			return err
+		}
+		// Commit edilmemiş satır transaction içinde görülür ama önbelleğe yazılmamalı
+		if name := cachedName(t, txRepo, id); name != "Yeni" {
+			t.Errorf("transaction içi okuma %q gördü", name)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("err = %v", err)
+	}
+
+	if name := cachedName(t, repo, id); name != "Eski" {
+		t.Errorf("geri alınan yazmadan sonra %q okundu, beklenen Eski", name)
+	}
+}
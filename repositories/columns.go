@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+const columnTagKey = "zatrano"
+
+// Alan etiketi olmasa da her modelde sıralanıp filtrelenebilen standart kolonlar
+var defaultListColumns = []string{"id", "created_at", "updated_at"}
+
+type modelColumns struct {
+	sortable   []string
+	filterable []string
+	searchable []string
+	// Sum/Min/Max/Avg yalnız zatrano:"aggregatable" etiketli sayısal kolonlarda çalışır
+	aggregatable []string
+	// Modelde created_by / updated_by kolonları varsa repository bunları context'teki kullanıcıyla doldurur
+	createdBy bool
+	updatedBy bool
+}
+
+// T'nin alanlarını (gömülü struct'lar dahil) gezip zatrano:"sortable,filterable,searchable,aggregatable" etiketlerinden izin listelerini çıkarır
+func discoverColumns[T any]() modelColumns {
+	columns := modelColumns{
+		sortable:   append([]string(nil), defaultListColumns...),
+		filterable: append([]string(nil), defaultListColumns...),
+	}
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() == reflect.Struct {
+		collectColumns(typ, schema.NamingStrategy{}, &columns)
+	}
+	return columns
+}
+
+func collectColumns(typ reflect.Type, naming schema.NamingStrategy, columns *modelColumns) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		gormTag := schema.ParseTagSetting(field.Tag.Get("gorm"), ";")
+		if _, ignored := gormTag["-"]; ignored {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if _, embedded := gormTag["EMBEDDED"]; (field.Anonymous || embedded) && fieldType.Kind() == reflect.Struct {
+			collectColumns(fieldType, naming, columns)
+			continue
+		}
+
+		column := gormTag["COLUMN"]
+		if column == "" {
+			column = naming.ColumnName("", field.Name)
+		}
+		switch column {
+		case createdByColumn:
+			columns.createdBy = true
+		case updatedByColumn:
+			columns.updatedBy = true
+		}
+
+		tag, ok := field.Tag.Lookup(columnTagKey)
+		if !ok {
+			continue
+		}
+
+		for _, option := range strings.Split(tag, ",") {
+			switch strings.TrimSpace(option) {
+			case "sortable":
+				columns.sortable = appendMissingColumn(columns.sortable, column)
+			case "filterable":
+				columns.filterable = appendMissingColumn(columns.filterable, column)
+			case "searchable":
+				columns.searchable = appendMissingColumn(columns.searchable, column)
+			case "aggregatable":
+				columns.aggregatable = appendMissingColumn(columns.aggregatable, column)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/testutil"
+)
+
+type renamedColumns struct {
+	ID      uint   `gorm:"primarykey"`
+	Title   string `gorm:"column:baslik;size:100" zatrano:"sortable,searchable"`
+	Total   int    `gorm:"not null;column:toplam_tutar" zatrano:"filterable,aggregatable"`
+	Author  *uint  `gorm:"column:created_by"`
+	Ignored string `gorm:"-" zatrano:"sortable"`
+}
+
+func TestDiscoverColumnsUsesGormColumnTag(t *testing.T) {
+	columns := discoverColumns[renamedColumns]()
+
+	checks := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"sortable", columns.sortable, append(append([]string(nil), defaultListColumns...), "baslik")},
+		{"filterable", columns.filterable, append(append([]string(nil), defaultListColumns...), "toplam_tutar")},
+		{"searchable", columns.searchable, []string{"baslik"}},
+		{"aggregatable", columns.aggregatable, []string{"toplam_tutar"}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s = %v, beklenen %v", c.name, c.got, c.want)
+		}
+	}
+	if !columns.createdBy || columns.updatedBy {
+		t.Errorf("createdBy = %v, updatedBy = %v; beklenen true, false", columns.createdBy, columns.updatedBy)
+	}
+
+	// Keşfedilen ad sorguda da kullanılabilmeli
+	db := testutil.NewDB(t, &renamedColumns{})
+	for _, title := range []string{"b", "a"} {
+		if err := db.Create(&renamedColumns{Title: title}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, _, err := NewBaseRepository[renamedColumns](db).GetAll(context.Background(), queryparams.ListParams{SortBy: "baslik", OrderBy: "asc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Title != "a" {
+		t.Fatalf("baslik'a göre sıralama = %+v", items)
+	}
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/testutil"
+)
+
+type cursorRow struct {
+	ID     uint   `gorm:"primarykey"`
+	Status string `zatrano:"sortable"`
+	Name   string `zatrano:"sortable"`
+	Secret string
+}
+
+func (r cursorRow) GetID() uint { return r.ID }
+
+// Sayfalar arasında eklenen satırlar, önceden var olan satırları çift göstermemeli ya da atlatmamalı
+func TestCursorPaginationIsStableWhileRowsAreInserted(t *testing.T) {
+	tests := []struct {
+		name    string
+		sortBy  string
+		orderBy string
+	}{
+		{"id artan", "id", "asc"},
+		{"id azalan", "id", "desc"},
+		// Eşit durum değerlerinde sıra id ile belirlenir
+		{"tekrarlı kolon artan", "status", "asc"},
+		{"tekrarlı kolon azalan", "status", "desc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutil.NewDB(t, &cursorRow{})
+			original := map[uint]bool{}
+			for i := 0; i < 10; i++ {
+				row := cursorRow{Status: []string{"a", "b", "c"}[i%3]}
+				if err := db.Create(&row).Error; err != nil {
+					t.Fatal(err)
+				}
+				original[row.ID] = true
+			}
+			repo := NewBaseRepository[cursorRow](db)
+
+			seen := map[uint]int{}
+			params := queryparams.ListParams{PerPage: 3, SortBy: tt.sortBy, OrderBy: tt.orderBy}
+			for page := 0; ; page++ {
+				if page > 20 {
+					t.Fatal("imleç sayfalaması bitmedi")
+				}
+				items, next, err := repo.GetAllCursor(context.Background(), params)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, item := range items {
+					seen[item.ID]++
+				}
+				if next == "" {
+					break
+				}
+				// Her sayfadan sonra imlecin hem önüne hem arkasına düşen satırlar eklenir
+				for _, status := range []string{"a", "c"} {
+					if err := db.Create(&cursorRow{Status: status}).Error; err != nil {
+						t.Fatal(err)
+					}
+				}
+				params.Cursor = next
+			}
+
+			for id, count := range seen {
+				if count > 1 {
+					t.Fatalf("id %d %d kez listelendi", id, count)
+				}
+			}
+			for id := range original {
+				if seen[id] != 1 {
+					t.Fatalf("id %d atlandı; görülenler %v", id, seen)
+				}
+			}
+		})
+	}
+}
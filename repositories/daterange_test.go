@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/templatehelpers"
+	"zatrano/pkg/testutil"
+)
+
+type dated struct {
+	ID        uint `gorm:"primarykey"`
+	Title     string
+	CreatedAt time.Time
+}
+
+// İstanbul'da 1 Mayıs 23:30 UTC'de hâlâ 1 Mayıs 20:30'dur; ekranda ve filtrede yerel gün esas alınır
+func TestLocalDayFilterMatchesLateEveningRecord(t *testing.T) {
+	db := testutil.NewDB(t, &dated{})
+	loc := configsapp.Location()
+	if loc.String() != "Europe/Istanbul" {
+		t.Fatalf("test saat dilimi %s, beklenen Europe/Istanbul", loc)
+	}
+	createdAt := time.Date(2024, 5, 1, 23, 30, 0, 0, loc)
+	if err := db.Create(&dated{Title: "gece", CreatedAt: createdAt.UTC()}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&dated{Title: "ertesi gün", CreatedAt: createdAt.Add(time.Hour).UTC()}).Error; err != nil {
+		t.Fatal(err)
+	}
+	repo := NewBaseRepository[dated](db)
+
+	for day, want := range map[string]string{"2024-05-01": "gece", "2024-05-02": "ertesi gün"} {
+		items, _, err := repo.GetAll(context.Background(), queryparams.ListParams{CreatedFrom: day, CreatedTo: day})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 || items[0].Title != want {
+			t.Errorf("%s filtresi = %v, beklenen yalnızca %q", day, items, want)
+		}
+	}
+
+	var stored dated
+	if err := db.Where("title = ?", "gece").First(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	templatehelpers.SetLocation(loc)
+	t.Cleanup(func() { templatehelpers.SetLocation(nil) })
+	formatDateTime := templatehelpers.TemplateHelpers()["formatDateTime"].(func(any) string)
+	if got := formatDateTime(stored.CreatedAt); got != "01.05.2024 23:30" {
+		t.Errorf("gösterilen tarih = %q, beklenen 01.05.2024 23:30", got)
+	}
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+func newDeletableUser(t *testing.T) (*gorm.DB, *BaseRepository[models.User], uint) {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{})
+	repo := NewBaseRepository[models.User](db)
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Type: models.Panel, Status: true}
+	if err := repo.Create(requestctx.WithUserID(context.Background(), 1), user); err != nil {
+		t.Fatal(err)
+	}
+	return db, repo, user.ID
+}
+
+func TestDeleteSoftDeletesAndStampsActor(t *testing.T) {
+	db, repo, id := newDeletableUser(t)
+
+	if err := repo.Delete(context.Background(), id); !errors.Is(err, ErrMissingUserID) {
+		t.Errorf("kullanıcısız Delete err = %v, beklenen ErrMissingUserID", err)
+	}
+	if err := repo.Delete(requestctx.WithUserID(context.Background(), 7), id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := repo.GetByID(context.Background(), id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("silinen kayıt okundu: %v", err)
+	}
+	var stored models.User
+	if err := db.Unscoped().First(&stored, id).Error; err != nil {
+		t.Fatalf("satır kalıcı olarak silindi: %v", err)
+	}
+	if !stored.DeletedAt.Valid || stored.DeletedBy == nil || *stored.DeletedBy != 7 {
+		t.Errorf("deleted_at = %v, deleted_by = %v; beklenen dolu ve 7", stored.DeletedAt, stored.DeletedBy)
+	}
+}
+
+func TestDeleteMissingOrAlreadyDeletedIsNotFound(t *testing.T) {
+	db, repo, id := newDeletableUser(t)
+
+	if err := repo.Delete(requestctx.WithUserID(context.Background(), 7), id+100); !errors.Is(err, ErrNotFound) {
+		t.Errorf("olmayan kayıt err = %v, beklenen ErrNotFound", err)
+	}
+
+	if err := repo.Delete(requestctx.WithUserID(context.Background(), 7), id); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(requestctx.WithUserID(context.Background(), 8), id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ikinci Delete err = %v, beklenen ErrNotFound", err)
+	}
+	// Tekrarlanan silme ilk silen kullanıcıyı ezmemeli
+	var stored models.User
+	if err := db.Unscoped().First(&stored, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.DeletedBy == nil || *stored.DeletedBy != 7 {
+		t.Errorf("deleted_by = %v, beklenen 7", stored.DeletedBy)
+	}
+}
+
+func listedIDs(t *testing.T, repo *BaseRepository[models.User], params queryparams.ListParams) []uint {
+	t.Helper()
+	users, total, err := repo.GetAll(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if int(total) != len(users) {
+		t.Errorf("toplam %d, liste %d kayıt", total, len(users))
+	}
+	ids := make([]uint, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+func TestDeleteRestoreLifecycle(t *testing.T) {
+	_, repo, id := newDeletableUser(t)
+	ctx := requestctx.WithUserID(context.Background(), 7)
+	active := queryparams.ListParams{}
+	deleted := queryparams.ListParams{OnlyDeleted: true}
+
+	if err := repo.Restore(ctx, id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("silinmemiş kaydı geri yükleme err = %v, beklenen ErrNotFound", err)
+	}
+
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if ids := listedIDs(t, repo, active); len(ids) != 0 {
+		t.Errorf("silinen kayıt normal listede: %v", ids)
+	}
+	if ids := listedIDs(t, repo, deleted); len(ids) != 1 || ids[0] != id {
+		t.Errorf("OnlyDeleted listesi = %v, beklenen [%d]", ids, id)
+	}
+
+	if err := repo.Restore(ctx, id); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if ids := listedIDs(t, repo, active); len(ids) != 1 || ids[0] != id {
+		t.Errorf("geri yüklenen kayıt normal listede yok: %v", ids)
+	}
+	if ids := listedIDs(t, repo, deleted); len(ids) != 0 {
+		t.Errorf("geri yüklenen kayıt OnlyDeleted listesinde: %v", ids)
+	}
+	restored, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("geri yüklenen kayıt okunamadı: %v", err)
+	}
+	if restored.DeletedBy != nil || restored.UpdatedBy != 7 {
+		t.Errorf("deleted_by = %v, updated_by = %d; beklenen nil ve 7", restored.DeletedBy, restored.UpdatedBy)
+	}
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/testutil"
+)
+
+type labelled struct {
+	ID    uint `gorm:"primarykey"`
+	Title string
+}
+
+func TestFilterLikeMatchesWildcardsLiterally(t *testing.T) {
+	db := testutil.NewDB(t, &labelled{})
+	for _, title := range []string{"100% pamuk", "1000 pamuk", "a_b", "axb"} {
+		if err := db.Create(&labelled{Title: title}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := NewBaseRepository[labelled](db)
+	repo.SetAllowedFilterColumns([]string{"title"})
+
+	cases := map[string]string{"100%": "100% pamuk", "a_b": "a_b"}
+	for value, want := range cases {
+		params := queryparams.ListParams{Filters: []queryparams.Filter{{Column: "title", Operator: queryparams.OpLike, Value: value}}}
+		items, _, err := repo.GetAll(context.Background(), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 || items[0].Title != want {
+			t.Errorf("like %q = %v, beklenen yalnızca %q", value, items, want)
+		}
+	}
+}
+
+func TestFilterEmptyInListIsSkipped(t *testing.T) {
+	db := testutil.NewDB(t, &labelled{})
+	for _, title := range []string{"a", "b"} {
+		if err := db.Create(&labelled{Title: title}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := NewBaseRepository[labelled](db)
+	repo.SetAllowedFilterColumns([]string{"title"})
+
+	params := queryparams.ListParams{Filters: []queryparams.Filter{{Column: "title", Operator: queryparams.OpIn, Value: " , "}}}
+	if _, total, err := repo.GetAll(context.Background(), params); err != nil || total != 2 {
+		t.Fatalf("boş in listesi: total = %d, err = %v; beklenen filtresiz 2 kayıt", total, err)
+	}
+}
+
+type contact struct {
+	ID    uint   `gorm:"primarykey"`
+	Name  string `zatrano:"searchable"`
+	Email string `zatrano:"searchable"`
+	Note  string
+}
+
+// Arama terimi aranabilir kolonlardan herhangi birinde geçen satırı bulur; her terim ayrı kolondan eşleşebilir
+func TestSearchMatchesAcrossSearchableColumns(t *testing.T) {
+	db := testutil.NewDB(t, &contact{})
+	for _, row := range []contact{
+		{Name: "deniz yıldız", Email: "dy@ornek.com"},
+		{Name: "ayşe kaya", Email: "deniz@ornek.com"},
+		{Name: "can", Email: "can@ornek.com", Note: "deniz"},
+		{Name: "kaya", Email: "mert@ornek.com"},
+	} {
+		row := row
+		if err := db.Create(&row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := NewBaseRepository[contact](db)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// Not aranabilir değil; üçüncü satır eşleşmez
+		{"deniz", []string{"deniz yıldız", "ayşe kaya"}},
+		// "kaya" addan, "deniz" e-postadan eşleşir
+		{"kaya deniz", []string{"ayşe kaya"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			items, _, err := repo.GetAll(context.Background(), queryparams.ListParams{Query: tt.query, SortBy: "id", OrderBy: "asc"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, len(items))
+			for i, item := range items {
+				got[i] = item.Name
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("arama %q = %v, beklenen %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+)
+
+// Kalıcılığa bağlı küçük işler (slug normalleştirme, cache temizleme, bildirim kuyruğa alma) için
+// repository seviyesinde kaydedilen hook'lar. Her hook tipi birden fazla handler alır ve kayıt sırasıyla çalışır.
+//
+// Before hook'lar veritabanı çağrısından önce çalışır; hata dönerse işlem iptal edilir.
+// After hook'lar yalnızca işlem başarılı olduktan sonra çalışır: repository Transaction ile açılmış
+// bir transaction'a bağlıysa (WithTx) commit sonrasına ertelenir, rollback olursa hiç çalışmaz.
+// gorm'un Transaction'ı gibi kuyruğu olmayan bir transaction'a bağlı repository'de işlemden hemen sonra çalışır.
+// After hook'lara verilen context'in iptali kaldırılır; ertelenen hook işlemin zaman aşımı bittikten sonra çalışır.
+//
+// Toplu işlemler hook'ları kayıt başına çağırır: BulkCreate her varlık için create hook'larını,
+// BulkUpdate ve BulkDelete koşula uyan her id için update / delete hook'larını çalıştırır.
+// Upsert ve BulkUpsert satırın eklenip eklenmediğini bilemediğinden hook çalıştırmaz.
+// Kayıt, istekler sürerken de yapılabilir; çalıştırıcılar o anki listenin kopyasıyla çalışır.
+// WithTx ile bağlanan kopyalar aynı hook kümesini paylaşır.
+type repositoryHooks[T any] struct {
+	mu           sync.RWMutex
+	beforeCreate []func(ctx context.Context, entity *T) error
+	afterCreate  []func(ctx context.Context, entity *T)
+	beforeUpdate []func(ctx context.Context, id uint, data map[string]interface{}) error
+	afterUpdate  []func(ctx context.Context, id uint, data map[string]interface{})
+	afterDelete  []func(ctx context.Context, id uint)
+}
+
+func (r *BaseRepository[T]) OnBeforeCreate(hook func(ctx context.Context, entity *T) error) {
+	r.hooks.mu.Lock()
+	defer r.hooks.mu.Unlock()
+	r.hooks.beforeCreate = append(r.hooks.beforeCreate, hook)
+}
+
+func (r *BaseRepository[T]) OnAfterCreate(hook func(ctx context.Context, entity *T)) {
+	r.hooks.mu.Lock()
+	defer r.hooks.mu.Unlock()
+	r.hooks.afterCreate = append(r.hooks.afterCreate, hook)
+}
+
+func (r *BaseRepository[T]) OnBeforeUpdate(hook func(ctx context.Context, id uint, data map[string]interface{}) error) {
+	r.hooks.mu.Lock()
+	defer r.hooks.mu.Unlock()
+	r.hooks.beforeUpdate = append(r.hooks.beforeUpdate, hook)
+}
+
+func (r *BaseRepository[T]) OnAfterUpdate(hook func(ctx context.Context, id uint, data map[string]interface{})) {
+	r.hooks.mu.Lock()
+	defer r.hooks.mu.Unlock()
+	r.hooks.afterUpdate = append(r.hooks.afterUpdate, hook)
+}
+
+func (r *BaseRepository[T]) OnAfterDelete(hook func(ctx context.Context, id uint)) {
+	r.hooks.mu.Lock()
+	defer r.hooks.mu.Unlock()
+	r.hooks.afterDelete = append(r.hooks.afterDelete, hook)
+}
+
+func (r *BaseRepository[T]) runBeforeCreate(ctx context.Context, entities ...*T) error {
+	r.hooks.mu.RLock()
+	hooks := r.hooks.beforeCreate
+	r.hooks.mu.RUnlock()
+	for _, entity := range entities {
+		for _, hook := range hooks {
+			if err := hook(ctx, entity); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *BaseRepository[T]) runAfterCreate(ctx context.Context, entities ...*T) {
+	r.hooks.mu.RLock()
+	hooks := r.hooks.afterCreate
+	r.hooks.mu.RUnlock()
+	if len(hooks) == 0 {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	afterCommit(r.db, func() {
+		for _, entity := range entities {
+			for _, hook := range hooks {
+				hook(ctx, entity)
+			}
+		}
+	})
+}
+
+func (r *BaseRepository[T]) runBeforeUpdate(ctx context.Context, ids []uint, data map[string]interface{}) error {
+	r.hooks.mu.RLock()
+	hooks := r.hooks.beforeUpdate
+	r.hooks.mu.RUnlock()
+	for _, id := range ids {
+		for _, hook := range hooks {
+			if err := hook(ctx, id, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *BaseRepository[T]) runAfterUpdate(ctx context.Context, ids []uint, data map[string]interface{}) {
+	r.hooks.mu.RLock()
+	hooks := r.hooks.afterUpdate
+	r.hooks.mu.RUnlock()
+	if len(hooks) == 0 {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	afterCommit(r.db, func() {
+		for _, id := range ids {
+			for _, hook := range hooks {
+				hook(ctx, id, data)
+			}
+		}
+	})
+}
+
+func (r *BaseRepository[T]) runAfterDelete(ctx context.Context, ids []uint) {
+	r.hooks.mu.RLock()
+	hooks := r.hooks.afterDelete
+	r.hooks.mu.RUnlock()
+	if len(hooks) == 0 {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	afterCommit(r.db, func() {
+		for _, id := range ids {
+			for _, hook := range hooks {
+				hook(ctx, id)
+			}
+		}
+	})
+}
+
+// Toplu güncelleme ve silmede hook'lar için koşula uyan id'ler gerekir
+func (r *BaseRepository[T]) needsUpdateIDs() bool {
+	r.hooks.mu.RLock()
+	defer r.hooks.mu.RUnlock()
+	return len(r.hooks.beforeUpdate) > 0 || len(r.hooks.afterUpdate) > 0
+}
+
+func (r *BaseRepository[T]) needsDeleteIDs() bool {
+	r.hooks.mu.RLock()
+	defer r.hooks.mu.RUnlock()
+	return len(r.hooks.afterDelete) > 0
+}
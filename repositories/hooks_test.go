@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+func TestHooksRunInRegistrationOrder(t *testing.T) {
+	db := testutil.NewDB(t, &labelled{})
+	repo := NewBaseRepository[labelled](db)
+
+	var calls []string
+	for _, name := range []string{"b1", "b2", "b3"} {
+		name := name
+		repo.OnBeforeCreate(func(ctx context.Context, entity *labelled) error {
+			calls = append(calls, name)
+			return nil
+		})
+	}
+	repo.OnAfterCreate(func(ctx context.Context, entity *labelled) { calls = append(calls, "a1") })
+	repo.OnAfterCreate(func(ctx context.Context, entity *labelled) { calls = append(calls, "a2") })
+	// Before hook varlığı değiştirebilir; değişiklik kaydedilir
+	repo.OnBeforeCreate(func(ctx context.Context, entity *labelled) error {
+		entity.Title = "slug-" + entity.Title
+		return nil
+	})
+
+	entity := &labelled{Title: "ilk"}
+	if err := repo.Create(context.Background(), entity); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"b1", "b2", "b3", "a1", "a2"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("çağrı sırası = %v, beklenen %v", calls, want)
+	}
+	var stored labelled
+	if err := db.First(&stored, entity.ID).Error; err != nil || stored.Title != "slug-ilk" {
+		t.Errorf("kaydedilen başlık = %q, %v", stored.Title, err)
+	}
+}
+
+func TestBeforeHookErrorAbortsOperation(t *testing.T) {
+	db := testutil.NewDB(t, &labelled{})
+	repo := NewBaseRepository[labelled](db)
+	errRejected := errors.New("reddedildi")
+
+	var secondRan, afterRan bool
+	repo.OnBeforeCreate(func(ctx context.Context, entity *labelled) error { return errRejected })
+	repo.OnBeforeCreate(func(ctx context.Context, entity *labelled) error {
+		secondRan = true
+		return nil
+	})
+	repo.OnAfterCreate(func(ctx context.Context, entity *labelled) { afterRan = true })
+
+	err := repo.Create(context.Background(), &labelled{Title: "x"})
+	if !errors.Is(err, errRejected) {
+		t.Fatalf("err = %v, beklenen hook hatası", err)
+	}
+	if secondRan || afterRan {
+		t.Errorf("hata sonrası hook çalıştı: ikinci before %v, after %v", secondRan, afterRan)
+	}
+	var count int64
+	db.Model(&labelled{}).Count(&count)
+	if count != 0 {
+		t.Errorf("kayıt sayısı = %d, beklenen 0", count)
+	}
+}
+
+func TestBeforeUpdateErrorLeavesRowUnchanged(t *testing.T) {
+	repo := newLabelledRepo(t, 1)
+	errRejected := errors.New("reddedildi")
+	var afterRan bool
+	repo.OnBeforeUpdate(func(ctx context.Context, id uint, data map[string]interface{}) error { return errRejected })
+	repo.OnAfterUpdate(func(ctx context.Context, id uint, data map[string]interface{}) { afterRan = true })
+
+	if _, err := repo.Update(context.Background(), 1, map[string]interface{}{"title": "yeni"}); !errors.Is(err, errRejected) {
+		t.Fatalf("err = %v, beklenen hook hatası", err)
+	}
+	stored, err := repo.GetByID(context.Background(), 1)
+	if err != nil || stored.Title != "k00" || afterRan {
+		t.Errorf("başlık = %q, err = %v, after çalıştı = %v", stored.Title, err, afterRan)
+	}
+}
+
+func TestAfterHooksSkipFailedOperations(t *testing.T) {
+	repo, id := newVersionedRepo(t)
+	var created, updated, deleted int
+	repo.OnAfterCreate(func(ctx context.Context, entity *versionedNote) { created++ })
+	repo.OnAfterUpdate(func(ctx context.Context, id uint, data map[string]interface{}) { updated++ })
+	repo.OnAfterDelete(func(ctx context.Context, id uint) { deleted++ })
+
+	// Title benzersizdir
+	if err := repo.Create(context.Background(), &versionedNote{Title: "ilk"}); err == nil {
+		t.Fatal("yinelenen başlık kabul edildi")
+	}
+	if _, err := repo.Update(context.Background(), id+100, map[string]interface{}{"body": "x"}); err == nil {
+		t.Fatal("olmayan kayıt güncellendi")
+	}
+	if err := repo.Delete(context.Background(), id+100); err == nil {
+		t.Fatal("olmayan kayıt silindi")
+	}
+	if created != 0 || updated != 0 || deleted != 0 {
+		t.Errorf("başarısız işlemlerde after hook çalıştı: create %d, update %d, delete %d", created, updated, deleted)
+	}
+}
+
+func TestAfterHooksWaitForCommitAndSkipRollback(t *testing.T) {
+	db := testutil.NewDB(t, &labelled{})
+	repo := NewBaseRepository[labelled](db)
+	var created []string
+	repo.OnAfterCreate(func(ctx context.Context, entity *labelled) { created = append(created, entity.Title) })
+
+	errAbort := errors.New("vazgeçildi")
+	err := Transaction(context.Background(), db, func(tx *gorm.DB) error {
+		if err := repo.WithTx(tx).Create(context.Background(), &labelled{Title: "geri alınan"}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("err = %v", err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("rollback sonrası after hook çalıştı: %v", created)
+	}
+
+	err = Transaction(context.Background(), db, func(tx *gorm.DB) error {
+		if err := repo.WithTx(tx).Create(context.Background(), &labelled{Title: "kalıcı"}); err != nil {
+			return err
+		}
+		if len(created) != 0 {
+			t.Error("after hook commit'ten önce çalıştı")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(created, []string{"kalıcı"}) {
+		t.Errorf("commit sonrası çalışan hook'lar = %v", created)
+	}
+}
+
+// -race ile çalıştırıldığında kayıt ve çalıştırmanın eşzamanlı erişimini denetler
+func TestHookRegistrationIsSafeDuringWrites(t *testing.T) {
+	db := testutil.NewDB(t, &labelled{})
+	repo := NewBaseRepository[labelled](db)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			repo.OnBeforeCreate(func(ctx context.Context, entity *labelled) error { return nil })
+			repo.OnAfterDelete(func(ctx context.Context, id uint) {})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if err := repo.Create(context.Background(), &labelled{Title: "eşzamanlı"}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	repo.hooks.mu.RLock()
+	defer repo.hooks.mu.RUnlock()
+	if len(repo.hooks.beforeCreate) != 50 || len(repo.hooks.afterDelete) != 50 {
+		t.Errorf("kaydedilen hook sayısı = %d/%d, beklenen 50", len(repo.hooks.beforeCreate), len(repo.hooks.afterDelete))
+	}
+}
@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/pkg/queryparams"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+var (
+	repoOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "repo_operation_duration_seconds",
+		Help:    "Repository işlemlerinin süresi (saniye).",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"entity", "method"})
+
+	repoOperationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "repo_operation_errors_total",
+		Help: "Hata ile sonuçlanan repository işlemlerinin sayısı.",
+	}, []string{"entity", "method"})
+)
+
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{repoOperationDuration, repoOperationErrors}
+}
+
+type InstrumentedRepository[T any] struct {
+	inner  IBaseRepository[T]
+	entity string
+}
+
+func NewInstrumentedRepository[T any](inner IBaseRepository[T], entityName string) *InstrumentedRepository[T] {
+	return &InstrumentedRepository[T]{inner: inner, entity: entityName}
+}
+
+func (r *InstrumentedRepository[T]) observe(method string, start time.Time, err *error) {
+	repoOperationDuration.WithLabelValues(r.entity, method).Observe(time.Since(start).Seconds())
+	if *err != nil {
+		repoOperationErrors.WithLabelValues(r.entity, method).Inc()
+	}
+}
+
+func (r *InstrumentedRepository[T]) WithTx(tx *gorm.DB) IBaseRepository[T] {
+	return &InstrumentedRepository[T]{inner: r.inner.WithTx(tx), entity: r.entity}
+}
+
+func (r *InstrumentedRepository[T]) WithTenant(tenantID uint) IBaseRepository[T] {
+	return &InstrumentedRepository[T]{inner: r.inner.WithTenant(tenantID), entity: r.entity}
+}
+
+func (r *InstrumentedRepository[T]) WithoutTenantScope() IBaseRepository[T] {
+	return &InstrumentedRepository[T]{inner: r.inner.WithoutTenantScope(), entity: r.entity}
+}
+
+func (r *InstrumentedRepository[T]) GetAll(ctx context.Context, params queryparams.ListParams) (results []T, total int64, err error) {
+	defer r.observe("GetAll", time.Now(), &err)
+	return r.inner.GetAll(ctx, params)
+}
+
+func (r *InstrumentedRepository[T]) GetAllScoped(ctx context.Context, params queryparams.ListParams, scopes ...func(*gorm.DB) *gorm.DB) (results []T, total int64, err error) {
+	defer r.observe("GetAllScoped", time.Now(), &err)
+	return r.inner.GetAllScoped(ctx, params, scopes...)
+}
+
+func (r *InstrumentedRepository[T]) GetAllCursor(ctx context.Context, params queryparams.ListParams) (results []T, nextCursor string, err error) {
+	defer r.observe("GetAllCursor", time.Now(), &err)
+	return r.inner.GetAllCursor(ctx, params)
+}
+
+func (r *InstrumentedRepository[T]) GetByID(ctx context.Context, id uint) (result *T, err error) {
+	defer r.observe("GetByID", time.Now(), &err)
+	return r.inner.GetByID(ctx, id)
+}
+
+func (r *InstrumentedRepository[T]) GetByIDs(ctx context.Context, ids []uint) (found map[uint]*T, err error) {
+	defer r.observe("GetByIDs", time.Now(), &err)
+	return r.inner.GetByIDs(ctx, ids)
+}
+
+func (r *InstrumentedRepository[T]) GetByIDForUpdate(ctx context.Context, id uint, options ...LockOption) (result *T, err error) {
+	defer r.observe("GetByIDForUpdate", time.Now(), &err)
+	return r.inner.GetByIDForUpdate(ctx, id, options...)
+}
+
+func (r *InstrumentedRepository[T]) Create(ctx context.Context, entity *T) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.inner.Create(ctx, entity)
+}
+
+func (r *InstrumentedRepository[T]) BulkCreate(ctx context.Context, entities []T) (err error) {
+	defer r.observe("BulkCreate", time.Now(), &err)
+	return r.inner.BulkCreate(ctx, entities)
+}
+
+func (r *InstrumentedRepository[T]) FirstOrCreate(ctx context.Context, condition map[string]interface{}, entity *T) (created bool, err error) {
+	defer r.observe("FirstOrCreate", time.Now(), &err)
+	return r.inner.FirstOrCreate(ctx, condition, entity)
+}
+
+func (r *InstrumentedRepository[T]) Upsert(ctx context.Context, entity *T, conflictColumns []string, updateColumns []string) (err error) {
+	defer r.observe("Upsert", time.Now(), &err)
+	return r.inner.Upsert(ctx, entity, conflictColumns, updateColumns)
+}
+
+func (r *InstrumentedRepository[T]) BulkUpsert(ctx context.Context, entities []T, conflictColumns []string, updateColumns []string) (err error) {
+	defer r.observe("BulkUpsert", time.Now(), &err)
+	return r.inner.BulkUpsert(ctx, entities, conflictColumns, updateColumns)
+}
+
+func (r *InstrumentedRepository[T]) Update(ctx context.Context, id uint, data map[string]interface{}) (_ *T, err error) {
+	defer r.observe("Update", time.Now(), &err)
+	return r.inner.Update(ctx, id, data)
+}
+
+func (r *InstrumentedRepository[T]) BulkUpdate(ctx context.Context, condition map[string]interface{}, data map[string]interface{}) (_ int64, err error) {
+	defer r.observe("BulkUpdate", time.Now(), &err)
+	return r.inner.BulkUpdate(ctx, condition, data)
+}
+
+func (r *InstrumentedRepository[T]) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.inner.Delete(ctx, id)
+}
+
+func (r *InstrumentedRepository[T]) Restore(ctx context.Context, id uint) (err error) {
+	defer r.observe("Restore", time.Now(), &err)
+	return r.inner.Restore(ctx, id)
+}
+
+func (r *InstrumentedRepository[T]) ForceDelete(ctx context.Context, id uint) (err error) {
+	defer r.observe("ForceDelete", time.Now(), &err)
+	return r.inner.ForceDelete(ctx, id)
+}
+
+func (r *InstrumentedRepository[T]) BulkDelete(ctx context.Context, condition map[string]interface{}) (deleted int64, err error) {
+	defer r.observe("BulkDelete", time.Now(), &err)
+	return r.inner.BulkDelete(ctx, condition)
+}
+
+func (r *InstrumentedRepository[T]) GetCount(ctx context.Context, params queryparams.ListParams) (total int64, err error) {
+	defer r.observe("GetCount", time.Now(), &err)
+	return r.inner.GetCount(ctx, params)
+}
+
+func (r *InstrumentedRepository[T]) CountWhere(ctx context.Context, condition map[string]interface{}) (total int64, err error) {
+	defer r.observe("CountWhere", time.Now(), &err)
+	return r.inner.CountWhere(ctx, condition)
+}
+
+func (r *InstrumentedRepository[T]) Exists(ctx context.Context, condition map[string]interface{}) (exists bool, err error) {
+	defer r.observe("Exists", time.Now(), &err)
+	return r.inner.Exists(ctx, condition)
+}
+
+func (r *InstrumentedRepository[T]) ForEachBatch(ctx context.Context, condition map[string]interface{}, batchSize int, fn func(batch []T) error) (err error) {
+	defer r.observe("ForEachBatch", time.Now(), &err)
+	return r.inner.ForEachBatch(ctx, condition, batchSize, fn)
+}
+
+func (r *InstrumentedRepository[T]) ForEachListBatch(ctx context.Context, params queryparams.ListParams, batchSize int, fn func(batch []T) error) (err error) {
+	defer r.observe("ForEachListBatch", time.Now(), &err)
+	return r.inner.ForEachListBatch(ctx, params, batchSize, fn)
+}
+
+func (r *InstrumentedRepository[T]) PluckIDs(ctx context.Context, condition map[string]interface{}) (ids []uint, err error) {
+	defer r.observe("PluckIDs", time.Now(), &err)
+	return r.inner.PluckIDs(ctx, condition)
+}
+
+func (r *InstrumentedRepository[T]) PluckStrings(ctx context.Context, column string, condition map[string]interface{}) (values []string, err error) {
+	defer r.observe("PluckStrings", time.Now(), &err)
+	return r.inner.PluckStrings(ctx, column, condition)
+}
+
+func (r *InstrumentedRepository[T]) SumWhere(ctx context.Context, column string, condition map[string]interface{}) (value float64, err error) {
+	defer r.observe("SumWhere", time.Now(), &err)
+	return r.inner.SumWhere(ctx, column, condition)
+}
+
+func (r *InstrumentedRepository[T]) MinWhere(ctx context.Context, column string, condition map[string]interface{}) (value float64, err error) {
+	defer r.observe("MinWhere", time.Now(), &err)
+	return r.inner.MinWhere(ctx, column, condition)
+}
+
+func (r *InstrumentedRepository[T]) MaxWhere(ctx context.Context, column string, condition map[string]interface{}) (value float64, err error) {
+	defer r.observe("MaxWhere", time.Now(), &err)
+	return r.inner.MaxWhere(ctx, column, condition)
+}
+
+func (r *InstrumentedRepository[T]) AvgWhere(ctx context.Context, column string, condition map[string]interface{}) (value float64, err error) {
+	defer r.observe("AvgWhere", time.Now(), &err)
+	return r.inner.AvgWhere(ctx, column, condition)
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// Histogramın örnek sayısı doğrudan okunamadığı için ayrı bir registry üzerinden toplanır
+func operationCount(t *testing.T, entity, method string) uint64 {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(repoOperationDuration)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["entity"] == entity && labels["method"] == method {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestInstrumentedRepositoryRecordsDurationsAndErrors(t *testing.T) {
+	const entity = "instrumented_test"
+	repo := NewInstrumentedRepository[labelled](newLabelledRepo(t, 2), entity)
+	ctx := context.Background()
+
+	if err := repo.Create(ctx, &labelled{Title: "yeni"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetByID(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetByID(ctx, 999); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, beklenen ErrNotFound", err)
+	}
+
+	if got := operationCount(t, entity, "Create"); got != 1 {
+		t.Errorf("Create süre örneği = %d, beklenen 1", got)
+	}
+	if got := operationCount(t, entity, "GetByID"); got != 2 {
+		t.Errorf("GetByID süre örneği = %d, beklenen 2", got)
+	}
+	if got := promtest.ToFloat64(repoOperationErrors.WithLabelValues(entity, "GetByID")); got != 1 {
+		t.Errorf("GetByID hata sayacı = %v, beklenen 1", got)
+	}
+	if got := promtest.ToFloat64(repoOperationErrors.WithLabelValues(entity, "Create")); got != 0 {
+		t.Errorf("Create hata sayacı = %v, beklenen 0", got)
+	}
+}
+
+func TestInstrumentedRepositoryKeepsLabelsOnBoundCopies(t *testing.T) {
+	const entity = "instrumented_tx_test"
+	base := newLabelledRepo(t, 1)
+	repo := NewInstrumentedRepository[labelled](base, entity)
+
+	if _, err := repo.WithTx(base.db).GetByID(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := operationCount(t, entity, "GetByID"); got != 1 {
+		t.Errorf("WithTx kopyasındaki GetByID süre örneği = %d, beklenen 1", got)
+	}
+}
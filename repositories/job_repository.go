@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IJobRepository interface {
+	Create(ctx context.Context, job *models.Job) error
+	GetByID(ctx context.Context, id uint) (*models.Job, error)
+	// Sıradaki en eski işi running durumuna çekip döndürür; sırada iş yoksa nil döner
+	ClaimNext(ctx context.Context) (*models.Job, error)
+	UpdateProgress(ctx context.Context, id uint, progress int) error
+	Finish(ctx context.Context, job *models.Job) error
+	// Yarıda kalmış işleri başarısız sayar; etkilenen iş sayısını döndürür
+	FailRunning(ctx context.Context, message string, at time.Time) (int64, error)
+}
+
+type JobRepository struct {
+	base IBaseRepository[models.Job]
+	db   *gorm.DB
+}
+
+func NewJobRepository() IJobRepository {
+	db := configsdatabase.GetDB()
+	return &JobRepository{base: NewBaseRepository[models.Job](db), db: db}
+}
+
+func (r *JobRepository) Create(ctx context.Context, job *models.Job) error {
+	return r.base.Create(ctx, job)
+}
+
+func (r *JobRepository) GetByID(ctx context.Context, id uint) (*models.Job, error) {
+	return r.base.GetByID(ctx, id)
+}
+
+// Birden fazla worker aynı işi görebilir; yalnızca durumu queued iken güncelleyebilen işi alır
+func (r *JobRepository) ClaimNext(ctx context.Context) (*models.Job, error) {
+	for {
+		var job models.Job
+		err := r.db.WithContext(ctx).Where("status = ?", models.JobQueued).Order("id").Take(&job).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		claimed, err := r.base.BulkUpdate(ctx,
+			map[string]interface{}{"id": job.ID, "status": models.JobQueued},
+			map[string]interface{}{"status": models.JobRunning, "started_at": now})
+		if err != nil {
+			return nil, err
+		}
+		if claimed == 1 {
+			job.Status = models.JobRunning
+			job.StartedAt = &now
+			return &job, nil
+		}
+	}
+}
+
+func (r *JobRepository) UpdateProgress(ctx context.Context, id uint, progress int) error {
+	_, err := r.base.BulkUpdate(ctx,
+		map[string]interface{}{"id": id, "status": models.JobRunning},
+		map[string]interface{}{"progress": progress})
+	return err
+}
+
+func (r *JobRepository) Finish(ctx context.Context, job *models.Job) error {
+	_, err := r.base.BulkUpdate(ctx,
+		map[string]interface{}{"id": job.ID},
+		map[string]interface{}{
+			"status":      job.Status,
+			"progress":    job.Progress,
+			"result":      job.Result,
+			"error":       job.Error,
+			"finished_at": job.FinishedAt,
+		})
+	return err
+}
+
+func (r *JobRepository) FailRunning(ctx context.Context, message string, at time.Time) (int64, error) {
+	return r.base.BulkUpdate(ctx,
+		map[string]interface{}{"status": models.JobRunning},
+		map[string]interface{}{"status": models.JobFailed, "error": message, "finished_at": at})
+}
+
+var _ IJobRepository = (*JobRepository)(nil)
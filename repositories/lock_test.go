@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"zatrano/pkg/testutil"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Sorguları çalıştırmadan transaction içindeymiş gibi davranan bağlantı; DryRun ile yalnız üretilen SQL incelenir
+type dryRunTx struct{}
+
+func (dryRunTx) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("dry run")
+}
+
+func (dryRunTx) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errors.New("dry run")
+}
+
+func (dryRunTx) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("dry run")
+}
+
+func (dryRunTx) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (dryRunTx) Commit() error   { return nil }
+func (dryRunTx) Rollback() error { return nil }
+
+// dialector ile açılan DryRun bağlantısında fn'in ürettiği son SELECT'i döndürür
+func capturedQuery(t *testing.T, dialector gorm.Dialector, fn func(db *gorm.DB)) string {
+	t.Helper()
+	db, err := gorm.Open(dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var query string
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		query = tx.Statement.SQL.String()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	fn(db)
+	return query
+}
+
+func TestGetByIDForUpdateEmitsLockingClause(t *testing.T) {
+	dialectors := map[string]func() gorm.Dialector{
+		"postgres": func() gorm.Dialector { return postgres.New(postgres.Config{Conn: dryRunTx{}}) },
+		"mysql": func() gorm.Dialector {
+			return mysql.New(mysql.Config{Conn: dryRunTx{}, SkipInitializeWithVersion: true})
+		},
+	}
+	tests := []struct {
+		name    string
+		options []LockOption
+		want    string
+	}{
+		{"yalın", nil, "FOR UPDATE"},
+		{"atla", []LockOption{SkipLocked}, "FOR UPDATE SKIP LOCKED"},
+		{"bekleme", []LockOption{NoWait}, "FOR UPDATE NOWAIT"},
+	}
+	for dialect, dialector := range dialectors {
+		for _, tt := range tests {
+			t.Run(dialect+"/"+tt.name, func(t *testing.T) {
+				query := capturedQuery(t, dialector(), func(db *gorm.DB) {
+					if _, err := NewBaseRepository[versionedNote](db).GetByIDForUpdate(context.Background(), 1, tt.options...); err != nil {
+						t.Fatal(err)
+					}
+				})
+				if !strings.HasSuffix(query, tt.want) {
+					t.Fatalf("sorgu = %q, %q ile bitmeli", query, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestGetByIDForUpdateRequiresTransaction(t *testing.T) {
+	db := testutil.NewDB(t, &versionedNote{})
+	if _, err := NewBaseRepository[versionedNote](db).GetByIDForUpdate(context.Background(), 1); !errors.Is(err, ErrNotInTransaction) {
+		t.Fatalf("err = %v, beklenen ErrNotInTransaction", err)
+	}
+}
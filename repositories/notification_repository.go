@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+
+	"gorm.io/gorm"
+)
+
+type INotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	ListForUser(ctx context.Context, userID uint, params queryparams.ListParams) ([]models.Notification, int64, error)
+	FindForUser(ctx context.Context, userID uint, id uint) (*models.Notification, error)
+	// Yalnızca okunmamış bildirimler işaretlenir; tekrar çağrı okunma zamanını değiştirmez
+	MarkRead(ctx context.Context, userID uint, ids []uint, at time.Time) (int64, error)
+	MarkAllRead(ctx context.Context, userID uint, at time.Time) (int64, error)
+	UnreadCount(ctx context.Context, userID uint) (int64, error)
+	DeleteForUser(ctx context.Context, userID uint) error
+}
+
+type NotificationRepository struct {
+	base IBaseRepository[models.Notification]
+	db   *gorm.DB
+}
+
+func NewNotificationRepository() INotificationRepository {
+	db := configsdatabase.GetDB()
+	return &NotificationRepository{base: NewBaseRepository[models.Notification](db), db: db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return r.base.Create(ctx, notification)
+}
+
+func (r *NotificationRepository) ListForUser(ctx context.Context, userID uint, params queryparams.ListParams) ([]models.Notification, int64, error) {
+	return r.base.GetAllScoped(ctx, params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	})
+}
+
+func (r *NotificationRepository) FindForUser(ctx context.Context, userID uint, id uint) (*models.Notification, error) {
+	var notification models.Notification
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID uint, ids []uint, at time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return r.base.BulkUpdate(ctx, map[string]interface{}{"user_id": userID, "id": ids, "read_at": nil}, map[string]interface{}{"read_at": at})
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uint, at time.Time) (int64, error) {
+	return r.base.BulkUpdate(ctx, map[string]interface{}{"user_id": userID, "read_at": nil}, map[string]interface{}{"read_at": at})
+}
+
+func (r *NotificationRepository) UnreadCount(ctx context.Context, userID uint) (int64, error) {
+	return r.base.CountWhere(ctx, map[string]interface{}{"user_id": userID, "read_at": nil})
+}
+
+// Bildirimlerin silinme kaydı tutulmaz; kullanıcı silindiğinde kalıcı olarak temizlenir
+func (r *NotificationRepository) DeleteForUser(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.Notification{}).Error
+}
+
+var _ INotificationRepository = (*NotificationRepository)(nil)
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrResetTokenUsed = errors.New("şifre sıfırlama bağlantısı daha önce kullanılmış")
+
+type IPasswordResetRepository interface {
+	Create(token *models.PasswordResetToken) error
+	FindByHash(tokenHash string) (*models.PasswordResetToken, error)
+	InvalidateForUser(userID uint) error
+	Consume(token *models.PasswordResetToken, passwordHash string) error
+}
+
+type PasswordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository() IPasswordResetRepository {
+	return &PasswordResetRepository{db: configsdatabase.GetDB()}
+}
+
+func (r *PasswordResetRepository) Create(token *models.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *PasswordResetRepository) FindByHash(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Yeni bağlantı istendiğinde öncekiler geçersiz olur
+func (r *PasswordResetRepository) InvalidateForUser(userID uint) error {
+	return r.db.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used = ?", userID, false).
+		UpdateColumns(map[string]interface{}{"used": true, "used_at": time.Now()}).Error
+}
+
+// Token'ı tek kullanımlık olarak işaretler ve şifreyi aynı transaction içinde günceller
+func (r *PasswordResetRepository) Consume(token *models.PasswordResetToken, passwordHash string) error {
+	now := time.Now()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used = ?", token.ID, false).
+			UpdateColumns(map[string]interface{}{"used": true, "used_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrResetTokenUsed
+		}
+
+		// Oturum açmış kullanıcı olmadığı için updated_by damgalanmaz; doğrudan kolon güncellemesi yapılır
+		return tx.Model(&models.User{}).Where("id = ?", token.UserID).UpdateColumns(map[string]interface{}{
+			"password":            passwordHash,
+			"password_changed_at": now,
+			"failed_login_count":  0,
+			"locked_until":        nil,
+		}).Error
+	})
+	if err == nil {
+		sharedUserBase().Invalidate(token.UserID)
+	}
+	return err
+}
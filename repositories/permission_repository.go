@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"errors"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IPermissionRepository interface {
+	ListPermissions() ([]models.Permission, error)
+	ListRoles() ([]models.Role, error)
+	GetRole(id uint) (*models.Role, error)
+	CreateRole(role *models.Role) error
+	RolePermissionIDs(roleID uint) ([]uint, error)
+	SetRolePermissions(roleID uint, permissionIDs []uint) error
+	PermissionNamesForUser(userID uint) ([]string, error)
+	RoleIDsForUser(userID uint) ([]uint, error)
+	SetUserRoles(userID uint, roleIDs []uint) error
+}
+
+type PermissionRepository struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepository() IPermissionRepository {
+	return &PermissionRepository{db: configsdatabase.GetDB()}
+}
+
+func (r *PermissionRepository) ListPermissions() ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := r.db.Order("name ASC").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *PermissionRepository) ListRoles() ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Order("name ASC").Find(&roles).Error
+	return roles, err
+}
+
+func (r *PermissionRepository) GetRole(id uint) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.First(&role, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *PermissionRepository) CreateRole(role *models.Role) error {
+	return r.db.Create(role).Error
+}
+
+func (r *PermissionRepository) RolePermissionIDs(roleID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.RolePermission{}).Where("role_id = ?", roleID).Pluck("permission_id", &ids).Error
+	return ids, err
+}
+
+func (r *PermissionRepository) SetRolePermissions(roleID uint, permissionIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", roleID).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(permissionIDs) == 0 {
+			return nil
+		}
+		links := make([]models.RolePermission, 0, len(permissionIDs))
+		for _, permissionID := range permissionIDs {
+			links = append(links, models.RolePermission{RoleID: roleID, PermissionID: permissionID})
+		}
+		return tx.Create(&links).Error
+	})
+}
+
+func (r *PermissionRepository) PermissionNamesForUser(userID uint) ([]string, error) {
+	var names []string
+	err := r.db.Model(&models.Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("permissions.name", &names).Error
+	return names, err
+}
+
+func (r *PermissionRepository) RoleIDsForUser(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.UserRole{}).Where("user_id = ?", userID).Pluck("role_id", &ids).Error
+	return ids, err
+}
+
+func (r *PermissionRepository) SetUserRoles(userID uint, roleIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		if len(roleIDs) == 0 {
+			return nil
+		}
+		links := make([]models.UserRole, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			links = append(links, models.UserRole{UserID: userID, RoleID: roleID})
+		}
+		return tx.Create(&links).Error
+	})
+}
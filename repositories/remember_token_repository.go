@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+// Aynı validator ile eşzamanlı iki döndürme isteğinden yalnızca biri başarılı olur
+var ErrRememberTokenRotated = errors.New("hatırlama token'ı başka bir istekte yenilenmiş")
+
+type IRememberTokenRepository interface {
+	Create(token *models.RememberToken) error
+	FindBySelector(selector string) (*models.RememberToken, error)
+	Rotate(token *models.RememberToken, newValidatorHash string, expiresAt time.Time) error
+	DeleteBySelector(selector string) error
+	DeleteAllForUser(userID uint) error
+	DeleteExpired(userID uint) error
+	WithTx(tx *gorm.DB) IRememberTokenRepository
+}
+
+type RememberTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRememberTokenRepository() IRememberTokenRepository {
+	return &RememberTokenRepository{db: configsdatabase.GetDB()}
+}
+
+func (r *RememberTokenRepository) WithTx(tx *gorm.DB) IRememberTokenRepository {
+	return &RememberTokenRepository{db: tx}
+}
+
+func (r *RememberTokenRepository) Create(token *models.RememberToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *RememberTokenRepository) FindBySelector(selector string) (*models.RememberToken, error) {
+	var token models.RememberToken
+	if err := r.db.Where("selector = ?", selector).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *RememberTokenRepository) Rotate(token *models.RememberToken, newValidatorHash string, expiresAt time.Time) error {
+	now := time.Now()
+	result := r.db.Model(&models.RememberToken{}).
+		Where("id = ? AND validator_hash = ?", token.ID, token.ValidatorHash).
+		UpdateColumns(map[string]interface{}{
+			"validator_hash": newValidatorHash,
+			"expires_at":     expiresAt,
+			"last_used_at":   now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRememberTokenRotated
+	}
+	token.ValidatorHash = newValidatorHash
+	token.ExpiresAt = expiresAt
+	token.LastUsedAt = &now
+	return nil
+}
+
+func (r *RememberTokenRepository) DeleteBySelector(selector string) error {
+	return r.db.Where("selector = ?", selector).Delete(&models.RememberToken{}).Error
+}
+
+func (r *RememberTokenRepository) DeleteAllForUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RememberToken{}).Error
+}
+
+func (r *RememberTokenRepository) DeleteExpired(userID uint) error {
+	return r.db.Where("user_id = ? AND expires_at < ?", userID, time.Now()).Delete(&models.RememberToken{}).Error
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configslog"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+func (r *BaseRepository[T]) SetRetryPolicy(maxAttempts int, backoff time.Duration) {
+	r.retryMaxAttempts = maxAttempts
+	r.retryBackoff = backoff
+}
+
+func (r *BaseRepository[T]) EnableCreateRetry() {
+	r.retryCreates = true
+}
+
+// Varsayılan politika DB_RETRY_MAX_ATTEMPTS / DB_RETRY_BACKOFF_MS ayarlarından gelir
+func (r *BaseRepository[T]) withRetry(ctx context.Context, operation string, fn func() error) error {
+	return retry(ctx, r.db, r.retryMaxAttempts, r.retryBackoff, operation, fn)
+}
+
+// Denetim transaction'ını repository açıyorsa tekrar deneme tüm transaction'ı kapsar;
+// bozulmuş bir transaction içinde tek sorguyu yeniden çalıştırmak işe yaramaz
+func (r *BaseRepository[T]) auditedWithRetry(ctx context.Context, operation string, fn func(repo *BaseRepository[T]) error) error {
+	return r.withRetry(ctx, operation, func() error {
+		return r.audited(ctx, fn)
+	})
+}
+
+// Transaction ile aynıdır; deadlock ve serialization hatasında transaction baştan çalıştırılır.
+// fn yan etkisiz olmalıdır (commit sonrası işler afterCommit ile ertelenir).
+func RetryableTransaction(ctx context.Context, db *gorm.DB, operation string, fn func(tx *gorm.DB) error) error {
+	maxAttempts, backoff := configsdatabase.GetRetryPolicy()
+	return retry(ctx, db, maxAttempts, backoff, operation, func() error {
+		return Transaction(ctx, db, fn)
+	})
+}
+
+func retry(ctx context.Context, db *gorm.DB, maxAttempts int, backoff time.Duration, operation string, fn func() error) error {
+	// Dıştaki transaction hata alan sorguyla bozulur; tekrar denemeyi o transaction'ı açan yapar.
+	if maxAttempts <= 1 || inTransaction(db) {
+		return fn()
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = fn()
+		if err == nil || attempt == maxAttempts || !isRetryableError(err) {
+			return err
+		}
+
+		delay := backoff << (attempt - 1)
+		delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+
+		configslog.FromContext(ctx).Warn("Veritabanı işlemi tekrar deneniyor",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+	return err
+}
+
+func isRetryableError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// UPDATE sorguları ilk failures kez verilen Postgres koduyla düşer; çağrı sayısını döndürür
+func injectFailures(t *testing.T, db *gorm.DB, failures int, code string) *int {
+	t.Helper()
+	calls := 0
+	err := db.Callback().Update().Before("gorm:update").Register("test:fail_update", func(tx *gorm.DB) {
+		calls++
+		if calls <= failures {
+			tx.AddError(&pgconn.PgError{Code: code})
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &calls
+}
+
+func newRetryRepo(t *testing.T, failures int, code string) (*BaseRepository[versionedNote], uint, *int) {
+	t.Helper()
+	repo, id := newVersionedRepo(t)
+	repo.SetRetryPolicy(3, time.Millisecond)
+	return repo, id, injectFailures(t, repo.db, failures, code)
+}
+
+func TestUpdateRetriesSerializationFailure(t *testing.T) {
+	repo, id, calls := newRetryRepo(t, 2, pgSerializationFailure)
+
+	updated, err := repo.Update(context.Background(), id, map[string]interface{}{"title": "yeni", "version": uint(1)})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if *calls != 3 || updated.Title != "yeni" {
+		t.Errorf("deneme = %d, başlık = %q; beklenen 3, yeni", *calls, updated.Title)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	repo, id, calls := newRetryRepo(t, 5, pgDeadlockDetected)
+
+	_, err := repo.Update(context.Background(), id, map[string]interface{}{"title": "yeni", "version": uint(1)})
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgDeadlockDetected {
+		t.Fatalf("err = %v, beklenen 40P01", err)
+	}
+	if *calls != 3 {
+		t.Errorf("deneme = %d, beklenen 3", *calls)
+	}
+}
+
+func TestNonRetryableErrorIsReturnedAtOnce(t *testing.T) {
+	repo, id, calls := newRetryRepo(t, 1, "23505")
+
+	if _, err := repo.Update(context.Background(), id, map[string]interface{}{"title": "yeni", "version": uint(1)}); err == nil {
+		t.Fatal("benzersizlik hatası yutuldu")
+	}
+	if *calls != 1 {
+		t.Errorf("deneme = %d, beklenen 1", *calls)
+	}
+}
+
+func TestCreateRetryIsOptIn(t *testing.T) {
+	repo, _ := newVersionedRepo(t)
+	repo.SetRetryPolicy(3, time.Millisecond)
+	calls, failures := 0, 1
+	err := repo.db.Callback().Create().Before("gorm:create").Register("test:fail_create", func(tx *gorm.DB) {
+		calls++
+		if calls <= failures {
+			tx.AddError(&pgconn.PgError{Code: pgSerializationFailure})
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.Create(context.Background(), &versionedNote{Title: "a"}); err == nil {
+		t.Fatal("Create varsayılan olarak tekrar denememeli")
+	}
+	calls, failures = 0, 1
+	repo.EnableCreateRetry()
+	if err := repo.Create(context.Background(), &versionedNote{Title: "b"}); err != nil {
+		t.Fatalf("EnableCreateRetry sonrası Create: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("deneme = %d, beklenen 2", calls)
+	}
+}
+
+func TestRetryStopsAtContextDeadline(t *testing.T) {
+	repo, id, calls := newRetryRepo(t, 5, pgSerializationFailure)
+	repo.SetRetryPolicy(5, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := repo.Update(ctx, id, map[string]interface{}{"title": "yeni", "version": uint(1)}); err == nil {
+		t.Fatal("hata bekleniyordu")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("deadline'a rağmen %v beklendi", elapsed)
+	}
+	if *calls != 1 {
+		t.Errorf("deneme = %d, beklenen 1", *calls)
+	}
+}
+
+// Denetimli repository'de UPDATE transaction içinde çalışır; tekrar deneme transaction'ın tamamını kapsar
+func TestAuditedUpdateRetriesWholeTransaction(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	repo := NewBaseRepository[models.User](db)
+	repo.EnableAudit(NewAuditRepository())
+	repo.SetRetryPolicy(3, time.Millisecond)
+	seedFailedLogins(t, repo, 0)
+	calls := injectFailures(t, db, 1, pgDeadlockDetected)
+
+	ctx := requestctx.WithUserID(context.Background(), 7)
+	var user models.User
+	if err := db.First(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Update(ctx, user.ID, map[string]interface{}{"name": "Yeni"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("deneme = %d, beklenen 2", *calls)
+	}
+	var entries int64
+	db.Model(&models.AuditLog{}).Where("action = ?", models.AuditUpdate).Count(&entries)
+	if entries != 1 {
+		t.Errorf("denetim kaydı = %d, beklenen 1 (geri alınan deneme kayıt bırakmamalı)", entries)
+	}
+}
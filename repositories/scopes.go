@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func WhereIn[V any](column string, values []V) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		items := make([]interface{}, len(values))
+		for i, v := range values {
+			items[i] = v
+		}
+		return db.Where(clause.IN{Column: clause.Column{Name: column}, Values: items})
+	}
+}
+
+func CreatedBetween(from, to time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !from.IsZero() {
+			db = db.Where("created_at >= ?", from)
+		}
+		if !to.IsZero() {
+			db = db.Where("created_at <= ?", to)
+		}
+		return db
+	}
+}
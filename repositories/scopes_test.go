@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+func newScopedRepo(t *testing.T) (*BaseRepository[models.User], *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	repo := NewBaseRepository[models.User](db)
+	// Sırasıyla panel, dashboard, panel, dashboard
+	seedFailedLogins(t, repo, 0, 0, 0, 0)
+	return repo, db
+}
+
+func TestDefaultScopeAppliesToDataAndCount(t *testing.T) {
+	repo, _ := newScopedRepo(t)
+	repo.RegisterDefaultScope(WhereIn("type", []models.UserType{models.Panel}))
+
+	users, total, err := repo.GetAllScoped(context.Background(), queryparams.ListParams{Page: 1, PerPage: 1})
+	if err != nil {
+		t.Fatalf("GetAllScoped: %v", err)
+	}
+	if total != 2 || len(users) != 1 || users[0].Type != models.Panel {
+		t.Errorf("sonuç = %d kayıt, toplam %d; beklenen 1 panel kaydı, toplam 2", len(users), total)
+	}
+	if count, err := repo.GetCount(context.Background(), queryparams.ListParams{}); err != nil || count != 2 {
+		t.Errorf("GetCount = %d, %v; beklenen 2", count, err)
+	}
+}
+
+func TestCallScopesCombineWithDefaultScope(t *testing.T) {
+	repo, db := newScopedRepo(t)
+	repo.RegisterDefaultScope(WhereIn("type", []models.UserType{models.Dashboard}))
+
+	var first models.User
+	if err := db.Where("type = ?", models.Dashboard).Order("id").First(&first).Error; err != nil {
+		t.Fatal(err)
+	}
+	users, total, err := repo.GetAllScoped(context.Background(), queryparams.ListParams{Page: 1, PerPage: 10},
+		WhereIn("id", []uint{first.ID}))
+	if err != nil {
+		t.Fatalf("GetAllScoped: %v", err)
+	}
+	if total != 1 || len(users) != 1 || users[0].ID != first.ID {
+		t.Errorf("sonuç = %d kayıt, toplam %d; beklenen yalnız %d", len(users), total, first.ID)
+	}
+}
+
+func TestCreatedBetween(t *testing.T) {
+	repo, db := newScopedRepo(t)
+	past := time.Now().Add(-48 * time.Hour).UTC()
+	if err := db.Model(&models.User{}).Where("type = ?", models.Dashboard).UpdateColumn("created_at", past).Error; err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	params := queryparams.ListParams{Page: 1, PerPage: 10}
+
+	if _, total, err := repo.GetAllScoped(ctx, params, CreatedBetween(time.Now().Add(-time.Hour), time.Time{})); err != nil || total != 2 {
+		t.Errorf("son bir saat = %d, %v; beklenen 2", total, err)
+	}
+	if _, total, err := repo.GetAllScoped(ctx, params, CreatedBetween(time.Time{}, time.Now().Add(-24*time.Hour))); err != nil || total != 2 {
+		t.Errorf("dünden önce = %d, %v; beklenen 2", total, err)
+	}
+	if _, total, err := repo.GetAllScoped(ctx, params, CreatedBetween(time.Time{}, time.Time{})); err != nil || total != 4 {
+		t.Errorf("sınırsız = %d, %v; beklenen 4", total, err)
+	}
+}
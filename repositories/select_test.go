@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// db üzerinde çalışan son SELECT'i yakalar
+func recordQueries(t *testing.T, db *gorm.DB) *string {
+	t.Helper()
+	var query string
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		query = tx.Statement.SQL.String()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Callback().Query().Remove("test:capture") })
+	return &query
+}
+
+func TestListSelectsOnlyRequestedColumns(t *testing.T) {
+	db := testutil.NewDB(t, &ranked{})
+	if err := db.Create(&ranked{Status: "a", Name: "ali", Secret: "gizli"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	repo := NewBaseRepository[ranked](db)
+	query := recordQueries(t, db)
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   string
+	}{
+		// id her zaman, sıralama kolonu sayfalama için eklenir; izin listesinde olmayanlar düşer
+		{"istenen kolonlar", []string{"name", "secret", "yok"}, "SELECT `id`,`name`,`status` FROM"},
+		{"geçerli kolon yoksa tümü", []string{"secret", "yok"}, "SELECT * FROM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, _, err := repo.GetAll(context.Background(), queryparams.ListParams{Fields: tt.fields, SortBy: "status"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(*query, tt.want) {
+				t.Fatalf("sorgu = %q, %q ile başlamalı", *query, tt.want)
+			}
+			if len(items) != 1 || items[0].Name != "ali" {
+				t.Fatalf("sonuç = %+v", items)
+			}
+		})
+	}
+
+	items, _, err := repo.GetAll(context.Background(), queryparams.ListParams{Fields: []string{"name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0].Secret != "" {
+		t.Errorf("seçilmeyen kolon dolu geldi: %q", items[0].Secret)
+	}
+}
+
+func TestCursorListSelectsOnlyRequestedColumns(t *testing.T) {
+	query := capturedQuery(t, postgres.New(postgres.Config{Conn: dryRunTx{}}), func(db *gorm.DB) {
+		params := queryparams.ListParams{Fields: []string{"name", "secret"}, SortBy: "status", OrderBy: "asc"}
+		if _, _, err := NewBaseRepository[cursorRow](db).GetAllCursor(context.Background(), params); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if want := `SELECT "id","name","status" FROM`; !strings.HasPrefix(query, want) {
+		t.Fatalf("sorgu = %q, %q ile başlamalı", query, want)
+	}
+}
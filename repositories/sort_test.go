@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/testutil"
+)
+
+type ranked struct {
+	ID     uint   `gorm:"primarykey"`
+	Status string `zatrano:"sortable"`
+	Name   string `zatrano:"sortable"`
+	Secret string
+}
+
+func rankedNames(t *testing.T, repo *BaseRepository[ranked], params queryparams.ListParams) []string {
+	t.Helper()
+	items, _, err := repo.GetAll(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return names
+}
+
+func TestMultiColumnSortAppliesValidColumnsInOrder(t *testing.T) {
+	db := testutil.NewDB(t, &ranked{})
+	for _, row := range []ranked{
+		{Status: "b", Name: "ayşe", Secret: "2"},
+		{Status: "a", Name: "can", Secret: "1"},
+		{Status: "b", Name: "deniz", Secret: "1"},
+		{Status: "a", Name: "ali", Secret: "2"},
+	} {
+		row := row
+		if err := db.Create(&row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := NewBaseRepository[ranked](db)
+
+	tests := []struct {
+		name string
+		sort string
+		want []string
+	}{
+		{"durum artan, ad azalan", "status:asc,name:desc", []string{"can", "ali", "deniz", "ayşe"}},
+		// İzin verilmeyen kolon ve geçersiz yön atlanır, kalanlar uygulanır
+		{"geçersizler atlanır", "secret:asc,status:yana,name:asc", []string{"ali", "ayşe", "can", "deniz"}},
+		// Hiçbiri geçerli değilse tek kolonlu varsayılan (id desc) kullanılır
+		{"varsayılana döner", "secret:asc", []string{"ali", "deniz", "can", "ayşe"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankedNames(t, repo, queryparams.ListParams{Sort: tt.sort})
+			if len(got) != len(tt.want) {
+				t.Fatalf("sonuç = %v, beklenen %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("sonuç = %v, beklenen %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
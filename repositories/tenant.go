@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configslog"
+	"zatrano/pkg/requestctx"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Kiracı kolonu ayarlanmış repository'de kiracı bilinmeden hiçbir sorgu çalışmaz
+var ErrMissingTenant = errors.New("kiracı kapsamlı repository için kiracı kimliği bulunamadı")
+
+type tenantUnscopedKey struct{}
+
+// Kiracı kolonu ayarlandığında tüm okuma, güncelleme ve silme sorgularına "kolon = kiracı" koşulu
+// varsayılan kapsam olarak eklenir, yeni kayıtlara kiracı yazılır. Başka kiracının kaydına erişim ErrNotFound ile sonuçlanır.
+// Kiracı WithTenant ile bağlanır ya da context'ten (AuthMiddleware oturumdan yazar) okunur;
+// context almayan GetByID, GetAll, GetAllCursor ve GetCount yalnızca WithTenant ile kullanılabilir.
+func (r *BaseRepository[T]) SetTenantColumn(column string) {
+	r.tenantColumn = column
+	r.RegisterDefaultScope(tenantScope(column))
+}
+
+func (r *BaseRepository[T]) WithTenant(tenantID uint) IBaseRepository[T] {
+	bound := *r
+	bound.tenantID = tenantID
+	return &bound
+}
+
+// Süper yönetici işlemleri içindir; kiracı koşulu eklenmez ve yeni kayıtların kiracısı olduğu gibi bırakılır.
+// Her çağrı çağıranın konumuyla loglanır.
+func (r *BaseRepository[T]) WithoutTenantScope() IBaseRepository[T] {
+	var t T
+	fields := []zap.Field{zap.String("model", reflect.TypeOf(t).Name())}
+	if _, file, line, ok := runtime.Caller(1); ok {
+		fields = append(fields, zap.String("caller", fmt.Sprintf("%s:%d", file, line)))
+	}
+	configslog.Log.Warn("Kiracı kapsamı devre dışı bırakıldı", fields...)
+
+	bound := *r
+	bound.tenantUnscoped = true
+	return &bound
+}
+
+func (r *BaseRepository[T]) tenantScoped() bool {
+	return r.tenantColumn != "" && !r.tenantUnscoped
+}
+
+func (r *BaseRepository[T]) currentTenant(ctx context.Context) (uint, bool) {
+	if r.tenantID != 0 {
+		return r.tenantID, true
+	}
+	return requestctx.TenantID(ctx)
+}
+
+func (r *BaseRepository[T]) scopeContext(ctx context.Context) context.Context {
+	if r.tenantUnscoped {
+		return context.WithValue(ctx, tenantUnscopedKey{}, true)
+	}
+	if r.tenantID != 0 {
+		return requestctx.WithTenantID(ctx, r.tenantID)
+	}
+	return ctx
+}
+
+// Kiracı bulunamazsa sorgu çalıştırılmadan ErrMissingTenant döner
+func tenantScope(column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		ctx := db.Statement.Context
+		if unscoped, _ := ctx.Value(tenantUnscopedKey{}).(bool); unscoped {
+			return db
+		}
+		tenantID, ok := requestctx.TenantID(ctx)
+		if !ok {
+			_ = db.AddError(ErrMissingTenant)
+			return db
+		}
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: tenantID})
+	}
+}
+
+// Varlıktaki değer ne olursa olsun kiracı kolonu geçerli kiracıyla ezilir
+func (r *BaseRepository[T]) stampTenant(ctx context.Context, entities ...*T) error {
+	if !r.tenantScoped() || len(entities) == 0 {
+		return nil
+	}
+	tenantID, ok := r.currentTenant(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(entities[0]); err != nil {
+		return err
+	}
+	field := stmt.Schema.LookUpField(r.tenantColumn)
+	if field == nil {
+		return fmt.Errorf("kiracı kolonu modelde bulunamadı: %s", r.tenantColumn)
+	}
+	for _, entity := range entities {
+		if err := field.Set(ctx, reflect.ValueOf(entity).Elem(), tenantID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Çakışan satır başka kiracınınsa güncellenmez. MySQL'in ON DUPLICATE KEY UPDATE'i koşul desteklemediği
+// için orada kiracı kapsamlı upsert reddedilir.
+func (r *BaseRepository[T]) tenantUpsertClause(ctx context.Context, onConflict clause.OnConflict) (clause.OnConflict, error) {
+	if !r.tenantScoped() || onConflict.DoNothing {
+		return onConflict, nil
+	}
+	if r.db.Dialector.Name() == configsdatabase.DriverMySQL {
+		return onConflict, errors.New("kiracı kapsamlı upsert MySQL'de desteklenmiyor")
+	}
+	tenantID, ok := r.currentTenant(ctx)
+	if !ok {
+		return onConflict, ErrMissingTenant
+	}
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return onConflict, err
+	}
+	onConflict.Where = clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: stmt.Schema.Table, Name: r.tenantColumn}, Value: tenantID},
+	}}
+	return onConflict, nil
+}
@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+const (
+	tenantA uint = 1
+	tenantB uint = 2
+)
+
+func tenantContext(tenantID uint) context.Context {
+	return requestctx.WithTenantID(requestctx.WithUserID(context.Background(), 99), tenantID)
+}
+
+// İki kiracıya birer kullanıcı ekler; B'nin kaydının kimliği döner
+func seedTenants(t *testing.T, repo IBaseRepository[models.User]) (uint, uint) {
+	t.Helper()
+	a := &models.User{Name: "A", Account: "a@example.com", Password: "x", Type: models.Panel, Status: true}
+	b := &models.User{Name: "B", Account: "b@example.com", Password: "x", Type: models.Panel, Status: true}
+	if err := repo.Create(tenantContext(tenantA), a); err != nil {
+		t.Fatalf("A oluşturulamadı: %v", err)
+	}
+	if err := repo.Create(tenantContext(tenantB), b); err != nil {
+		t.Fatalf("B oluşturulamadı: %v", err)
+	}
+	return a.ID, b.ID
+}
+
+func newTenantRepo(t *testing.T) *BaseRepository[models.User] {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	repo := NewBaseRepository[models.User](db)
+	repo.SetTenantColumn(userTenantColumn)
+	return repo
+}
+
+func TestTenantCreateStampsTenant(t *testing.T) {
+	repo := newTenantRepo(t)
+	aID, bID := seedTenants(t, repo)
+
+	for id, want := range map[uint]uint{aID: tenantA, bID: tenantB} {
+		user, err := repo.WithTenant(want).GetByID(context.Background(), id)
+		if err != nil {
+			t.Fatalf("GetByID(%d): %v", id, err)
+		}
+		if user.OrganizationID == nil || *user.OrganizationID != want {
+			t.Errorf("kullanıcı %d organization_id = %v, beklenen %d", id, user.OrganizationID, want)
+		}
+	}
+}
+
+func TestTenantCannotReadOtherTenant(t *testing.T) {
+	repo := newTenantRepo(t)
+	_, bID := seedTenants(t, repo)
+	ctx := tenantContext(tenantA)
+	bound := repo.WithTenant(tenantA)
+
+	if _, err := bound.GetByID(context.Background(), bID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetByID err = %v, beklenen ErrNotFound", err)
+	}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		_, err := repo.WithTx(tx).GetByIDForUpdate(ctx, bID)
+		return err
+	})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetByIDForUpdate err = %v, beklenen ErrNotFound", err)
+	}
+	if found, err := repo.GetByIDs(ctx, []uint{bID}); err != nil || len(found) != 0 {
+		t.Errorf("GetByIDs = %v, %v; beklenen boş", found, err)
+	}
+	users, total, err := bound.GetAll(context.Background(), queryparams.ListParams{})
+	if err != nil || total != 1 || len(users) != 1 || users[0].Account != "a@example.com" {
+		t.Errorf("GetAll = %d kayıt (toplam %d), %v; yalnızca A beklenirdi", len(users), total, err)
+	}
+	if count, err := bound.GetCount(context.Background(), queryparams.ListParams{}); err != nil || count != 1 {
+		t.Errorf("GetCount = %d, %v; beklenen 1", count, err)
+	}
+	if exists, err := repo.Exists(ctx, map[string]interface{}{"account": "b@example.com"}); err != nil || exists {
+		t.Errorf("Exists = %v, %v; beklenen false", exists, err)
+	}
+	if count, err := repo.CountWhere(ctx, map[string]interface{}{}); err != nil || count != 1 {
+		t.Errorf("CountWhere = %d, %v; beklenen 1", count, err)
+	}
+	if ids, err := repo.PluckIDs(ctx, map[string]interface{}{}); err != nil || len(ids) != 1 || ids[0] == bID {
+		t.Errorf("PluckIDs = %v, %v; B görünmemeli", ids, err)
+	}
+	var batched int
+	err = repo.ForEachBatch(ctx, map[string]interface{}{}, 10, func(batch []models.User) error {
+		batched += len(batch)
+		return nil
+	})
+	if err != nil || batched != 1 {
+		t.Errorf("ForEachBatch = %d kayıt, %v; beklenen 1", batched, err)
+	}
+}
+
+func TestTenantCannotWriteOtherTenant(t *testing.T) {
+	repo := newTenantRepo(t)
+	_, bID := seedTenants(t, repo)
+	ctx := tenantContext(tenantA)
+
+	if _, err := repo.Update(ctx, bID, map[string]interface{}{"name": "ele geçirildi"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update err = %v, beklenen ErrNotFound", err)
+	}
+	if n, err := repo.BulkUpdate(ctx, map[string]interface{}{"account": "b@example.com"}, map[string]interface{}{"name": "ele geçirildi"}); err != nil || n != 0 {
+		t.Errorf("BulkUpdate = %d, %v; beklenen 0", n, err)
+	}
+	if err := repo.Delete(ctx, bID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete err = %v, beklenen ErrNotFound", err)
+	}
+	if err := repo.ForceDelete(ctx, bID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ForceDelete err = %v, beklenen ErrNotFound", err)
+	}
+	if n, err := repo.BulkDelete(ctx, map[string]interface{}{"account": "b@example.com"}); err != nil || n != 0 {
+		t.Errorf("BulkDelete = %d, %v; beklenen 0", n, err)
+	}
+
+	b, err := repo.WithTenant(tenantB).GetByID(context.Background(), bID)
+	if err != nil {
+		t.Fatalf("B kaydı kayboldu: %v", err)
+	}
+	if b.Name != "B" {
+		t.Errorf("B kaydının adı %q olmuş", b.Name)
+	}
+}
+
+func TestTenantMissingTenantFailsClosed(t *testing.T) {
+	repo := newTenantRepo(t)
+	seedTenants(t, repo)
+
+	if _, _, err := repo.GetAll(context.Background(), queryparams.ListParams{}); !errors.Is(err, ErrMissingTenant) {
+		t.Errorf("GetAll err = %v, beklenen ErrMissingTenant", err)
+	}
+	user := &models.User{Name: "C", Account: "c@example.com", Password: "x", Type: models.Panel}
+	if err := repo.Create(requestctx.WithUserID(context.Background(), 99), user); !errors.Is(err, ErrMissingTenant) {
+		t.Errorf("Create err = %v, beklenen ErrMissingTenant", err)
+	}
+}
+
+func TestCachedRepositoryKeysByTenant(t *testing.T) {
+	repo := newTenantRepo(t)
+	aID, _ := seedTenants(t, repo)
+	cached := NewCachedRepository[models.User](repo, time.Minute)
+
+	if _, err := cached.WithTenant(tenantA).GetByID(context.Background(), aID); err != nil {
+		t.Fatalf("A kendi kaydını okuyamadı: %v", err)
+	}
+	// A'nın okuması önbelleğe düştü; B aynı kimliği önbellekten alamamalı
+	if _, err := cached.WithTenant(tenantB).GetByID(context.Background(), aID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("B için GetByID err = %v, beklenen ErrNotFound", err)
+	}
+	if _, err := cached.GetByID(context.Background(), aID); !errors.Is(err, ErrMissingTenant) {
+		t.Errorf("kiracısız GetByID err = %v, beklenen ErrMissingTenant", err)
+	}
+}
+
+func TestUserRepositoryScopesByContextTenant(t *testing.T) {
+	testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.Notification{})
+	cfg := configsapp.Get()
+	cfg.MultiTenant = true
+	t.Cleanup(func() { cfg.MultiTenant = false })
+
+	repo := NewUserRepository()
+	a := &models.User{Name: "A", Account: "a@example.com", Password: "x", Type: models.Panel, Status: true}
+	b := &models.User{Name: "B", Account: "b@example.com", Password: "x", Type: models.Panel, Status: true}
+	if err := repo.CreateUser(tenantContext(tenantA), a); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CreateUser(tenantContext(tenantB), b); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := tenantContext(tenantA)
+	if _, err := repo.GetUserByID(ctx, a.ID); err != nil {
+		t.Fatalf("A kendi kaydını okuyamadı: %v", err)
+	}
+	if _, err := repo.GetUserByID(ctx, b.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetUserByID err = %v, beklenen ErrNotFound", err)
+	}
+	if _, err := repo.GetUserByIDFromPrimary(ctx, b.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetUserByIDFromPrimary err = %v, beklenen ErrNotFound", err)
+	}
+	if users, _, err := repo.GetAllUsers(ctx, queryparams.ListParams{}); err != nil || len(users) != 1 {
+		t.Errorf("GetAllUsers = %d kayıt, %v; beklenen 1", len(users), err)
+	}
+	// Hesap adı kiracılar arasında da tekil
+	if taken, err := repo.AccountTaken(ctx, "b@example.com"); err != nil || !taken {
+		t.Errorf("AccountTaken = %v, %v; beklenen true", taken, err)
+	}
+}
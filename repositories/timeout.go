@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrQueryTimeout = errors.New("sorgu zaman aşımına uğradı")
+
+func (r *BaseRepository[T]) SetQueryTimeout(timeout time.Duration) {
+	r.queryTimeout = timeout
+}
+
+// operationContext, gelen context'te deadline yoksa repository zaman aşımını uygular;
+// dönen fonksiyon defer ile çağrılmalı, zaman aşımı hatasını ErrQueryTimeout ile sarar.
+func (r *BaseRepository[T]) operationContext(ctx context.Context, err *error) (context.Context, func()) {
+	if r.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, r.queryTimeout)
+	return timeoutCtx, func() {
+		if *err != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			*err = fmt.Errorf("%w: %v", ErrQueryTimeout, *err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/testutil"
+)
+
+func TestReadsUseCallerContext(t *testing.T) {
+	repo := NewBaseRepository[versionedNote](testutil.NewDB(t, &versionedNote{}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := repo.GetAll(ctx, queryparams.ListParams{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAll hatası = %v, beklenen context.Canceled", err)
+	}
+	if _, err := repo.GetByID(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetByID hatası = %v, beklenen context.Canceled", err)
+	}
+	if _, err := repo.GetCount(ctx, queryparams.ListParams{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetCount hatası = %v, beklenen context.Canceled", err)
+	}
+}
+
+func TestQueryTimeoutWrapsDeadline(t *testing.T) {
+	repo := NewBaseRepository[versionedNote](testutil.NewDB(t, &versionedNote{}))
+	repo.SetQueryTimeout(time.Nanosecond)
+
+	if _, _, err := repo.GetAll(context.Background(), queryparams.ListParams{}); !errors.Is(err, ErrQueryTimeout) {
+		t.Fatalf("hata = %v, beklenen ErrQueryTimeout", err)
+	}
+
+	// Çağıranın kendi deadline'ı varsa repository zaman aşımı uygulanmaz
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, _, err := repo.GetAll(ctx, queryparams.ListParams{}); err != nil {
+		t.Fatalf("çağıran deadline'ı ile hata = %v", err)
+	}
+}
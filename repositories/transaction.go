@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Transaction ile açılan transaction'ın commit sonrası çalışacak işlerini taşıyan gorm ayarı
+const afterCommitSetting = "zatrano:after_commit"
+
+type afterCommitQueue struct {
+	fns []func()
+}
+
+// İç içe çağrılarda mevcut transaction kullanılır; afterCommit ile ertelenen işler en dıştaki commit'ten sonra çalışır
+func Transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if inTransaction(db) {
+		return fn(db.WithContext(ctx))
+	}
+
+	queue := &afterCommitQueue{}
+	if err := db.WithContext(ctx).Set(afterCommitSetting, queue).Transaction(fn); err != nil {
+		return err
+	}
+	for _, f := range queue.fns {
+		f()
+	}
+	return nil
+}
+
+func inTransaction(db *gorm.DB) bool {
+	committer, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok && committer != nil
+}
+
+// db Transaction ile açılmış bir transaction'a bağlıysa fn commit sonrasına ertelenir, aksi halde hemen çalışır
+func afterCommit(db *gorm.DB, fn func()) {
+	if inTransaction(db) {
+		if value, ok := db.Get(afterCommitSetting); ok {
+			queue := value.(*afterCommitQueue)
+			queue.fns = append(queue.fns, fn)
+			return
+		}
+	}
+	fn()
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+func countRows(t *testing.T, db *gorm.DB, model interface{}) int64 {
+	t.Helper()
+	var count int64
+	if err := db.Model(model).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestTransactionRollsBackEveryRepositoryOnError(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.Notification{})
+	users := NewBaseRepository[models.User](db)
+	notifications := NewBaseRepository[models.Notification](db)
+	ctx := requestctx.WithUserID(context.Background(), 1)
+
+	err := Transaction(ctx, db, func(tx *gorm.DB) error {
+		user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Type: models.Panel, Status: true}
+		if err := users.WithTx(tx).Create(ctx, user); err != nil {
+			return err
+		}
+		if err := notifications.WithTx(tx).Create(ctx, &models.Notification{UserID: user.ID, Title: "Hoş geldin"}); err != nil {
+			return err
+		}
+		// İkinci repository'nin hatası ilk repository'nin eklemesini de geri almalı
+		_, err := notifications.WithTx(tx).Update(ctx, 9999, map[string]interface{}{"title": "yok"})
+		return err
+	})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, beklenen ErrNotFound", err)
+	}
+	if n := countRows(t, db, &models.User{}); n != 0 {
+		t.Errorf("geri alınan transaction'dan sonra %d kullanıcı kaldı", n)
+	}
+	if n := countRows(t, db, &models.Notification{}); n != 0 {
+		t.Errorf("geri alınan transaction'dan sonra %d bildirim kaldı", n)
+	}
+}
+
+func TestNestedTransactionReusesOuterTransaction(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{})
+	users := NewBaseRepository[models.User](db)
+	ctx := requestctx.WithUserID(context.Background(), 1)
+
+	committed := false
+	err := Transaction(ctx, db, func(outer *gorm.DB) error {
+		err := Transaction(ctx, outer, func(inner *gorm.DB) error {
+			if inner.Statement.ConnPool != outer.Statement.ConnPool {
+				t.Error("iç çağrı yeni bir transaction açtı")
+			}
+			afterCommit(inner, func() { committed = true })
+			return users.WithTx(inner).Create(ctx, &models.User{Name: "İç", Account: "ic@example.com", Password: "x", Type: models.Panel})
+		})
+		if committed {
+			t.Error("iç çağrının commit sonrası işi dış transaction bitmeden çalıştı")
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !committed {
+		t.Error("commit sonrası iş çalışmadı")
+	}
+	if n := countRows(t, db, &models.User{}); n != 1 {
+		t.Fatalf("%d kullanıcı, beklenen 1", n)
+	}
+
+	// İç çağrının hatası dış transaction'ın yazdıklarını da geri alır
+	errInner := errors.New("iç hata")
+	err = Transaction(ctx, db, func(outer *gorm.DB) error {
+		if err := users.WithTx(outer).Create(ctx, &models.User{Name: "Dış", Account: "dis@example.com", Password: "x", Type: models.Panel}); err != nil {
+			return err
+		}
+		return Transaction(ctx, outer, func(inner *gorm.DB) error {
+			afterCommit(inner, func() { t.Error("geri alınan transaction'ın commit sonrası işi çalıştı") })
+			return errInner
+		})
+	})
+	if !errors.Is(err, errInner) {
+		t.Fatalf("err = %v, beklenen iç hata", err)
+	}
+	if n := countRows(t, db, &models.User{}); n != 1 {
+		t.Errorf("%d kullanıcı, dış eklemenin geri alınması bekleniyordu", n)
+	}
+}
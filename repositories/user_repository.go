@@ -2,41 +2,137 @@ package repositories
 
 import (
 	"context"
+	"sync"
 
+	"zatrano/configs/configsapp"
 	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configslog"
 	"zatrano/models"
 	"zatrano/pkg/queryparams"
+	"zatrano/pkg/requestctx"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IUserRepository interface {
-	GetAllUsers(params queryparams.ListParams) ([]models.User, int64, error)
-	GetUserByID(id uint) (*models.User, error)
+	GetAllUsers(ctx context.Context, params queryparams.ListParams) ([]models.User, int64, error)
+	GetAllUsersCursor(ctx context.Context, params queryparams.ListParams) ([]models.User, string, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	GetUserByIDFromPrimary(ctx context.Context, id uint) (*models.User, error)
 	CreateUser(ctx context.Context, user *models.User) error
 	BulkCreateUsers(ctx context.Context, users []models.User) error
-	UpdateUser(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
-	BulkUpdateUsers(ctx context.Context, condition map[string]interface{}, data map[string]interface{}, updatedBy uint) error
+	UpdateUser(ctx context.Context, id uint, data map[string]interface{}) (*models.User, error)
+	BulkUpdateUsers(ctx context.Context, condition map[string]interface{}, data map[string]interface{}) (int64, error)
 	DeleteUser(ctx context.Context, id uint) error
-	BulkDeleteUsers(ctx context.Context, condition map[string]interface{}) error
-	GetUserCount() (int64, error)
+	RestoreUser(ctx context.Context, id uint) error
+	ForceDeleteUser(ctx context.Context, id uint) error
+	BulkDeleteUsers(ctx context.Context, condition map[string]interface{}) (int64, error)
+	GetUserCount(ctx context.Context, params queryparams.ListParams) (int64, error)
+	ExportUsers(ctx context.Context, params queryparams.ListParams, batchSize int, fn func(batch []models.User) error) error
+	UserExists(ctx context.Context, condition map[string]interface{}) (bool, error)
+	AccountTaken(ctx context.Context, account string) (bool, error)
+	LockActiveAdminIDs(ctx context.Context) ([]uint, error)
+	WithTx(tx *gorm.DB) IUserRepository
+	InTransaction(ctx context.Context, fn func(repo IUserRepository) error) error
 }
 
 type UserRepository struct {
 	base IBaseRepository[models.User]
+	tx   *gorm.DB
+}
+
+// Çok kiracılı kurulumda kullanıcılar kuruluşlarına göre kapsamlanır
+const userTenantColumn = "organization_id"
+
+// Panelde aynı kullanıcıyı düzenleyen iki yönetici birbirinin değişikliğini ezmesin diye
+const userVersionColumn = "version"
+
+var (
+	userBaseMu     sync.Mutex
+	userBase       *CachedRepository[models.User]
+	userBaseDB     *gorm.DB
+	userBaseTenant bool
+)
+
+// Bağlantı ya da kiracı ayarı değişirse (testlerde her test kendi veritabanını açar) önbellekle birlikte yeniden kurulur
+func sharedUserBase() *CachedRepository[models.User] {
+	userBaseMu.Lock()
+	defer userBaseMu.Unlock()
+
+	db := configsdatabase.GetDB()
+	multiTenant := configsapp.Get().MultiTenant
+	if userBase == nil || userBaseDB != db || userBaseTenant != multiTenant {
+		base := NewBaseRepositoryWithReadDB[models.User](db, configsdatabase.GetReadDB())
+		base.EnableAudit(NewAuditRepository())
+		base.EnableOptimisticLocking(userVersionColumn)
+		base.OnAfterDelete(deleteUserNotifications)
+		if multiTenant {
+			base.SetTenantColumn(userTenantColumn)
+		}
+
+		ttl := configsapp.Get().Cache.UserTTL
+		userBase = NewCachedRepository[models.User](NewInstrumentedRepository[models.User](base, "user"), ttl)
+		userBaseDB = db
+		userBaseTenant = multiTenant
+	}
+	return userBase
+}
+
+// Silinen kullanıcının bildirimleri de temizlenir; hata kullanıcı silmeyi geri almaz
+func deleteUserNotifications(ctx context.Context, userID uint) {
+	if err := NewNotificationRepository().DeleteForUser(ctx, userID); err != nil {
+		configslog.FromContext(ctx).Warn("Silinen kullanıcının bildirimleri temizlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+func NewUserBaseRepository() IBaseRepository[models.User] {
+	return sharedUserBase()
 }
 
 func NewUserRepository() IUserRepository {
-	base := NewBaseRepository[models.User](configsdatabase.GetDB())
-	base.SetAllowedSortColumns([]string{"id", "name", "account", "created_at", "status", "type"})
+	return &UserRepository{base: sharedUserBase()}
+}
+
+func (r *UserRepository) WithTx(tx *gorm.DB) IUserRepository {
+	return &UserRepository{base: r.base.WithTx(tx), tx: tx}
+}
+
+func (r *UserRepository) InTransaction(ctx context.Context, fn func(repo IUserRepository) error) error {
+	return Transaction(ctx, configsdatabase.GetDB(), func(tx *gorm.DB) error {
+		return fn(r.WithTx(tx))
+	})
+}
 
-	return &UserRepository{base: base}
+// Context almayan base metotları kiracıyı göremez; kiracı context'ten okunup repository'ye bağlanır
+func (r *UserRepository) forTenant(ctx context.Context) IBaseRepository[models.User] {
+	if tenantID, ok := requestctx.TenantID(ctx); ok {
+		return r.base.WithTenant(tenantID)
+	}
+	return r.base
 }
 
-func (r *UserRepository) GetAllUsers(params queryparams.ListParams) ([]models.User, int64, error) {
-	return r.base.GetAll(params)
+func (r *UserRepository) GetAllUsers(ctx context.Context, params queryparams.ListParams) ([]models.User, int64, error) {
+	return r.forTenant(ctx).GetAll(ctx, params)
 }
 
-func (r *UserRepository) GetUserByID(id uint) (*models.User, error) {
-	return r.base.GetByID(id)
+func (r *UserRepository) GetAllUsersCursor(ctx context.Context, params queryparams.ListParams) ([]models.User, string, error) {
+	return r.forTenant(ctx).GetAllCursor(ctx, params)
+}
+
+func (r *UserRepository) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	return r.forTenant(ctx).GetByID(ctx, id)
+}
+
+// Yetki kararlarında önbellek ve replika gecikmesi kabul edilmez; kayıt birincil veritabanından okunur
+func (r *UserRepository) GetUserByIDFromPrimary(ctx context.Context, id uint) (*models.User, error) {
+	primary := configsdatabase.GetDB().WithContext(ctx)
+	base := r.forTenant(ctx)
+	if cached, ok := base.(*CachedRepository[models.User]); ok {
+		return cached.Refresh(ctx, primary, id)
+	}
+	return base.WithTx(primary).GetByID(ctx, id)
 }
 
 func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
@@ -47,25 +143,71 @@ func (r *UserRepository) BulkCreateUsers(ctx context.Context, users []models.Use
 	return r.base.BulkCreate(ctx, users)
 }
 
-func (r *UserRepository) UpdateUser(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
-	return r.base.Update(ctx, id, data, updatedBy)
+func (r *UserRepository) UpdateUser(ctx context.Context, id uint, data map[string]interface{}) (*models.User, error) {
+	return r.base.Update(ctx, id, data)
 }
 
-func (r *UserRepository) BulkUpdateUsers(ctx context.Context, condition map[string]interface{}, data map[string]interface{}, updatedBy uint) error {
-	return r.base.BulkUpdate(ctx, condition, data, updatedBy)
+func (r *UserRepository) BulkUpdateUsers(ctx context.Context, condition map[string]interface{}, data map[string]interface{}) (int64, error) {
+	return r.base.BulkUpdate(ctx, condition, data)
 }
 
 func (r *UserRepository) DeleteUser(ctx context.Context, id uint) error {
 	return r.base.Delete(ctx, id)
 }
 
-func (r *UserRepository) BulkDeleteUsers(ctx context.Context, condition map[string]interface{}) error {
+func (r *UserRepository) RestoreUser(ctx context.Context, id uint) error {
+	return r.base.Restore(ctx, id)
+}
+
+func (r *UserRepository) ForceDeleteUser(ctx context.Context, id uint) error {
+	return r.base.ForceDelete(ctx, id)
+}
+
+func (r *UserRepository) BulkDeleteUsers(ctx context.Context, condition map[string]interface{}) (int64, error) {
 	return r.base.BulkDelete(ctx, condition)
 }
 
-func (r *UserRepository) GetUserCount() (int64, error) {
-	return r.base.GetCount()
+func (r *UserRepository) GetUserCount(ctx context.Context, params queryparams.ListParams) (int64, error) {
+	return r.forTenant(ctx).GetCount(ctx, params)
+}
+
+func (r *UserRepository) UserExists(ctx context.Context, condition map[string]interface{}) (bool, error) {
+	return r.base.Exists(ctx, condition)
+}
+
+// Hesap adı tüm kiracılarda tekil olduğundan kontrol kiracı kapsamı dışında yapılır.
+// Gecikmeli replika az önce eklenen hesabı göstermeyebilir; transaction dışında da birincilden okunur.
+func (r *UserRepository) AccountTaken(ctx context.Context, account string) (bool, error) {
+	base := r.base
+	if r.tx == nil {
+		base = base.WithTx(configsdatabase.GetDB())
+	}
+	if configsapp.Get().MultiTenant {
+		base = base.WithoutTenantScope()
+	}
+	return base.Exists(ctx, map[string]interface{}{"account": account})
+}
+
+// Son yönetici kontrolü içindir: aktif yönetici satırları transaction bitene kadar kilitlenir (SELECT ... FOR UPDATE)
+// ki eşzamanlı iki pasifleştirme aynı sayımı görüp son yöneticiyi de kapatamasın. Postgres COUNT ile FOR UPDATE'i
+// birlikte kabul etmediği için kilitlenen satırların id'leri döner.
+func (r *UserRepository) LockActiveAdminIDs(ctx context.Context) ([]uint, error) {
+	if r.tx == nil {
+		return nil, ErrNotInTransaction
+	}
+	var ids []uint
+	err := r.tx.WithContext(ctx).Model(&models.User{}).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("type = ? AND status = ?", models.Dashboard, true).
+		Order("id").
+		Pluck("id", &ids).Error
+	return ids, err
 }
 
 var _ IUserRepository = (*UserRepository)(nil)
 var _ IBaseRepository[models.User] = (*BaseRepository[models.User])(nil)
+var _ IBaseRepository[models.User] = (*CachedRepository[models.User])(nil)
+
+func (r *UserRepository) ExportUsers(ctx context.Context, params queryparams.ListParams, batchSize int, fn func(batch []models.User) error) error {
+	return r.base.ForEachListBatch(ctx, params, batchSize, fn)
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IUserSessionRepository interface {
+	Create(session *models.UserSession) error
+	FindBySessionID(sessionID string) (*models.UserSession, error)
+	FindForUser(userID uint, id uint) (*models.UserSession, error)
+	ListActive(userID uint, activeSince time.Time) ([]models.UserSession, error)
+	Touch(sessionID string, at time.Time) error
+	DeleteStale(userID uint, before time.Time) error
+	Revoke(sessionID string) error
+	RevokeAllForUser(userID uint) ([]string, error)
+}
+
+type UserSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository() IUserSessionRepository {
+	return &UserSessionRepository{db: configsdatabase.GetDB()}
+}
+
+func (r *UserSessionRepository) Create(session *models.UserSession) error {
+	return r.db.Create(session).Error
+}
+
+func (r *UserSessionRepository) FindBySessionID(sessionID string) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := r.db.Where("session_id = ?", sessionID).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *UserSessionRepository) FindForUser(userID uint, id uint) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *UserSessionRepository) ListActive(userID uint, activeSince time.Time) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND last_activity_at >= ?", userID, activeSince).
+		Order("last_activity_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *UserSessionRepository) Touch(sessionID string, at time.Time) error {
+	return r.db.Model(&models.UserSession{}).
+		Where("session_id = ?", sessionID).
+		UpdateColumn("last_activity_at", at).Error
+}
+
+// Storage'daki karşılığı süresi dolarak silinmiş kayıtları temizler
+func (r *UserSessionRepository) DeleteStale(userID uint, before time.Time) error {
+	return r.db.Where("user_id = ? AND last_activity_at < ?", userID, before).
+		Delete(&models.UserSession{}).Error
+}
+
+func (r *UserSessionRepository) Revoke(sessionID string) error {
+	return r.db.Model(&models.UserSession{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		UpdateColumn("revoked_at", time.Now()).Error
+}
+
+// İptal edilen oturumların kimliklerini döner; çağıran bunları session storage'dan da siler
+func (r *UserSessionRepository) RevokeAllForUser(userID uint) ([]string, error) {
+	var sessionIDs []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.UserSession{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+		if err := query.Pluck("session_id", &sessionIDs).Error; err != nil {
+			return err
+		}
+		if len(sessionIDs) == 0 {
+			return nil
+		}
+		return tx.Model(&models.UserSession{}).
+			Where("session_id IN ?", sessionIDs).
+			UpdateColumn("revoked_at", time.Now()).Error
+	})
+	return sessionIDs, err
+}
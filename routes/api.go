@@ -0,0 +1,30 @@
+package routes
+
+import (
+	handlers "zatrano/handlers/dashboard"
+	"zatrano/middlewares"
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// API rotaları dashboard handler'larını yeniden kullanır; TokenAuth yanıtları JSON'a zorlar
+func registerAPIRoutes(app *fiber.App) {
+	apiGroup := app.Group("/api/v1")
+	apiGroup.Use(
+		middlewares.TokenAuth,
+		middlewares.RequireUserType(models.Dashboard),
+	)
+
+	canView := middlewares.RequirePermission(models.PermUsersView)
+	canCreate := middlewares.RequirePermission(models.PermUsersCreate)
+	canUpdate := middlewares.RequirePermission(models.PermUsersUpdate)
+	canDelete := middlewares.RequirePermission(models.PermUsersDelete)
+
+	userHandler := handlers.NewUserHandler()
+	apiGroup.Get("/users", canView, middlewares.ListETag(), userHandler.ListUsers)
+	apiGroup.Get("/users/:id", canView, userHandler.ShowUser)
+	apiGroup.Post("/users", canCreate, userHandler.CreateUser)
+	apiGroup.Put("/users/:id", canUpdate, userHandler.UpdateUser)
+	apiGroup.Delete("/users/:id", canDelete, userHandler.DeleteUser)
+}
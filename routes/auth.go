@@ -13,9 +13,20 @@ func registerAuthRoutes(app *fiber.App) {
 	authGroup := app.Group("/auth")
 
 	authGroup.Get("/login", middlewares.GuestMiddleware, authHandler.ShowLogin)
-	authGroup.Post("/login", middlewares.GuestMiddleware, authHandler.Login)
+	authGroup.Post("/login", middlewares.GuestMiddleware, middlewares.LoginRateLimiter(), authHandler.Login)
+
+	authGroup.Get("/forgot-password", middlewares.GuestMiddleware, authHandler.ShowForgotPassword)
+	authGroup.Post("/forgot-password", middlewares.GuestMiddleware, middlewares.PasswordResetRateLimiter(), authHandler.ForgotPassword)
+	authGroup.Get("/reset-password", middlewares.GuestMiddleware, authHandler.ShowResetPassword)
+	authGroup.Post("/reset-password", middlewares.GuestMiddleware, authHandler.ResetPassword)
 
 	authGroup.Get("/logout", middlewares.AuthMiddleware, authHandler.Logout)
 	authGroup.Get("/profile", middlewares.AuthMiddleware, authHandler.Profile)
-	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, authHandler.UpdatePassword)
+	// Kimliğe bürünen yönetici hedef kullanıcının kimlik bilgilerini ve oturumlarını değiştiremez
+	authGroup.Post("/profile", middlewares.AuthMiddleware, middlewares.RequireRealIdentity, authHandler.UpdateProfile)
+	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, middlewares.RequireRealIdentity, authHandler.UpdatePassword)
+	authGroup.Post("/profile/sessions/revoke/:id", middlewares.AuthMiddleware, middlewares.RequireRealIdentity, authHandler.RevokeSession)
+	authGroup.Post("/profile/tokens", middlewares.AuthMiddleware, middlewares.RequireRealIdentity, authHandler.CreateAPIToken)
+	authGroup.Post("/profile/tokens/revoke/:id", middlewares.AuthMiddleware, middlewares.RequireRealIdentity, authHandler.RevokeAPIToken)
+	authGroup.Post("/impersonate/stop", middlewares.AuthMiddleware, authHandler.StopImpersonation)
 }
@@ -13,17 +13,38 @@ func registerDashboardRoutes(app *fiber.App) {
 	dashboardGroup.Use(
 		middlewares.AuthMiddleware,
 		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.Dashboard),
+		middlewares.RequireUserType(models.Dashboard),
 	)
 
 	dashboardHomeHandler := handlers.NewDashboardHomeHandler()
 	dashboardGroup.Get("/home", dashboardHomeHandler.HomePage)
 
+	canView := middlewares.RequirePermission(models.PermUsersView)
+	canCreate := middlewares.RequirePermission(models.PermUsersCreate)
+	canUpdate := middlewares.RequirePermission(models.PermUsersUpdate)
+	canDelete := middlewares.RequirePermission(models.PermUsersDelete)
+	listETag := middlewares.ListETag()
+
 	userHandler := handlers.NewUserHandler()
-	dashboardGroup.Get("/users", userHandler.ListUsers)
-	dashboardGroup.Get("/users/create", userHandler.ShowCreateUser)
-	dashboardGroup.Post("/users/create", userHandler.CreateUser)
-	dashboardGroup.Get("/users/update/:id", userHandler.ShowUpdateUser)
-	dashboardGroup.Post("/users/update/:id", userHandler.UpdateUser)
-	dashboardGroup.Delete("/users/delete/:id", userHandler.DeleteUser)
+	dashboardGroup.Get("/users", canView, listETag, userHandler.ListUsers)
+	dashboardGroup.Get("/users/export", canView, userHandler.ExportUsersCSV)
+	dashboardGroup.Get("/users/import", canCreate, userHandler.ShowImportUsers)
+	dashboardGroup.Post("/users/import", canCreate, userHandler.ImportUsers)
+	dashboardGroup.Get("/users/create", canCreate, userHandler.ShowCreateUser)
+	dashboardGroup.Post("/users/create", canCreate, userHandler.CreateUser)
+	dashboardGroup.Get("/users/update/:id", canUpdate, userHandler.ShowUpdateUser)
+	dashboardGroup.Post("/users/update/:id", canUpdate, userHandler.UpdateUser)
+	dashboardGroup.Delete("/users/delete/:id", canDelete, userHandler.DeleteUser)
+	dashboardGroup.Post("/users/unlock/:id", canUpdate, userHandler.UnlockUser)
+	dashboardGroup.Post("/users/logout/:id", canUpdate, userHandler.LogoutUser)
+	dashboardGroup.Post("/users/deactivate/:id", canUpdate, userHandler.DeactivateUser)
+	dashboardGroup.Post("/users/reactivate/:id", canUpdate, userHandler.ReactivateUser)
+	dashboardGroup.Get("/users/sessions/:id", canUpdate, userHandler.ListUserSessions)
+	dashboardGroup.Post("/users/sessions/:id/revoke/:sessionId", canUpdate, userHandler.RevokeUserSession)
+
+	jobHandler := handlers.NewJobHandler()
+	dashboardGroup.Get("/jobs/:id", jobHandler.ShowJob)
+
+	auditHandler := handlers.NewAuditHandler()
+	dashboardGroup.Get("/audit/:entity/:id", middlewares.RequirePermission(models.PermAuditView), listETag, auditHandler.ListEntityAudit)
 }
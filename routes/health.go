@@ -0,0 +1,14 @@
+package routes
+
+import (
+	handlers "zatrano/handlers/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Oturum, kimlik doğrulama ve istek loglama middleware'lerinden önce kaydedilmelidir
+func registerHealthRoutes(app *fiber.App) {
+	healthHandler := handlers.NewHealthHandler()
+	app.Get("/healthz", healthHandler.Liveness)
+	app.Get("/readyz", healthHandler.Readiness)
+}
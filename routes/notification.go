@@ -0,0 +1,22 @@
+package routes
+
+import (
+	handlers "zatrano/handlers/notification"
+	"zatrano/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func registerNotificationRoutes(app *fiber.App) {
+	notificationGroup := app.Group("/notifications")
+	notificationGroup.Use(
+		middlewares.AuthMiddleware,
+		middlewares.StatusMiddleware,
+	)
+
+	notificationHandler := handlers.NewNotificationHandler()
+	notificationGroup.Get("/", middlewares.ListETag(), notificationHandler.List)
+	notificationGroup.Get("/open/:id", notificationHandler.Open)
+	notificationGroup.Post("/read", notificationHandler.MarkRead)
+	notificationGroup.Post("/read-all", notificationHandler.MarkAllRead)
+}
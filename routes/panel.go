@@ -1,6 +1,7 @@
 package routes
 
 import (
+	dashboardhandlers "zatrano/handlers/dashboard"
 	handlers "zatrano/handlers/panel"
 	"zatrano/middlewares"
 	"zatrano/models"
@@ -9,11 +10,34 @@ import (
 )
 
 func registerPanelRoutes(app *fiber.App) {
+	// Panel kullanıcısının yerine geçmeyi yönetici başlatır; grubun panel tipi kontrolünden önce kaydedilir
+	app.Post("/panel/users/:id/impersonate",
+		middlewares.AuthMiddleware,
+		middlewares.StatusMiddleware,
+		middlewares.RequireUserType(models.Dashboard),
+		middlewares.RequirePermission(models.PermUsersImpersonate),
+		dashboardhandlers.NewUserHandler().ImpersonateUser,
+	)
+
+	// Rol ekranları kullanıcı tipine değil roles.manage iznine bağlıdır; bu yüzden de grubun tip kontrolünden önce kaydedilir
+	roleAccess := []fiber.Handler{
+		middlewares.AuthMiddleware,
+		middlewares.StatusMiddleware,
+		middlewares.RequirePermission(models.PermRolesManage),
+	}
+	roleHandler := handlers.NewRoleHandler()
+	app.Get("/panel/roles", append(roleAccess, middlewares.ListETag(), roleHandler.ListRoles)...)
+	app.Post("/panel/roles/create", append(roleAccess, roleHandler.CreateRole)...)
+	app.Get("/panel/roles/update/:id", append(roleAccess, roleHandler.ShowUpdateRole)...)
+	app.Post("/panel/roles/update/:id", append(roleAccess, roleHandler.UpdateRole)...)
+	app.Get("/panel/users/roles/:id", append(roleAccess, roleHandler.ShowUserRoles)...)
+	app.Post("/panel/users/roles/:id", append(roleAccess, roleHandler.UpdateUserRoles)...)
+
 	panelGroup := app.Group("/panel")
 	panelGroup.Use(
 		middlewares.AuthMiddleware,
 		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.Panel),
+		middlewares.RequireUserType(models.Panel),
 	)
 
 	panelGroup.Get("/home", handlers.PanelHomeHandler)
@@ -1,28 +1,38 @@
 package routes
 
 import (
+	"zatrano/configs/configsapp"
 	"zatrano/configs/configssession"
+	"zatrano/middlewares"
 	"zatrano/models"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"gorm.io/gorm"
 )
 
 func SetupRoutes(app *fiber.App, db *gorm.DB) {
-	app.Use(logger.New())
+	registerHealthRoutes(app)
+
+	app.Use(middlewares.RequestLogger(configsapp.Get().Log.SkipPaths...))
 
 	sessionStore := configssession.SetupSession()
 	app.Use(func(c *fiber.Ctx) error {
 		c.Locals("session", sessionStore)
 		return c.Next()
 	})
+	app.Use(middlewares.LocaleMiddleware)
+	app.Use(middlewares.MethodOverride)
 
 	registerAuthRoutes(app)
 	registerDashboardRoutes(app)
 	registerPanelRoutes(app)
+	registerNotificationRoutes(app)
+	registerAPIRoutes(app)
 
-	app.Use(rootRedirector)
+	app.Get("/", rootRedirector)
+	app.Use(func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
 }
 
 func rootRedirector(c *fiber.Ctx) error {
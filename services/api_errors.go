@@ -0,0 +1,38 @@
+package services
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"zatrano/pkg/apierrors"
+)
+
+// JSON hata yanıtlarında servis hatalarının HTTP durum karşılıkları
+func init() {
+	apierrors.Register(http.StatusNotFound,
+		ErrUserNotFound, ErrNotificationNotFound, ErrRoleNotFound, ErrAPITokenNotFound, ErrSessionNotFound)
+	apierrors.Register(http.StatusUnauthorized, ErrInvalidCredentials, ErrAPITokenInvalid, ErrSessionRevoked)
+	apierrors.Register(http.StatusForbidden,
+		ErrUserInactive, ErrDeactivateSelf, ErrLastActiveAdmin,
+		ErrImpersonateSelf, ErrImpersonateAdmin, ErrImpersonateInactive, ErrImpersonatorRevoked)
+	apierrors.Register(http.StatusLocked, ErrAccountLocked)
+	apierrors.Register(http.StatusConflict, ErrAccountTaken, ErrUserModified)
+	apierrors.Register(http.StatusUnprocessableEntity,
+		ErrPasswordPolicy, ErrPasswordSameAsOld, ErrCurrentPasswordIncorrect,
+		ErrResetTokenInvalid, ErrNameRequired, ErrAccountInvalid, ErrNotificationTitle,
+		ErrAPITokenNameRequired, ErrRoleNameRequired, ErrInvalidFilter, ErrInvalidDate)
+	apierrors.Register(http.StatusServiceUnavailable, ErrQueryTimeout)
+}
+
+// Kilit mesajının çevirisi kalan dakikayı bekler
+func (e *AccountLockedError) MessageArgs() []interface{} {
+	minutes := int(math.Ceil(time.Until(e.Until).Minutes()))
+	return []interface{}{max(minutes, 1)}
+}
+
+// İhlal edilen kurallar kullanıcıya yöneliktir
+func (e *PasswordPolicyError) UserMessage(locale string) string {
+	return strings.Join(e.Messages(locale), " ")
+}
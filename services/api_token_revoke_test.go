@@ -0,0 +1,32 @@
+package services
+
+import (
+	"testing"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+)
+
+func TestPasswordChangeRevokesOwnAPITokens(t *testing.T) {
+	f := newStatusFixture(t)
+	if err := configsdatabase.DB.AutoMigrate(&models.APIToken{}); err != nil {
+		t.Fatal(err)
+	}
+	tokens := NewAPITokenService()
+	for _, userID := range []uint{f.member.ID, f.member.ID, f.admins[0].ID} {
+		if _, _, err := tokens.Issue(userID, "cli", 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := f.auth.UpdatePassword(f.member.ID, memberPassword, "Yeni5678!Parola"); err != nil {
+		t.Fatalf("UpdatePassword: %v", err)
+	}
+
+	if n := countRows(t, &models.APIToken{}, "user_id = ?", f.member.ID); n != 0 {
+		t.Errorf("şifresi değişen kullanıcının %d API token'ı kaldı", n)
+	}
+	if n := countRows(t, &models.APIToken{}, "user_id = ?", f.admins[0].ID); n != 1 {
+		t.Errorf("başka kullanıcının API token sayısı = %d, beklenen 1", n)
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	apiTokenPrefix      = "zt_"
+	apiTokenBytes       = 32
+	apiTokenNameMaxLen  = 100
+	apiTokenDisplayPart = 8
+)
+
+var (
+	ErrAPITokenInvalid      = errors.New("API token'ı geçersiz veya süresi dolmuş")
+	ErrAPITokenNotFound     = errors.New("API token'ı bulunamadı")
+	ErrAPITokenNameRequired = errors.New("token adı boş olamaz")
+)
+
+type IAPITokenService interface {
+	Issue(userID uint, name string, validFor time.Duration) (plain string, token *models.APIToken, err error)
+	Authenticate(plain string) (*models.APIToken, error)
+	List(userID uint) ([]models.APIToken, error)
+	Revoke(userID uint, id uint) error
+	// Şifre değiştiğinde eski şifreyle üretilmiş tüm token'lar geçersiz olur
+	RevokeAllForUser(userID uint) error
+}
+
+type APITokenService struct {
+	repo repositories.IAPITokenRepository
+}
+
+func NewAPITokenService() IAPITokenService {
+	return &APITokenService{repo: repositories.NewAPITokenRepository()}
+}
+
+// validFor sıfır ise token süresizdir; düz metin token yalnızca burada döner
+func (s *APITokenService) Issue(userID uint, name string, validFor time.Duration) (string, *models.APIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, ErrAPITokenNameRequired
+	}
+	if len(name) > apiTokenNameMaxLen {
+		name = name[:apiTokenNameMaxLen]
+	}
+
+	secret, err := randomToken(apiTokenBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	plain := apiTokenPrefix + secret
+
+	token := &models.APIToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: hashValidator(plain),
+		Prefix:    plain[:len(apiTokenPrefix)+apiTokenDisplayPart],
+	}
+	if validFor > 0 {
+		expiresAt := time.Now().Add(validFor)
+		token.ExpiresAt = &expiresAt
+	}
+	if err := s.repo.Create(token); err != nil {
+		return "", nil, err
+	}
+
+	configslog.Log.Info("API token'ı oluşturuldu", zap.Uint("user_id", userID), zap.Uint("token_id", token.ID))
+	return plain, token, nil
+}
+
+func (s *APITokenService) Authenticate(plain string) (*models.APIToken, error) {
+	if !strings.HasPrefix(plain, apiTokenPrefix) {
+		return nil, ErrAPITokenInvalid
+	}
+
+	token, err := s.repo.FindByHash(hashValidator(plain))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrAPITokenInvalid
+		}
+		return nil, err
+	}
+	if token.IsExpired() {
+		return nil, ErrAPITokenInvalid
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= activityTouchInterval {
+		if err := s.repo.Touch(token.ID, now); err != nil {
+			configslog.Log.Warn("API token kullanım zamanı güncellenemedi", zap.Uint("token_id", token.ID), zap.Error(err))
+		}
+	}
+	return token, nil
+}
+
+func (s *APITokenService) List(userID uint) ([]models.APIToken, error) {
+	return s.repo.ListForUser(userID)
+}
+
+func (s *APITokenService) Revoke(userID uint, id uint) error {
+	if err := s.repo.DeleteForUser(userID, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrAPITokenNotFound
+		}
+		return err
+	}
+	configslog.Log.Info("API token'ı iptal edildi", zap.Uint("user_id", userID), zap.Uint("token_id", id))
+	return nil
+}
+
+func (s *APITokenService) RevokeAllForUser(userID uint) error {
+	count, err := s.repo.DeleteAllForUser(userID)
+	if err != nil {
+		configslog.Log.Error("Kullanıcının API token'ları iptal edilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	if count > 0 {
+		configslog.Log.Info("Kullanıcının tüm API token'ları iptal edildi", zap.Uint("user_id", userID), zap.Int64("token_count", count))
+	}
+	return nil
+}
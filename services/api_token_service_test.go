@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+)
+
+func newTokenFixture(t *testing.T) (*statusFixture, IAPITokenService) {
+	t.Helper()
+	f := newStatusFixture(t)
+	if err := configsdatabase.DB.AutoMigrate(&models.APIToken{}); err != nil {
+		t.Fatal(err)
+	}
+	return f, NewAPITokenService()
+}
+
+func TestAPITokenIsStoredHashed(t *testing.T) {
+	f, tokens := newTokenFixture(t)
+
+	plain, token, err := tokens.Issue(f.member.ID, "  betik  ", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(plain, apiTokenPrefix) || !strings.HasPrefix(plain, token.Prefix) {
+		t.Errorf("token %q, görünen önek %q", plain, token.Prefix)
+	}
+	if token.Name != "betik" || token.ExpiresAt != nil {
+		t.Errorf("kayıt adı %q, bitiş %v", token.Name, token.ExpiresAt)
+	}
+
+	var stored models.APIToken
+	if err := configsdatabase.DB.First(&stored, token.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.TokenHash == plain || strings.Contains(stored.TokenHash, plain[len(apiTokenPrefix):]) {
+		t.Error("token veritabanında açık metin saklanıyor")
+	}
+
+	listed, err := tokens.List(f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].ID != token.ID {
+		t.Errorf("List = %+v", listed)
+	}
+
+	if _, _, err := tokens.Issue(f.member.ID, "   ", 0); !errors.Is(err, ErrAPITokenNameRequired) {
+		t.Errorf("boş ad için err = %v, beklenen ErrAPITokenNameRequired", err)
+	}
+}
+
+func TestAPITokenAuthenticate(t *testing.T) {
+	f, tokens := newTokenFixture(t)
+	plain, token, err := tokens.Issue(f.member.ID, "cli", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := tokens.Authenticate(plain)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if found.ID != token.ID || found.UserID != f.member.ID {
+		t.Errorf("bulunan token %d (kullanıcı %d)", found.ID, found.UserID)
+	}
+	var stored models.APIToken
+	if err := configsdatabase.DB.First(&stored, token.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.LastUsedAt == nil {
+		t.Error("son kullanım zamanı yazılmadı")
+	}
+
+	for _, value := range []string{"", "zt_", "baska_" + plain, plain + "x"} {
+		if _, err := tokens.Authenticate(value); !errors.Is(err, ErrAPITokenInvalid) {
+			t.Errorf("Authenticate(%q) err = %v, beklenen ErrAPITokenInvalid", value, err)
+		}
+	}
+
+	if err := configsdatabase.DB.Model(&models.APIToken{}).Where("id = ?", token.ID).
+		UpdateColumn("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.Authenticate(plain); !errors.Is(err, ErrAPITokenInvalid) {
+		t.Errorf("süresi dolmuş token err = %v, beklenen ErrAPITokenInvalid", err)
+	}
+}
+
+func TestAPITokenRevoke(t *testing.T) {
+	f, tokens := newTokenFixture(t)
+	plain, token, err := tokens.Issue(f.member.ID, "cli", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tokens.Revoke(f.admins[0].ID, token.ID); !errors.Is(err, ErrAPITokenNotFound) {
+		t.Fatalf("başkasının token'ı için err = %v, beklenen ErrAPITokenNotFound", err)
+	}
+	if _, err := tokens.Authenticate(plain); err != nil {
+		t.Fatalf("başkası tarafından iptal denenen token geçersizleşti: %v", err)
+	}
+
+	if err := tokens.Revoke(f.member.ID, token.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if _, err := tokens.Authenticate(plain); !errors.Is(err, ErrAPITokenInvalid) {
+		t.Errorf("iptal edilen token err = %v, beklenen ErrAPITokenInvalid", err)
+	}
+}
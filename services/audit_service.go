@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+type IAuditService interface {
+	GetEntityAuditTrail(ctx context.Context, entityType string, entityID uint, params queryparams.ListParams) (*queryparams.Paginated[models.AuditLog], error)
+}
+
+type AuditService struct {
+	repo repositories.IAuditRepository
+}
+
+func NewAuditService() IAuditService {
+	return &AuditService{repo: repositories.NewAuditRepository()}
+}
+
+func (s *AuditService) GetEntityAuditTrail(ctx context.Context, entityType string, entityID uint, params queryparams.ListParams) (*queryparams.Paginated[models.AuditLog], error) {
+	params.Normalize()
+	logs, totalCount, err := s.repo.ListForEntity(ctx, entityType, entityID, params)
+	if err != nil {
+		configslog.FromContext(ctx).Error("Denetim kayıtları alınamadı",
+			zap.String("entity_type", entityType),
+			zap.Uint("entity_id", entityID),
+			zap.Error(err))
+		return nil, errors.New("denetim kayıtları getirilirken bir hata oluştu")
+	}
+	return queryparams.NewPaginated(logs, totalCount, params), nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+func TestAuthServiceQueriesUserOnceForRepeatedLookups(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Status: true, Type: models.Panel}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var queries atomic.Int32
+	err := db.Callback().Query().After("gorm:query").Register("test:count_user_queries", func(tx *gorm.DB) {
+		if tx.Statement.Table == "users" {
+			queries.Add(1)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Her istek yeni bir servis oluşturur; önbellek servisler arasında paylaşılır
+	for i := 0; i < 3; i++ {
+		profile, err := NewAuthService().GetUserProfile(user.ID)
+		if err != nil || profile.Name != "Ayşe" {
+			t.Fatalf("GetUserProfile = %v, %v", profile, err)
+		}
+	}
+	if got := queries.Load(); got != 1 {
+		t.Fatalf("tekrarlanan okumalar %d sorgu yaptı, beklenen 1", got)
+	}
+
+	if _, err := NewAuthService().UpdateProfile(requestctx.WithUserID(context.Background(), user.ID), user.ID, "Ayşe Yılmaz", user.Account, ""); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	before := queries.Load()
+	profile, err := NewAuthService().GetUserProfile(user.ID)
+	if err != nil || profile.Name != "Ayşe Yılmaz" {
+		t.Fatalf("güncelleme sonrası GetUserProfile = %v, %v", profile, err)
+	}
+	if queries.Load() != before+1 {
+		t.Errorf("güncelleme önbelleği temizlemedi: %d yeni sorgu", queries.Load()-before)
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+	"zatrano/pkg/mailer"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+)
+
+type recordingMailer struct {
+	sent chan mailer.Message
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.sent <- msg
+	return nil
+}
+
+func newProfileFixture(t *testing.T) (*AuthService, *recordingMailer, *models.User) {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Status: true, Type: models.Panel}
+	if err := db.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	m := &recordingMailer{sent: make(chan mailer.Message, 1)}
+	return &AuthService{repo: repositories.NewAuthRepository(), mailer: m}, m, user
+}
+
+func TestUpdateProfileNotifiesOldAccount(t *testing.T) {
+	svc, m, user := newProfileFixture(t)
+
+	updated, err := svc.UpdateProfile(context.Background(), user.ID, "Ayşe Yılmaz", "ayse.yilmaz@example.com", "")
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if updated.Account != "ayse.yilmaz@example.com" || updated.UpdatedBy != user.ID {
+		t.Errorf("güncellenen kayıt = %q (updated_by %d), beklenen yeni hesap ve updated_by %d", updated.Account, updated.UpdatedBy, user.ID)
+	}
+
+	select {
+	case msg := <-m.sent:
+		if len(msg.To) != 1 || msg.To[0] != "ayse@example.com" {
+			t.Errorf("bildirim alıcısı %v, beklenen eski adres", msg.To)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("eski adrese bildirim gönderilmedi")
+	}
+}
+
+func TestUpdateProfileNameOnlySendsNoMail(t *testing.T) {
+	svc, m, user := newProfileFixture(t)
+
+	if _, err := svc.UpdateProfile(context.Background(), user.ID, "Ayşe Y.", user.Account, ""); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	select {
+	case msg := <-m.sent:
+		t.Errorf("hesap adı değişmediği halde e-posta gönderildi: %v", msg.To)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestUpdateProfileRejectsTakenAccount(t *testing.T) {
+	svc, _, user := newProfileFixture(t)
+	other := &models.User{Name: "Veli", Account: "veli@example.com", Password: "x", Type: models.Panel}
+	if err := configsdatabase.DB.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(other).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.UpdateProfile(context.Background(), user.ID, "Ayşe", "veli@example.com", ""); !errors.Is(err, ErrAccountTaken) {
+		t.Fatalf("err = %v, beklenen ErrAccountTaken", err)
+	}
+}
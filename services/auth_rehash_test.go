@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+	"zatrano/pkg/passwordhash"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthenticateRehashesLegacyBcrypt(t *testing.T) {
+	testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	previous := passwordhash.Current()
+	params := passwordhash.DefaultParams()
+	params.Memory, params.Iterations, params.Parallelism = 1024, 1, 1
+	passwordhash.Configure(params)
+	t.Cleanup(func() { passwordhash.Configure(previous) })
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("eski-şifre"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: string(legacy), Status: true, Type: models.Panel}
+	if err := configsdatabase.DB.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	svc := &AuthService{repo: repositories.NewAuthRepository()}
+
+	authenticated, err := svc.Authenticate(user.Account, "eski-şifre")
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if !strings.HasPrefix(authenticated.Password, "$argon2id$") {
+		t.Errorf("dönen kullanıcının özeti yükseltilmedi: %q", authenticated.Password)
+	}
+
+	var stored models.User
+	if err := configsdatabase.DB.First(&stored, user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(stored.Password, "$argon2id$") {
+		t.Fatalf("veritabanındaki özet yükseltilmedi: %q", stored.Password)
+	}
+	if ok, needsRehash, err := passwordhash.Verify(stored.Password, "eski-şifre"); !ok || needsRehash || err != nil {
+		t.Errorf("yeni özet Verify = %v, %v, %v", ok, needsRehash, err)
+	}
+
+	// Güncel özetle ikinci girişte tekrar yazılmaz
+	if _, err := svc.Authenticate(user.Account, "eski-şifre"); err != nil {
+		t.Fatalf("ikinci Authenticate: %v", err)
+	}
+	var again models.User
+	if err := configsdatabase.DB.First(&again, user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if again.Password != stored.Password {
+		t.Error("güncel özet ikinci girişte yeniden hashlendi")
+	}
+}
+
+func TestAuthenticateWrongPasswordKeepsLegacyHash(t *testing.T) {
+	testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	legacy, err := bcrypt.GenerateFromPassword([]byte("eski-şifre"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: string(legacy), Status: true, Type: models.Panel}
+	if err := configsdatabase.DB.WithContext(requestctx.WithUserID(context.Background(), 1)).Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	svc := &AuthService{repo: repositories.NewAuthRepository()}
+
+	if _, err := svc.Authenticate(user.Account, "yanlış"); err == nil {
+		t.Fatal("yanlış şifreyle giriş kabul edildi")
+	}
+	var stored models.User
+	if err := configsdatabase.DB.First(&stored, user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Password != string(legacy) {
+		t.Error("yanlış şifre denemesi özeti değiştirdi")
+	}
+}
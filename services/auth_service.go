@@ -1,12 +1,26 @@
 package services
 
 import (
-	"davet.link/configs/configslog"
-	"davet.link/models"
-	"davet.link/repositories"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/mailer"
+	"zatrano/pkg/passwordhash"
+	"zatrano/repositories"
 
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -21,27 +35,107 @@ const (
 	ErrUserNotFound             ServiceError = "kullanıcı bulunamadı"
 	ErrUserInactive             ServiceError = "kullanıcı aktif değil"
 	ErrCurrentPasswordIncorrect ServiceError = "mevcut şifre hatalı"
-	ErrPasswordTooShort         ServiceError = "yeni şifre en az 6 karakter olmalıdır"
 	ErrPasswordSameAsOld        ServiceError = "yeni şifre mevcut şifre ile aynı olamaz"
 	ErrAuthGeneric              ServiceError = "kimlik doğrulaması sırasında bir hata oluştu"
 	ErrProfileGeneric           ServiceError = "profil bilgileri alınırken hata"
 	ErrUpdatePasswordGeneric    ServiceError = "şifre güncellenirken bir hata oluştu"
 	ErrHashingFailed            ServiceError = "yeni şifre oluşturulurken hata"
 	ErrDatabaseUpdateFailed     ServiceError = "veritabanı güncellemesi başarısız oldu"
+	ErrAccountLocked            ServiceError = "hesap geçici olarak kilitlendi"
+	ErrResetTokenInvalid        ServiceError = "şifre sıfırlama bağlantısı geçersiz veya süresi dolmuş"
+	ErrResetRequestFailed       ServiceError = "şifre sıfırlama isteği işlenemedi"
+	ErrPasswordPolicy           ServiceError = "yeni şifre şifre politikasını karşılamıyor"
+	ErrNameRequired             ServiceError = "ad alanı boş olamaz"
+	ErrAccountInvalid           ServiceError = "hesap adı geçerli bir e-posta adresi olmalıdır"
+	ErrAccountTaken             ServiceError = "bu hesap adı zaten kullanılıyor"
 )
 
+// errors.Is(err, ErrAccountLocked) ile yakalanır; handler kalan süreyi Until üzerinden hesaplar
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return string(ErrAccountLocked)
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+var serviceErrorKeys = map[ServiceError]string{
+	ErrInvalidCredentials:       "auth.invalid_credentials",
+	ErrUserNotFound:             "auth.user_not_found",
+	ErrUserInactive:             "auth.user_inactive",
+	ErrCurrentPasswordIncorrect: "auth.current_password_wrong",
+	ErrPasswordSameAsOld:        "auth.password_same_as_old",
+	ErrAuthGeneric:              "auth.generic",
+	ErrProfileGeneric:           "auth.profile_generic",
+	ErrUpdatePasswordGeneric:    "auth.update_password_generic",
+	ErrHashingFailed:            "auth.hashing_failed",
+	ErrDatabaseUpdateFailed:     "auth.database_update_failed",
+	ErrAccountLocked:            "auth.account_locked",
+	ErrResetTokenInvalid:        "auth.reset_token_invalid",
+	ErrResetRequestFailed:       "auth.reset_request_failed",
+	ErrPasswordPolicy:           "auth.password_policy",
+	ErrNameRequired:             "auth.name_required",
+	ErrAccountInvalid:           "auth.account_invalid",
+	ErrAccountTaken:             "auth.account_taken",
+}
+
+// Kullanıcıya gösterilecek mesajın i18n anahtarı
+func (e ServiceError) MessageKey() string {
+	if key, ok := serviceErrorKeys[e]; ok {
+		return key
+	}
+	return "common.unexpected_error"
+}
+
 type IAuthService interface {
 	Authenticate(account, password string) (*models.User, error)
 	GetUserProfile(id uint) (*models.User, error)
 	UpdatePassword(userID uint, currentPass, newPassword string) error
+	// currentPassword yalnızca hesap adı değişiyorsa ve ayar gerektiriyorsa kontrol edilir
+	UpdateProfile(ctx context.Context, userID uint, name, account, currentPassword string) (*models.User, error)
+	AccountChangeRequiresPassword() bool
+	RequestPasswordReset(account string) error
+	ResetPassword(token, newPassword string) error
+	ValidateResetToken(token string) error
+	PasswordPolicy() PasswordPolicy
+	RecordLogin(userID uint, ip string) error
 }
 
 type AuthService struct {
-	repo repositories.IAuthRepository
+	repo            repositories.IAuthRepository
+	resetRepo       repositories.IPasswordResetRepository
+	sessions        ISessionService
+	apiTokens       IAPITokenService
+	mailer          mailer.Mailer
+	policy          PasswordPolicy
+	lockoutAttempts int
+	lockoutDuration time.Duration
+	resetTokenTTL   time.Duration
+	appURL          string
+	// Hesap adı (e-posta) değişikliği mevcut parolanın yeniden girilmesini gerektirir
+	accountChangeNeedsPassword bool
 }
 
 func NewAuthService() IAuthService {
-	return &AuthService{repo: repositories.NewAuthRepository()}
+	cfg := configsapp.Get()
+	return &AuthService{
+		repo:            repositories.NewAuthRepository(),
+		resetRepo:       repositories.NewPasswordResetRepository(),
+		sessions:        NewSessionService(),
+		apiTokens:       NewAPITokenService(),
+		mailer:          mailer.New(cfg.Mail),
+		policy:          PasswordPolicyFromSettings(cfg.Password),
+		lockoutAttempts: cfg.Auth.LockoutThreshold,
+		lockoutDuration: cfg.Auth.LockoutDuration,
+		resetTokenTTL:   cfg.Auth.ResetTokenTTL,
+		appURL:          cfg.AppURL,
+
+		accountChangeNeedsPassword: cfg.Auth.AccountChangeNeedsPassword,
+	}
 }
 
 func (s *AuthService) logAuthSuccess(account string, userID uint) {
@@ -87,15 +181,38 @@ func (s *AuthService) getUserByID(id uint) (*models.User, error) {
 }
 
 func (s *AuthService) comparePasswords(hashedPassword, plainPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
+	_, err := s.verifyPassword(hashedPassword, plainPassword)
+	return err
+}
+
+// Şifre doğruysa, özetin geçerli şema/parametrelerle yeniden üretilmesi gerekip gerekmediğini döner
+func (s *AuthService) verifyPassword(hashedPassword, plainPassword string) (needsRehash bool, err error) {
+	ok, needsRehash, err := passwordhash.Verify(hashedPassword, plainPassword)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, models.ErrPasswordMismatch
+	}
+	return needsRehash, nil
 }
 
 func (s *AuthService) hashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return passwordhash.Hash(password)
+}
+
+// Eski şemayla (ör. bcrypt) saklanan özet, giriş sırasında bilinen şifreyle sessizce yenilenir
+func (s *AuthService) rehashPassword(user *models.User, password string) {
+	hashedPassword, err := s.hashPassword(password)
 	if err != nil {
-		return "", err
+		s.logDBError("Parola yeniden hashleme", err, zap.Uint("user_id", user.ID))
+		return
 	}
-	return string(hashedPassword), nil
+	if err := s.repo.RehashPassword(user.ID, hashedPassword); err != nil {
+		return
+	}
+	user.Password = hashedPassword
+	configslog.Log.Info("Parola özeti güncel şemaya yükseltildi", zap.Uint("user_id", user.ID))
 }
 
 func (s *AuthService) Authenticate(account, password string) (*models.User, error) {
@@ -112,18 +229,68 @@ func (s *AuthService) Authenticate(account, password string) (*models.User, erro
 		return nil, ErrUserInactive
 	}
 
-	if err := s.comparePasswords(user.Password, password); err != nil {
+	// Kilitli hesapta parola hiç kontrol edilmez; doğru olup olmadığı sızdırılmaz
+	if user.IsLocked() {
+		s.logWarn("Kilitli hesaba giriş denemesi",
+			zap.String("account", account),
+			zap.Uint("user_id", user.ID),
+		)
+		return nil, &AccountLockedError{Until: *user.LockedUntil}
+	}
+	if user.LockedUntil != nil {
+		// Kilit süresi dolmuş; sayaç sıfırdan başlar
+		if err := s.repo.ResetFailedLogins(user.ID); err != nil {
+			return nil, ErrAuthGeneric
+		}
+		user.FailedLoginCount = 0
+		user.LockedUntil = nil
+	}
+
+	needsRehash, err := s.verifyPassword(user.Password, password)
+	if err != nil {
 		s.logWarn("Geçersiz parola",
 			zap.String("account", account),
 			zap.Uint("user_id", user.ID),
 		)
-		return nil, ErrInvalidCredentials
+		return nil, s.registerFailedLogin(user)
+	}
+	if needsRehash {
+		s.rehashPassword(user, password)
+	}
+
+	if user.FailedLoginCount > 0 {
+		if err := s.repo.ResetFailedLogins(user.ID); err != nil {
+			return nil, ErrAuthGeneric
+		}
+		user.FailedLoginCount = 0
 	}
 
 	s.logAuthSuccess(account, user.ID)
 	return user, nil
 }
 
+func (s *AuthService) registerFailedLogin(user *models.User) error {
+	count, err := s.repo.IncrementFailedLogins(user.ID)
+	if err != nil {
+		return ErrAuthGeneric
+	}
+	if s.lockoutAttempts <= 0 || count < s.lockoutAttempts {
+		return ErrInvalidCredentials
+	}
+
+	until := time.Now().Add(s.lockoutDuration)
+	if err := s.repo.LockUser(user.ID, until); err != nil {
+		return ErrAuthGeneric
+	}
+	configslog.Log.Warn("Hesap başarısız denemeler nedeniyle kilitlendi",
+		zap.Uint("user_id", user.ID),
+		zap.String("account", user.Account),
+		zap.Int("failed_attempts", count),
+		zap.Time("locked_until", until),
+	)
+	return &AccountLockedError{Until: until}
+}
+
 func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
 	return s.getUserByID(id)
 }
@@ -139,29 +306,242 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 		return ErrCurrentPasswordIncorrect
 	}
 
-	if len(newPassword) < 6 {
-		s.logWarn("Yeni parola çok kısa", zap.Uint("user_id", userID))
-		return ErrPasswordTooShort
+	hashedPassword, err := s.prepareNewPassword(user, newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdatePassword(user.ID, hashedPassword); err != nil {
+		s.logDBError("Kullanıcı güncelleme", err, zap.Uint("user_id", userID))
+		return ErrDatabaseUpdateFailed
+	}
+
+	configslog.Log.Info("Parola başarıyla güncellendi", zap.Uint("user_id", userID))
+	s.revokeCredentials(user.ID)
+	return nil
+}
+
+// Hesap adı değişirse eski adrese bildirim gider ki hesabı ele geçiren biri bunu sessizce yapamasın
+func (s *AuthService) UpdateProfile(ctx context.Context, userID uint, name, account, currentPassword string) (*models.User, error) {
+	name = strings.TrimSpace(name)
+	account = strings.TrimSpace(account)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if !isValidAccount(account) {
+		return nil, ErrAccountInvalid
+	}
+
+	user, err := s.getUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account != user.Account {
+		if s.accountChangeNeedsPassword {
+			if err := s.comparePasswords(user.Password, currentPassword); err != nil {
+				s.logWarn("Hesap adı değişikliğinde mevcut parola hatalı", zap.Uint("user_id", userID))
+				return nil, ErrCurrentPasswordIncorrect
+			}
+		}
+		taken, err := s.repo.AccountExists(account, userID)
+		if err != nil {
+			return nil, ErrDatabaseUpdateFailed
+		}
+		if taken {
+			s.logWarn("Hesap adı kullanımda", zap.Uint("user_id", userID), zap.String("account", account))
+			return nil, ErrAccountTaken
+		}
+	}
+
+	updated, err := s.repo.UpdateProfile(ctx, userID, name, account)
+	if err != nil {
+		return nil, ErrDatabaseUpdateFailed
+	}
+
+	configslog.FromContext(ctx).Info("Profil güncellendi",
+		zap.Uint("user_id", userID),
+		zap.String("old_account", user.Account),
+		zap.String("account", updated.Account),
+	)
+	if updated.Account != user.Account {
+		s.notifyAccountChanged(ctx, user.Account, updated)
+	}
+	return updated, nil
+}
+
+// Gönderim isteği bekletmez; istek bittikten sonra da sürmesi için iptal edilmeyen context kullanılır
+func (s *AuthService) notifyAccountChanged(ctx context.Context, oldAccount string, user *models.User) {
+	msg := mailer.Message{
+		To:      []string{oldAccount},
+		Subject: "Hesap adınız değiştirildi",
+		Body: fmt.Sprintf("Merhaba %s,\n\nHesabınızın giriş adı %s olarak değiştirildi. Bu adrese artık bildirim gönderilmeyecek.\n\nBu değişikliği siz yapmadıysanız hemen yöneticinizle iletişime geçin.\n",
+			user.Name, user.Account),
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			configslog.FromContext(ctx).Error("Hesap adı değişikliği bildirimi gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+	}()
+}
+
+func (s *AuthService) AccountChangeRequiresPassword() bool {
+	return s.accountChangeNeedsPassword
+}
+
+// Hesap adı şifre sıfırlama e-postalarının alıcısıdır; görünen ad içermeyen yalın bir adres olmalıdır
+func isValidAccount(account string) bool {
+	if account == "" || len(account) > 100 {
+		return false
 	}
+	addr, err := mail.ParseAddress(account)
+	return err == nil && addr.Address == account
+}
+
+// Şifre değiştikten sonra diğer tarayıcılardaki oturumlar ve API token'ları hemen geçersiz olur;
+// oturum iptali başarısız olsa da AuthMiddleware password_changed_at kontrolüyle onları reddeder
+func (s *AuthService) revokeCredentials(userID uint) {
+	if err := s.sessions.RevokeAllForUser(userID); err != nil {
+		s.logDBError("Oturumları sonlandırma", err, zap.Uint("user_id", userID))
+	}
+	if err := s.apiTokens.RevokeAllForUser(userID); err != nil {
+		s.logDBError("API token'larını iptal etme", err, zap.Uint("user_id", userID))
+	}
+}
 
-	if currentPass == newPassword {
-		s.logWarn("Yeni parola eskiyle aynı", zap.Uint("user_id", userID))
-		return ErrPasswordSameAsOld
+// UpdatePassword ve şifre sıfırlama akışı aynı kuralları paylaşır
+func (s *AuthService) prepareNewPassword(user *models.User, newPassword string) (string, error) {
+	if violations := s.policy.Validate(newPassword, user.Account); len(violations) > 0 {
+		policyErr := &PasswordPolicyError{Violations: violations}
+		s.logWarn("Yeni parola politikaya uymuyor", zap.Uint("user_id", user.ID), zap.Strings("violations", policyErr.Messages(i18n.DefaultLocale)))
+		return "", policyErr
+	}
+
+	if s.comparePasswords(user.Password, newPassword) == nil {
+		s.logWarn("Yeni parola eskiyle aynı", zap.Uint("user_id", user.ID))
+		return "", ErrPasswordSameAsOld
 	}
 
 	hashedPassword, err := s.hashPassword(newPassword)
 	if err != nil {
-		s.logDBError("Parola hashleme", err, zap.Uint("user_id", userID))
-		return ErrHashingFailed
+		s.logDBError("Parola hashleme", err, zap.Uint("user_id", user.ID))
+		return "", ErrHashingFailed
 	}
+	return hashedPassword, nil
+}
 
-	user.Password = hashedPassword
-	if err := s.repo.UpdateUser(user); err != nil {
-		s.logDBError("Kullanıcı güncelleme", err, zap.Uint("user_id", userID))
+func (s *AuthService) RecordLogin(userID uint, ip string) error {
+	return s.repo.RecordLogin(userID, ip, time.Now())
+}
+
+func (s *AuthService) PasswordPolicy() PasswordPolicy {
+	return s.policy
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Hesabın var olup olmadığı çağırana hiçbir şekilde yansıtılmaz
+func (s *AuthService) RequestPasswordReset(account string) error {
+	user, err := s.getUserByAccount(account)
+	if err != nil {
+		if err == ErrUserNotFound {
+			return nil
+		}
+		return ErrResetRequestFailed
+	}
+	if !user.Status {
+		s.logWarn("Pasif hesap için şifre sıfırlama", zap.Uint("user_id", user.ID))
+		return nil
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		s.logDBError("Sıfırlama token'ı üretme", err, zap.Uint("user_id", user.ID))
+		return ErrResetRequestFailed
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := s.resetRepo.InvalidateForUser(user.ID); err != nil {
+		s.logDBError("Eski sıfırlama token'larını iptal etme", err, zap.Uint("user_id", user.ID))
+		return ErrResetRequestFailed
+	}
+	record := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(s.resetTokenTTL),
+	}
+	if err := s.resetRepo.Create(record); err != nil {
+		s.logDBError("Sıfırlama token'ı kaydetme", err, zap.Uint("user_id", user.ID))
+		return ErrResetRequestFailed
+	}
+
+	msg := mailer.Message{
+		To:      []string{user.Account},
+		Subject: "Şifre sıfırlama",
+		Body: fmt.Sprintf("Merhaba %s,\n\nŞifrenizi sıfırlamak için aşağıdaki bağlantıyı kullanın. Bağlantı %d dakika geçerlidir ve yalnızca bir kez kullanılabilir.\n\n%s/auth/reset-password?token=%s\n\nBu isteği siz yapmadıysanız bu e-postayı dikkate almayın.\n",
+			user.Name, int(s.resetTokenTTL.Minutes()), s.appURL, token),
+	}
+	// Gönderim süresi hesabın varlığını ele vermesin diye yanıt beklenmez
+	go func(userID uint) {
+		if err := s.mailer.Send(context.Background(), msg); err != nil {
+			s.logDBError("Şifre sıfırlama e-postası gönderme", err, zap.Uint("user_id", userID))
+		}
+	}(user.ID)
+
+	configslog.Log.Info("Şifre sıfırlama bağlantısı oluşturuldu", zap.Uint("user_id", user.ID))
+	return nil
+}
+
+func (s *AuthService) findActiveResetToken(token string) (*models.PasswordResetToken, error) {
+	record, err := s.resetRepo.FindByHash(hashResetToken(token))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrResetTokenInvalid
+		}
+		s.logDBError("Sıfırlama token'ı sorgulama", err)
+		return nil, ErrResetRequestFailed
+	}
+	if record.Used || record.IsExpired() {
+		return nil, ErrResetTokenInvalid
+	}
+	return record, nil
+}
+
+func (s *AuthService) ValidateResetToken(token string) error {
+	_, err := s.findActiveResetToken(token)
+	return err
+}
+
+func (s *AuthService) ResetPassword(token, newPassword string) error {
+	record, err := s.findActiveResetToken(token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.getUserByID(record.UserID)
+	if err != nil {
+		return ErrResetTokenInvalid
+	}
+
+	hashedPassword, err := s.prepareNewPassword(user, newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.resetRepo.Consume(record, hashedPassword); err != nil {
+		if errors.Is(err, repositories.ErrResetTokenUsed) {
+			return ErrResetTokenInvalid
+		}
+		s.logDBError("Şifre sıfırlama", err, zap.Uint("user_id", user.ID))
 		return ErrDatabaseUpdateFailed
 	}
 
-	configslog.Log.Info("Parola başarıyla güncellendi", zap.Uint("user_id", userID))
+	configslog.Log.Info("Parola sıfırlama bağlantısıyla güncellendi", zap.Uint("user_id", user.ID))
+	s.revokeCredentials(user.ID)
 	return nil
 }
 
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/requestctx"
+	"zatrano/repositories"
+
+	"gorm.io/gorm"
+)
+
+var ErrMissingActor = errors.New("işlemi yapan kullanıcı kimliği geçersiz")
+
+type txContextKey struct{}
+
+type BaseService[T any] struct {
+	db   *gorm.DB
+	repo repositories.IBaseRepository[T]
+
+	beforeCreate []func(ctx context.Context, entity *T) error
+	afterCreate  []func(ctx context.Context, entity *T) error
+	beforeUpdate []func(ctx context.Context, id uint, data map[string]interface{}) error
+	afterUpdate  []func(ctx context.Context, id uint, data map[string]interface{}) error
+	beforeDelete []func(ctx context.Context, id uint) error
+	afterDelete  []func(ctx context.Context, id uint) error
+}
+
+func NewBaseService[T any](db *gorm.DB, repo repositories.IBaseRepository[T]) *BaseService[T] {
+	return &BaseService[T]{db: db, repo: repo}
+}
+
+// Hook'lar repository çağrısıyla aynı transaction içinde çalışır; TxFromContext ile o transaction'a erişilebilir
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+func (s *BaseService[T]) BeforeCreate(hook func(ctx context.Context, entity *T) error) {
+	s.beforeCreate = append(s.beforeCreate, hook)
+}
+
+func (s *BaseService[T]) AfterCreate(hook func(ctx context.Context, entity *T) error) {
+	s.afterCreate = append(s.afterCreate, hook)
+}
+
+func (s *BaseService[T]) BeforeUpdate(hook func(ctx context.Context, id uint, data map[string]interface{}) error) {
+	s.beforeUpdate = append(s.beforeUpdate, hook)
+}
+
+func (s *BaseService[T]) AfterUpdate(hook func(ctx context.Context, id uint, data map[string]interface{}) error) {
+	s.afterUpdate = append(s.afterUpdate, hook)
+}
+
+func (s *BaseService[T]) BeforeDelete(hook func(ctx context.Context, id uint) error) {
+	s.beforeDelete = append(s.beforeDelete, hook)
+}
+
+func (s *BaseService[T]) AfterDelete(hook func(ctx context.Context, id uint) error) {
+	s.afterDelete = append(s.afterDelete, hook)
+}
+
+func (s *BaseService[T]) WithTx(tx *gorm.DB) *BaseService[T] {
+	bound := *s
+	bound.db = tx
+	bound.repo = s.repo.WithTx(tx)
+	return &bound
+}
+
+func (s *BaseService[T]) List(ctx context.Context, params queryparams.ListParams) (*queryparams.Paginated[T], error) {
+	items, total, err := s.repo.GetAll(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return queryparams.NewPaginated(items, total, params), nil
+}
+
+func (s *BaseService[T]) Get(ctx context.Context, id uint) (*T, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *BaseService[T]) Create(ctx context.Context, entity *T) error {
+	return s.transaction(ctx, func(ctx context.Context, repo repositories.IBaseRepository[T]) error {
+		for _, hook := range s.beforeCreate {
+			if err := hook(ctx, entity); err != nil {
+				return err
+			}
+		}
+		if err := repo.Create(ctx, entity); err != nil {
+			return err
+		}
+		for _, hook := range s.afterCreate {
+			if err := hook(ctx, entity); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BaseService[T]) Update(ctx context.Context, id uint, data map[string]interface{}) (*T, error) {
+	if _, ok := requestctx.UserID(ctx); !ok {
+		return nil, ErrMissingActor
+	}
+
+	var updated *T
+	err := s.retryableTransaction(ctx, "Update", func(ctx context.Context, repo repositories.IBaseRepository[T]) error {
+		for _, hook := range s.beforeUpdate {
+			if err := hook(ctx, id, data); err != nil {
+				return err
+			}
+		}
+		entity, err := repo.Update(ctx, id, data)
+		if err != nil {
+			return err
+		}
+		for _, hook := range s.afterUpdate {
+			if err := hook(ctx, id, data); err != nil {
+				return err
+			}
+		}
+		updated = entity
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *BaseService[T]) Delete(ctx context.Context, id uint) error {
+	return s.retryableTransaction(ctx, "Delete", func(ctx context.Context, repo repositories.IBaseRepository[T]) error {
+		for _, hook := range s.beforeDelete {
+			if err := hook(ctx, id); err != nil {
+				return err
+			}
+		}
+		if err := repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		for _, hook := range s.afterDelete {
+			if err := hook(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BaseService[T]) transaction(ctx context.Context, fn func(ctx context.Context, repo repositories.IBaseRepository[T]) error) error {
+	return repositories.Transaction(ctx, s.db, func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx), s.repo.WithTx(tx))
+	})
+}
+
+// Update ve Delete hook'larıyla birlikte deadlock/serialization hatasında baştan denenir; Create yalnız transaction'da kalır
+func (s *BaseService[T]) retryableTransaction(ctx context.Context, operation string, fn func(ctx context.Context, repo repositories.IBaseRepository[T]) error) error {
+	return repositories.RetryableTransaction(ctx, s.db, operation, func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx), s.repo.WithTx(tx))
+	})
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+
+	"gorm.io/gorm"
+)
+
+type serviceNote struct {
+	ID        uint `gorm:"primarykey"`
+	Title     string
+	DeletedAt gorm.DeletedAt
+	DeletedBy *uint
+}
+
+func newNoteService(t *testing.T) (*BaseService[serviceNote], *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t, &serviceNote{})
+	return NewBaseService[serviceNote](db, repositories.NewBaseRepository[serviceNote](db)), db
+}
+
+func noteCount(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var count int64
+	if err := db.Model(&serviceNote{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestBaseServiceHooksRunInOrderInsideTransaction(t *testing.T) {
+	svc, _ := newNoteService(t)
+	var calls []string
+	svc.BeforeCreate(func(ctx context.Context, note *serviceNote) error {
+		calls = append(calls, "before-1")
+		note.Title = "düzeltilmiş " + note.Title
+		return nil
+	})
+	svc.BeforeCreate(func(ctx context.Context, note *serviceNote) error {
+		calls = append(calls, "before-2")
+		return nil
+	})
+	svc.AfterCreate(func(ctx context.Context, note *serviceNote) error {
+		tx, ok := TxFromContext(ctx)
+		if !ok {
+			return errors.New("hook transaction dışında çalıştı")
+		}
+		// Kayıt aynı transaction içinden görülebilmeli
+		var stored serviceNote
+		if err := tx.First(&stored, note.ID).Error; err != nil {
+			return err
+		}
+		calls = append(calls, "after:"+stored.Title)
+		return nil
+	})
+
+	if err := svc.Create(context.Background(), &serviceNote{Title: "not"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"before-1", "before-2", "after:düzeltilmiş not"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("çağrılar = %v, beklenen %v", calls, want)
+	}
+}
+
+func TestBaseServiceAfterHookErrorRollsBack(t *testing.T) {
+	svc, db := newNoteService(t)
+	errHook := errors.New("bildirim kuyruğa alınamadı")
+	svc.AfterCreate(func(ctx context.Context, note *serviceNote) error { return errHook })
+
+	err := svc.Create(context.Background(), &serviceNote{Title: "geri alınacak"})
+	if !errors.Is(err, errHook) {
+		t.Fatalf("err = %v, beklenen hook hatası", err)
+	}
+	if n := noteCount(t, db); n != 0 {
+		t.Errorf("kayıt sayısı = %d, hook hatası rollback yapmalı", n)
+	}
+}
+
+func TestBaseServiceUpdateHooks(t *testing.T) {
+	svc, db := newNoteService(t)
+	note := &serviceNote{Title: "ilk"}
+	if err := db.Create(note).Error; err != nil {
+		t.Fatal(err)
+	}
+	ctx := requestctx.WithUserID(context.Background(), 1)
+
+	if _, err := svc.Update(context.Background(), note.ID, map[string]interface{}{"title": "x"}); !errors.Is(err, ErrMissingActor) {
+		t.Errorf("kullanıcısız güncelleme err = %v, beklenen ErrMissingActor", err)
+	}
+
+	errRejected := errors.New("başlık reddedildi")
+	var afterRan bool
+	svc.BeforeUpdate(func(ctx context.Context, id uint, data map[string]interface{}) error {
+		if data["title"] == "yasak" {
+			return errRejected
+		}
+		return nil
+	})
+	svc.AfterUpdate(func(ctx context.Context, id uint, data map[string]interface{}) error {
+		afterRan = true
+		return nil
+	})
+
+	if _, err := svc.Update(ctx, note.ID, map[string]interface{}{"title": "yasak"}); !errors.Is(err, errRejected) {
+		t.Fatalf("err = %v, beklenen hook hatası", err)
+	}
+	if afterRan {
+		t.Error("reddedilen güncellemede after hook çalıştı")
+	}
+	updated, err := svc.Update(ctx, note.ID, map[string]interface{}{"title": "ikinci"})
+	if err != nil || updated.Title != "ikinci" || !afterRan {
+		t.Errorf("güncelleme = %+v, %v, after çalıştı = %v", updated, err, afterRan)
+	}
+}
+
+func TestBaseServiceDeleteHookErrorKeepsRow(t *testing.T) {
+	svc, db := newNoteService(t)
+	note := &serviceNote{Title: "silinmeyecek"}
+	if err := db.Create(note).Error; err != nil {
+		t.Fatal(err)
+	}
+	errHook := errors.New("önbellek temizlenemedi")
+	svc.AfterDelete(func(ctx context.Context, id uint) error { return errHook })
+
+	if err := svc.Delete(requestctx.WithUserID(context.Background(), 1), note.ID); !errors.Is(err, errHook) {
+		t.Fatalf("err = %v, beklenen hook hatası", err)
+	}
+	if n := noteCount(t, db); n != 1 {
+		t.Errorf("kayıt sayısı = %d, silme geri alınmalı", n)
+	}
+}
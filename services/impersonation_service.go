@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrImpersonateSelf     = errors.New("kendi hesabınızın yerine geçemezsiniz")
+	ErrImpersonateAdmin    = errors.New("başka bir yöneticinin yerine geçilemez")
+	ErrImpersonateInactive = errors.New("aktif olmayan bir kullanıcının yerine geçilemez")
+	ErrImpersonateFailed   = errors.New("kimliğe bürünme işlemi tamamlanamadı")
+	// Yönetici pasifleştirildi, tipi değişti ya da users.impersonate izni geri alındı
+	ErrImpersonatorRevoked = errors.New("kimliğe bürünme yetkisi artık geçerli değil")
+)
+
+// Oturum anahtarlarını handler yazar; servis kuralları uygular ve her başlangıç/bitişi denetim kaydına yazar
+type IImpersonationService interface {
+	Start(ctx context.Context, impersonatorID, targetID uint) (*models.User, error)
+	// Yöneticinin kaydını döndürür; oturum bu kayıtla geri yüklenir
+	Stop(ctx context.Context, impersonatorID, targetID uint) (*models.User, error)
+	// Bürünme süresince her istekte çağrılır; yönetici hâlâ aktif ve yetkili değilse ErrImpersonatorRevoked döner
+	Verify(ctx context.Context, impersonatorID uint) (*models.User, error)
+}
+
+type ImpersonationService struct {
+	users       repositories.IUserRepository
+	audit       repositories.IAuditRepository
+	permissions IPermissionService
+}
+
+func NewImpersonationService() IImpersonationService {
+	return &ImpersonationService{
+		users:       repositories.NewUserRepository(),
+		audit:       repositories.NewAuditRepository(),
+		permissions: NewPermissionService(),
+	}
+}
+
+func (s *ImpersonationService) Verify(ctx context.Context, impersonatorID uint) (*models.User, error) {
+	impersonator, err := s.users.GetUserByIDFromPrimary(ctx, impersonatorID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrImpersonatorRevoked
+		}
+		configslog.FromContext(ctx).Error("Kimliğe bürünme: yönetici alınamadı", zap.Uint("impersonator_id", impersonatorID), zap.Error(err))
+		return nil, ErrImpersonateFailed
+	}
+	if !impersonator.Status || impersonator.Type != models.Dashboard {
+		return nil, ErrImpersonatorRevoked
+	}
+	allowed, err := s.permissions.HasPermission(impersonatorID, models.PermUsersImpersonate)
+	if err != nil {
+		return nil, ErrImpersonateFailed
+	}
+	if !allowed {
+		return nil, ErrImpersonatorRevoked
+	}
+	return impersonator, nil
+}
+
+func (s *ImpersonationService) Start(ctx context.Context, impersonatorID, targetID uint) (*models.User, error) {
+	if impersonatorID == targetID {
+		return nil, ErrImpersonateSelf
+	}
+	if _, err := s.Verify(ctx, impersonatorID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.users.GetUserByID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		configslog.FromContext(ctx).Error("Kimliğe bürünme: hedef kullanıcı alınamadı", zap.Uint("target_id", targetID), zap.Error(err))
+		return nil, ErrImpersonateFailed
+	}
+	// Yönetim paneline erişen her kullanıcı yönetici sayılır
+	if target.Type == models.Dashboard {
+		configslog.FromContext(ctx).Warn("Yöneticinin yerine geçme denemesi reddedildi",
+			zap.Uint("impersonator_id", impersonatorID),
+			zap.Uint("target_id", targetID),
+		)
+		return nil, ErrImpersonateAdmin
+	}
+	if !target.Status {
+		return nil, ErrImpersonateInactive
+	}
+
+	if err := s.record(ctx, models.AuditImpersonateStart, impersonatorID, targetID); err != nil {
+		return nil, ErrImpersonateFailed
+	}
+	configslog.FromContext(ctx).Info("Kimliğe bürünme başladı",
+		zap.Uint("impersonator_id", impersonatorID),
+		zap.Uint("target_id", targetID),
+	)
+	return target, nil
+}
+
+// Yetkisi geri alınmış bir yöneticinin oturumu geri yüklenmez; bürünme yine de denetim kaydıyla kapatılır
+func (s *ImpersonationService) Stop(ctx context.Context, impersonatorID, targetID uint) (*models.User, error) {
+	impersonator, err := s.Verify(ctx, impersonatorID)
+
+	// Denetim kaydı yazılamasa da yönetici kendi kimliğine döner
+	_ = s.record(ctx, models.AuditImpersonateStop, impersonatorID, targetID)
+	if err != nil {
+		configslog.FromContext(ctx).Warn("Kimliğe bürünme sona erdi, yönetici kimliği geri yüklenmedi",
+			zap.Uint("impersonator_id", impersonatorID),
+			zap.Uint("target_id", targetID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	configslog.FromContext(ctx).Info("Kimliğe bürünme sona erdi",
+		zap.Uint("impersonator_id", impersonatorID),
+		zap.Uint("target_id", targetID),
+	)
+	return impersonator, nil
+}
+
+func (s *ImpersonationService) record(ctx context.Context, action models.AuditAction, impersonatorID, targetID uint) error {
+	err := s.audit.Record(ctx, models.AuditLog{
+		EntityType: "User",
+		EntityID:   targetID,
+		Action:     action,
+		ActorID:    impersonatorID,
+	})
+	if err != nil {
+		configslog.FromContext(ctx).Error("Kimliğe bürünme denetim kaydı yazılamadı",
+			zap.String("action", string(action)),
+			zap.Uint("impersonator_id", impersonatorID),
+			zap.Uint("target_id", targetID),
+			zap.Error(err),
+		)
+	}
+	return err
+}
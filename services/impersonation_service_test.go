@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/authz"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+
+	"gorm.io/gorm"
+)
+
+type stubPermissions struct {
+	IPermissionService
+	granted map[uint]bool
+}
+
+func (s stubPermissions) HasPermission(userID uint, permission string) (bool, error) {
+	return permission == models.PermUsersImpersonate && s.granted[userID], nil
+}
+
+func (s stubPermissions) PermissionsFor(userID uint) (authz.Set, error) {
+	if s.granted[userID] {
+		return authz.NewSet([]string{models.PermUsersImpersonate}), nil
+	}
+	return authz.Set{}, nil
+}
+
+func newImpersonationFixture(t *testing.T) (*ImpersonationService, *gorm.DB, *models.User, *models.User, stubPermissions) {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	ctx := requestctx.WithUserID(context.Background(), 1)
+
+	admin := &models.User{Name: "Yönetici", Account: "admin@example.com", Password: "x", Status: true, Type: models.Dashboard}
+	target := &models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "x", Status: true, Type: models.Panel}
+	for _, u := range []*models.User{admin, target} {
+		if err := db.WithContext(ctx).Create(u).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	perms := stubPermissions{granted: map[uint]bool{admin.ID: true}}
+	svc := &ImpersonationService{
+		users:       repositories.NewUserRepository(),
+		audit:       repositories.NewAuditRepository(),
+		permissions: perms,
+	}
+	return svc, db, admin, target, perms
+}
+
+func TestImpersonationStopRejectsDeactivatedAdmin(t *testing.T) {
+	svc, db, admin, target, _ := newImpersonationFixture(t)
+	ctx := requestctx.WithUserID(context.Background(), admin.ID)
+
+	if _, err := svc.Start(ctx, admin.ID, target.ID); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := db.Model(&models.User{}).Where("id = ?", admin.ID).UpdateColumn("status", false).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Stop(ctx, admin.ID, target.ID); !errors.Is(err, ErrImpersonatorRevoked) {
+		t.Fatalf("Stop err = %v, beklenen ErrImpersonatorRevoked", err)
+	}
+	var stops int64
+	db.Model(&models.AuditLog{}).Where("action = ?", models.AuditImpersonateStop).Count(&stops)
+	if stops != 1 {
+		t.Errorf("%d impersonate_stop kaydı, beklenen 1", stops)
+	}
+}
+
+func TestImpersonationVerifyRequiresPermission(t *testing.T) {
+	svc, _, admin, _, perms := newImpersonationFixture(t)
+	ctx := context.Background()
+
+	if _, err := svc.Verify(ctx, admin.ID); err != nil {
+		t.Fatalf("yetkili yönetici reddedildi: %v", err)
+	}
+	delete(perms.granted, admin.ID)
+	if _, err := svc.Verify(ctx, admin.ID); !errors.Is(err, ErrImpersonatorRevoked) {
+		t.Errorf("izni geri alınan yönetici: err = %v", err)
+	}
+}
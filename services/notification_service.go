@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("bildirim bulunamadı")
+	ErrNotificationTitle    = errors.New("bildirim başlığı boş olamaz")
+)
+
+type INotificationService interface {
+	Notify(ctx context.Context, userID uint, title, body, link string) error
+	ListForUser(ctx context.Context, userID uint, params queryparams.ListParams) (*queryparams.Paginated[models.Notification], error)
+	Get(ctx context.Context, userID uint, id uint) (*models.Notification, error)
+	MarkRead(ctx context.Context, userID uint, ids []uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+	UnreadCount(ctx context.Context, userID uint) (int64, error)
+}
+
+type NotificationService struct {
+	repo repositories.INotificationRepository
+}
+
+func NewNotificationService() INotificationService {
+	return &NotificationService{repo: repositories.NewNotificationRepository()}
+}
+
+// Bildirim asıl işin yan ürünüdür; çağıranlar hatayı loglayıp akışa devam edebilir
+func (s *NotificationService) Notify(ctx context.Context, userID uint, title, body, link string) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return ErrNotificationTitle
+	}
+	notification := &models.Notification{
+		UserID: userID,
+		Title:  title,
+		Body:   strings.TrimSpace(body),
+		Link:   safeNotificationLink(link),
+	}
+	if err := s.repo.Create(ctx, notification); err != nil {
+		configslog.FromContext(ctx).Error("Bildirim oluşturulamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s *NotificationService) ListForUser(ctx context.Context, userID uint, params queryparams.ListParams) (*queryparams.Paginated[models.Notification], error) {
+	notifications, total, err := s.repo.ListForUser(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+	return queryparams.NewPaginated(notifications, total, params), nil
+}
+
+func (s *NotificationService) Get(ctx context.Context, userID uint, id uint) (*models.Notification, error) {
+	notification, err := s.repo.FindForUser(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	return notification, nil
+}
+
+// Başka kullanıcıya ait ya da zaten okunmuş kimlikler sessizce atlanır
+func (s *NotificationService) MarkRead(ctx context.Context, userID uint, ids []uint) error {
+	_, err := s.repo.MarkRead(ctx, userID, ids, time.Now())
+	return err
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uint) error {
+	_, err := s.repo.MarkAllRead(ctx, userID, time.Now())
+	return err
+}
+
+func (s *NotificationService) UnreadCount(ctx context.Context, userID uint) (int64, error) {
+	return s.repo.UnreadCount(ctx, userID)
+}
+
+// Bildirim bağlantıları yalnızca uygulama içi yollar olabilir; aksi halde açık yönlendirmeye dönüşür
+func safeNotificationLink(link string) string {
+	link = strings.TrimSpace(link)
+	if !strings.HasPrefix(link, "/") || strings.HasPrefix(link, "//") || strings.HasPrefix(link, "/\\") {
+		return ""
+	}
+	return link
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/testutil"
+)
+
+func newNotificationFixture(t *testing.T) (INotificationService, []uint) {
+	t.Helper()
+	testutil.NewDB(t, &models.Notification{}, &models.AuditLog{})
+	svc := NewNotificationService()
+	ctx := context.Background()
+	for _, userID := range []uint{1, 1, 1, 2} {
+		if err := svc.Notify(ctx, userID, "Yeni ileti", "", "/panel/home"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := svc.ListForUser(ctx, 1, queryparams.DefaultListParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]uint, 0, len(result.Items))
+	for _, n := range result.Items {
+		ids = append(ids, n.ID)
+	}
+	return svc, ids
+}
+
+func TestUnreadCountTracksReadsPerUser(t *testing.T) {
+	svc, ids := newNotificationFixture(t)
+	ctx := context.Background()
+
+	if n, err := svc.UnreadCount(ctx, 1); err != nil || n != 3 {
+		t.Fatalf("UnreadCount = %d, %v; beklenen 3", n, err)
+	}
+	if err := svc.MarkRead(ctx, 1, ids[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := svc.UnreadCount(ctx, 1); n != 1 {
+		t.Errorf("iki bildirim okunduktan sonra UnreadCount = %d, beklenen 1", n)
+	}
+	// Başka kullanıcının bildirimleri okunmuş işaretlenemez ve sayısı değişmez
+	if err := svc.MarkRead(ctx, 2, ids); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := svc.UnreadCount(ctx, 1); n != 1 {
+		t.Errorf("başka kullanıcının MarkRead çağrısından sonra UnreadCount = %d, beklenen 1", n)
+	}
+	if err := svc.MarkAllRead(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := svc.UnreadCount(ctx, 1); n != 0 {
+		t.Errorf("MarkAllRead sonrası UnreadCount = %d", n)
+	}
+	if n, _ := svc.UnreadCount(ctx, 2); n != 1 {
+		t.Errorf("diğer kullanıcının UnreadCount = %d, beklenen 1", n)
+	}
+}
+
+func TestMarkReadIsIdempotent(t *testing.T) {
+	svc, ids := newNotificationFixture(t)
+	ctx := context.Background()
+
+	if err := svc.MarkRead(ctx, 1, ids[:1]); err != nil {
+		t.Fatal(err)
+	}
+	first, err := svc.Get(ctx, 1, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.MarkRead(ctx, 1, ids[:1]); err != nil {
+		t.Fatalf("ikinci MarkRead: %v", err)
+	}
+	second, err := svc.Get(ctx, 1, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ReadAt == nil || second.ReadAt == nil || !first.ReadAt.Equal(*second.ReadAt) {
+		t.Errorf("okunma zamanı değişti: %v -> %v", first.ReadAt, second.ReadAt)
+	}
+}
+
+func TestNotificationQueriesUseCallerContext(t *testing.T) {
+	svc, ids := newNotificationFixture(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := svc.UnreadCount(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("UnreadCount err = %v, beklenen context.Canceled", err)
+	}
+	if _, err := svc.ListForUser(ctx, 1, queryparams.DefaultListParams()); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListForUser err = %v, beklenen context.Canceled", err)
+	}
+	if err := svc.MarkRead(ctx, 1, ids); err == nil {
+		t.Error("iptal edilmiş context ile MarkRead hata döndürmedi")
+	}
+	if n := countRows(t, &models.Notification{}, "read_at IS NOT NULL"); n != 0 {
+		t.Errorf("iptal edilen MarkRead %d bildirimi işaretledi", n)
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"zatrano/configs/configsapp"
+	"zatrano/pkg/i18n"
+)
+
+type PasswordPolicy struct {
+	MinLength           int
+	RequireDigit        bool
+	RequireLetter       bool
+	RequireUpper        bool
+	RequireSymbol       bool
+	DisallowAccountName bool
+}
+
+// Mesaj metni değil çeviri anahtarı taşır; metin isteğin dilinde üretilir
+type PasswordViolation struct {
+	Key  string
+	Args []interface{}
+}
+
+func (v PasswordViolation) Message(locale string) string {
+	return i18n.Translate(locale, v.Key, v.Args...)
+}
+
+// errors.Is(err, ErrPasswordPolicy) ile yakalanır; Violations ihlal edilen tüm kuralları taşır
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return string(ErrPasswordPolicy) + ": " + strings.Join(e.Messages(i18n.DefaultLocale), " ")
+}
+
+func (e *PasswordPolicyError) Messages(locale string) []string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message(locale)
+	}
+	return messages
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicy
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:           8,
+		RequireDigit:        true,
+		RequireLetter:       true,
+		DisallowAccountName: true,
+	}
+}
+
+func PasswordPolicyFromSettings(settings configsapp.PasswordSettings) PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:           settings.MinLength,
+		RequireDigit:        settings.RequireDigit,
+		RequireLetter:       settings.RequireLetter,
+		RequireUpper:        settings.RequireUpper,
+		RequireSymbol:       settings.RequireSymbol,
+		DisallowAccountName: settings.DisallowAccountName,
+	}
+}
+
+// İlk hatada durmaz; ihlal edilen her kural için bir kayıt döner
+func (p PasswordPolicy) Validate(password, account string) []PasswordViolation {
+	var violations []PasswordViolation
+
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, PasswordViolation{Key: "password.min_length", Args: []interface{}{p.MinLength}})
+	}
+
+	var hasDigit, hasLetter, hasUpper, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsLetter(r):
+			hasLetter = true
+			if unicode.IsUpper(r) {
+				hasUpper = true
+			}
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, PasswordViolation{Key: "password.require_digit"})
+	}
+	if p.RequireLetter && !hasLetter {
+		violations = append(violations, PasswordViolation{Key: "password.require_letter"})
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, PasswordViolation{Key: "password.require_upper"})
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, PasswordViolation{Key: "password.require_symbol"})
+	}
+	if p.DisallowAccountName && containsAccountName(password, account) {
+		violations = append(violations, PasswordViolation{Key: "password.contains_account"})
+	}
+
+	return violations
+}
+
+// E-posta biçimli hesaplarda @ öncesi kısım da kontrol edilir
+func containsAccountName(password, account string) bool {
+	password = strings.ToLower(password)
+	account = strings.ToLower(strings.TrimSpace(account))
+	if account == "" {
+		return false
+	}
+	if strings.Contains(password, account) {
+		return true
+	}
+	if at := strings.Index(account, "@"); at >= 3 {
+		return strings.Contains(password, account[:at])
+	}
+	return false
+}
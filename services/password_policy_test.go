@@ -0,0 +1,42 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPasswordPolicyMessagesFollowConfigAndLocale(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	policy.MinLength = 10
+	policy.RequireUpper = true
+
+	err := &PasswordPolicyError{Violations: policy.Validate("abc", "ayse@example.com")}
+
+	wantTR := []string{
+		"Şifre en az 10 karakter olmalıdır.",
+		"Şifre en az bir rakam içermelidir.",
+		"Şifre en az bir büyük harf içermelidir.",
+	}
+	if got := err.Messages("tr"); !reflect.DeepEqual(got, wantTR) {
+		t.Errorf("tr mesajları\n got: %q\nwant: %q", got, wantTR)
+	}
+	wantEN := []string{
+		"The password must be at least 10 characters long.",
+		"The password must contain at least one digit.",
+		"The password must contain at least one uppercase letter.",
+	}
+	if got := err.Messages("en"); !reflect.DeepEqual(got, wantEN) {
+		t.Errorf("en mesajları\n got: %q\nwant: %q", got, wantEN)
+	}
+	if !errors.Is(err, ErrPasswordPolicy) {
+		t.Error("errors.Is(err, ErrPasswordPolicy) false döndü")
+	}
+}
+
+func TestPasswordPolicyRejectsAccountName(t *testing.T) {
+	violations := DefaultPasswordPolicy().Validate("ayse2024x", "ayse@example.com")
+	if len(violations) != 1 || violations[0].Key != "password.contains_account" {
+		t.Errorf("got %+v", violations)
+	}
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
+	"zatrano/models"
+	"zatrano/pkg/authz"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRoleNotFound     = errors.New("rol bulunamadı")
+	ErrRoleNameRequired = errors.New("rol adı boş olamaz")
+)
+
+type IPermissionService interface {
+	PermissionsFor(userID uint) (authz.Set, error)
+	HasPermission(userID uint, permission string) (bool, error)
+	ListPermissions() ([]models.Permission, error)
+	ListRoles() ([]models.Role, error)
+	GetRole(id uint) (*models.Role, error)
+	CreateRole(name, description string) (*models.Role, error)
+	RolePermissionIDs(roleID uint) ([]uint, error)
+	UpdateRolePermissions(roleID uint, permissionIDs []uint) error
+	UserRoleIDs(userID uint) ([]uint, error)
+	UpdateUserRoles(userID uint, roleIDs []uint) error
+}
+
+type PermissionService struct {
+	repo repositories.IPermissionRepository
+}
+
+func NewPermissionService() IPermissionService {
+	return &PermissionService{repo: repositories.NewPermissionRepository()}
+}
+
+// Servisler istek başına oluşturulduğu için önbellek paket düzeyinde tutulur
+type permissionCacheEntry struct {
+	set       authz.Set
+	expiresAt time.Time
+}
+
+var permissionCache = struct {
+	sync.RWMutex
+	entries map[uint]permissionCacheEntry
+	// Paylaşılan storage'daki sürümün bu süreçte en son görülen değeri
+	version string
+}{entries: make(map[uint]permissionCacheEntry)}
+
+// Rol ya da izin değişikliği paylaşılan storage'daki sürümü yeniler; diğer instance'lar
+// bir sonraki okumada sürümün değiştiğini görüp önbelleklerini boşaltır.
+const permissionVersionKey = "permissions:version"
+
+var permissionStorage = configssession.GetStorage
+
+func permissionCacheTTL() time.Duration {
+	return configsapp.Get().Cache.PermissionTTL
+}
+
+func invalidatePermissionCache(userID uint) {
+	permissionCache.Lock()
+	delete(permissionCache.entries, userID)
+	permissionCache.Unlock()
+}
+
+func flushPermissionCache() {
+	permissionCache.Lock()
+	permissionCache.entries = make(map[uint]permissionCacheEntry)
+	permissionCache.Unlock()
+}
+
+// Storage yoksa (tek süreç, bellek içi oturum) yerel geçersiz kılma yeterlidir.
+// Sürüm okunamazsa eski izinlerle devam etmek yerine önbellek boşaltılır.
+func syncPermissionCache() {
+	storage := permissionStorage()
+	if storage == nil {
+		return
+	}
+	raw, err := storage.Get(permissionVersionKey)
+	if err != nil {
+		configslog.Log.Warn("İzin önbelleği sürümü okunamadı", zap.Error(err))
+		flushPermissionCache()
+		return
+	}
+
+	version := string(raw)
+	permissionCache.Lock()
+	if version != permissionCache.version {
+		permissionCache.entries = make(map[uint]permissionCacheEntry)
+		permissionCache.version = version
+	}
+	permissionCache.Unlock()
+}
+
+func publishPermissionChange() {
+	storage := permissionStorage()
+	if storage == nil {
+		return
+	}
+	version := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := storage.Set(permissionVersionKey, []byte(version), 0); err != nil {
+		configslog.Log.Error("İzin değişikliği diğer sunuculara bildirilemedi", zap.Error(err))
+	}
+}
+
+func (s *PermissionService) PermissionsFor(userID uint) (authz.Set, error) {
+	syncPermissionCache()
+	permissionCache.RLock()
+	entry, ok := permissionCache.entries[userID]
+	permissionCache.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.set, nil
+	}
+
+	names, err := s.repo.PermissionNamesForUser(userID)
+	if err != nil {
+		configslog.Log.Error("Kullanıcı izinleri alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	set := authz.NewSet(names)
+
+	permissionCache.Lock()
+	permissionCache.entries[userID] = permissionCacheEntry{set: set, expiresAt: time.Now().Add(permissionCacheTTL())}
+	permissionCache.Unlock()
+	return set, nil
+}
+
+func (s *PermissionService) HasPermission(userID uint, permission string) (bool, error) {
+	set, err := s.PermissionsFor(userID)
+	if err != nil {
+		return false, err
+	}
+	return set.Has(permission), nil
+}
+
+func (s *PermissionService) ListPermissions() ([]models.Permission, error) {
+	return s.repo.ListPermissions()
+}
+
+func (s *PermissionService) ListRoles() ([]models.Role, error) {
+	return s.repo.ListRoles()
+}
+
+func (s *PermissionService) GetRole(id uint) (*models.Role, error) {
+	role, err := s.repo.GetRole(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrRoleNotFound
+	}
+	return role, err
+}
+
+func (s *PermissionService) CreateRole(name, description string) (*models.Role, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrRoleNameRequired
+	}
+	role := &models.Role{Name: name, Description: strings.TrimSpace(description)}
+	if err := s.repo.CreateRole(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *PermissionService) RolePermissionIDs(roleID uint) ([]uint, error) {
+	return s.repo.RolePermissionIDs(roleID)
+}
+
+// Rolün izinleri birçok kullanıcıyı etkilediği için önbelleğin tamamı boşaltılır
+func (s *PermissionService) UpdateRolePermissions(roleID uint, permissionIDs []uint) error {
+	if _, err := s.GetRole(roleID); err != nil {
+		return err
+	}
+	if err := s.repo.SetRolePermissions(roleID, permissionIDs); err != nil {
+		return err
+	}
+	flushPermissionCache()
+	publishPermissionChange()
+	return nil
+}
+
+func (s *PermissionService) UserRoleIDs(userID uint) ([]uint, error) {
+	return s.repo.RoleIDsForUser(userID)
+}
+
+func (s *PermissionService) UpdateUserRoles(userID uint, roleIDs []uint) error {
+	if err := s.repo.SetUserRoles(userID, roleIDs); err != nil {
+		return err
+	}
+	invalidatePermissionCache(userID)
+	publishPermissionChange()
+	return nil
+}
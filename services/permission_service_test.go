@@ -0,0 +1,123 @@
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Instance'lar arasında paylaşılan storage'ın (Redis, veritabanı) yerine geçer
+type mapStorage struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (s *mapStorage) Get(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key], nil
+}
+
+func (s *mapStorage) Set(key string, value []byte, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *mapStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+	return nil
+}
+
+func (s *mapStorage) Reset() error { return nil }
+func (s *mapStorage) Close() error { return nil }
+
+func newPermissionFixture(t *testing.T) (*gorm.DB, *mapStorage, models.Role) {
+	t.Helper()
+	db := testutil.NewDB(t, &models.Permission{}, &models.Role{}, &models.RolePermission{}, &models.UserRole{})
+
+	storage := &mapStorage{values: map[string][]byte{}}
+	previous := permissionStorage
+	permissionStorage = func() fiber.Storage { return storage }
+	flushPermissionCache()
+	permissionCache.version = ""
+	t.Cleanup(func() {
+		permissionStorage = previous
+		flushPermissionCache()
+		permissionCache.version = ""
+	})
+
+	permission := models.Permission{Name: models.PermUsersView}
+	role := models.Role{Name: models.RoleReporter}
+	if err := db.Create(&permission).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&role).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.RolePermission{RoleID: role.ID, PermissionID: permission.ID}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.UserRole{UserID: 1, RoleID: role.ID}).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db, storage, role
+}
+
+func hasUsersView(t *testing.T, svc IPermissionService) bool {
+	t.Helper()
+	ok, err := svc.HasPermission(1, models.PermUsersView)
+	if err != nil {
+		t.Fatalf("HasPermission: %v", err)
+	}
+	return ok
+}
+
+func TestPermissionCacheFollowsSharedVersion(t *testing.T) {
+	db, storage, _ := newPermissionFixture(t)
+	svc := NewPermissionService()
+
+	if !hasUsersView(t, svc) {
+		t.Fatal("başlangıçta users.view bekleniyordu")
+	}
+
+	// Başka bir instance rolü kaldırdı ama henüz sürümü yenilemedi: önbellek geçerli
+	if err := db.Where("user_id = ?", 1).Delete(&models.UserRole{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !hasUsersView(t, svc) {
+		t.Fatal("sürüm değişmeden önbellekteki izin kullanılmalıydı")
+	}
+
+	storage.Set(permissionVersionKey, []byte("baska-instance"), 0)
+	if hasUsersView(t, svc) {
+		t.Fatal("sürüm değiştikten sonra geri alınan izin hâlâ geçerli")
+	}
+}
+
+func TestUpdateUserRolesPublishesVersion(t *testing.T) {
+	_, storage, _ := newPermissionFixture(t)
+	svc := NewPermissionService()
+
+	if !hasUsersView(t, svc) {
+		t.Fatal("başlangıçta users.view bekleniyordu")
+	}
+	if err := svc.UpdateUserRoles(1, nil); err != nil {
+		t.Fatalf("UpdateUserRoles: %v", err)
+	}
+	if version, _ := storage.Get(permissionVersionKey); len(version) == 0 {
+		t.Error("rol değişikliği paylaşılan sürümü yenilemedi")
+	}
+	if hasUsersView(t, svc) {
+		t.Error("rolü kaldırılan kullanıcı izni korudu")
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"zatrano/configs/configsapp"
+	"zatrano/configs/configslog"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	rememberSelectorBytes  = 12
+	rememberValidatorBytes = 32
+)
+
+var (
+	ErrRememberTokenInvalid = errors.New("hatırlama token'ı geçersiz veya süresi dolmuş")
+	ErrRememberTokenTheft   = errors.New("hatırlama token'ı yeniden kullanıldı")
+)
+
+type IRememberService interface {
+	Issue(userID uint) (value string, expiresAt time.Time, err error)
+	Consume(value string) (userID uint, newValue string, expiresAt time.Time, err error)
+	Forget(value string) error
+	Lifetime() time.Duration
+}
+
+type RememberService struct {
+	repo     repositories.IRememberTokenRepository
+	sessions ISessionService
+	lifetime time.Duration
+}
+
+func NewRememberService() IRememberService {
+	return &RememberService{
+		repo:     repositories.NewRememberTokenRepository(),
+		sessions: NewSessionService(),
+		lifetime: configsapp.Get().Auth.RememberMe,
+	}
+}
+
+func (s *RememberService) Lifetime() time.Duration {
+	return s.lifetime
+}
+
+func (s *RememberService) Issue(userID uint) (string, time.Time, error) {
+	selector, err := randomToken(rememberSelectorBytes)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	validator, err := randomToken(rememberValidatorBytes)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if err := s.repo.DeleteExpired(userID); err != nil {
+		configslog.Log.Warn("Süresi dolmuş hatırlama token'ları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	expiresAt := time.Now().Add(s.lifetime)
+	token := &models.RememberToken{
+		UserID:        userID,
+		Selector:      selector,
+		ValidatorHash: hashValidator(validator),
+		ExpiresAt:     expiresAt,
+	}
+	if err := s.repo.Create(token); err != nil {
+		return "", time.Time{}, err
+	}
+	return selector + ":" + validator, expiresAt, nil
+}
+
+// Geçerli token her kullanımda yeni bir validator ile döndürülür. Selector'ı doğru fakat
+// validator'ı eski olan bir token çalınmış kabul edilir; kullanıcının tüm token ve oturumları kapatılır.
+func (s *RememberService) Consume(value string) (uint, string, time.Time, error) {
+	selector, validator, ok := strings.Cut(value, ":")
+	if !ok || selector == "" || validator == "" {
+		return 0, "", time.Time{}, ErrRememberTokenInvalid
+	}
+
+	token, err := s.repo.FindBySelector(selector)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return 0, "", time.Time{}, ErrRememberTokenInvalid
+		}
+		return 0, "", time.Time{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token.ValidatorHash), []byte(hashValidator(validator))) != 1 {
+		configslog.Log.Warn("Hatırlama token'ı yeniden kullanıldı, kullanıcının tüm oturumları kapatılıyor",
+			zap.Uint("user_id", token.UserID))
+		if err := s.sessions.RevokeAllForUser(token.UserID); err != nil {
+			return 0, "", time.Time{}, err
+		}
+		return 0, "", time.Time{}, ErrRememberTokenTheft
+	}
+
+	if token.IsExpired() {
+		_ = s.repo.DeleteBySelector(selector)
+		return 0, "", time.Time{}, ErrRememberTokenInvalid
+	}
+
+	newValidator, err := randomToken(rememberValidatorBytes)
+	if err != nil {
+		return 0, "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(s.lifetime)
+	if err := s.repo.Rotate(token, hashValidator(newValidator), expiresAt); err != nil {
+		if errors.Is(err, repositories.ErrRememberTokenRotated) {
+			return 0, "", time.Time{}, ErrRememberTokenInvalid
+		}
+		return 0, "", time.Time{}, err
+	}
+	return token.UserID, selector + ":" + newValidator, expiresAt, nil
+}
+
+func (s *RememberService) Forget(value string) error {
+	selector, _, _ := strings.Cut(value, ":")
+	if selector == "" {
+		return nil
+	}
+	return s.repo.DeleteBySelector(selector)
+}
+
+func randomToken(size int) (string, error) {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashValidator(validator string) string {
+	sum := sha256.Sum256([]byte(validator))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+)
+
+func countRememberTokens(t *testing.T, userID uint) int64 {
+	t.Helper()
+	var count int64
+	if err := configsdatabase.DB.Model(&models.RememberToken{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestRememberTokenRotatesOnUse(t *testing.T) {
+	f := newStatusFixture(t)
+	remember := NewRememberService()
+
+	value, expiresAt, err := remember.Issue(f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if until := time.Until(expiresAt); until <= 0 || until > remember.Lifetime() {
+		t.Errorf("bitiş %v, ömür %v ile uyumsuz", expiresAt, remember.Lifetime())
+	}
+	var stored models.RememberToken
+	selector, validator, _ := strings.Cut(value, ":")
+	if err := configsdatabase.DB.Where("selector = ?", selector).First(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.ValidatorHash == validator {
+		t.Error("validator veritabanında açık metin saklanıyor")
+	}
+
+	userID, rotated, _, err := remember.Consume(value)
+	if err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	if userID != f.member.ID {
+		t.Errorf("kullanıcı %d, beklenen %d", userID, f.member.ID)
+	}
+	if rotated == value || !strings.HasPrefix(rotated, selector+":") {
+		t.Errorf("döndürülen token %q, aynı selector ile yeni validator beklenir", rotated)
+	}
+
+	if userID, _, _, err := remember.Consume(rotated); err != nil || userID != f.member.ID {
+		t.Errorf("döndürülen token kullanılamadı: %d, %v", userID, err)
+	}
+}
+
+func TestRememberTokenReuseRevokesEverything(t *testing.T) {
+	f := newStatusFixture(t)
+	remember := NewRememberService()
+	sessions := NewSessionService()
+	if err := sessions.Track(f.member.ID, "tarayici", "10.0.0.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	stolen, _, err := remember.Issue(f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := remember.Consume(stolen); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, err := remember.Consume(stolen); !errors.Is(err, ErrRememberTokenTheft) {
+		t.Fatalf("eski validator err = %v, beklenen ErrRememberTokenTheft", err)
+	}
+	if n := countRememberTokens(t, f.member.ID); n != 0 {
+		t.Errorf("hırsızlık sonrası %d token kaldı", n)
+	}
+	if err := sessions.Validate("tarayici"); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("oturum Validate err = %v, beklenen ErrSessionRevoked", err)
+	}
+}
+
+func TestRememberTokenExpiry(t *testing.T) {
+	f := newStatusFixture(t)
+	remember := NewRememberService()
+	value, _, err := remember.Issue(f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector, _, _ := strings.Cut(value, ":")
+	if err := configsdatabase.DB.Model(&models.RememberToken{}).Where("selector = ?", selector).
+		UpdateColumn("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, err := remember.Consume(value); !errors.Is(err, ErrRememberTokenInvalid) {
+		t.Fatalf("süresi dolmuş token err = %v, beklenen ErrRememberTokenInvalid", err)
+	}
+	var count int64
+	if err := configsdatabase.DB.Model(&models.RememberToken{}).Where("selector = ?", selector).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Error("süresi dolmuş token silinmedi")
+	}
+}
+
+func TestRememberTokenRejectsMalformedAndForgotten(t *testing.T) {
+	f := newStatusFixture(t)
+	remember := NewRememberService()
+	for _, value := range []string{"", "selector-yok", ":validator", "selector:", "bilinmeyen:deger"} {
+		if _, _, _, err := remember.Consume(value); !errors.Is(err, ErrRememberTokenInvalid) {
+			t.Errorf("Consume(%q) err = %v, beklenen ErrRememberTokenInvalid", value, err)
+		}
+	}
+
+	value, _, err := remember.Issue(f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remember.Forget(value); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := remember.Consume(value); !errors.Is(err, ErrRememberTokenInvalid) {
+		t.Errorf("çıkışta silinen token err = %v, beklenen ErrRememberTokenInvalid", err)
+	}
+}
+
+func TestPasswordChangeDeletesRememberTokens(t *testing.T) {
+	f := newStatusFixture(t)
+	if _, _, err := NewRememberService().Issue(f.member.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.auth.UpdatePassword(f.member.ID, memberPassword, "Yeni5678!Parola"); err != nil {
+		t.Fatalf("UpdatePassword: %v", err)
+	}
+	if n := countRememberTokens(t, f.member.ID); n != 0 {
+		t.Errorf("şifre değişikliğinden sonra %d token kaldı", n)
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
+	"zatrano/models"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	maxUserAgentLength = 255
+	// Her istekte yazmamak için son etkinlik en fazla bu sıklıkla güncellenir
+	activityTouchInterval = time.Minute
+	// Geçerli bulunan oturum bu süre boyunca veritabanına sorulmadan kabul edilir
+	sessionValidationTTL = 15 * time.Second
+)
+
+var (
+	ErrSessionRevoked  = errors.New("oturum sonlandırılmış")
+	ErrSessionNotFound = errors.New("oturum bulunamadı")
+	// Takip kaydı yazılamamış ya da takipten önce açılmış oturum; çağıran yeniden kaydeder
+	ErrSessionUntracked = errors.New("oturum takip edilmiyor")
+)
+
+// Servisler istek başına oluşturulduğu için önbellek paket düzeyinde tutulur.
+// İptal storage'daki oturumu da sildiğinden diğer instance'lar iptal edilen oturumu önbellekten bağımsız reddeder.
+var validSessions = struct {
+	sync.RWMutex
+	expiresAt map[string]time.Time
+}{expiresAt: make(map[string]time.Time)}
+
+func cachedValid(sessionID string) bool {
+	validSessions.RLock()
+	defer validSessions.RUnlock()
+	expiresAt, ok := validSessions.expiresAt[sessionID]
+	return ok && time.Now().Before(expiresAt)
+}
+
+func rememberValid(sessionID string) {
+	validSessions.Lock()
+	defer validSessions.Unlock()
+	now := time.Now()
+	for id, expiresAt := range validSessions.expiresAt {
+		if !now.Before(expiresAt) {
+			delete(validSessions.expiresAt, id)
+		}
+	}
+	validSessions.expiresAt[sessionID] = now.Add(sessionValidationTTL)
+}
+
+func forgetValid(sessionIDs ...string) {
+	validSessions.Lock()
+	defer validSessions.Unlock()
+	for _, id := range sessionIDs {
+		delete(validSessions.expiresAt, id)
+	}
+}
+
+type ISessionService interface {
+	Track(userID uint, sessionID string, ip string, userAgent string) error
+	Validate(sessionID string) error
+	Revoke(sessionID string) error
+	RevokeAllForUser(userID uint) error
+	ListSessions(userID uint) ([]models.UserSession, error)
+	RevokeSession(userID uint, id uint) error
+}
+
+type SessionService struct {
+	repo         repositories.IUserSessionRepository
+	rememberRepo repositories.IRememberTokenRepository
+}
+
+func NewSessionService() ISessionService {
+	return &SessionService{
+		repo:         repositories.NewUserSessionRepository(),
+		rememberRepo: repositories.NewRememberTokenRepository(),
+	}
+}
+
+func (s *SessionService) Track(userID uint, sessionID string, ip string, userAgent string) error {
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	now := time.Now()
+	if err := s.repo.DeleteStale(userID, now.Add(-configssession.Lifetime())); err != nil {
+		configslog.Log.Warn("Eski oturum kayıtları temizlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return s.repo.Create(&models.UserSession{
+		SessionID:      sessionID,
+		UserID:         userID,
+		IPAddress:      ip,
+		UserAgent:      userAgent,
+		LastActivityAt: now,
+	})
+}
+
+// İptal edilmiş oturumlar geçersizdir; geçerli oturumun son etkinliği yenilenir.
+// Takip kaydı yoksa ErrSessionUntracked döner ki kayıt yazılamadı diye kullanıcı dışarı atılmasın.
+func (s *SessionService) Validate(sessionID string) error {
+	if cachedValid(sessionID) {
+		return nil
+	}
+	session, err := s.repo.FindBySessionID(sessionID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrSessionUntracked
+		}
+		return err
+	}
+	if session.IsRevoked() {
+		return ErrSessionRevoked
+	}
+	if now := time.Now(); now.Sub(session.LastActivityAt) >= activityTouchInterval {
+		if err := s.repo.Touch(sessionID, now); err != nil {
+			configslog.Log.Warn("Oturum etkinliği güncellenemedi", zap.Uint("user_id", session.UserID), zap.Error(err))
+		}
+	}
+	rememberValid(sessionID)
+	return nil
+}
+
+func (s *SessionService) ListSessions(userID uint) ([]models.UserSession, error) {
+	return s.repo.ListActive(userID, time.Now().Add(-configssession.Lifetime()))
+}
+
+// id, oturum kimliği değil takip kaydının kimliğidir; oturum kimlikleri arayüze hiç yazılmaz
+func (s *SessionService) RevokeSession(userID uint, id uint) error {
+	session, err := s.repo.FindForUser(userID, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	return s.Revoke(session.SessionID)
+}
+
+func (s *SessionService) Revoke(sessionID string) error {
+	forgetValid(sessionID)
+	if err := s.repo.Revoke(sessionID); err != nil {
+		return err
+	}
+	s.deleteStored(sessionID)
+	return nil
+}
+
+// Şifre değişikliği, hesap pasifleştirme ve yönetici "oturumu kapat" işlemlerinde kullanılır;
+// "beni hatırla" token'ları da silinir ki oturum kendiliğinden yeniden açılmasın
+func (s *SessionService) RevokeAllForUser(userID uint) error {
+	if err := s.rememberRepo.DeleteAllForUser(userID); err != nil {
+		configslog.Log.Error("Hatırlama token'ları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	sessionIDs, err := s.repo.RevokeAllForUser(userID)
+	if err != nil {
+		configslog.Log.Error("Kullanıcı oturumları iptal edilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	forgetValid(sessionIDs...)
+	for _, sessionID := range sessionIDs {
+		s.deleteStored(sessionID)
+	}
+	configslog.Log.Info("Kullanıcının tüm oturumları sonlandırıldı",
+		zap.Uint("user_id", userID),
+		zap.Int("session_count", len(sessionIDs)),
+	)
+	return nil
+}
+
+// Storage'dan silmek, iptal kontrolü yapılmayan yollarda da oturumun kullanılamamasını sağlar
+func (s *SessionService) deleteStored(sessionID string) {
+	if configssession.Session == nil {
+		return
+	}
+	if err := configssession.Session.Delete(sessionID); err != nil {
+		configslog.Log.Warn("Oturum storage'dan silinemedi", zap.Error(err))
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/configs/configssession"
+	"zatrano/models"
+)
+
+func TestPasswordChangeRevokesOtherCachedSession(t *testing.T) {
+	f := newStatusFixture(t)
+	sessions := NewSessionService()
+	for _, id := range []string{"tarayici-1", "tarayici-2"} {
+		if err := sessions.Track(f.member.ID, id, "10.0.0.1", "test"); err != nil {
+			t.Fatal(err)
+		}
+		// İlk doğrulama sonucu önbelleğe yazar
+		if err := sessions.Validate(id); err != nil {
+			t.Fatalf("Validate(%s): %v", id, err)
+		}
+	}
+
+	if err := f.auth.UpdatePassword(f.member.ID, memberPassword, "Yeni5678!Parola"); err != nil {
+		t.Fatalf("UpdatePassword: %v", err)
+	}
+	if err := sessions.Validate("tarayici-2"); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("diğer oturum Validate err = %v, beklenen ErrSessionRevoked", err)
+	}
+}
+
+func TestValidateCachesValidSessions(t *testing.T) {
+	f := newStatusFixture(t)
+	sessions := NewSessionService()
+	if err := sessions.Track(f.member.ID, "tarayici", "10.0.0.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sessions.Validate("tarayici"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Kayıt veritabanından silinse de önbellek süresi içinde yeniden sorulmaz
+	if err := configsdatabase.DB.Where("session_id = ?", "tarayici").Delete(&models.UserSession{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := sessions.Validate("tarayici"); err != nil {
+		t.Errorf("önbellekteki oturum Validate err = %v", err)
+	}
+}
+
+func TestValidateReportsUntrackedSession(t *testing.T) {
+	newStatusFixture(t)
+	if err := NewSessionService().Validate("kayitsiz"); !errors.Is(err, ErrSessionUntracked) {
+		t.Errorf("Validate err = %v, beklenen ErrSessionUntracked", err)
+	}
+}
+
+func TestListSessionsHidesRevokedAndStale(t *testing.T) {
+	f := newStatusFixture(t)
+	sessions := NewSessionService()
+	for _, id := range []string{"aktif", "iptal", "eski"} {
+		if err := sessions.Track(f.member.ID, id, "10.0.0.1", "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sessions.Track(f.admins[0].ID, "baskasi", "10.0.0.2", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sessions.Revoke("iptal"); err != nil {
+		t.Fatal(err)
+	}
+	staleAt := time.Now().Add(-configssession.Lifetime() - time.Hour)
+	if err := configsdatabase.DB.Model(&models.UserSession{}).Where("session_id = ?", "eski").UpdateColumn("last_activity_at", staleAt).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := sessions.ListSessions(f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].SessionID != "aktif" {
+		t.Fatalf("ListSessions = %+v, beklenen yalnızca aktif oturum", list)
+	}
+
+	// Yeni girişte süresi dolmuş kayıtlar temizlenir
+	if err := sessions.Track(f.member.ID, "yeni", "10.0.0.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	if err := configsdatabase.DB.Model(&models.UserSession{}).Where("session_id = ?", "eski").Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Error("süresi dolmuş oturum kaydı temizlenmedi")
+	}
+}
+
+func TestRevokeSessionChecksOwner(t *testing.T) {
+	f := newStatusFixture(t)
+	sessions := NewSessionService()
+	if err := sessions.Track(f.member.ID, "tarayici", "10.0.0.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sessions.Validate("tarayici"); err != nil {
+		t.Fatal(err)
+	}
+	var record models.UserSession
+	if err := configsdatabase.DB.Where("session_id = ?", "tarayici").First(&record).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sessions.RevokeSession(f.admins[0].ID, record.ID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("başkasının oturumu için err = %v, beklenen ErrSessionNotFound", err)
+	}
+	if err := sessions.Validate("tarayici"); err != nil {
+		t.Fatalf("başkası tarafından iptal denenen oturum geçersizleşti: %v", err)
+	}
+
+	if err := sessions.RevokeSession(f.member.ID, record.ID); err != nil {
+		t.Fatalf("RevokeSession: %v", err)
+	}
+	// Önbellekte geçerli görünen oturum da hemen reddedilmeli
+	if err := sessions.Validate("tarayici"); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("Validate err = %v, beklenen ErrSessionRevoked", err)
+	}
+	if err := sessions.RevokeSession(f.member.ID, record.ID+100); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("olmayan kayıt için err = %v, beklenen ErrSessionNotFound", err)
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/jobs"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Yazılanı saklamaz; iş dosyayı hiç bulamaz
+type forgetfulStorage struct {
+	*mapStorage
+}
+
+func (forgetfulStorage) Set(string, []byte, time.Duration) error { return nil }
+
+func newImportJobFixture(t *testing.T) (*UserService, *mapStorage) {
+	t.Helper()
+	testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.Job{})
+	svc := &UserService{
+		repo:        repositories.NewUserRepository(),
+		permissions: roleManagerPermissions{},
+	}
+
+	storage := &mapStorage{values: map[string][]byte{}}
+	previous := importFileStorage
+	importFileStorage = func() fiber.Storage { return storage }
+
+	jobs.Register(UserImportJobType, svc.ImportUsersJob)
+	if err := jobs.Start(1); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = jobs.Shutdown(ctx)
+		importFileStorage = previous
+	})
+	return svc, storage
+}
+
+func waitForJob(t *testing.T, id uint) *models.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := jobs.Get(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.IsFinished() {
+			return job
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("iş %d zamanında bitmedi", id)
+	return nil
+}
+
+func TestImportJobReadsSharedStorageAndDeletesFile(t *testing.T) {
+	svc, storage := newImportJobFixture(t)
+	ctx := requestctx.WithUserID(context.Background(), 7)
+
+	queued, err := svc.EnqueueImportUsers(ctx, strings.NewReader(importCSV), false)
+	if err != nil {
+		t.Fatalf("EnqueueImportUsers: %v", err)
+	}
+	if strings.Contains(queued.Payload, "ali@example.com") {
+		t.Error("dosya içeriği iş yüküne yazıldı; yalnızca storage anahtarı olmalı")
+	}
+
+	job := waitForJob(t, queued.ID)
+	if job.Status != models.JobDone {
+		t.Fatalf("iş durumu %s (%s), beklenen done", job.Status, job.Error)
+	}
+	if !strings.Contains(job.Result, `"Inserted":2`) {
+		t.Errorf("sonuç = %s; yetkisiz yönetici satırı dışında 2 kayıt beklenir", job.Result)
+	}
+	if len(storage.values) != 0 {
+		t.Errorf("iş bittikten sonra storage'da %d dosya kaldı", len(storage.values))
+	}
+}
+
+func TestImportJobFailsWhenFileIsGone(t *testing.T) {
+	svc, storage := newImportJobFixture(t)
+	ctx := requestctx.WithUserID(context.Background(), 7)
+
+	// Storage'ı paylaşmayan bir süreç ya da TTL'i dolmuş dosya
+	importFileStorage = func() fiber.Storage { return forgetfulStorage{storage} }
+	queued, err := svc.EnqueueImportUsers(ctx, strings.NewReader(importCSV), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := waitForJob(t, queued.ID)
+	if job.Status != models.JobFailed || job.Error != ErrImportFileMissing.Error() {
+		t.Errorf("iş = %s (%s); beklenen dosya bulunamadı hatası", job.Status, job.Error)
+	}
+}
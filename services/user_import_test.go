@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+	"zatrano/repositories"
+)
+
+type roleManagerPermissions struct {
+	IPermissionService
+	managers map[uint]bool
+}
+
+func (s roleManagerPermissions) HasPermission(userID uint, permission string) (bool, error) {
+	return permission == models.PermRolesManage && s.managers[userID], nil
+}
+
+const importCSV = "Ad Soyad,Hesap,Şifre,Kullanıcı Tipi\n" +
+	"Ali,ali@example.com,Gizli1234,panel\n" +
+	"Kök,kok@example.com,Gizli1234,dashboard\n" +
+	"Veli,veli@example.com,Gizli1234,\n"
+
+func newImportService(t *testing.T, managers map[uint]bool) *UserService {
+	t.Helper()
+	testutil.NewDB(t, &models.User{}, &models.AuditLog{})
+	return &UserService{
+		repo:        repositories.NewUserRepository(),
+		permissions: roleManagerPermissions{managers: managers},
+	}
+}
+
+func TestImportUsersRejectsDashboardRowsWithoutPermission(t *testing.T) {
+	svc := newImportService(t, nil)
+	ctx := requestctx.WithUserID(context.Background(), 7)
+
+	result, err := svc.ImportUsers(ctx, strings.NewReader(importCSV), false)
+	if err != nil {
+		t.Fatalf("ImportUsers: %v", err)
+	}
+	if result.Inserted != 2 {
+		t.Errorf("Inserted = %d, beklenen 2", result.Inserted)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Row != 3 || result.Errors[0].Column != "Kullanıcı Tipi" {
+		t.Fatalf("hatalar = %+v; beklenen 3. satırda kullanıcı tipi hatası", result.Errors)
+	}
+	if exists, _ := svc.repo.UserExists(ctx, map[string]interface{}{"account": "kok@example.com"}); exists {
+		t.Error("yönetici hesabı yetkisiz içe aktarıldı")
+	}
+}
+
+func TestImportUsersAllowsDashboardRowsForRoleManager(t *testing.T) {
+	svc := newImportService(t, map[uint]bool{7: true})
+	ctx := requestctx.WithUserID(context.Background(), 7)
+
+	result, err := svc.ImportUsers(ctx, strings.NewReader(importCSV), true)
+	if err != nil {
+		t.Fatalf("ImportUsers: %v", err)
+	}
+	if result.Inserted != 3 || len(result.Errors) != 0 {
+		t.Fatalf("sonuç = %+v; beklenen 3 kayıt, hata yok", result)
+	}
+}
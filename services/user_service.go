@@ -1,59 +1,144 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
+	"slices"
+	"time"
+	"zatrano/configs/configsdatabase"
 	"zatrano/configs/configslog"
+	"zatrano/configs/configssession"
 	"zatrano/models"
+	"zatrano/pkg/importer"
+	"zatrano/pkg/jobs"
 	"zatrano/pkg/queryparams"
+	"zatrano/pkg/requestctx"
 	"zatrano/repositories"
 
+	"github.com/gofiber/fiber/v2/utils"
 	"go.uber.org/zap"
 )
 
-const contextUserIDKey = "user_id"
+const (
+	exportBatchSize = 500
+
+	UserImportJobType = "users.import"
+)
+
+var (
+	ErrQueryTimeout  = errors.New("sorgu çok uzun sürdü, lütfen filtreleri daraltıp tekrar deneyin")
+	ErrInvalidFilter = errors.New("filtre parametreleri geçersiz")
+	ErrInvalidDate   = errors.New("tarih filtresi geçersiz")
+
+	ErrImportFileMissing = errors.New("içe aktarma dosyası bulunamadı")
+
+	ErrDeactivateSelf  = errors.New("kendi hesabınızı pasifleştiremezsiniz")
+	ErrLastActiveAdmin = errors.New("son aktif yönetici pasifleştirilemez")
+
+	ErrUserModified = errors.New("kullanıcı siz düzenlerken başka bir yönetici tarafından değiştirildi, lütfen sayfayı yenileyin")
+)
 
 type IUserService interface {
-	GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
-	GetUserByID(id uint) (*models.User, error)
+	GetAllUsers(ctx context.Context, params queryparams.ListParams) (*queryparams.Paginated[models.User], error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
 	CreateUser(ctx context.Context, user *models.User) error
 	UpdateUser(ctx context.Context, id uint, userData *models.User) error
 	DeleteUser(ctx context.Context, id uint) error
-	GetUserCount() (int64, error)
+	UnlockUser(ctx context.Context, id uint) error
+	LogoutUser(ctx context.Context, id uint) error
+	DeactivateUser(ctx context.Context, adminID, userID uint) error
+	ReactivateUser(ctx context.Context, adminID, userID uint) error
+	GetUserCount(ctx context.Context) (int64, error)
+	ExportUsers(ctx context.Context, params queryparams.ListParams, fn func(batch []models.User) error) error
+	ImportUsers(ctx context.Context, r io.Reader, strict bool) (*importer.Result, error)
+	EnqueueImportUsers(ctx context.Context, r io.Reader, strict bool) (*models.Job, error)
+	ImportUsersJob(ctx context.Context, task *jobs.Task) (any, error)
 }
 
 type UserService struct {
-	repo repositories.IUserRepository
+	repo           repositories.IUserRepository
+	users          *BaseService[models.User]
+	sessions       ISessionService
+	apiTokens      IAPITokenService
+	rememberTokens repositories.IRememberTokenRepository
+	notifications  INotificationService
+	permissions    IPermissionService
 }
 
 func NewUserService() IUserService {
-	return &UserService{repo: repositories.NewUserRepository()}
+	s := &UserService{
+		repo:           repositories.NewUserRepository(),
+		sessions:       NewSessionService(),
+		apiTokens:      NewAPITokenService(),
+		rememberTokens: repositories.NewRememberTokenRepository(),
+		notifications:  NewNotificationService(),
+		permissions:    NewPermissionService(),
+	}
+	s.users = NewBaseService[models.User](configsdatabase.GetDB(), repositories.NewUserBaseRepository())
+	s.users.BeforeCreate(s.ensureAccountAvailable)
+	s.users.BeforeCreate(hashNewUserPassword)
+	s.users.BeforeUpdate(hashUpdatedPassword)
+	s.users.BeforeUpdate(s.guardDeactivation)
+	s.users.AfterUpdate(s.forgetDeactivatedUser)
+	return s
 }
 
-func (s *UserService) GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
-	users, totalCount, err := s.repo.GetAllUsers(params)
+func (s *UserService) GetAllUsers(ctx context.Context, params queryparams.ListParams) (*queryparams.Paginated[models.User], error) {
+	if params.UseCursor {
+		return s.getAllUsersCursor(ctx, params)
+	}
+
+	users, totalCount, err := s.repo.GetAllUsers(ctx, params)
 	if err != nil {
 		configslog.Log.Error("Kullanıcılar alınamadı", zap.Error(err))
+		if errors.Is(err, repositories.ErrQueryTimeout) {
+			return nil, ErrQueryTimeout
+		}
+		if errors.Is(err, repositories.ErrFilterNotAllowed) {
+			return nil, ErrInvalidFilter
+		}
+		if errors.Is(err, queryparams.ErrInvalidDate) {
+			return nil, ErrInvalidDate
+		}
 		return nil, errors.New("kullanıcılar getirilirken bir hata oluştu")
 	}
 
-	result := &queryparams.PaginatedResult{
-		Data: users,
-		Meta: queryparams.PaginationMeta{
-			CurrentPage: params.Page,
-			PerPage:     params.PerPage,
-			TotalItems:  totalCount,
-			TotalPages:  queryparams.CalculateTotalPages(totalCount, params.PerPage),
-		},
+	return queryparams.NewPaginated(users, totalCount, params), nil
+}
+
+func (s *UserService) getAllUsersCursor(ctx context.Context, params queryparams.ListParams) (*queryparams.Paginated[models.User], error) {
+	users, nextCursor, err := s.repo.GetAllUsersCursor(ctx, params)
+	if err != nil {
+		configslog.Log.Error("Kullanıcılar alınamadı (cursor)", zap.String("cursor", params.Cursor), zap.Error(err))
+		if errors.Is(err, repositories.ErrQueryTimeout) {
+			return nil, ErrQueryTimeout
+		}
+		if errors.Is(err, repositories.ErrFilterNotAllowed) {
+			return nil, ErrInvalidFilter
+		}
+		if errors.Is(err, queryparams.ErrInvalidDate) {
+			return nil, ErrInvalidDate
+		}
+		return nil, errors.New("kullanıcılar getirilirken bir hata oluştu")
 	}
+
+	result := queryparams.NewPaginated(users, 0, params)
+	result.NextCursor = nextCursor
+	result.HasNext = nextCursor != ""
 	return result, nil
 }
 
-func (s *UserService) GetUserByID(id uint) (*models.User, error) {
-	user, err := s.repo.GetUserByID(id)
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.repo.GetUserByID(ctx, id)
 	if err != nil {
-		configslog.Log.Warn("Kullanıcı bulunamadı", zap.Uint("user_id", id), zap.Error(err))
-		return nil, errors.New("kullanıcı bulunamadı")
+		if errors.Is(err, repositories.ErrNotFound) {
+			configslog.Log.Warn("Kullanıcı bulunamadı", zap.Uint("user_id", id))
+			return nil, ErrUserNotFound
+		}
+		configslog.Log.Error("Kullanıcı sorgulanamadı", zap.Uint("user_id", id), zap.Error(err))
+		return nil, err
 	}
 	return user, nil
 }
@@ -62,48 +147,394 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
 	if user.Password == "" {
 		return errors.New("şifre alanı boş olamaz")
 	}
+	return s.users.Create(ctx, user)
+}
+
+func (s *UserService) ensureAccountAvailable(ctx context.Context, user *models.User) error {
+	repo := s.repo
+	if tx, ok := TxFromContext(ctx); ok {
+		repo = repo.WithTx(tx)
+	}
+
+	exists, err := repo.AccountTaken(ctx, user.Account)
+	if err != nil {
+		configslog.FromContext(ctx).Error("Hesap adı kontrol edilemedi", zap.String("account", user.Account), zap.Error(err))
+		return errors.New("kullanıcı oluşturulurken bir hata oluştu")
+	}
+	if exists {
+		return errors.New("bu hesap adı zaten kullanılıyor")
+	}
+	return nil
+}
+
+func hashNewUserPassword(ctx context.Context, user *models.User) error {
 	if err := user.SetPassword(user.Password); err != nil {
-		configslog.Log.Error("Şifre oluşturulamadı", zap.Error(err))
+		configslog.FromContext(ctx).Error("Şifre oluşturulamadı", zap.Error(err))
 		return errors.New("şifre oluşturulurken hata oluştu")
 	}
-	return s.repo.CreateUser(ctx, user)
+	return nil
+}
+
+func hashUpdatedPassword(_ context.Context, _ uint, data map[string]interface{}) error {
+	password, ok := data["password"].(string)
+	if !ok || password == "" {
+		delete(data, "password")
+		return nil
+	}
+
+	hashed := models.User{}
+	if err := hashed.SetPassword(password); err != nil {
+		return errors.New("şifre oluşturulurken hata oluştu")
+	}
+	data["password"] = hashed.Password
+	return nil
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.User) error {
-	currentUserID, ok := ctx.Value(contextUserIDKey).(uint)
-	if !ok || currentUserID == 0 {
+	if _, ok := requestctx.UserID(ctx); !ok {
 		return errors.New("güncelleyen kullanıcı kimliği geçersiz")
 	}
 
-	_, err := s.repo.GetUserByID(id)
+	_, err := s.repo.GetUserByID(ctx, id)
 	if err != nil {
 		return errors.New("kullanıcı bulunamadı")
 	}
 
 	updateData := map[string]interface{}{
-		"name":    userData.Name,
-		"account": userData.Account,
-		"status":  userData.Status,
-		"type":    userData.Type,
+		"name":     userData.Name,
+		"account":  userData.Account,
+		"status":   userData.Status,
+		"type":     userData.Type,
+		"password": userData.Password,
+		"version":  userData.Version,
+	}
+
+	if _, err := s.users.Update(ctx, id, updateData); err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return ErrUserModified
+		}
+		return err
 	}
 
 	if userData.Password != "" {
-		hashed := models.User{}
-		if err := hashed.SetPassword(userData.Password); err != nil {
-			return errors.New("şifre oluşturulurken hata oluştu")
+		if err := s.apiTokens.RevokeAllForUser(id); err != nil {
+			return err
 		}
-		updateData["password"] = hashed.Password
+		_ = s.notifications.Notify(ctx, id,
+			"Şifreniz bir yönetici tarafından değiştirildi",
+			"Bu işlemi siz talep etmediyseniz lütfen yöneticinizle iletişime geçin.",
+			"/auth/profile",
+		)
 	}
 
-	return s.repo.UpdateUser(ctx, id, updateData, currentUserID)
+	// Pasifleştirilen ya da şifresi değiştirilen kullanıcının açık oturumları kapatılır
+	if !userData.Status || userData.Password != "" {
+		return s.sessions.RevokeAllForUser(id)
+	}
+	return nil
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
-	return s.repo.DeleteUser(ctx, id)
+	if err := s.users.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.sessions.RevokeAllForUser(id)
+}
+
+func (s *UserService) UnlockUser(ctx context.Context, id uint) error {
+	_, err := s.users.Update(repositories.WithoutVersionCheck(ctx), id, map[string]interface{}{
+		"failed_login_count": 0,
+		"locked_until":       nil,
+	})
+	return err
+}
+
+func (s *UserService) LogoutUser(ctx context.Context, id uint) error {
+	if _, err := s.repo.GetUserByID(ctx, id); err != nil {
+		return errors.New("kullanıcı bulunamadı")
+	}
+	return s.sessions.RevokeAllForUser(id)
+}
+
+// Pasifleştirilen kullanıcının oturumları bir sonraki profil kontrolünü beklemeden hemen kapatılır
+func (s *UserService) DeactivateUser(ctx context.Context, adminID, userID uint) error {
+	if err := s.setStatus(ctx, adminID, userID, false); err != nil {
+		return err
+	}
+	return s.sessions.RevokeAllForUser(userID)
+}
+
+func (s *UserService) ReactivateUser(ctx context.Context, adminID, userID uint) error {
+	return s.setStatus(ctx, adminID, userID, true)
+}
+
+// Durum repository üzerinden değiştirilir ki updated_by yöneticiyle doldurulsun
+func (s *UserService) setStatus(ctx context.Context, adminID, userID uint, active bool) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if user.Status == active {
+		return nil
+	}
+
+	action := models.AuditReactivate
+	if !active {
+		action = models.AuditDeactivate
+	}
+	// Tek denetim kaydı: repository'nin güncelleme kaydı bu eylemle ve durum farkıyla yazılır
+	ctx = repositories.WithAuditAction(requestctx.WithUserID(ctx, adminID), action)
+	ctx = repositories.WithoutVersionCheck(ctx)
+	if _, err := s.users.Update(ctx, userID, map[string]interface{}{"status": active}); err != nil {
+		return err
+	}
+
+	configslog.FromContext(ctx).Info("Hesap durumu değiştirildi",
+		zap.String("action", string(action)),
+		zap.Uint("admin_id", adminID),
+		zap.Uint("user_id", userID),
+	)
+	return nil
+}
+
+// Düzenleme formundaki durum kutusu da aynı kurala tabidir: yönetici kendini ya da
+// son aktif yöneticiyi pasifleştiremez, aksi halde yönetim paneline giren kimse kalmaz
+func (s *UserService) guardDeactivation(ctx context.Context, id uint, data map[string]interface{}) error {
+	if active, ok := data["status"].(bool); !ok || active {
+		return nil
+	}
+	if actorID, _ := requestctx.UserID(ctx); actorID == id {
+		return ErrDeactivateSelf
+	}
+
+	// Kilit güncellemeyle aynı transaction'da alınır; eşzamanlı pasifleştirme commit'e kadar bekler
+	tx, ok := TxFromContext(ctx)
+	if !ok {
+		return repositories.ErrNotInTransaction
+	}
+	adminIDs, err := s.repo.WithTx(tx).LockActiveAdminIDs(ctx)
+	if err != nil {
+		configslog.FromContext(ctx).Error("Aktif yöneticiler kilitlenemedi", zap.Error(err))
+		return err
+	}
+	if !slices.Contains(adminIDs, id) {
+		return nil
+	}
+	if len(adminIDs) <= 1 {
+		return ErrLastActiveAdmin
+	}
+	return nil
 }
 
-func (s *UserService) GetUserCount() (int64, error) {
-	return s.repo.GetUserCount()
+// "Beni hatırla" token'ları durum değişikliğiyle aynı transaction'da silinir: silme başarısız olursa
+// pasifleştirme de geri alınır, cookie ile yeniden oturum açılabilen pasif hesap kalmaz
+func (s *UserService) forgetDeactivatedUser(ctx context.Context, id uint, data map[string]interface{}) error {
+	if active, ok := data["status"].(bool); !ok || active {
+		return nil
+	}
+	tx, ok := TxFromContext(ctx)
+	if !ok {
+		return repositories.ErrNotInTransaction
+	}
+	return s.rememberTokens.WithTx(tx).DeleteAllForUser(id)
+}
+
+func (s *UserService) GetUserCount(ctx context.Context) (int64, error) {
+	return s.repo.GetUserCount(ctx, queryparams.ListParams{})
 }
 
 var _ IUserService = (*UserService)(nil)
+
+func (s *UserService) ExportUsers(ctx context.Context, params queryparams.ListParams, fn func(batch []models.User) error) error {
+	err := s.repo.ExportUsers(ctx, params, exportBatchSize, fn)
+	if err != nil {
+		configslog.Log.Error("Kullanıcılar dışa aktarılamadı", zap.Error(err))
+	}
+	return err
+}
+
+var userImportMapping = map[string]string{
+	"Ad Soyad":       "Name",
+	"Hesap":          "Account",
+	"Şifre":          "Password",
+	"Kullanıcı Tipi": "Type",
+	"Durum":          "Status",
+}
+
+// Strict modda tüm dosya tek transaction'dadır; aksi halde geçerli satırlar eklenir, hatalılar raporlanır.
+// Yönetici hesapları yalnızca rol yönetimi iznine sahip kullanıcı tarafından içe aktarılabilir.
+func (s *UserService) ImportUsers(ctx context.Context, r io.Reader, strict bool) (*importer.Result, error) {
+	allowDashboard := s.canImportDashboardUsers(ctx)
+	var result *importer.Result
+	run := func(repo repositories.IUserRepository) error {
+		seenAccounts := make(map[string]bool)
+		opts := importer.Options[models.User]{
+			Mapping:   userImportMapping,
+			Required:  []string{"Ad Soyad", "Hesap", "Şifre"},
+			BatchSize: exportBatchSize,
+			Strict:    strict,
+			Validate: func(row int, user *models.User) []importer.RowError {
+				return validateImportedUser(ctx, repo, seenAccounts, allowDashboard, row, user)
+			},
+		}
+
+		var err error
+		result, err = importer.Import(r, opts, func(batch []models.User) error {
+			return repo.BulkCreateUsers(ctx, batch)
+		})
+		return err
+	}
+
+	var err error
+	if strict {
+		err = s.repo.InTransaction(ctx, run)
+	} else {
+		err = run(s.repo)
+	}
+	if err != nil && !errors.Is(err, importer.ErrStrictRejected) {
+		configslog.Log.Error("Kullanıcılar içe aktarılamadı", zap.Error(err))
+	}
+	return result, err
+}
+
+func (s *UserService) canImportDashboardUsers(ctx context.Context) bool {
+	actorID, ok := requestctx.UserID(ctx)
+	if !ok {
+		return false
+	}
+	allowed, err := s.permissions.HasPermission(actorID, models.PermRolesManage)
+	if err != nil {
+		configslog.FromContext(ctx).Error("İçe aktarma: yetki kontrol edilemedi", zap.Uint("user_id", actorID), zap.Error(err))
+		return false
+	}
+	return allowed
+}
+
+// İş, isteğin dışında çalıştığı için kiracı da yükle taşınır
+type userImportPayload struct {
+	FileKey  string
+	Strict   bool
+	TenantID uint
+}
+
+// Yüklenen dosya oturumlarla aynı storage'da tutulur; Redis kullanılıyorsa işi hangi süreç alırsa alsın okur.
+// İş bitince silinir; süreç iş ortasında çökerse TTL dolunca storage'dan düşer.
+var importFileStorage = configssession.GetStorage
+
+const importFileTTL = 24 * time.Hour
+
+func (s *UserService) EnqueueImportUsers(ctx context.Context, r io.Reader, strict bool) (*models.Job, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		configslog.FromContext(ctx).Error("İçe aktarma dosyası okunamadı", zap.Error(err))
+		return nil, err
+	}
+	storage := importFileStorage()
+	key := "import:users:" + utils.UUIDv4()
+	if err := storage.Set(key, data, importFileTTL); err != nil {
+		configslog.FromContext(ctx).Error("İçe aktarma dosyası kaydedilemedi", zap.Error(err))
+		return nil, err
+	}
+
+	payload := userImportPayload{FileKey: key, Strict: strict}
+	payload.TenantID, _ = requestctx.TenantID(ctx)
+	job, err := jobs.Enqueue(ctx, UserImportJobType, payload)
+	if err != nil {
+		_ = storage.Delete(key)
+		configslog.FromContext(ctx).Error("İçe aktarma işi kuyruğa eklenemedi", zap.Error(err))
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *UserService) ImportUsersJob(ctx context.Context, task *jobs.Task) (any, error) {
+	var payload userImportPayload
+	if err := task.Decode(&payload); err != nil {
+		return nil, err
+	}
+	storage := importFileStorage()
+	defer func() {
+		if err := storage.Delete(payload.FileKey); err != nil {
+			configslog.FromContext(ctx).Warn("İçe aktarma dosyası silinemedi", zap.String("key", payload.FileKey), zap.Error(err))
+		}
+	}()
+	if payload.TenantID != 0 {
+		ctx = requestctx.WithTenantID(ctx, payload.TenantID)
+	}
+
+	data, err := storage.Get(payload.FileKey)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrImportFileMissing
+	}
+
+	reader := &progressReader{r: bytes.NewReader(data), total: int64(len(data)), report: task.SetProgress}
+	result, err := s.ImportUsers(ctx, reader, payload.Strict)
+	if result == nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// Okunan bayt oranını ilerleme olarak bildirir; %100 işin sonunda işaretlenir
+type progressReader struct {
+	r      io.Reader
+	total  int64
+	read   int64
+	report func(percent int)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.total > 0 {
+		p.report(int(p.read * 99 / p.total))
+	}
+	return n, err
+}
+
+func validateImportedUser(ctx context.Context, repo repositories.IUserRepository, seenAccounts map[string]bool, allowDashboard bool, row int, user *models.User) []importer.RowError {
+	var rowErrors []importer.RowError
+	if user.Name == "" {
+		rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Ad Soyad", Reason: "zorunlu alan boş"})
+	}
+	if user.Password == "" {
+		rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Şifre", Reason: "zorunlu alan boş"})
+	}
+	if user.Type == "" {
+		user.Type = models.Panel
+	}
+	if user.Type != models.Dashboard && user.Type != models.Panel {
+		rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Kullanıcı Tipi", Reason: "geçersiz kullanıcı tipi"})
+	}
+	if user.Type == models.Dashboard && !allowDashboard {
+		rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Kullanıcı Tipi", Reason: "yönetici hesabı içe aktarma yetkiniz yok"})
+	}
+
+	switch {
+	case user.Account == "":
+		rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Hesap", Reason: "zorunlu alan boş"})
+	case seenAccounts[user.Account]:
+		rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Hesap", Reason: "dosyada tekrar eden hesap adı"})
+	default:
+		exists, err := repo.AccountTaken(ctx, user.Account)
+		if err != nil {
+			rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Hesap", Reason: "hesap adı kontrol edilemedi"})
+		} else if exists {
+			rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Hesap", Reason: "bu hesap adı zaten kullanılıyor"})
+		}
+		seenAccounts[user.Account] = true
+	}
+
+	if len(rowErrors) == 0 {
+		if err := user.SetPassword(user.Password); err != nil {
+			rowErrors = append(rowErrors, importer.RowError{Row: row, Column: "Şifre", Reason: "şifre oluşturulamadı"})
+		}
+	}
+	return rowErrors
+}
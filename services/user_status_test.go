@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/configs/configsdatabase"
+	"zatrano/models"
+	"zatrano/pkg/passwordhash"
+	"zatrano/pkg/requestctx"
+	"zatrano/pkg/testutil"
+)
+
+type statusFixture struct {
+	users  *UserService
+	auth   *AuthService
+	admins []models.User
+	member models.User
+}
+
+const memberPassword = "Gizli1234!"
+
+func newStatusFixture(t *testing.T) *statusFixture {
+	t.Helper()
+	db := testutil.NewDB(t, &models.User{}, &models.AuditLog{}, &models.RememberToken{}, &models.UserSession{})
+	ctx := requestctx.WithUserID(context.Background(), 1)
+
+	hash, err := passwordhash.Hash(memberPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &statusFixture{
+		admins: []models.User{
+			{Name: "Yönetici A", Account: "a@example.com", Password: hash, Status: true, Type: models.Dashboard},
+			{Name: "Yönetici B", Account: "b@example.com", Password: hash, Status: true, Type: models.Dashboard},
+		},
+		member: models.User{Name: "Ayşe", Account: "ayse@example.com", Password: hash, Status: true, Type: models.Panel},
+	}
+	for i := range f.admins {
+		if err := db.WithContext(ctx).Create(&f.admins[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.WithContext(ctx).Create(&f.member).Error; err != nil {
+		t.Fatal(err)
+	}
+	token := models.RememberToken{UserID: f.member.ID, Selector: "sel", ValidatorHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := db.Create(&token).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	f.users = NewUserService().(*UserService)
+	f.auth = NewAuthService().(*AuthService)
+	return f
+}
+
+func countRows(t *testing.T, model any, query string, args ...any) int64 {
+	t.Helper()
+	var n int64
+	if err := configsdatabase.DB.Model(model).Where(query, args...).Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestDeactivateUserRevokesRememberTokensAndAuditsOnce(t *testing.T) {
+	f := newStatusFixture(t)
+	admin := f.admins[0].ID
+
+	if err := f.users.DeactivateUser(context.Background(), admin, f.member.ID); err != nil {
+		t.Fatalf("DeactivateUser: %v", err)
+	}
+
+	if n := countRows(t, &models.RememberToken{}, "user_id = ?", f.member.ID); n != 0 {
+		t.Errorf("pasifleştirilen kullanıcının %d hatırlama token'ı kaldı", n)
+	}
+	var entries []models.AuditLog
+	configsdatabase.DB.Where("entity_type = ? AND entity_id = ?", "User", f.member.ID).Find(&entries)
+	if len(entries) != 1 || entries[0].Action != models.AuditDeactivate || entries[0].ActorID != admin {
+		t.Fatalf("denetim kayıtları = %+v; beklenen yöneticinin tek deactivate kaydı", entries)
+	}
+	if entries[0].Changes == "" {
+		t.Error("deactivate kaydında durum farkı yok")
+	}
+}
+
+func TestDeactivateUserKeepsLastActiveAdmin(t *testing.T) {
+	f := newStatusFixture(t)
+	first, second := f.admins[0].ID, f.admins[1].ID
+
+	if err := f.users.DeactivateUser(context.Background(), first, first); !errors.Is(err, ErrDeactivateSelf) {
+		t.Errorf("kendini pasifleştirme: err = %v", err)
+	}
+	if err := f.users.DeactivateUser(context.Background(), first, second); err != nil {
+		t.Fatalf("ikinci yöneticiyi pasifleştirme: %v", err)
+	}
+	// Yetkili başka bir hesap bile son aktif yöneticiyi kapatamaz
+	if err := f.users.DeactivateUser(context.Background(), f.member.ID, first); !errors.Is(err, ErrLastActiveAdmin) {
+		t.Errorf("son yönetici: err = %v, beklenen ErrLastActiveAdmin", err)
+	}
+	if n := countRows(t, &models.User{}, "id = ? AND status = ?", first, true); n != 1 {
+		t.Error("son aktif yönetici pasifleştirildi")
+	}
+}
+
+func TestAuthenticateRejectsInactiveUser(t *testing.T) {
+	f := newStatusFixture(t)
+
+	if _, err := f.auth.Authenticate(f.member.Account, memberPassword); err != nil {
+		t.Fatalf("aktif kullanıcı girişi: %v", err)
+	}
+	if err := f.users.DeactivateUser(context.Background(), f.admins[0].ID, f.member.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, password := range []string{memberPassword, "yanlis-sifre"} {
+		if _, err := f.auth.Authenticate(f.member.Account, password); !errors.Is(err, ErrUserInactive) {
+			t.Errorf("pasif kullanıcı (%q): err = %v, beklenen ErrUserInactive", password, err)
+		}
+	}
+	// Pasif hesapta yanlış şifre kilit sayacını artırmaz
+	if n := countRows(t, &models.User{}, "id = ? AND failed_login_count = 0", f.member.ID); n != 1 {
+		t.Error("pasif hesaba deneme başarısız giriş sayacını artırdı")
+	}
+
+	if err := f.users.ReactivateUser(context.Background(), f.admins[0].ID, f.member.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.auth.Authenticate(f.member.Account, memberPassword); err != nil {
+		t.Errorf("yeniden aktifleştirilen kullanıcı girişi: %v", err)
+	}
+}
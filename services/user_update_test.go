@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/requestctx"
+)
+
+func editForm(user models.User, name string) *models.User {
+	return &models.User{Name: name, Account: user.Account, Status: user.Status, Type: user.Type, Version: user.Version}
+}
+
+func TestConcurrentPanelEditsSecondGetsConflict(t *testing.T) {
+	f := newStatusFixture(t)
+	ctx := requestctx.WithUserID(context.Background(), f.admins[0].ID)
+
+	// İki yönetici formu aynı anda açtı
+	loaded, err := f.users.GetUserByID(ctx, f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := editForm(*loaded, "Ayşe Yılmaz"), editForm(*loaded, "Ayşe Kaya")
+
+	if err := f.users.UpdateUser(ctx, f.member.ID, first); err != nil {
+		t.Fatalf("ilk UpdateUser: %v", err)
+	}
+	if err := f.users.UpdateUser(requestctx.WithUserID(context.Background(), f.admins[1].ID), f.member.ID, second); !errors.Is(err, ErrUserModified) {
+		t.Fatalf("ikinci UpdateUser err = %v, beklenen ErrUserModified", err)
+	}
+
+	stored, err := f.users.GetUserByID(ctx, f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != "Ayşe Yılmaz" || stored.Version != loaded.Version+1 {
+		t.Errorf("kayıt = %q/v%d, beklenen ilk yöneticinin değişikliği v%d", stored.Name, stored.Version, loaded.Version+1)
+	}
+}
+
+// Durum değişikliği sürüm istemez ama sürümü artırır; o sırada açık olan form eskir
+func TestStatusChangeInvalidatesOpenForm(t *testing.T) {
+	f := newStatusFixture(t)
+	ctx := requestctx.WithUserID(context.Background(), f.admins[0].ID)
+
+	loaded, err := f.users.GetUserByID(ctx, f.member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.users.DeactivateUser(ctx, f.admins[1].ID, f.member.ID); err != nil {
+		t.Fatalf("DeactivateUser: %v", err)
+	}
+	if err := f.users.UpdateUser(ctx, f.member.ID, editForm(*loaded, "Ayşe Yılmaz")); !errors.Is(err, ErrUserModified) {
+		t.Fatalf("eski formla UpdateUser err = %v, beklenen ErrUserModified", err)
+	}
+}